@@ -0,0 +1,574 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: skeleton/v1/audit.proto
+
+package skeletonv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type AuditRecord struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	RequestId string                 `protobuf:"bytes,2,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	// Action, such as "insert" or "lock".
+	Action    string                 `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// JSON document describing the change.
+	Data         []byte `protobuf:"bytes,5,opt,name=data,proto3" json:"data,omitempty"`
+	OriginIp     string `protobuf:"bytes,6,opt,name=origin_ip,json=originIp,proto3" json:"origin_ip,omitempty"`
+	ResourceId   int64  `protobuf:"varint,7,opt,name=resource_id,json=resourceId,proto3" json:"resource_id,omitempty"`
+	ResourceType string `protobuf:"bytes,8,opt,name=resource_type,json=resourceType,proto3" json:"resource_type,omitempty"`
+	UserId       int64  `protobuf:"varint,9,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// ID of the organization the record was made in, if any.
+	OrganizationId string `protobuf:"bytes,10,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AuditRecord) Reset() {
+	*x = AuditRecord{}
+	mi := &file_skeleton_v1_audit_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditRecord) ProtoMessage() {}
+
+func (x *AuditRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_skeleton_v1_audit_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditRecord.ProtoReflect.Descriptor instead.
+func (*AuditRecord) Descriptor() ([]byte, []int) {
+	return file_skeleton_v1_audit_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AuditRecord) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AuditRecord) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *AuditRecord) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *AuditRecord) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *AuditRecord) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *AuditRecord) GetOriginIp() string {
+	if x != nil {
+		return x.OriginIp
+	}
+	return ""
+}
+
+func (x *AuditRecord) GetResourceId() int64 {
+	if x != nil {
+		return x.ResourceId
+	}
+	return 0
+}
+
+func (x *AuditRecord) GetResourceType() string {
+	if x != nil {
+		return x.ResourceType
+	}
+	return ""
+}
+
+func (x *AuditRecord) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *AuditRecord) GetOrganizationId() string {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return ""
+}
+
+type GetAuditRecordRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAuditRecordRequest) Reset() {
+	*x = GetAuditRecordRequest{}
+	mi := &file_skeleton_v1_audit_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAuditRecordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAuditRecordRequest) ProtoMessage() {}
+
+func (x *GetAuditRecordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_skeleton_v1_audit_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAuditRecordRequest.ProtoReflect.Descriptor instead.
+func (*GetAuditRecordRequest) Descriptor() ([]byte, []int) {
+	return file_skeleton_v1_audit_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetAuditRecordRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetAuditRecordResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Record        *AuditRecord           `protobuf:"bytes,1,opt,name=record,proto3" json:"record,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAuditRecordResponse) Reset() {
+	*x = GetAuditRecordResponse{}
+	mi := &file_skeleton_v1_audit_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAuditRecordResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAuditRecordResponse) ProtoMessage() {}
+
+func (x *GetAuditRecordResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_skeleton_v1_audit_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAuditRecordResponse.ProtoReflect.Descriptor instead.
+func (*GetAuditRecordResponse) Descriptor() ([]byte, []int) {
+	return file_skeleton_v1_audit_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetAuditRecordResponse) GetRecord() *AuditRecord {
+	if x != nil {
+		return x.Record
+	}
+	return nil
+}
+
+type ListAuditRecordsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Page  *Page                  `protobuf:"bytes,1,opt,name=page,proto3" json:"page,omitempty"`
+	// Ordering, such as "-created_at".
+	Sort          string `protobuf:"bytes,2,opt,name=sort,proto3" json:"sort,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuditRecordsRequest) Reset() {
+	*x = ListAuditRecordsRequest{}
+	mi := &file_skeleton_v1_audit_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditRecordsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditRecordsRequest) ProtoMessage() {}
+
+func (x *ListAuditRecordsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_skeleton_v1_audit_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditRecordsRequest.ProtoReflect.Descriptor instead.
+func (*ListAuditRecordsRequest) Descriptor() ([]byte, []int) {
+	return file_skeleton_v1_audit_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListAuditRecordsRequest) GetPage() *Page {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+func (x *ListAuditRecordsRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+type ListAuditRecordsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Records       []*AuditRecord         `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
+	Page          *Page                  `protobuf:"bytes,2,opt,name=page,proto3" json:"page,omitempty"`
+	Navigation    *Navigation            `protobuf:"bytes,3,opt,name=navigation,proto3" json:"navigation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuditRecordsResponse) Reset() {
+	*x = ListAuditRecordsResponse{}
+	mi := &file_skeleton_v1_audit_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditRecordsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditRecordsResponse) ProtoMessage() {}
+
+func (x *ListAuditRecordsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_skeleton_v1_audit_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditRecordsResponse.ProtoReflect.Descriptor instead.
+func (*ListAuditRecordsResponse) Descriptor() ([]byte, []int) {
+	return file_skeleton_v1_audit_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListAuditRecordsResponse) GetRecords() []*AuditRecord {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+func (x *ListAuditRecordsResponse) GetPage() *Page {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+func (x *ListAuditRecordsResponse) GetNavigation() *Navigation {
+	if x != nil {
+		return x.Navigation
+	}
+	return nil
+}
+
+// StreamAuditRecordsRequest narrows a stream to the records matching every
+// filter set. A filter of several values matches any of them.
+type StreamAuditRecordsRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Actions        []string               `protobuf:"bytes,1,rep,name=actions,proto3" json:"actions,omitempty"`
+	ResourceTypes  []string               `protobuf:"bytes,2,rep,name=resource_types,json=resourceTypes,proto3" json:"resource_types,omitempty"`
+	OrganizationId string                 `protobuf:"bytes,3,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	// ID of the last record received, to resume a stream after it.
+	LastRecordId  string `protobuf:"bytes,4,opt,name=last_record_id,json=lastRecordId,proto3" json:"last_record_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamAuditRecordsRequest) Reset() {
+	*x = StreamAuditRecordsRequest{}
+	mi := &file_skeleton_v1_audit_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamAuditRecordsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamAuditRecordsRequest) ProtoMessage() {}
+
+func (x *StreamAuditRecordsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_skeleton_v1_audit_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamAuditRecordsRequest.ProtoReflect.Descriptor instead.
+func (*StreamAuditRecordsRequest) Descriptor() ([]byte, []int) {
+	return file_skeleton_v1_audit_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *StreamAuditRecordsRequest) GetActions() []string {
+	if x != nil {
+		return x.Actions
+	}
+	return nil
+}
+
+func (x *StreamAuditRecordsRequest) GetResourceTypes() []string {
+	if x != nil {
+		return x.ResourceTypes
+	}
+	return nil
+}
+
+func (x *StreamAuditRecordsRequest) GetOrganizationId() string {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return ""
+}
+
+func (x *StreamAuditRecordsRequest) GetLastRecordId() string {
+	if x != nil {
+		return x.LastRecordId
+	}
+	return ""
+}
+
+type StreamAuditRecordsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Record        *AuditRecord           `protobuf:"bytes,1,opt,name=record,proto3" json:"record,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamAuditRecordsResponse) Reset() {
+	*x = StreamAuditRecordsResponse{}
+	mi := &file_skeleton_v1_audit_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamAuditRecordsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamAuditRecordsResponse) ProtoMessage() {}
+
+func (x *StreamAuditRecordsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_skeleton_v1_audit_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamAuditRecordsResponse.ProtoReflect.Descriptor instead.
+func (*StreamAuditRecordsResponse) Descriptor() ([]byte, []int) {
+	return file_skeleton_v1_audit_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *StreamAuditRecordsResponse) GetRecord() *AuditRecord {
+	if x != nil {
+		return x.Record
+	}
+	return nil
+}
+
+var File_skeleton_v1_audit_proto protoreflect.FileDescriptor
+
+const file_skeleton_v1_audit_proto_rawDesc = "" +
+	"\n" +
+	"\x17skeleton/v1/audit.proto\x12\vskeleton.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x18skeleton/v1/common.proto\"\xc8\x02\n" +
+	"\vAuditRecord\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x02 \x01(\tR\trequestId\x12\x16\n" +
+	"\x06action\x18\x03 \x01(\tR\x06action\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x12\n" +
+	"\x04data\x18\x05 \x01(\fR\x04data\x12\x1b\n" +
+	"\torigin_ip\x18\x06 \x01(\tR\boriginIp\x12\x1f\n" +
+	"\vresource_id\x18\a \x01(\x03R\n" +
+	"resourceId\x12#\n" +
+	"\rresource_type\x18\b \x01(\tR\fresourceType\x12\x17\n" +
+	"\auser_id\x18\t \x01(\x03R\x06userId\x12'\n" +
+	"\x0forganization_id\x18\n" +
+	" \x01(\tR\x0eorganizationId\"'\n" +
+	"\x15GetAuditRecordRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"J\n" +
+	"\x16GetAuditRecordResponse\x120\n" +
+	"\x06record\x18\x01 \x01(\v2\x18.skeleton.v1.AuditRecordR\x06record\"T\n" +
+	"\x17ListAuditRecordsRequest\x12%\n" +
+	"\x04page\x18\x01 \x01(\v2\x11.skeleton.v1.PageR\x04page\x12\x12\n" +
+	"\x04sort\x18\x02 \x01(\tR\x04sort\"\xae\x01\n" +
+	"\x18ListAuditRecordsResponse\x122\n" +
+	"\arecords\x18\x01 \x03(\v2\x18.skeleton.v1.AuditRecordR\arecords\x12%\n" +
+	"\x04page\x18\x02 \x01(\v2\x11.skeleton.v1.PageR\x04page\x127\n" +
+	"\n" +
+	"navigation\x18\x03 \x01(\v2\x17.skeleton.v1.NavigationR\n" +
+	"navigation\"\xab\x01\n" +
+	"\x19StreamAuditRecordsRequest\x12\x18\n" +
+	"\aactions\x18\x01 \x03(\tR\aactions\x12%\n" +
+	"\x0eresource_types\x18\x02 \x03(\tR\rresourceTypes\x12'\n" +
+	"\x0forganization_id\x18\x03 \x01(\tR\x0eorganizationId\x12$\n" +
+	"\x0elast_record_id\x18\x04 \x01(\tR\flastRecordId\"N\n" +
+	"\x1aStreamAuditRecordsResponse\x120\n" +
+	"\x06record\x18\x01 \x01(\v2\x18.skeleton.v1.AuditRecordR\x06record2\xb3\x02\n" +
+	"\fAuditService\x12Y\n" +
+	"\x0eGetAuditRecord\x12\".skeleton.v1.GetAuditRecordRequest\x1a#.skeleton.v1.GetAuditRecordResponse\x12_\n" +
+	"\x10ListAuditRecords\x12$.skeleton.v1.ListAuditRecordsRequest\x1a%.skeleton.v1.ListAuditRecordsResponse\x12g\n" +
+	"\x12StreamAuditRecords\x12&.skeleton.v1.StreamAuditRecordsRequest\x1a'.skeleton.v1.StreamAuditRecordsResponse0\x01B;Z9github.com/kianooshaz/skeleton/api/skeleton/v1;skeletonv1b\x06proto3"
+
+var (
+	file_skeleton_v1_audit_proto_rawDescOnce sync.Once
+	file_skeleton_v1_audit_proto_rawDescData []byte
+)
+
+func file_skeleton_v1_audit_proto_rawDescGZIP() []byte {
+	file_skeleton_v1_audit_proto_rawDescOnce.Do(func() {
+		file_skeleton_v1_audit_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_skeleton_v1_audit_proto_rawDesc), len(file_skeleton_v1_audit_proto_rawDesc)))
+	})
+	return file_skeleton_v1_audit_proto_rawDescData
+}
+
+var file_skeleton_v1_audit_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_skeleton_v1_audit_proto_goTypes = []any{
+	(*AuditRecord)(nil),                // 0: skeleton.v1.AuditRecord
+	(*GetAuditRecordRequest)(nil),      // 1: skeleton.v1.GetAuditRecordRequest
+	(*GetAuditRecordResponse)(nil),     // 2: skeleton.v1.GetAuditRecordResponse
+	(*ListAuditRecordsRequest)(nil),    // 3: skeleton.v1.ListAuditRecordsRequest
+	(*ListAuditRecordsResponse)(nil),   // 4: skeleton.v1.ListAuditRecordsResponse
+	(*StreamAuditRecordsRequest)(nil),  // 5: skeleton.v1.StreamAuditRecordsRequest
+	(*StreamAuditRecordsResponse)(nil), // 6: skeleton.v1.StreamAuditRecordsResponse
+	(*timestamppb.Timestamp)(nil),      // 7: google.protobuf.Timestamp
+	(*Page)(nil),                       // 8: skeleton.v1.Page
+	(*Navigation)(nil),                 // 9: skeleton.v1.Navigation
+}
+var file_skeleton_v1_audit_proto_depIdxs = []int32{
+	7,  // 0: skeleton.v1.AuditRecord.created_at:type_name -> google.protobuf.Timestamp
+	0,  // 1: skeleton.v1.GetAuditRecordResponse.record:type_name -> skeleton.v1.AuditRecord
+	8,  // 2: skeleton.v1.ListAuditRecordsRequest.page:type_name -> skeleton.v1.Page
+	0,  // 3: skeleton.v1.ListAuditRecordsResponse.records:type_name -> skeleton.v1.AuditRecord
+	8,  // 4: skeleton.v1.ListAuditRecordsResponse.page:type_name -> skeleton.v1.Page
+	9,  // 5: skeleton.v1.ListAuditRecordsResponse.navigation:type_name -> skeleton.v1.Navigation
+	0,  // 6: skeleton.v1.StreamAuditRecordsResponse.record:type_name -> skeleton.v1.AuditRecord
+	1,  // 7: skeleton.v1.AuditService.GetAuditRecord:input_type -> skeleton.v1.GetAuditRecordRequest
+	3,  // 8: skeleton.v1.AuditService.ListAuditRecords:input_type -> skeleton.v1.ListAuditRecordsRequest
+	5,  // 9: skeleton.v1.AuditService.StreamAuditRecords:input_type -> skeleton.v1.StreamAuditRecordsRequest
+	2,  // 10: skeleton.v1.AuditService.GetAuditRecord:output_type -> skeleton.v1.GetAuditRecordResponse
+	4,  // 11: skeleton.v1.AuditService.ListAuditRecords:output_type -> skeleton.v1.ListAuditRecordsResponse
+	6,  // 12: skeleton.v1.AuditService.StreamAuditRecords:output_type -> skeleton.v1.StreamAuditRecordsResponse
+	10, // [10:13] is the sub-list for method output_type
+	7,  // [7:10] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_skeleton_v1_audit_proto_init() }
+func file_skeleton_v1_audit_proto_init() {
+	if File_skeleton_v1_audit_proto != nil {
+		return
+	}
+	file_skeleton_v1_common_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_skeleton_v1_audit_proto_rawDesc), len(file_skeleton_v1_audit_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_skeleton_v1_audit_proto_goTypes,
+		DependencyIndexes: file_skeleton_v1_audit_proto_depIdxs,
+		MessageInfos:      file_skeleton_v1_audit_proto_msgTypes,
+	}.Build()
+	File_skeleton_v1_audit_proto = out.File
+	file_skeleton_v1_audit_proto_goTypes = nil
+	file_skeleton_v1_audit_proto_depIdxs = nil
+}
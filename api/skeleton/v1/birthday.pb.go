@@ -0,0 +1,889 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: skeleton/v1/birthday.proto
+
+package skeletonv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Birthday struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	DateOfBirth   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=date_of_birth,json=dateOfBirth,proto3" json:"date_of_birth,omitempty"`
+	Age           int32                  `protobuf:"varint,4,opt,name=age,proto3" json:"age,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	PlaceOfBirth  *string                `protobuf:"bytes,7,opt,name=place_of_birth,json=placeOfBirth,proto3,oneof" json:"place_of_birth,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Birthday) Reset() {
+	*x = Birthday{}
+	mi := &file_skeleton_v1_birthday_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Birthday) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Birthday) ProtoMessage() {}
+
+func (x *Birthday) ProtoReflect() protoreflect.Message {
+	mi := &file_skeleton_v1_birthday_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Birthday.ProtoReflect.Descriptor instead.
+func (*Birthday) Descriptor() ([]byte, []int) {
+	return file_skeleton_v1_birthday_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Birthday) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Birthday) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Birthday) GetDateOfBirth() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DateOfBirth
+	}
+	return nil
+}
+
+func (x *Birthday) GetAge() int32 {
+	if x != nil {
+		return x.Age
+	}
+	return 0
+}
+
+func (x *Birthday) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Birthday) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Birthday) GetPlaceOfBirth() string {
+	if x != nil && x.PlaceOfBirth != nil {
+		return *x.PlaceOfBirth
+	}
+	return ""
+}
+
+type CreateBirthdayRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	DateOfBirth   *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=date_of_birth,json=dateOfBirth,proto3" json:"date_of_birth,omitempty"`
+	PlaceOfBirth  *string                `protobuf:"bytes,3,opt,name=place_of_birth,json=placeOfBirth,proto3,oneof" json:"place_of_birth,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateBirthdayRequest) Reset() {
+	*x = CreateBirthdayRequest{}
+	mi := &file_skeleton_v1_birthday_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateBirthdayRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateBirthdayRequest) ProtoMessage() {}
+
+func (x *CreateBirthdayRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_skeleton_v1_birthday_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateBirthdayRequest.ProtoReflect.Descriptor instead.
+func (*CreateBirthdayRequest) Descriptor() ([]byte, []int) {
+	return file_skeleton_v1_birthday_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateBirthdayRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CreateBirthdayRequest) GetDateOfBirth() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DateOfBirth
+	}
+	return nil
+}
+
+func (x *CreateBirthdayRequest) GetPlaceOfBirth() string {
+	if x != nil && x.PlaceOfBirth != nil {
+		return *x.PlaceOfBirth
+	}
+	return ""
+}
+
+type CreateBirthdayResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Birthday      *Birthday              `protobuf:"bytes,1,opt,name=birthday,proto3" json:"birthday,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateBirthdayResponse) Reset() {
+	*x = CreateBirthdayResponse{}
+	mi := &file_skeleton_v1_birthday_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateBirthdayResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateBirthdayResponse) ProtoMessage() {}
+
+func (x *CreateBirthdayResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_skeleton_v1_birthday_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateBirthdayResponse.ProtoReflect.Descriptor instead.
+func (*CreateBirthdayResponse) Descriptor() ([]byte, []int) {
+	return file_skeleton_v1_birthday_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateBirthdayResponse) GetBirthday() *Birthday {
+	if x != nil {
+		return x.Birthday
+	}
+	return nil
+}
+
+type GetBirthdayRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBirthdayRequest) Reset() {
+	*x = GetBirthdayRequest{}
+	mi := &file_skeleton_v1_birthday_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBirthdayRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBirthdayRequest) ProtoMessage() {}
+
+func (x *GetBirthdayRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_skeleton_v1_birthday_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBirthdayRequest.ProtoReflect.Descriptor instead.
+func (*GetBirthdayRequest) Descriptor() ([]byte, []int) {
+	return file_skeleton_v1_birthday_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetBirthdayRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetBirthdayResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Birthday      *Birthday              `protobuf:"bytes,1,opt,name=birthday,proto3" json:"birthday,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBirthdayResponse) Reset() {
+	*x = GetBirthdayResponse{}
+	mi := &file_skeleton_v1_birthday_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBirthdayResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBirthdayResponse) ProtoMessage() {}
+
+func (x *GetBirthdayResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_skeleton_v1_birthday_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBirthdayResponse.ProtoReflect.Descriptor instead.
+func (*GetBirthdayResponse) Descriptor() ([]byte, []int) {
+	return file_skeleton_v1_birthday_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetBirthdayResponse) GetBirthday() *Birthday {
+	if x != nil {
+		return x.Birthday
+	}
+	return nil
+}
+
+type GetUserBirthdayRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserBirthdayRequest) Reset() {
+	*x = GetUserBirthdayRequest{}
+	mi := &file_skeleton_v1_birthday_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserBirthdayRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserBirthdayRequest) ProtoMessage() {}
+
+func (x *GetUserBirthdayRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_skeleton_v1_birthday_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserBirthdayRequest.ProtoReflect.Descriptor instead.
+func (*GetUserBirthdayRequest) Descriptor() ([]byte, []int) {
+	return file_skeleton_v1_birthday_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetUserBirthdayRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetUserBirthdayResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Birthday      *Birthday              `protobuf:"bytes,1,opt,name=birthday,proto3" json:"birthday,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserBirthdayResponse) Reset() {
+	*x = GetUserBirthdayResponse{}
+	mi := &file_skeleton_v1_birthday_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserBirthdayResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserBirthdayResponse) ProtoMessage() {}
+
+func (x *GetUserBirthdayResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_skeleton_v1_birthday_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserBirthdayResponse.ProtoReflect.Descriptor instead.
+func (*GetUserBirthdayResponse) Descriptor() ([]byte, []int) {
+	return file_skeleton_v1_birthday_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetUserBirthdayResponse) GetBirthday() *Birthday {
+	if x != nil {
+		return x.Birthday
+	}
+	return nil
+}
+
+// UpdateBirthdayRequest leaves the fields it does not set untouched.
+type UpdateBirthdayRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	DateOfBirth *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=date_of_birth,json=dateOfBirth,proto3" json:"date_of_birth,omitempty"`
+	// An empty place of birth clears it.
+	PlaceOfBirth  *string `protobuf:"bytes,3,opt,name=place_of_birth,json=placeOfBirth,proto3,oneof" json:"place_of_birth,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateBirthdayRequest) Reset() {
+	*x = UpdateBirthdayRequest{}
+	mi := &file_skeleton_v1_birthday_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateBirthdayRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateBirthdayRequest) ProtoMessage() {}
+
+func (x *UpdateBirthdayRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_skeleton_v1_birthday_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateBirthdayRequest.ProtoReflect.Descriptor instead.
+func (*UpdateBirthdayRequest) Descriptor() ([]byte, []int) {
+	return file_skeleton_v1_birthday_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *UpdateBirthdayRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateBirthdayRequest) GetDateOfBirth() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DateOfBirth
+	}
+	return nil
+}
+
+func (x *UpdateBirthdayRequest) GetPlaceOfBirth() string {
+	if x != nil && x.PlaceOfBirth != nil {
+		return *x.PlaceOfBirth
+	}
+	return ""
+}
+
+type UpdateBirthdayResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Birthday      *Birthday              `protobuf:"bytes,1,opt,name=birthday,proto3" json:"birthday,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateBirthdayResponse) Reset() {
+	*x = UpdateBirthdayResponse{}
+	mi := &file_skeleton_v1_birthday_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateBirthdayResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateBirthdayResponse) ProtoMessage() {}
+
+func (x *UpdateBirthdayResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_skeleton_v1_birthday_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateBirthdayResponse.ProtoReflect.Descriptor instead.
+func (*UpdateBirthdayResponse) Descriptor() ([]byte, []int) {
+	return file_skeleton_v1_birthday_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UpdateBirthdayResponse) GetBirthday() *Birthday {
+	if x != nil {
+		return x.Birthday
+	}
+	return nil
+}
+
+type DeleteBirthdayRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteBirthdayRequest) Reset() {
+	*x = DeleteBirthdayRequest{}
+	mi := &file_skeleton_v1_birthday_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteBirthdayRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteBirthdayRequest) ProtoMessage() {}
+
+func (x *DeleteBirthdayRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_skeleton_v1_birthday_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteBirthdayRequest.ProtoReflect.Descriptor instead.
+func (*DeleteBirthdayRequest) Descriptor() ([]byte, []int) {
+	return file_skeleton_v1_birthday_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DeleteBirthdayRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteBirthdayResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteBirthdayResponse) Reset() {
+	*x = DeleteBirthdayResponse{}
+	mi := &file_skeleton_v1_birthday_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteBirthdayResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteBirthdayResponse) ProtoMessage() {}
+
+func (x *DeleteBirthdayResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_skeleton_v1_birthday_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteBirthdayResponse.ProtoReflect.Descriptor instead.
+func (*DeleteBirthdayResponse) Descriptor() ([]byte, []int) {
+	return file_skeleton_v1_birthday_proto_rawDescGZIP(), []int{10}
+}
+
+type ListBirthdaysRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Page  *Page                  `protobuf:"bytes,1,opt,name=page,proto3" json:"page,omitempty"`
+	// Ordering, such as "date_of_birth".
+	Sort          string  `protobuf:"bytes,2,opt,name=sort,proto3" json:"sort,omitempty"`
+	UserId        *string `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3,oneof" json:"user_id,omitempty"`
+	MinAge        *int32  `protobuf:"varint,4,opt,name=min_age,json=minAge,proto3,oneof" json:"min_age,omitempty"`
+	MaxAge        *int32  `protobuf:"varint,5,opt,name=max_age,json=maxAge,proto3,oneof" json:"max_age,omitempty"`
+	BirthMonth    *int32  `protobuf:"varint,6,opt,name=birth_month,json=birthMonth,proto3,oneof" json:"birth_month,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBirthdaysRequest) Reset() {
+	*x = ListBirthdaysRequest{}
+	mi := &file_skeleton_v1_birthday_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBirthdaysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBirthdaysRequest) ProtoMessage() {}
+
+func (x *ListBirthdaysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_skeleton_v1_birthday_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBirthdaysRequest.ProtoReflect.Descriptor instead.
+func (*ListBirthdaysRequest) Descriptor() ([]byte, []int) {
+	return file_skeleton_v1_birthday_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListBirthdaysRequest) GetPage() *Page {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+func (x *ListBirthdaysRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListBirthdaysRequest) GetUserId() string {
+	if x != nil && x.UserId != nil {
+		return *x.UserId
+	}
+	return ""
+}
+
+func (x *ListBirthdaysRequest) GetMinAge() int32 {
+	if x != nil && x.MinAge != nil {
+		return *x.MinAge
+	}
+	return 0
+}
+
+func (x *ListBirthdaysRequest) GetMaxAge() int32 {
+	if x != nil && x.MaxAge != nil {
+		return *x.MaxAge
+	}
+	return 0
+}
+
+func (x *ListBirthdaysRequest) GetBirthMonth() int32 {
+	if x != nil && x.BirthMonth != nil {
+		return *x.BirthMonth
+	}
+	return 0
+}
+
+type ListBirthdaysResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Birthdays     []*Birthday            `protobuf:"bytes,1,rep,name=birthdays,proto3" json:"birthdays,omitempty"`
+	Page          *Page                  `protobuf:"bytes,2,opt,name=page,proto3" json:"page,omitempty"`
+	Navigation    *Navigation            `protobuf:"bytes,3,opt,name=navigation,proto3" json:"navigation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBirthdaysResponse) Reset() {
+	*x = ListBirthdaysResponse{}
+	mi := &file_skeleton_v1_birthday_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBirthdaysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBirthdaysResponse) ProtoMessage() {}
+
+func (x *ListBirthdaysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_skeleton_v1_birthday_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBirthdaysResponse.ProtoReflect.Descriptor instead.
+func (*ListBirthdaysResponse) Descriptor() ([]byte, []int) {
+	return file_skeleton_v1_birthday_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListBirthdaysResponse) GetBirthdays() []*Birthday {
+	if x != nil {
+		return x.Birthdays
+	}
+	return nil
+}
+
+func (x *ListBirthdaysResponse) GetPage() *Page {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+func (x *ListBirthdaysResponse) GetNavigation() *Navigation {
+	if x != nil {
+		return x.Navigation
+	}
+	return nil
+}
+
+var File_skeleton_v1_birthday_proto protoreflect.FileDescriptor
+
+const file_skeleton_v1_birthday_proto_rawDesc = "" +
+	"\n" +
+	"\x1askeleton/v1/birthday.proto\x12\vskeleton.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x18skeleton/v1/common.proto\"\xb9\x02\n" +
+	"\bBirthday\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12>\n" +
+	"\rdate_of_birth\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\vdateOfBirth\x12\x10\n" +
+	"\x03age\x18\x04 \x01(\x05R\x03age\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12)\n" +
+	"\x0eplace_of_birth\x18\a \x01(\tH\x00R\fplaceOfBirth\x88\x01\x01B\x11\n" +
+	"\x0f_place_of_birth\"\xae\x01\n" +
+	"\x15CreateBirthdayRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12>\n" +
+	"\rdate_of_birth\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\vdateOfBirth\x12)\n" +
+	"\x0eplace_of_birth\x18\x03 \x01(\tH\x00R\fplaceOfBirth\x88\x01\x01B\x11\n" +
+	"\x0f_place_of_birth\"K\n" +
+	"\x16CreateBirthdayResponse\x121\n" +
+	"\bbirthday\x18\x01 \x01(\v2\x15.skeleton.v1.BirthdayR\bbirthday\"$\n" +
+	"\x12GetBirthdayRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"H\n" +
+	"\x13GetBirthdayResponse\x121\n" +
+	"\bbirthday\x18\x01 \x01(\v2\x15.skeleton.v1.BirthdayR\bbirthday\"1\n" +
+	"\x16GetUserBirthdayRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"L\n" +
+	"\x17GetUserBirthdayResponse\x121\n" +
+	"\bbirthday\x18\x01 \x01(\v2\x15.skeleton.v1.BirthdayR\bbirthday\"\xa5\x01\n" +
+	"\x15UpdateBirthdayRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12>\n" +
+	"\rdate_of_birth\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\vdateOfBirth\x12)\n" +
+	"\x0eplace_of_birth\x18\x03 \x01(\tH\x00R\fplaceOfBirth\x88\x01\x01B\x11\n" +
+	"\x0f_place_of_birth\"K\n" +
+	"\x16UpdateBirthdayResponse\x121\n" +
+	"\bbirthday\x18\x01 \x01(\v2\x15.skeleton.v1.BirthdayR\bbirthday\"'\n" +
+	"\x15DeleteBirthdayRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x18\n" +
+	"\x16DeleteBirthdayResponse\"\x85\x02\n" +
+	"\x14ListBirthdaysRequest\x12%\n" +
+	"\x04page\x18\x01 \x01(\v2\x11.skeleton.v1.PageR\x04page\x12\x12\n" +
+	"\x04sort\x18\x02 \x01(\tR\x04sort\x12\x1c\n" +
+	"\auser_id\x18\x03 \x01(\tH\x00R\x06userId\x88\x01\x01\x12\x1c\n" +
+	"\amin_age\x18\x04 \x01(\x05H\x01R\x06minAge\x88\x01\x01\x12\x1c\n" +
+	"\amax_age\x18\x05 \x01(\x05H\x02R\x06maxAge\x88\x01\x01\x12$\n" +
+	"\vbirth_month\x18\x06 \x01(\x05H\x03R\n" +
+	"birthMonth\x88\x01\x01B\n" +
+	"\n" +
+	"\b_user_idB\n" +
+	"\n" +
+	"\b_min_ageB\n" +
+	"\n" +
+	"\b_max_ageB\x0e\n" +
+	"\f_birth_month\"\xac\x01\n" +
+	"\x15ListBirthdaysResponse\x123\n" +
+	"\tbirthdays\x18\x01 \x03(\v2\x15.skeleton.v1.BirthdayR\tbirthdays\x12%\n" +
+	"\x04page\x18\x02 \x01(\v2\x11.skeleton.v1.PageR\x04page\x127\n" +
+	"\n" +
+	"navigation\x18\x03 \x01(\v2\x17.skeleton.v1.NavigationR\n" +
+	"navigation2\xaa\x04\n" +
+	"\x0fBirthdayService\x12Y\n" +
+	"\x0eCreateBirthday\x12\".skeleton.v1.CreateBirthdayRequest\x1a#.skeleton.v1.CreateBirthdayResponse\x12P\n" +
+	"\vGetBirthday\x12\x1f.skeleton.v1.GetBirthdayRequest\x1a .skeleton.v1.GetBirthdayResponse\x12\\\n" +
+	"\x0fGetUserBirthday\x12#.skeleton.v1.GetUserBirthdayRequest\x1a$.skeleton.v1.GetUserBirthdayResponse\x12Y\n" +
+	"\x0eUpdateBirthday\x12\".skeleton.v1.UpdateBirthdayRequest\x1a#.skeleton.v1.UpdateBirthdayResponse\x12Y\n" +
+	"\x0eDeleteBirthday\x12\".skeleton.v1.DeleteBirthdayRequest\x1a#.skeleton.v1.DeleteBirthdayResponse\x12V\n" +
+	"\rListBirthdays\x12!.skeleton.v1.ListBirthdaysRequest\x1a\".skeleton.v1.ListBirthdaysResponseB;Z9github.com/kianooshaz/skeleton/api/skeleton/v1;skeletonv1b\x06proto3"
+
+var (
+	file_skeleton_v1_birthday_proto_rawDescOnce sync.Once
+	file_skeleton_v1_birthday_proto_rawDescData []byte
+)
+
+func file_skeleton_v1_birthday_proto_rawDescGZIP() []byte {
+	file_skeleton_v1_birthday_proto_rawDescOnce.Do(func() {
+		file_skeleton_v1_birthday_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_skeleton_v1_birthday_proto_rawDesc), len(file_skeleton_v1_birthday_proto_rawDesc)))
+	})
+	return file_skeleton_v1_birthday_proto_rawDescData
+}
+
+var file_skeleton_v1_birthday_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_skeleton_v1_birthday_proto_goTypes = []any{
+	(*Birthday)(nil),                // 0: skeleton.v1.Birthday
+	(*CreateBirthdayRequest)(nil),   // 1: skeleton.v1.CreateBirthdayRequest
+	(*CreateBirthdayResponse)(nil),  // 2: skeleton.v1.CreateBirthdayResponse
+	(*GetBirthdayRequest)(nil),      // 3: skeleton.v1.GetBirthdayRequest
+	(*GetBirthdayResponse)(nil),     // 4: skeleton.v1.GetBirthdayResponse
+	(*GetUserBirthdayRequest)(nil),  // 5: skeleton.v1.GetUserBirthdayRequest
+	(*GetUserBirthdayResponse)(nil), // 6: skeleton.v1.GetUserBirthdayResponse
+	(*UpdateBirthdayRequest)(nil),   // 7: skeleton.v1.UpdateBirthdayRequest
+	(*UpdateBirthdayResponse)(nil),  // 8: skeleton.v1.UpdateBirthdayResponse
+	(*DeleteBirthdayRequest)(nil),   // 9: skeleton.v1.DeleteBirthdayRequest
+	(*DeleteBirthdayResponse)(nil),  // 10: skeleton.v1.DeleteBirthdayResponse
+	(*ListBirthdaysRequest)(nil),    // 11: skeleton.v1.ListBirthdaysRequest
+	(*ListBirthdaysResponse)(nil),   // 12: skeleton.v1.ListBirthdaysResponse
+	(*timestamppb.Timestamp)(nil),   // 13: google.protobuf.Timestamp
+	(*Page)(nil),                    // 14: skeleton.v1.Page
+	(*Navigation)(nil),              // 15: skeleton.v1.Navigation
+}
+var file_skeleton_v1_birthday_proto_depIdxs = []int32{
+	13, // 0: skeleton.v1.Birthday.date_of_birth:type_name -> google.protobuf.Timestamp
+	13, // 1: skeleton.v1.Birthday.created_at:type_name -> google.protobuf.Timestamp
+	13, // 2: skeleton.v1.Birthday.updated_at:type_name -> google.protobuf.Timestamp
+	13, // 3: skeleton.v1.CreateBirthdayRequest.date_of_birth:type_name -> google.protobuf.Timestamp
+	0,  // 4: skeleton.v1.CreateBirthdayResponse.birthday:type_name -> skeleton.v1.Birthday
+	0,  // 5: skeleton.v1.GetBirthdayResponse.birthday:type_name -> skeleton.v1.Birthday
+	0,  // 6: skeleton.v1.GetUserBirthdayResponse.birthday:type_name -> skeleton.v1.Birthday
+	13, // 7: skeleton.v1.UpdateBirthdayRequest.date_of_birth:type_name -> google.protobuf.Timestamp
+	0,  // 8: skeleton.v1.UpdateBirthdayResponse.birthday:type_name -> skeleton.v1.Birthday
+	14, // 9: skeleton.v1.ListBirthdaysRequest.page:type_name -> skeleton.v1.Page
+	0,  // 10: skeleton.v1.ListBirthdaysResponse.birthdays:type_name -> skeleton.v1.Birthday
+	14, // 11: skeleton.v1.ListBirthdaysResponse.page:type_name -> skeleton.v1.Page
+	15, // 12: skeleton.v1.ListBirthdaysResponse.navigation:type_name -> skeleton.v1.Navigation
+	1,  // 13: skeleton.v1.BirthdayService.CreateBirthday:input_type -> skeleton.v1.CreateBirthdayRequest
+	3,  // 14: skeleton.v1.BirthdayService.GetBirthday:input_type -> skeleton.v1.GetBirthdayRequest
+	5,  // 15: skeleton.v1.BirthdayService.GetUserBirthday:input_type -> skeleton.v1.GetUserBirthdayRequest
+	7,  // 16: skeleton.v1.BirthdayService.UpdateBirthday:input_type -> skeleton.v1.UpdateBirthdayRequest
+	9,  // 17: skeleton.v1.BirthdayService.DeleteBirthday:input_type -> skeleton.v1.DeleteBirthdayRequest
+	11, // 18: skeleton.v1.BirthdayService.ListBirthdays:input_type -> skeleton.v1.ListBirthdaysRequest
+	2,  // 19: skeleton.v1.BirthdayService.CreateBirthday:output_type -> skeleton.v1.CreateBirthdayResponse
+	4,  // 20: skeleton.v1.BirthdayService.GetBirthday:output_type -> skeleton.v1.GetBirthdayResponse
+	6,  // 21: skeleton.v1.BirthdayService.GetUserBirthday:output_type -> skeleton.v1.GetUserBirthdayResponse
+	8,  // 22: skeleton.v1.BirthdayService.UpdateBirthday:output_type -> skeleton.v1.UpdateBirthdayResponse
+	10, // 23: skeleton.v1.BirthdayService.DeleteBirthday:output_type -> skeleton.v1.DeleteBirthdayResponse
+	12, // 24: skeleton.v1.BirthdayService.ListBirthdays:output_type -> skeleton.v1.ListBirthdaysResponse
+	19, // [19:25] is the sub-list for method output_type
+	13, // [13:19] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
+}
+
+func init() { file_skeleton_v1_birthday_proto_init() }
+func file_skeleton_v1_birthday_proto_init() {
+	if File_skeleton_v1_birthday_proto != nil {
+		return
+	}
+	file_skeleton_v1_common_proto_init()
+	file_skeleton_v1_birthday_proto_msgTypes[0].OneofWrappers = []any{}
+	file_skeleton_v1_birthday_proto_msgTypes[1].OneofWrappers = []any{}
+	file_skeleton_v1_birthday_proto_msgTypes[7].OneofWrappers = []any{}
+	file_skeleton_v1_birthday_proto_msgTypes[11].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_skeleton_v1_birthday_proto_rawDesc), len(file_skeleton_v1_birthday_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   13,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_skeleton_v1_birthday_proto_goTypes,
+		DependencyIndexes: file_skeleton_v1_birthday_proto_depIdxs,
+		MessageInfos:      file_skeleton_v1_birthday_proto_msgTypes,
+	}.Build()
+	File_skeleton_v1_birthday_proto = out.File
+	file_skeleton_v1_birthday_proto_goTypes = nil
+	file_skeleton_v1_birthday_proto_depIdxs = nil
+}
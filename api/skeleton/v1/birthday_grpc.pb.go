@@ -0,0 +1,317 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: skeleton/v1/birthday.proto
+
+package skeletonv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	BirthdayService_CreateBirthday_FullMethodName  = "/skeleton.v1.BirthdayService/CreateBirthday"
+	BirthdayService_GetBirthday_FullMethodName     = "/skeleton.v1.BirthdayService/GetBirthday"
+	BirthdayService_GetUserBirthday_FullMethodName = "/skeleton.v1.BirthdayService/GetUserBirthday"
+	BirthdayService_UpdateBirthday_FullMethodName  = "/skeleton.v1.BirthdayService/UpdateBirthday"
+	BirthdayService_DeleteBirthday_FullMethodName  = "/skeleton.v1.BirthdayService/DeleteBirthday"
+	BirthdayService_ListBirthdays_FullMethodName   = "/skeleton.v1.BirthdayService/ListBirthdays"
+)
+
+// BirthdayServiceClient is the client API for BirthdayService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// BirthdayService manages the birthdays of users.
+type BirthdayServiceClient interface {
+	CreateBirthday(ctx context.Context, in *CreateBirthdayRequest, opts ...grpc.CallOption) (*CreateBirthdayResponse, error)
+	GetBirthday(ctx context.Context, in *GetBirthdayRequest, opts ...grpc.CallOption) (*GetBirthdayResponse, error)
+	// GetUserBirthday returns the birthday of a user.
+	GetUserBirthday(ctx context.Context, in *GetUserBirthdayRequest, opts ...grpc.CallOption) (*GetUserBirthdayResponse, error)
+	UpdateBirthday(ctx context.Context, in *UpdateBirthdayRequest, opts ...grpc.CallOption) (*UpdateBirthdayResponse, error)
+	DeleteBirthday(ctx context.Context, in *DeleteBirthdayRequest, opts ...grpc.CallOption) (*DeleteBirthdayResponse, error)
+	ListBirthdays(ctx context.Context, in *ListBirthdaysRequest, opts ...grpc.CallOption) (*ListBirthdaysResponse, error)
+}
+
+type birthdayServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBirthdayServiceClient(cc grpc.ClientConnInterface) BirthdayServiceClient {
+	return &birthdayServiceClient{cc}
+}
+
+func (c *birthdayServiceClient) CreateBirthday(ctx context.Context, in *CreateBirthdayRequest, opts ...grpc.CallOption) (*CreateBirthdayResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateBirthdayResponse)
+	err := c.cc.Invoke(ctx, BirthdayService_CreateBirthday_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *birthdayServiceClient) GetBirthday(ctx context.Context, in *GetBirthdayRequest, opts ...grpc.CallOption) (*GetBirthdayResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBirthdayResponse)
+	err := c.cc.Invoke(ctx, BirthdayService_GetBirthday_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *birthdayServiceClient) GetUserBirthday(ctx context.Context, in *GetUserBirthdayRequest, opts ...grpc.CallOption) (*GetUserBirthdayResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUserBirthdayResponse)
+	err := c.cc.Invoke(ctx, BirthdayService_GetUserBirthday_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *birthdayServiceClient) UpdateBirthday(ctx context.Context, in *UpdateBirthdayRequest, opts ...grpc.CallOption) (*UpdateBirthdayResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateBirthdayResponse)
+	err := c.cc.Invoke(ctx, BirthdayService_UpdateBirthday_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *birthdayServiceClient) DeleteBirthday(ctx context.Context, in *DeleteBirthdayRequest, opts ...grpc.CallOption) (*DeleteBirthdayResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteBirthdayResponse)
+	err := c.cc.Invoke(ctx, BirthdayService_DeleteBirthday_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *birthdayServiceClient) ListBirthdays(ctx context.Context, in *ListBirthdaysRequest, opts ...grpc.CallOption) (*ListBirthdaysResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListBirthdaysResponse)
+	err := c.cc.Invoke(ctx, BirthdayService_ListBirthdays_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BirthdayServiceServer is the server API for BirthdayService service.
+// All implementations must embed UnimplementedBirthdayServiceServer
+// for forward compatibility.
+//
+// BirthdayService manages the birthdays of users.
+type BirthdayServiceServer interface {
+	CreateBirthday(context.Context, *CreateBirthdayRequest) (*CreateBirthdayResponse, error)
+	GetBirthday(context.Context, *GetBirthdayRequest) (*GetBirthdayResponse, error)
+	// GetUserBirthday returns the birthday of a user.
+	GetUserBirthday(context.Context, *GetUserBirthdayRequest) (*GetUserBirthdayResponse, error)
+	UpdateBirthday(context.Context, *UpdateBirthdayRequest) (*UpdateBirthdayResponse, error)
+	DeleteBirthday(context.Context, *DeleteBirthdayRequest) (*DeleteBirthdayResponse, error)
+	ListBirthdays(context.Context, *ListBirthdaysRequest) (*ListBirthdaysResponse, error)
+	mustEmbedUnimplementedBirthdayServiceServer()
+}
+
+// UnimplementedBirthdayServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedBirthdayServiceServer struct{}
+
+func (UnimplementedBirthdayServiceServer) CreateBirthday(context.Context, *CreateBirthdayRequest) (*CreateBirthdayResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateBirthday not implemented")
+}
+func (UnimplementedBirthdayServiceServer) GetBirthday(context.Context, *GetBirthdayRequest) (*GetBirthdayResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBirthday not implemented")
+}
+func (UnimplementedBirthdayServiceServer) GetUserBirthday(context.Context, *GetUserBirthdayRequest) (*GetUserBirthdayResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserBirthday not implemented")
+}
+func (UnimplementedBirthdayServiceServer) UpdateBirthday(context.Context, *UpdateBirthdayRequest) (*UpdateBirthdayResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateBirthday not implemented")
+}
+func (UnimplementedBirthdayServiceServer) DeleteBirthday(context.Context, *DeleteBirthdayRequest) (*DeleteBirthdayResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteBirthday not implemented")
+}
+func (UnimplementedBirthdayServiceServer) ListBirthdays(context.Context, *ListBirthdaysRequest) (*ListBirthdaysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBirthdays not implemented")
+}
+func (UnimplementedBirthdayServiceServer) mustEmbedUnimplementedBirthdayServiceServer() {}
+func (UnimplementedBirthdayServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafeBirthdayServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BirthdayServiceServer will
+// result in compilation errors.
+type UnsafeBirthdayServiceServer interface {
+	mustEmbedUnimplementedBirthdayServiceServer()
+}
+
+func RegisterBirthdayServiceServer(s grpc.ServiceRegistrar, srv BirthdayServiceServer) {
+	// If the following call pancis, it indicates UnimplementedBirthdayServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&BirthdayService_ServiceDesc, srv)
+}
+
+func _BirthdayService_CreateBirthday_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateBirthdayRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BirthdayServiceServer).CreateBirthday(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BirthdayService_CreateBirthday_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BirthdayServiceServer).CreateBirthday(ctx, req.(*CreateBirthdayRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BirthdayService_GetBirthday_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBirthdayRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BirthdayServiceServer).GetBirthday(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BirthdayService_GetBirthday_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BirthdayServiceServer).GetBirthday(ctx, req.(*GetBirthdayRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BirthdayService_GetUserBirthday_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserBirthdayRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BirthdayServiceServer).GetUserBirthday(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BirthdayService_GetUserBirthday_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BirthdayServiceServer).GetUserBirthday(ctx, req.(*GetUserBirthdayRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BirthdayService_UpdateBirthday_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateBirthdayRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BirthdayServiceServer).UpdateBirthday(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BirthdayService_UpdateBirthday_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BirthdayServiceServer).UpdateBirthday(ctx, req.(*UpdateBirthdayRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BirthdayService_DeleteBirthday_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteBirthdayRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BirthdayServiceServer).DeleteBirthday(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BirthdayService_DeleteBirthday_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BirthdayServiceServer).DeleteBirthday(ctx, req.(*DeleteBirthdayRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BirthdayService_ListBirthdays_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBirthdaysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BirthdayServiceServer).ListBirthdays(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BirthdayService_ListBirthdays_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BirthdayServiceServer).ListBirthdays(ctx, req.(*ListBirthdaysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// BirthdayService_ServiceDesc is the grpc.ServiceDesc for BirthdayService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var BirthdayService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "skeleton.v1.BirthdayService",
+	HandlerType: (*BirthdayServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateBirthday",
+			Handler:    _BirthdayService_CreateBirthday_Handler,
+		},
+		{
+			MethodName: "GetBirthday",
+			Handler:    _BirthdayService_GetBirthday_Handler,
+		},
+		{
+			MethodName: "GetUserBirthday",
+			Handler:    _BirthdayService_GetUserBirthday_Handler,
+		},
+		{
+			MethodName: "UpdateBirthday",
+			Handler:    _BirthdayService_UpdateBirthday_Handler,
+		},
+		{
+			MethodName: "DeleteBirthday",
+			Handler:    _BirthdayService_DeleteBirthday_Handler,
+		},
+		{
+			MethodName: "ListBirthdays",
+			Handler:    _BirthdayService_ListBirthdays_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "skeleton/v1/birthday.proto",
+}
@@ -0,0 +1,248 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: skeleton/v1/common.proto
+
+package skeletonv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Page addresses a page of a listing, by its number or by the cursor of a
+// keyset listing.
+type Page struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Page number, 1-based; zero addresses the first page.
+	PageNumber uint32 `protobuf:"varint,1,opt,name=page_number,json=pageNumber,proto3" json:"page_number,omitempty"`
+	// Rows per page; zero takes the default of the server.
+	PageRows uint32 `protobuf:"varint,2,opt,name=page_rows,json=pageRows,proto3" json:"page_rows,omitempty"`
+	// Cursor continuing a keyset listing; when set page_number is ignored.
+	Cursor string `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// Whether a keyset listing counts its rows.
+	WithTotal     bool `protobuf:"varint,4,opt,name=with_total,json=withTotal,proto3" json:"with_total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Page) Reset() {
+	*x = Page{}
+	mi := &file_skeleton_v1_common_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Page) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Page) ProtoMessage() {}
+
+func (x *Page) ProtoReflect() protoreflect.Message {
+	mi := &file_skeleton_v1_common_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Page.ProtoReflect.Descriptor instead.
+func (*Page) Descriptor() ([]byte, []int) {
+	return file_skeleton_v1_common_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Page) GetPageNumber() uint32 {
+	if x != nil {
+		return x.PageNumber
+	}
+	return 0
+}
+
+func (x *Page) GetPageRows() uint32 {
+	if x != nil {
+		return x.PageRows
+	}
+	return 0
+}
+
+func (x *Page) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *Page) GetWithTotal() bool {
+	if x != nil {
+		return x.WithTotal
+	}
+	return false
+}
+
+// Navigation describes where a page sits within its listing.
+type Navigation struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Total rows and pages, left out of keyset listings that did not ask for them.
+	TotalRows int64 `protobuf:"varint,1,opt,name=total_rows,json=totalRows,proto3" json:"total_rows,omitempty"`
+	TotalPage int64 `protobuf:"varint,2,opt,name=total_page,json=totalPage,proto3" json:"total_page,omitempty"`
+	// Whether rows follow the page.
+	HasMore       bool   `protobuf:"varint,3,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
+	NextCursor    string `protobuf:"bytes,4,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	PrevCursor    string `protobuf:"bytes,5,opt,name=prev_cursor,json=prevCursor,proto3" json:"prev_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Navigation) Reset() {
+	*x = Navigation{}
+	mi := &file_skeleton_v1_common_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Navigation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Navigation) ProtoMessage() {}
+
+func (x *Navigation) ProtoReflect() protoreflect.Message {
+	mi := &file_skeleton_v1_common_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Navigation.ProtoReflect.Descriptor instead.
+func (*Navigation) Descriptor() ([]byte, []int) {
+	return file_skeleton_v1_common_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Navigation) GetTotalRows() int64 {
+	if x != nil {
+		return x.TotalRows
+	}
+	return 0
+}
+
+func (x *Navigation) GetTotalPage() int64 {
+	if x != nil {
+		return x.TotalPage
+	}
+	return 0
+}
+
+func (x *Navigation) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+func (x *Navigation) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *Navigation) GetPrevCursor() string {
+	if x != nil {
+		return x.PrevCursor
+	}
+	return ""
+}
+
+var File_skeleton_v1_common_proto protoreflect.FileDescriptor
+
+const file_skeleton_v1_common_proto_rawDesc = "" +
+	"\n" +
+	"\x18skeleton/v1/common.proto\x12\vskeleton.v1\"{\n" +
+	"\x04Page\x12\x1f\n" +
+	"\vpage_number\x18\x01 \x01(\rR\n" +
+	"pageNumber\x12\x1b\n" +
+	"\tpage_rows\x18\x02 \x01(\rR\bpageRows\x12\x16\n" +
+	"\x06cursor\x18\x03 \x01(\tR\x06cursor\x12\x1d\n" +
+	"\n" +
+	"with_total\x18\x04 \x01(\bR\twithTotal\"\xa7\x01\n" +
+	"\n" +
+	"Navigation\x12\x1d\n" +
+	"\n" +
+	"total_rows\x18\x01 \x01(\x03R\ttotalRows\x12\x1d\n" +
+	"\n" +
+	"total_page\x18\x02 \x01(\x03R\ttotalPage\x12\x19\n" +
+	"\bhas_more\x18\x03 \x01(\bR\ahasMore\x12\x1f\n" +
+	"\vnext_cursor\x18\x04 \x01(\tR\n" +
+	"nextCursor\x12\x1f\n" +
+	"\vprev_cursor\x18\x05 \x01(\tR\n" +
+	"prevCursorB;Z9github.com/kianooshaz/skeleton/api/skeleton/v1;skeletonv1b\x06proto3"
+
+var (
+	file_skeleton_v1_common_proto_rawDescOnce sync.Once
+	file_skeleton_v1_common_proto_rawDescData []byte
+)
+
+func file_skeleton_v1_common_proto_rawDescGZIP() []byte {
+	file_skeleton_v1_common_proto_rawDescOnce.Do(func() {
+		file_skeleton_v1_common_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_skeleton_v1_common_proto_rawDesc), len(file_skeleton_v1_common_proto_rawDesc)))
+	})
+	return file_skeleton_v1_common_proto_rawDescData
+}
+
+var file_skeleton_v1_common_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_skeleton_v1_common_proto_goTypes = []any{
+	(*Page)(nil),       // 0: skeleton.v1.Page
+	(*Navigation)(nil), // 1: skeleton.v1.Navigation
+}
+var file_skeleton_v1_common_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_skeleton_v1_common_proto_init() }
+func file_skeleton_v1_common_proto_init() {
+	if File_skeleton_v1_common_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_skeleton_v1_common_proto_rawDesc), len(file_skeleton_v1_common_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_skeleton_v1_common_proto_goTypes,
+		DependencyIndexes: file_skeleton_v1_common_proto_depIdxs,
+		MessageInfos:      file_skeleton_v1_common_proto_msgTypes,
+	}.Build()
+	File_skeleton_v1_common_proto = out.File
+	file_skeleton_v1_common_proto_goTypes = nil
+	file_skeleton_v1_common_proto_depIdxs = nil
+}
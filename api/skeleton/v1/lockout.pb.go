@@ -0,0 +1,165 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: skeleton/v1/lockout.proto
+
+package skeletonv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type UnlockAccountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnlockAccountRequest) Reset() {
+	*x = UnlockAccountRequest{}
+	mi := &file_skeleton_v1_lockout_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnlockAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlockAccountRequest) ProtoMessage() {}
+
+func (x *UnlockAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_skeleton_v1_lockout_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlockAccountRequest.ProtoReflect.Descriptor instead.
+func (*UnlockAccountRequest) Descriptor() ([]byte, []int) {
+	return file_skeleton_v1_lockout_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *UnlockAccountRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+type UnlockAccountResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnlockAccountResponse) Reset() {
+	*x = UnlockAccountResponse{}
+	mi := &file_skeleton_v1_lockout_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnlockAccountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlockAccountResponse) ProtoMessage() {}
+
+func (x *UnlockAccountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_skeleton_v1_lockout_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlockAccountResponse.ProtoReflect.Descriptor instead.
+func (*UnlockAccountResponse) Descriptor() ([]byte, []int) {
+	return file_skeleton_v1_lockout_proto_rawDescGZIP(), []int{1}
+}
+
+var File_skeleton_v1_lockout_proto protoreflect.FileDescriptor
+
+const file_skeleton_v1_lockout_proto_rawDesc = "" +
+	"\n" +
+	"\x19skeleton/v1/lockout.proto\x12\vskeleton.v1\"5\n" +
+	"\x14UnlockAccountRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\"\x17\n" +
+	"\x15UnlockAccountResponse2h\n" +
+	"\x0eLockoutService\x12V\n" +
+	"\rUnlockAccount\x12!.skeleton.v1.UnlockAccountRequest\x1a\".skeleton.v1.UnlockAccountResponseB;Z9github.com/kianooshaz/skeleton/api/skeleton/v1;skeletonv1b\x06proto3"
+
+var (
+	file_skeleton_v1_lockout_proto_rawDescOnce sync.Once
+	file_skeleton_v1_lockout_proto_rawDescData []byte
+)
+
+func file_skeleton_v1_lockout_proto_rawDescGZIP() []byte {
+	file_skeleton_v1_lockout_proto_rawDescOnce.Do(func() {
+		file_skeleton_v1_lockout_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_skeleton_v1_lockout_proto_rawDesc), len(file_skeleton_v1_lockout_proto_rawDesc)))
+	})
+	return file_skeleton_v1_lockout_proto_rawDescData
+}
+
+var file_skeleton_v1_lockout_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_skeleton_v1_lockout_proto_goTypes = []any{
+	(*UnlockAccountRequest)(nil),  // 0: skeleton.v1.UnlockAccountRequest
+	(*UnlockAccountResponse)(nil), // 1: skeleton.v1.UnlockAccountResponse
+}
+var file_skeleton_v1_lockout_proto_depIdxs = []int32{
+	0, // 0: skeleton.v1.LockoutService.UnlockAccount:input_type -> skeleton.v1.UnlockAccountRequest
+	1, // 1: skeleton.v1.LockoutService.UnlockAccount:output_type -> skeleton.v1.UnlockAccountResponse
+	1, // [1:2] is the sub-list for method output_type
+	0, // [0:1] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_skeleton_v1_lockout_proto_init() }
+func file_skeleton_v1_lockout_proto_init() {
+	if File_skeleton_v1_lockout_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_skeleton_v1_lockout_proto_rawDesc), len(file_skeleton_v1_lockout_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_skeleton_v1_lockout_proto_goTypes,
+		DependencyIndexes: file_skeleton_v1_lockout_proto_depIdxs,
+		MessageInfos:      file_skeleton_v1_lockout_proto_msgTypes,
+	}.Build()
+	File_skeleton_v1_lockout_proto = out.File
+	file_skeleton_v1_lockout_proto_goTypes = nil
+	file_skeleton_v1_lockout_proto_depIdxs = nil
+}
@@ -0,0 +1,129 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: skeleton/v1/lockout.proto
+
+package skeletonv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	LockoutService_UnlockAccount_FullMethodName = "/skeleton.v1.LockoutService/UnlockAccount"
+)
+
+// LockoutServiceClient is the client API for LockoutService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// LockoutService manages the locks of accounts that failed too many
+// credential attempts.
+type LockoutServiceClient interface {
+	// UnlockAccount lifts the lock of the account. Only admins may.
+	UnlockAccount(ctx context.Context, in *UnlockAccountRequest, opts ...grpc.CallOption) (*UnlockAccountResponse, error)
+}
+
+type lockoutServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewLockoutServiceClient(cc grpc.ClientConnInterface) LockoutServiceClient {
+	return &lockoutServiceClient{cc}
+}
+
+func (c *lockoutServiceClient) UnlockAccount(ctx context.Context, in *UnlockAccountRequest, opts ...grpc.CallOption) (*UnlockAccountResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnlockAccountResponse)
+	err := c.cc.Invoke(ctx, LockoutService_UnlockAccount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// LockoutServiceServer is the server API for LockoutService service.
+// All implementations must embed UnimplementedLockoutServiceServer
+// for forward compatibility.
+//
+// LockoutService manages the locks of accounts that failed too many
+// credential attempts.
+type LockoutServiceServer interface {
+	// UnlockAccount lifts the lock of the account. Only admins may.
+	UnlockAccount(context.Context, *UnlockAccountRequest) (*UnlockAccountResponse, error)
+	mustEmbedUnimplementedLockoutServiceServer()
+}
+
+// UnimplementedLockoutServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedLockoutServiceServer struct{}
+
+func (UnimplementedLockoutServiceServer) UnlockAccount(context.Context, *UnlockAccountRequest) (*UnlockAccountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnlockAccount not implemented")
+}
+func (UnimplementedLockoutServiceServer) mustEmbedUnimplementedLockoutServiceServer() {}
+func (UnimplementedLockoutServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeLockoutServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to LockoutServiceServer will
+// result in compilation errors.
+type UnsafeLockoutServiceServer interface {
+	mustEmbedUnimplementedLockoutServiceServer()
+}
+
+func RegisterLockoutServiceServer(s grpc.ServiceRegistrar, srv LockoutServiceServer) {
+	// If the following call pancis, it indicates UnimplementedLockoutServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&LockoutService_ServiceDesc, srv)
+}
+
+func _LockoutService_UnlockAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnlockAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LockoutServiceServer).UnlockAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LockoutService_UnlockAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LockoutServiceServer).UnlockAccount(ctx, req.(*UnlockAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// LockoutService_ServiceDesc is the grpc.ServiceDesc for LockoutService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var LockoutService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "skeleton.v1.LockoutService",
+	HandlerType: (*LockoutServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "UnlockAccount",
+			Handler:    _LockoutService_UnlockAccount_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "skeleton/v1/lockout.proto",
+}
@@ -0,0 +1,458 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: skeleton/v1/organization.proto
+
+package skeletonv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Organization struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ID, such as org_0190f5b2-....
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Organization) Reset() {
+	*x = Organization{}
+	mi := &file_skeleton_v1_organization_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Organization) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Organization) ProtoMessage() {}
+
+func (x *Organization) ProtoReflect() protoreflect.Message {
+	mi := &file_skeleton_v1_organization_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Organization.ProtoReflect.Descriptor instead.
+func (*Organization) Descriptor() ([]byte, []int) {
+	return file_skeleton_v1_organization_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Organization) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Organization) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type CreateOrganizationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOrganizationRequest) Reset() {
+	*x = CreateOrganizationRequest{}
+	mi := &file_skeleton_v1_organization_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrganizationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrganizationRequest) ProtoMessage() {}
+
+func (x *CreateOrganizationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_skeleton_v1_organization_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrganizationRequest.ProtoReflect.Descriptor instead.
+func (*CreateOrganizationRequest) Descriptor() ([]byte, []int) {
+	return file_skeleton_v1_organization_proto_rawDescGZIP(), []int{1}
+}
+
+type CreateOrganizationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Organization  *Organization          `protobuf:"bytes,1,opt,name=organization,proto3" json:"organization,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOrganizationResponse) Reset() {
+	*x = CreateOrganizationResponse{}
+	mi := &file_skeleton_v1_organization_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrganizationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrganizationResponse) ProtoMessage() {}
+
+func (x *CreateOrganizationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_skeleton_v1_organization_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrganizationResponse.ProtoReflect.Descriptor instead.
+func (*CreateOrganizationResponse) Descriptor() ([]byte, []int) {
+	return file_skeleton_v1_organization_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateOrganizationResponse) GetOrganization() *Organization {
+	if x != nil {
+		return x.Organization
+	}
+	return nil
+}
+
+type GetOrganizationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrganizationRequest) Reset() {
+	*x = GetOrganizationRequest{}
+	mi := &file_skeleton_v1_organization_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrganizationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrganizationRequest) ProtoMessage() {}
+
+func (x *GetOrganizationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_skeleton_v1_organization_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrganizationRequest.ProtoReflect.Descriptor instead.
+func (*GetOrganizationRequest) Descriptor() ([]byte, []int) {
+	return file_skeleton_v1_organization_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetOrganizationRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetOrganizationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Organization  *Organization          `protobuf:"bytes,1,opt,name=organization,proto3" json:"organization,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrganizationResponse) Reset() {
+	*x = GetOrganizationResponse{}
+	mi := &file_skeleton_v1_organization_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrganizationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrganizationResponse) ProtoMessage() {}
+
+func (x *GetOrganizationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_skeleton_v1_organization_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrganizationResponse.ProtoReflect.Descriptor instead.
+func (*GetOrganizationResponse) Descriptor() ([]byte, []int) {
+	return file_skeleton_v1_organization_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetOrganizationResponse) GetOrganization() *Organization {
+	if x != nil {
+		return x.Organization
+	}
+	return nil
+}
+
+type ListOrganizationsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Page  *Page                  `protobuf:"bytes,1,opt,name=page,proto3" json:"page,omitempty"`
+	// Ordering, such as "-created_at,id".
+	Sort          string `protobuf:"bytes,2,opt,name=sort,proto3" json:"sort,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrganizationsRequest) Reset() {
+	*x = ListOrganizationsRequest{}
+	mi := &file_skeleton_v1_organization_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrganizationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrganizationsRequest) ProtoMessage() {}
+
+func (x *ListOrganizationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_skeleton_v1_organization_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrganizationsRequest.ProtoReflect.Descriptor instead.
+func (*ListOrganizationsRequest) Descriptor() ([]byte, []int) {
+	return file_skeleton_v1_organization_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListOrganizationsRequest) GetPage() *Page {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+func (x *ListOrganizationsRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+type ListOrganizationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Organizations []*Organization        `protobuf:"bytes,1,rep,name=organizations,proto3" json:"organizations,omitempty"`
+	Page          *Page                  `protobuf:"bytes,2,opt,name=page,proto3" json:"page,omitempty"`
+	Navigation    *Navigation            `protobuf:"bytes,3,opt,name=navigation,proto3" json:"navigation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrganizationsResponse) Reset() {
+	*x = ListOrganizationsResponse{}
+	mi := &file_skeleton_v1_organization_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrganizationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrganizationsResponse) ProtoMessage() {}
+
+func (x *ListOrganizationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_skeleton_v1_organization_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrganizationsResponse.ProtoReflect.Descriptor instead.
+func (*ListOrganizationsResponse) Descriptor() ([]byte, []int) {
+	return file_skeleton_v1_organization_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListOrganizationsResponse) GetOrganizations() []*Organization {
+	if x != nil {
+		return x.Organizations
+	}
+	return nil
+}
+
+func (x *ListOrganizationsResponse) GetPage() *Page {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+func (x *ListOrganizationsResponse) GetNavigation() *Navigation {
+	if x != nil {
+		return x.Navigation
+	}
+	return nil
+}
+
+var File_skeleton_v1_organization_proto protoreflect.FileDescriptor
+
+const file_skeleton_v1_organization_proto_rawDesc = "" +
+	"\n" +
+	"\x1eskeleton/v1/organization.proto\x12\vskeleton.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x18skeleton/v1/common.proto\"Y\n" +
+	"\fOrganization\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x129\n" +
+	"\n" +
+	"created_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\x1b\n" +
+	"\x19CreateOrganizationRequest\"[\n" +
+	"\x1aCreateOrganizationResponse\x12=\n" +
+	"\forganization\x18\x01 \x01(\v2\x19.skeleton.v1.OrganizationR\forganization\"(\n" +
+	"\x16GetOrganizationRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"X\n" +
+	"\x17GetOrganizationResponse\x12=\n" +
+	"\forganization\x18\x01 \x01(\v2\x19.skeleton.v1.OrganizationR\forganization\"U\n" +
+	"\x18ListOrganizationsRequest\x12%\n" +
+	"\x04page\x18\x01 \x01(\v2\x11.skeleton.v1.PageR\x04page\x12\x12\n" +
+	"\x04sort\x18\x02 \x01(\tR\x04sort\"\xbc\x01\n" +
+	"\x19ListOrganizationsResponse\x12?\n" +
+	"\rorganizations\x18\x01 \x03(\v2\x19.skeleton.v1.OrganizationR\rorganizations\x12%\n" +
+	"\x04page\x18\x02 \x01(\v2\x11.skeleton.v1.PageR\x04page\x127\n" +
+	"\n" +
+	"navigation\x18\x03 \x01(\v2\x17.skeleton.v1.NavigationR\n" +
+	"navigation2\xbe\x02\n" +
+	"\x13OrganizationService\x12e\n" +
+	"\x12CreateOrganization\x12&.skeleton.v1.CreateOrganizationRequest\x1a'.skeleton.v1.CreateOrganizationResponse\x12\\\n" +
+	"\x0fGetOrganization\x12#.skeleton.v1.GetOrganizationRequest\x1a$.skeleton.v1.GetOrganizationResponse\x12b\n" +
+	"\x11ListOrganizations\x12%.skeleton.v1.ListOrganizationsRequest\x1a&.skeleton.v1.ListOrganizationsResponseB;Z9github.com/kianooshaz/skeleton/api/skeleton/v1;skeletonv1b\x06proto3"
+
+var (
+	file_skeleton_v1_organization_proto_rawDescOnce sync.Once
+	file_skeleton_v1_organization_proto_rawDescData []byte
+)
+
+func file_skeleton_v1_organization_proto_rawDescGZIP() []byte {
+	file_skeleton_v1_organization_proto_rawDescOnce.Do(func() {
+		file_skeleton_v1_organization_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_skeleton_v1_organization_proto_rawDesc), len(file_skeleton_v1_organization_proto_rawDesc)))
+	})
+	return file_skeleton_v1_organization_proto_rawDescData
+}
+
+var file_skeleton_v1_organization_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_skeleton_v1_organization_proto_goTypes = []any{
+	(*Organization)(nil),               // 0: skeleton.v1.Organization
+	(*CreateOrganizationRequest)(nil),  // 1: skeleton.v1.CreateOrganizationRequest
+	(*CreateOrganizationResponse)(nil), // 2: skeleton.v1.CreateOrganizationResponse
+	(*GetOrganizationRequest)(nil),     // 3: skeleton.v1.GetOrganizationRequest
+	(*GetOrganizationResponse)(nil),    // 4: skeleton.v1.GetOrganizationResponse
+	(*ListOrganizationsRequest)(nil),   // 5: skeleton.v1.ListOrganizationsRequest
+	(*ListOrganizationsResponse)(nil),  // 6: skeleton.v1.ListOrganizationsResponse
+	(*timestamppb.Timestamp)(nil),      // 7: google.protobuf.Timestamp
+	(*Page)(nil),                       // 8: skeleton.v1.Page
+	(*Navigation)(nil),                 // 9: skeleton.v1.Navigation
+}
+var file_skeleton_v1_organization_proto_depIdxs = []int32{
+	7,  // 0: skeleton.v1.Organization.created_at:type_name -> google.protobuf.Timestamp
+	0,  // 1: skeleton.v1.CreateOrganizationResponse.organization:type_name -> skeleton.v1.Organization
+	0,  // 2: skeleton.v1.GetOrganizationResponse.organization:type_name -> skeleton.v1.Organization
+	8,  // 3: skeleton.v1.ListOrganizationsRequest.page:type_name -> skeleton.v1.Page
+	0,  // 4: skeleton.v1.ListOrganizationsResponse.organizations:type_name -> skeleton.v1.Organization
+	8,  // 5: skeleton.v1.ListOrganizationsResponse.page:type_name -> skeleton.v1.Page
+	9,  // 6: skeleton.v1.ListOrganizationsResponse.navigation:type_name -> skeleton.v1.Navigation
+	1,  // 7: skeleton.v1.OrganizationService.CreateOrganization:input_type -> skeleton.v1.CreateOrganizationRequest
+	3,  // 8: skeleton.v1.OrganizationService.GetOrganization:input_type -> skeleton.v1.GetOrganizationRequest
+	5,  // 9: skeleton.v1.OrganizationService.ListOrganizations:input_type -> skeleton.v1.ListOrganizationsRequest
+	2,  // 10: skeleton.v1.OrganizationService.CreateOrganization:output_type -> skeleton.v1.CreateOrganizationResponse
+	4,  // 11: skeleton.v1.OrganizationService.GetOrganization:output_type -> skeleton.v1.GetOrganizationResponse
+	6,  // 12: skeleton.v1.OrganizationService.ListOrganizations:output_type -> skeleton.v1.ListOrganizationsResponse
+	10, // [10:13] is the sub-list for method output_type
+	7,  // [7:10] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_skeleton_v1_organization_proto_init() }
+func file_skeleton_v1_organization_proto_init() {
+	if File_skeleton_v1_organization_proto != nil {
+		return
+	}
+	file_skeleton_v1_common_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_skeleton_v1_organization_proto_rawDesc), len(file_skeleton_v1_organization_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_skeleton_v1_organization_proto_goTypes,
+		DependencyIndexes: file_skeleton_v1_organization_proto_depIdxs,
+		MessageInfos:      file_skeleton_v1_organization_proto_msgTypes,
+	}.Build()
+	File_skeleton_v1_organization_proto = out.File
+	file_skeleton_v1_organization_proto_goTypes = nil
+	file_skeleton_v1_organization_proto_depIdxs = nil
+}
@@ -0,0 +1,283 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: skeleton/v1/password.proto
+
+package skeletonv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type UpdatePasswordRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	AccountId string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	// Current password proving the change is wanted, left empty by accounts
+	// without a password yet.
+	CurrentPassword string `protobuf:"bytes,4,opt,name=current_password,json=currentPassword,proto3" json:"current_password,omitempty"`
+	NewPassword     string `protobuf:"bytes,3,opt,name=new_password,json=newPassword,proto3" json:"new_password,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdatePasswordRequest) Reset() {
+	*x = UpdatePasswordRequest{}
+	mi := &file_skeleton_v1_password_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePasswordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePasswordRequest) ProtoMessage() {}
+
+func (x *UpdatePasswordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_skeleton_v1_password_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePasswordRequest.ProtoReflect.Descriptor instead.
+func (*UpdatePasswordRequest) Descriptor() ([]byte, []int) {
+	return file_skeleton_v1_password_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *UpdatePasswordRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *UpdatePasswordRequest) GetCurrentPassword() string {
+	if x != nil {
+		return x.CurrentPassword
+	}
+	return ""
+}
+
+func (x *UpdatePasswordRequest) GetNewPassword() string {
+	if x != nil {
+		return x.NewPassword
+	}
+	return ""
+}
+
+type UpdatePasswordResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdatePasswordResponse) Reset() {
+	*x = UpdatePasswordResponse{}
+	mi := &file_skeleton_v1_password_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePasswordResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePasswordResponse) ProtoMessage() {}
+
+func (x *UpdatePasswordResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_skeleton_v1_password_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePasswordResponse.ProtoReflect.Descriptor instead.
+func (*UpdatePasswordResponse) Descriptor() ([]byte, []int) {
+	return file_skeleton_v1_password_proto_rawDescGZIP(), []int{1}
+}
+
+type GetPasswordGuidelinesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPasswordGuidelinesRequest) Reset() {
+	*x = GetPasswordGuidelinesRequest{}
+	mi := &file_skeleton_v1_password_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPasswordGuidelinesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPasswordGuidelinesRequest) ProtoMessage() {}
+
+func (x *GetPasswordGuidelinesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_skeleton_v1_password_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPasswordGuidelinesRequest.ProtoReflect.Descriptor instead.
+func (*GetPasswordGuidelinesRequest) Descriptor() ([]byte, []int) {
+	return file_skeleton_v1_password_proto_rawDescGZIP(), []int{2}
+}
+
+type GetPasswordGuidelinesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Required      []string               `protobuf:"bytes,1,rep,name=required,proto3" json:"required,omitempty"`
+	BetterHave    []string               `protobuf:"bytes,2,rep,name=better_have,json=betterHave,proto3" json:"better_have,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPasswordGuidelinesResponse) Reset() {
+	*x = GetPasswordGuidelinesResponse{}
+	mi := &file_skeleton_v1_password_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPasswordGuidelinesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPasswordGuidelinesResponse) ProtoMessage() {}
+
+func (x *GetPasswordGuidelinesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_skeleton_v1_password_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPasswordGuidelinesResponse.ProtoReflect.Descriptor instead.
+func (*GetPasswordGuidelinesResponse) Descriptor() ([]byte, []int) {
+	return file_skeleton_v1_password_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetPasswordGuidelinesResponse) GetRequired() []string {
+	if x != nil {
+		return x.Required
+	}
+	return nil
+}
+
+func (x *GetPasswordGuidelinesResponse) GetBetterHave() []string {
+	if x != nil {
+		return x.BetterHave
+	}
+	return nil
+}
+
+var File_skeleton_v1_password_proto protoreflect.FileDescriptor
+
+const file_skeleton_v1_password_proto_rawDesc = "" +
+	"\n" +
+	"\x1askeleton/v1/password.proto\x12\vskeleton.v1\"\x8f\x01\n" +
+	"\x15UpdatePasswordRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12)\n" +
+	"\x10current_password\x18\x04 \x01(\tR\x0fcurrentPassword\x12!\n" +
+	"\fnew_password\x18\x03 \x01(\tR\vnewPasswordJ\x04\b\x02\x10\x03R\x03otp\"\x18\n" +
+	"\x16UpdatePasswordResponse\"\x1e\n" +
+	"\x1cGetPasswordGuidelinesRequest\"\\\n" +
+	"\x1dGetPasswordGuidelinesResponse\x12\x1a\n" +
+	"\brequired\x18\x01 \x03(\tR\brequired\x12\x1f\n" +
+	"\vbetter_have\x18\x02 \x03(\tR\n" +
+	"betterHave2\xdc\x01\n" +
+	"\x0fPasswordService\x12Y\n" +
+	"\x0eUpdatePassword\x12\".skeleton.v1.UpdatePasswordRequest\x1a#.skeleton.v1.UpdatePasswordResponse\x12n\n" +
+	"\x15GetPasswordGuidelines\x12).skeleton.v1.GetPasswordGuidelinesRequest\x1a*.skeleton.v1.GetPasswordGuidelinesResponseB;Z9github.com/kianooshaz/skeleton/api/skeleton/v1;skeletonv1b\x06proto3"
+
+var (
+	file_skeleton_v1_password_proto_rawDescOnce sync.Once
+	file_skeleton_v1_password_proto_rawDescData []byte
+)
+
+func file_skeleton_v1_password_proto_rawDescGZIP() []byte {
+	file_skeleton_v1_password_proto_rawDescOnce.Do(func() {
+		file_skeleton_v1_password_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_skeleton_v1_password_proto_rawDesc), len(file_skeleton_v1_password_proto_rawDesc)))
+	})
+	return file_skeleton_v1_password_proto_rawDescData
+}
+
+var file_skeleton_v1_password_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_skeleton_v1_password_proto_goTypes = []any{
+	(*UpdatePasswordRequest)(nil),         // 0: skeleton.v1.UpdatePasswordRequest
+	(*UpdatePasswordResponse)(nil),        // 1: skeleton.v1.UpdatePasswordResponse
+	(*GetPasswordGuidelinesRequest)(nil),  // 2: skeleton.v1.GetPasswordGuidelinesRequest
+	(*GetPasswordGuidelinesResponse)(nil), // 3: skeleton.v1.GetPasswordGuidelinesResponse
+}
+var file_skeleton_v1_password_proto_depIdxs = []int32{
+	0, // 0: skeleton.v1.PasswordService.UpdatePassword:input_type -> skeleton.v1.UpdatePasswordRequest
+	2, // 1: skeleton.v1.PasswordService.GetPasswordGuidelines:input_type -> skeleton.v1.GetPasswordGuidelinesRequest
+	1, // 2: skeleton.v1.PasswordService.UpdatePassword:output_type -> skeleton.v1.UpdatePasswordResponse
+	3, // 3: skeleton.v1.PasswordService.GetPasswordGuidelines:output_type -> skeleton.v1.GetPasswordGuidelinesResponse
+	2, // [2:4] is the sub-list for method output_type
+	0, // [0:2] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_skeleton_v1_password_proto_init() }
+func file_skeleton_v1_password_proto_init() {
+	if File_skeleton_v1_password_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_skeleton_v1_password_proto_rawDesc), len(file_skeleton_v1_password_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_skeleton_v1_password_proto_goTypes,
+		DependencyIndexes: file_skeleton_v1_password_proto_depIdxs,
+		MessageInfos:      file_skeleton_v1_password_proto_msgTypes,
+	}.Build()
+	File_skeleton_v1_password_proto = out.File
+	file_skeleton_v1_password_proto_goTypes = nil
+	file_skeleton_v1_password_proto_depIdxs = nil
+}
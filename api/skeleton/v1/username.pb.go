@@ -0,0 +1,991 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: skeleton/v1/username.proto
+
+package skeletonv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Username struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Username string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	// ID of the account, such as acc_0190f5b2-....
+	AccountId string `protobuf:"bytes,3,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	// Names of the status flags set, such as "primary".
+	Status        []string               `protobuf:"bytes,4,rep,name=status,proto3" json:"status,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Username) Reset() {
+	*x = Username{}
+	mi := &file_skeleton_v1_username_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Username) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Username) ProtoMessage() {}
+
+func (x *Username) ProtoReflect() protoreflect.Message {
+	mi := &file_skeleton_v1_username_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Username.ProtoReflect.Descriptor instead.
+func (*Username) Descriptor() ([]byte, []int) {
+	return file_skeleton_v1_username_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Username) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Username) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *Username) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *Username) GetStatus() []string {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *Username) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Username) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// ListedUsername is a username as listings describe it.
+type ListedUsername struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	AccountId     string                 `protobuf:"bytes,3,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Primary       bool                   `protobuf:"varint,4,opt,name=primary,proto3" json:"primary,omitempty"`
+	Locked        bool                   `protobuf:"varint,5,opt,name=locked,proto3" json:"locked,omitempty"`
+	Blocked       bool                   `protobuf:"varint,6,opt,name=blocked,proto3" json:"blocked,omitempty"`
+	Reserved      bool                   `protobuf:"varint,7,opt,name=reserved,proto3" json:"reserved,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListedUsername) Reset() {
+	*x = ListedUsername{}
+	mi := &file_skeleton_v1_username_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListedUsername) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListedUsername) ProtoMessage() {}
+
+func (x *ListedUsername) ProtoReflect() protoreflect.Message {
+	mi := &file_skeleton_v1_username_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListedUsername.ProtoReflect.Descriptor instead.
+func (*ListedUsername) Descriptor() ([]byte, []int) {
+	return file_skeleton_v1_username_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListedUsername) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ListedUsername) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *ListedUsername) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *ListedUsername) GetPrimary() bool {
+	if x != nil {
+		return x.Primary
+	}
+	return false
+}
+
+func (x *ListedUsername) GetLocked() bool {
+	if x != nil {
+		return x.Locked
+	}
+	return false
+}
+
+func (x *ListedUsername) GetBlocked() bool {
+	if x != nil {
+		return x.Blocked
+	}
+	return false
+}
+
+func (x *ListedUsername) GetReserved() bool {
+	if x != nil {
+		return x.Reserved
+	}
+	return false
+}
+
+type AssignUsernameRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssignUsernameRequest) Reset() {
+	*x = AssignUsernameRequest{}
+	mi := &file_skeleton_v1_username_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignUsernameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignUsernameRequest) ProtoMessage() {}
+
+func (x *AssignUsernameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_skeleton_v1_username_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignUsernameRequest.ProtoReflect.Descriptor instead.
+func (*AssignUsernameRequest) Descriptor() ([]byte, []int) {
+	return file_skeleton_v1_username_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *AssignUsernameRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *AssignUsernameRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+type AssignUsernameResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      *Username              `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssignUsernameResponse) Reset() {
+	*x = AssignUsernameResponse{}
+	mi := &file_skeleton_v1_username_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignUsernameResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignUsernameResponse) ProtoMessage() {}
+
+func (x *AssignUsernameResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_skeleton_v1_username_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignUsernameResponse.ProtoReflect.Descriptor instead.
+func (*AssignUsernameResponse) Descriptor() ([]byte, []int) {
+	return file_skeleton_v1_username_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *AssignUsernameResponse) GetUsername() *Username {
+	if x != nil {
+		return x.Username
+	}
+	return nil
+}
+
+type GetUsernameRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUsernameRequest) Reset() {
+	*x = GetUsernameRequest{}
+	mi := &file_skeleton_v1_username_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUsernameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUsernameRequest) ProtoMessage() {}
+
+func (x *GetUsernameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_skeleton_v1_username_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUsernameRequest.ProtoReflect.Descriptor instead.
+func (*GetUsernameRequest) Descriptor() ([]byte, []int) {
+	return file_skeleton_v1_username_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetUsernameRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetUsernameResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      *Username              `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUsernameResponse) Reset() {
+	*x = GetUsernameResponse{}
+	mi := &file_skeleton_v1_username_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUsernameResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUsernameResponse) ProtoMessage() {}
+
+func (x *GetUsernameResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_skeleton_v1_username_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUsernameResponse.ProtoReflect.Descriptor instead.
+func (*GetUsernameResponse) Descriptor() ([]byte, []int) {
+	return file_skeleton_v1_username_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetUsernameResponse) GetUsername() *Username {
+	if x != nil {
+		return x.Username
+	}
+	return nil
+}
+
+type ListUsernamesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Page  *Page                  `protobuf:"bytes,1,opt,name=page,proto3" json:"page,omitempty"`
+	// Ordering, such as "username".
+	Sort      string  `protobuf:"bytes,2,opt,name=sort,proto3" json:"sort,omitempty"`
+	Username  *string `protobuf:"bytes,3,opt,name=username,proto3,oneof" json:"username,omitempty"`
+	AccountId *string `protobuf:"bytes,4,opt,name=account_id,json=accountId,proto3,oneof" json:"account_id,omitempty"`
+	// Names of the status flags the usernames must all carry.
+	StatusAll []string `protobuf:"bytes,5,rep,name=status_all,json=statusAll,proto3" json:"status_all,omitempty"`
+	// Names of the status flags the usernames must carry at least one of.
+	StatusAny []string `protobuf:"bytes,6,rep,name=status_any,json=statusAny,proto3" json:"status_any,omitempty"`
+	// Names of the status flags the usernames must not carry.
+	StatusNone    []string `protobuf:"bytes,7,rep,name=status_none,json=statusNone,proto3" json:"status_none,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsernamesRequest) Reset() {
+	*x = ListUsernamesRequest{}
+	mi := &file_skeleton_v1_username_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsernamesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsernamesRequest) ProtoMessage() {}
+
+func (x *ListUsernamesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_skeleton_v1_username_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsernamesRequest.ProtoReflect.Descriptor instead.
+func (*ListUsernamesRequest) Descriptor() ([]byte, []int) {
+	return file_skeleton_v1_username_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListUsernamesRequest) GetPage() *Page {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+func (x *ListUsernamesRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListUsernamesRequest) GetUsername() string {
+	if x != nil && x.Username != nil {
+		return *x.Username
+	}
+	return ""
+}
+
+func (x *ListUsernamesRequest) GetAccountId() string {
+	if x != nil && x.AccountId != nil {
+		return *x.AccountId
+	}
+	return ""
+}
+
+func (x *ListUsernamesRequest) GetStatusAll() []string {
+	if x != nil {
+		return x.StatusAll
+	}
+	return nil
+}
+
+func (x *ListUsernamesRequest) GetStatusAny() []string {
+	if x != nil {
+		return x.StatusAny
+	}
+	return nil
+}
+
+func (x *ListUsernamesRequest) GetStatusNone() []string {
+	if x != nil {
+		return x.StatusNone
+	}
+	return nil
+}
+
+type ListUsernamesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Usernames     []*ListedUsername      `protobuf:"bytes,1,rep,name=usernames,proto3" json:"usernames,omitempty"`
+	Page          *Page                  `protobuf:"bytes,2,opt,name=page,proto3" json:"page,omitempty"`
+	Navigation    *Navigation            `protobuf:"bytes,3,opt,name=navigation,proto3" json:"navigation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsernamesResponse) Reset() {
+	*x = ListUsernamesResponse{}
+	mi := &file_skeleton_v1_username_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsernamesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsernamesResponse) ProtoMessage() {}
+
+func (x *ListUsernamesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_skeleton_v1_username_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsernamesResponse.ProtoReflect.Descriptor instead.
+func (*ListUsernamesResponse) Descriptor() ([]byte, []int) {
+	return file_skeleton_v1_username_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListUsernamesResponse) GetUsernames() []*ListedUsername {
+	if x != nil {
+		return x.Usernames
+	}
+	return nil
+}
+
+func (x *ListUsernamesResponse) GetPage() *Page {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+func (x *ListUsernamesResponse) GetNavigation() *Navigation {
+	if x != nil {
+		return x.Navigation
+	}
+	return nil
+}
+
+type ListAssignedUsernamesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Page          *Page                  `protobuf:"bytes,2,opt,name=page,proto3" json:"page,omitempty"`
+	Sort          string                 `protobuf:"bytes,3,opt,name=sort,proto3" json:"sort,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAssignedUsernamesRequest) Reset() {
+	*x = ListAssignedUsernamesRequest{}
+	mi := &file_skeleton_v1_username_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAssignedUsernamesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAssignedUsernamesRequest) ProtoMessage() {}
+
+func (x *ListAssignedUsernamesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_skeleton_v1_username_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAssignedUsernamesRequest.ProtoReflect.Descriptor instead.
+func (*ListAssignedUsernamesRequest) Descriptor() ([]byte, []int) {
+	return file_skeleton_v1_username_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListAssignedUsernamesRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *ListAssignedUsernamesRequest) GetPage() *Page {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+func (x *ListAssignedUsernamesRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+type ListAssignedUsernamesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Usernames     []*ListedUsername      `protobuf:"bytes,1,rep,name=usernames,proto3" json:"usernames,omitempty"`
+	Page          *Page                  `protobuf:"bytes,2,opt,name=page,proto3" json:"page,omitempty"`
+	Navigation    *Navigation            `protobuf:"bytes,3,opt,name=navigation,proto3" json:"navigation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAssignedUsernamesResponse) Reset() {
+	*x = ListAssignedUsernamesResponse{}
+	mi := &file_skeleton_v1_username_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAssignedUsernamesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAssignedUsernamesResponse) ProtoMessage() {}
+
+func (x *ListAssignedUsernamesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_skeleton_v1_username_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAssignedUsernamesResponse.ProtoReflect.Descriptor instead.
+func (*ListAssignedUsernamesResponse) Descriptor() ([]byte, []int) {
+	return file_skeleton_v1_username_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListAssignedUsernamesResponse) GetUsernames() []*ListedUsername {
+	if x != nil {
+		return x.Usernames
+	}
+	return nil
+}
+
+func (x *ListAssignedUsernamesResponse) GetPage() *Page {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+func (x *ListAssignedUsernamesResponse) GetNavigation() *Navigation {
+	if x != nil {
+		return x.Navigation
+	}
+	return nil
+}
+
+type UnassignUsernameRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnassignUsernameRequest) Reset() {
+	*x = UnassignUsernameRequest{}
+	mi := &file_skeleton_v1_username_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnassignUsernameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnassignUsernameRequest) ProtoMessage() {}
+
+func (x *UnassignUsernameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_skeleton_v1_username_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnassignUsernameRequest.ProtoReflect.Descriptor instead.
+func (*UnassignUsernameRequest) Descriptor() ([]byte, []int) {
+	return file_skeleton_v1_username_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *UnassignUsernameRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type UnassignUsernameResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnassignUsernameResponse) Reset() {
+	*x = UnassignUsernameResponse{}
+	mi := &file_skeleton_v1_username_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnassignUsernameResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnassignUsernameResponse) ProtoMessage() {}
+
+func (x *UnassignUsernameResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_skeleton_v1_username_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnassignUsernameResponse.ProtoReflect.Descriptor instead.
+func (*UnassignUsernameResponse) Descriptor() ([]byte, []int) {
+	return file_skeleton_v1_username_proto_rawDescGZIP(), []int{11}
+}
+
+type SetPrimaryUsernameRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetPrimaryUsernameRequest) Reset() {
+	*x = SetPrimaryUsernameRequest{}
+	mi := &file_skeleton_v1_username_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetPrimaryUsernameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetPrimaryUsernameRequest) ProtoMessage() {}
+
+func (x *SetPrimaryUsernameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_skeleton_v1_username_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetPrimaryUsernameRequest.ProtoReflect.Descriptor instead.
+func (*SetPrimaryUsernameRequest) Descriptor() ([]byte, []int) {
+	return file_skeleton_v1_username_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SetPrimaryUsernameRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type SetPrimaryUsernameResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetPrimaryUsernameResponse) Reset() {
+	*x = SetPrimaryUsernameResponse{}
+	mi := &file_skeleton_v1_username_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetPrimaryUsernameResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetPrimaryUsernameResponse) ProtoMessage() {}
+
+func (x *SetPrimaryUsernameResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_skeleton_v1_username_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetPrimaryUsernameResponse.ProtoReflect.Descriptor instead.
+func (*SetPrimaryUsernameResponse) Descriptor() ([]byte, []int) {
+	return file_skeleton_v1_username_proto_rawDescGZIP(), []int{13}
+}
+
+var File_skeleton_v1_username_proto protoreflect.FileDescriptor
+
+const file_skeleton_v1_username_proto_rawDesc = "" +
+	"\n" +
+	"\x1askeleton/v1/username.proto\x12\vskeleton.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x18skeleton/v1/common.proto\"\xe3\x01\n" +
+	"\bUsername\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x03 \x01(\tR\taccountId\x12\x16\n" +
+	"\x06status\x18\x04 \x03(\tR\x06status\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xc3\x01\n" +
+	"\x0eListedUsername\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x03 \x01(\tR\taccountId\x12\x18\n" +
+	"\aprimary\x18\x04 \x01(\bR\aprimary\x12\x16\n" +
+	"\x06locked\x18\x05 \x01(\bR\x06locked\x12\x18\n" +
+	"\ablocked\x18\x06 \x01(\bR\ablocked\x12\x1a\n" +
+	"\breserved\x18\a \x01(\bR\breserved\"R\n" +
+	"\x15AssignUsernameRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\"K\n" +
+	"\x16AssignUsernameResponse\x121\n" +
+	"\busername\x18\x01 \x01(\v2\x15.skeleton.v1.UsernameR\busername\"$\n" +
+	"\x12GetUsernameRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"H\n" +
+	"\x13GetUsernameResponse\x121\n" +
+	"\busername\x18\x01 \x01(\v2\x15.skeleton.v1.UsernameR\busername\"\x91\x02\n" +
+	"\x14ListUsernamesRequest\x12%\n" +
+	"\x04page\x18\x01 \x01(\v2\x11.skeleton.v1.PageR\x04page\x12\x12\n" +
+	"\x04sort\x18\x02 \x01(\tR\x04sort\x12\x1f\n" +
+	"\busername\x18\x03 \x01(\tH\x00R\busername\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"account_id\x18\x04 \x01(\tH\x01R\taccountId\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"status_all\x18\x05 \x03(\tR\tstatusAll\x12\x1d\n" +
+	"\n" +
+	"status_any\x18\x06 \x03(\tR\tstatusAny\x12\x1f\n" +
+	"\vstatus_none\x18\a \x03(\tR\n" +
+	"statusNoneB\v\n" +
+	"\t_usernameB\r\n" +
+	"\v_account_id\"\xb2\x01\n" +
+	"\x15ListUsernamesResponse\x129\n" +
+	"\tusernames\x18\x01 \x03(\v2\x1b.skeleton.v1.ListedUsernameR\tusernames\x12%\n" +
+	"\x04page\x18\x02 \x01(\v2\x11.skeleton.v1.PageR\x04page\x127\n" +
+	"\n" +
+	"navigation\x18\x03 \x01(\v2\x17.skeleton.v1.NavigationR\n" +
+	"navigation\"x\n" +
+	"\x1cListAssignedUsernamesRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12%\n" +
+	"\x04page\x18\x02 \x01(\v2\x11.skeleton.v1.PageR\x04page\x12\x12\n" +
+	"\x04sort\x18\x03 \x01(\tR\x04sort\"\xba\x01\n" +
+	"\x1dListAssignedUsernamesResponse\x129\n" +
+	"\tusernames\x18\x01 \x03(\v2\x1b.skeleton.v1.ListedUsernameR\tusernames\x12%\n" +
+	"\x04page\x18\x02 \x01(\v2\x11.skeleton.v1.PageR\x04page\x127\n" +
+	"\n" +
+	"navigation\x18\x03 \x01(\v2\x17.skeleton.v1.NavigationR\n" +
+	"navigation\")\n" +
+	"\x17UnassignUsernameRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x1a\n" +
+	"\x18UnassignUsernameResponse\"+\n" +
+	"\x19SetPrimaryUsernameRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x1c\n" +
+	"\x1aSetPrimaryUsernameResponse2\xce\x04\n" +
+	"\x0fUsernameService\x12Y\n" +
+	"\x0eAssignUsername\x12\".skeleton.v1.AssignUsernameRequest\x1a#.skeleton.v1.AssignUsernameResponse\x12P\n" +
+	"\vGetUsername\x12\x1f.skeleton.v1.GetUsernameRequest\x1a .skeleton.v1.GetUsernameResponse\x12V\n" +
+	"\rListUsernames\x12!.skeleton.v1.ListUsernamesRequest\x1a\".skeleton.v1.ListUsernamesResponse\x12n\n" +
+	"\x15ListAssignedUsernames\x12).skeleton.v1.ListAssignedUsernamesRequest\x1a*.skeleton.v1.ListAssignedUsernamesResponse\x12_\n" +
+	"\x10UnassignUsername\x12$.skeleton.v1.UnassignUsernameRequest\x1a%.skeleton.v1.UnassignUsernameResponse\x12e\n" +
+	"\x12SetPrimaryUsername\x12&.skeleton.v1.SetPrimaryUsernameRequest\x1a'.skeleton.v1.SetPrimaryUsernameResponseB;Z9github.com/kianooshaz/skeleton/api/skeleton/v1;skeletonv1b\x06proto3"
+
+var (
+	file_skeleton_v1_username_proto_rawDescOnce sync.Once
+	file_skeleton_v1_username_proto_rawDescData []byte
+)
+
+func file_skeleton_v1_username_proto_rawDescGZIP() []byte {
+	file_skeleton_v1_username_proto_rawDescOnce.Do(func() {
+		file_skeleton_v1_username_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_skeleton_v1_username_proto_rawDesc), len(file_skeleton_v1_username_proto_rawDesc)))
+	})
+	return file_skeleton_v1_username_proto_rawDescData
+}
+
+var file_skeleton_v1_username_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_skeleton_v1_username_proto_goTypes = []any{
+	(*Username)(nil),                      // 0: skeleton.v1.Username
+	(*ListedUsername)(nil),                // 1: skeleton.v1.ListedUsername
+	(*AssignUsernameRequest)(nil),         // 2: skeleton.v1.AssignUsernameRequest
+	(*AssignUsernameResponse)(nil),        // 3: skeleton.v1.AssignUsernameResponse
+	(*GetUsernameRequest)(nil),            // 4: skeleton.v1.GetUsernameRequest
+	(*GetUsernameResponse)(nil),           // 5: skeleton.v1.GetUsernameResponse
+	(*ListUsernamesRequest)(nil),          // 6: skeleton.v1.ListUsernamesRequest
+	(*ListUsernamesResponse)(nil),         // 7: skeleton.v1.ListUsernamesResponse
+	(*ListAssignedUsernamesRequest)(nil),  // 8: skeleton.v1.ListAssignedUsernamesRequest
+	(*ListAssignedUsernamesResponse)(nil), // 9: skeleton.v1.ListAssignedUsernamesResponse
+	(*UnassignUsernameRequest)(nil),       // 10: skeleton.v1.UnassignUsernameRequest
+	(*UnassignUsernameResponse)(nil),      // 11: skeleton.v1.UnassignUsernameResponse
+	(*SetPrimaryUsernameRequest)(nil),     // 12: skeleton.v1.SetPrimaryUsernameRequest
+	(*SetPrimaryUsernameResponse)(nil),    // 13: skeleton.v1.SetPrimaryUsernameResponse
+	(*timestamppb.Timestamp)(nil),         // 14: google.protobuf.Timestamp
+	(*Page)(nil),                          // 15: skeleton.v1.Page
+	(*Navigation)(nil),                    // 16: skeleton.v1.Navigation
+}
+var file_skeleton_v1_username_proto_depIdxs = []int32{
+	14, // 0: skeleton.v1.Username.created_at:type_name -> google.protobuf.Timestamp
+	14, // 1: skeleton.v1.Username.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 2: skeleton.v1.AssignUsernameResponse.username:type_name -> skeleton.v1.Username
+	0,  // 3: skeleton.v1.GetUsernameResponse.username:type_name -> skeleton.v1.Username
+	15, // 4: skeleton.v1.ListUsernamesRequest.page:type_name -> skeleton.v1.Page
+	1,  // 5: skeleton.v1.ListUsernamesResponse.usernames:type_name -> skeleton.v1.ListedUsername
+	15, // 6: skeleton.v1.ListUsernamesResponse.page:type_name -> skeleton.v1.Page
+	16, // 7: skeleton.v1.ListUsernamesResponse.navigation:type_name -> skeleton.v1.Navigation
+	15, // 8: skeleton.v1.ListAssignedUsernamesRequest.page:type_name -> skeleton.v1.Page
+	1,  // 9: skeleton.v1.ListAssignedUsernamesResponse.usernames:type_name -> skeleton.v1.ListedUsername
+	15, // 10: skeleton.v1.ListAssignedUsernamesResponse.page:type_name -> skeleton.v1.Page
+	16, // 11: skeleton.v1.ListAssignedUsernamesResponse.navigation:type_name -> skeleton.v1.Navigation
+	2,  // 12: skeleton.v1.UsernameService.AssignUsername:input_type -> skeleton.v1.AssignUsernameRequest
+	4,  // 13: skeleton.v1.UsernameService.GetUsername:input_type -> skeleton.v1.GetUsernameRequest
+	6,  // 14: skeleton.v1.UsernameService.ListUsernames:input_type -> skeleton.v1.ListUsernamesRequest
+	8,  // 15: skeleton.v1.UsernameService.ListAssignedUsernames:input_type -> skeleton.v1.ListAssignedUsernamesRequest
+	10, // 16: skeleton.v1.UsernameService.UnassignUsername:input_type -> skeleton.v1.UnassignUsernameRequest
+	12, // 17: skeleton.v1.UsernameService.SetPrimaryUsername:input_type -> skeleton.v1.SetPrimaryUsernameRequest
+	3,  // 18: skeleton.v1.UsernameService.AssignUsername:output_type -> skeleton.v1.AssignUsernameResponse
+	5,  // 19: skeleton.v1.UsernameService.GetUsername:output_type -> skeleton.v1.GetUsernameResponse
+	7,  // 20: skeleton.v1.UsernameService.ListUsernames:output_type -> skeleton.v1.ListUsernamesResponse
+	9,  // 21: skeleton.v1.UsernameService.ListAssignedUsernames:output_type -> skeleton.v1.ListAssignedUsernamesResponse
+	11, // 22: skeleton.v1.UsernameService.UnassignUsername:output_type -> skeleton.v1.UnassignUsernameResponse
+	13, // 23: skeleton.v1.UsernameService.SetPrimaryUsername:output_type -> skeleton.v1.SetPrimaryUsernameResponse
+	18, // [18:24] is the sub-list for method output_type
+	12, // [12:18] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
+}
+
+func init() { file_skeleton_v1_username_proto_init() }
+func file_skeleton_v1_username_proto_init() {
+	if File_skeleton_v1_username_proto != nil {
+		return
+	}
+	file_skeleton_v1_common_proto_init()
+	file_skeleton_v1_username_proto_msgTypes[6].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_skeleton_v1_username_proto_rawDesc), len(file_skeleton_v1_username_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   14,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_skeleton_v1_username_proto_goTypes,
+		DependencyIndexes: file_skeleton_v1_username_proto_depIdxs,
+		MessageInfos:      file_skeleton_v1_username_proto_msgTypes,
+	}.Build()
+	File_skeleton_v1_username_proto = out.File
+	file_skeleton_v1_username_proto_goTypes = nil
+	file_skeleton_v1_username_proto_depIdxs = nil
+}
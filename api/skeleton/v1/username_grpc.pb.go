@@ -0,0 +1,317 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: skeleton/v1/username.proto
+
+package skeletonv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	UsernameService_AssignUsername_FullMethodName        = "/skeleton.v1.UsernameService/AssignUsername"
+	UsernameService_GetUsername_FullMethodName           = "/skeleton.v1.UsernameService/GetUsername"
+	UsernameService_ListUsernames_FullMethodName         = "/skeleton.v1.UsernameService/ListUsernames"
+	UsernameService_ListAssignedUsernames_FullMethodName = "/skeleton.v1.UsernameService/ListAssignedUsernames"
+	UsernameService_UnassignUsername_FullMethodName      = "/skeleton.v1.UsernameService/UnassignUsername"
+	UsernameService_SetPrimaryUsername_FullMethodName    = "/skeleton.v1.UsernameService/SetPrimaryUsername"
+)
+
+// UsernameServiceClient is the client API for UsernameService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// UsernameService assigns usernames to accounts.
+type UsernameServiceClient interface {
+	AssignUsername(ctx context.Context, in *AssignUsernameRequest, opts ...grpc.CallOption) (*AssignUsernameResponse, error)
+	GetUsername(ctx context.Context, in *GetUsernameRequest, opts ...grpc.CallOption) (*GetUsernameResponse, error)
+	ListUsernames(ctx context.Context, in *ListUsernamesRequest, opts ...grpc.CallOption) (*ListUsernamesResponse, error)
+	ListAssignedUsernames(ctx context.Context, in *ListAssignedUsernamesRequest, opts ...grpc.CallOption) (*ListAssignedUsernamesResponse, error)
+	UnassignUsername(ctx context.Context, in *UnassignUsernameRequest, opts ...grpc.CallOption) (*UnassignUsernameResponse, error)
+	// SetPrimaryUsername makes a username the primary one of its account.
+	SetPrimaryUsername(ctx context.Context, in *SetPrimaryUsernameRequest, opts ...grpc.CallOption) (*SetPrimaryUsernameResponse, error)
+}
+
+type usernameServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewUsernameServiceClient(cc grpc.ClientConnInterface) UsernameServiceClient {
+	return &usernameServiceClient{cc}
+}
+
+func (c *usernameServiceClient) AssignUsername(ctx context.Context, in *AssignUsernameRequest, opts ...grpc.CallOption) (*AssignUsernameResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AssignUsernameResponse)
+	err := c.cc.Invoke(ctx, UsernameService_AssignUsername_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usernameServiceClient) GetUsername(ctx context.Context, in *GetUsernameRequest, opts ...grpc.CallOption) (*GetUsernameResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUsernameResponse)
+	err := c.cc.Invoke(ctx, UsernameService_GetUsername_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usernameServiceClient) ListUsernames(ctx context.Context, in *ListUsernamesRequest, opts ...grpc.CallOption) (*ListUsernamesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListUsernamesResponse)
+	err := c.cc.Invoke(ctx, UsernameService_ListUsernames_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usernameServiceClient) ListAssignedUsernames(ctx context.Context, in *ListAssignedUsernamesRequest, opts ...grpc.CallOption) (*ListAssignedUsernamesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAssignedUsernamesResponse)
+	err := c.cc.Invoke(ctx, UsernameService_ListAssignedUsernames_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usernameServiceClient) UnassignUsername(ctx context.Context, in *UnassignUsernameRequest, opts ...grpc.CallOption) (*UnassignUsernameResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnassignUsernameResponse)
+	err := c.cc.Invoke(ctx, UsernameService_UnassignUsername_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usernameServiceClient) SetPrimaryUsername(ctx context.Context, in *SetPrimaryUsernameRequest, opts ...grpc.CallOption) (*SetPrimaryUsernameResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetPrimaryUsernameResponse)
+	err := c.cc.Invoke(ctx, UsernameService_SetPrimaryUsername_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UsernameServiceServer is the server API for UsernameService service.
+// All implementations must embed UnimplementedUsernameServiceServer
+// for forward compatibility.
+//
+// UsernameService assigns usernames to accounts.
+type UsernameServiceServer interface {
+	AssignUsername(context.Context, *AssignUsernameRequest) (*AssignUsernameResponse, error)
+	GetUsername(context.Context, *GetUsernameRequest) (*GetUsernameResponse, error)
+	ListUsernames(context.Context, *ListUsernamesRequest) (*ListUsernamesResponse, error)
+	ListAssignedUsernames(context.Context, *ListAssignedUsernamesRequest) (*ListAssignedUsernamesResponse, error)
+	UnassignUsername(context.Context, *UnassignUsernameRequest) (*UnassignUsernameResponse, error)
+	// SetPrimaryUsername makes a username the primary one of its account.
+	SetPrimaryUsername(context.Context, *SetPrimaryUsernameRequest) (*SetPrimaryUsernameResponse, error)
+	mustEmbedUnimplementedUsernameServiceServer()
+}
+
+// UnimplementedUsernameServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedUsernameServiceServer struct{}
+
+func (UnimplementedUsernameServiceServer) AssignUsername(context.Context, *AssignUsernameRequest) (*AssignUsernameResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AssignUsername not implemented")
+}
+func (UnimplementedUsernameServiceServer) GetUsername(context.Context, *GetUsernameRequest) (*GetUsernameResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUsername not implemented")
+}
+func (UnimplementedUsernameServiceServer) ListUsernames(context.Context, *ListUsernamesRequest) (*ListUsernamesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUsernames not implemented")
+}
+func (UnimplementedUsernameServiceServer) ListAssignedUsernames(context.Context, *ListAssignedUsernamesRequest) (*ListAssignedUsernamesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAssignedUsernames not implemented")
+}
+func (UnimplementedUsernameServiceServer) UnassignUsername(context.Context, *UnassignUsernameRequest) (*UnassignUsernameResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnassignUsername not implemented")
+}
+func (UnimplementedUsernameServiceServer) SetPrimaryUsername(context.Context, *SetPrimaryUsernameRequest) (*SetPrimaryUsernameResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetPrimaryUsername not implemented")
+}
+func (UnimplementedUsernameServiceServer) mustEmbedUnimplementedUsernameServiceServer() {}
+func (UnimplementedUsernameServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafeUsernameServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to UsernameServiceServer will
+// result in compilation errors.
+type UnsafeUsernameServiceServer interface {
+	mustEmbedUnimplementedUsernameServiceServer()
+}
+
+func RegisterUsernameServiceServer(s grpc.ServiceRegistrar, srv UsernameServiceServer) {
+	// If the following call pancis, it indicates UnimplementedUsernameServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&UsernameService_ServiceDesc, srv)
+}
+
+func _UsernameService_AssignUsername_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AssignUsernameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsernameServiceServer).AssignUsername(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UsernameService_AssignUsername_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsernameServiceServer).AssignUsername(ctx, req.(*AssignUsernameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UsernameService_GetUsername_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUsernameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsernameServiceServer).GetUsername(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UsernameService_GetUsername_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsernameServiceServer).GetUsername(ctx, req.(*GetUsernameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UsernameService_ListUsernames_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUsernamesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsernameServiceServer).ListUsernames(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UsernameService_ListUsernames_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsernameServiceServer).ListUsernames(ctx, req.(*ListUsernamesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UsernameService_ListAssignedUsernames_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAssignedUsernamesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsernameServiceServer).ListAssignedUsernames(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UsernameService_ListAssignedUsernames_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsernameServiceServer).ListAssignedUsernames(ctx, req.(*ListAssignedUsernamesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UsernameService_UnassignUsername_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnassignUsernameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsernameServiceServer).UnassignUsername(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UsernameService_UnassignUsername_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsernameServiceServer).UnassignUsername(ctx, req.(*UnassignUsernameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UsernameService_SetPrimaryUsername_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetPrimaryUsernameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsernameServiceServer).SetPrimaryUsername(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UsernameService_SetPrimaryUsername_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsernameServiceServer).SetPrimaryUsername(ctx, req.(*SetPrimaryUsernameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// UsernameService_ServiceDesc is the grpc.ServiceDesc for UsernameService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var UsernameService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "skeleton.v1.UsernameService",
+	HandlerType: (*UsernameServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AssignUsername",
+			Handler:    _UsernameService_AssignUsername_Handler,
+		},
+		{
+			MethodName: "GetUsername",
+			Handler:    _UsernameService_GetUsername_Handler,
+		},
+		{
+			MethodName: "ListUsernames",
+			Handler:    _UsernameService_ListUsernames_Handler,
+		},
+		{
+			MethodName: "ListAssignedUsernames",
+			Handler:    _UsernameService_ListAssignedUsernames_Handler,
+		},
+		{
+			MethodName: "UnassignUsername",
+			Handler:    _UsernameService_UnassignUsername_Handler,
+		},
+		{
+			MethodName: "SetPrimaryUsername",
+			Handler:    _UsernameService_SetPrimaryUsername_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "skeleton/v1/username.proto",
+}
@@ -31,19 +31,24 @@ func run() error {
 
 	// Start all services
 	if err := c.Start(cancel); err != nil {
-		cancel()
+		return fmt.Errorf("starting container: %w", err)
 	}
 
 	c.Logger().Info("Application started successfully")
 
-	waitForShutdown(ctx, c)
+	if err := waitForShutdown(ctx, c); err != nil {
+		return fmt.Errorf("stopping container: %w", err)
+	}
 
 	c.Logger().Info("Application shut down gracefully")
 
 	return nil
 }
 
-func waitForShutdown(ctx context.Context, c container.Container) {
+// waitForShutdown stops c on a shutdown signal, or once ctx is cancelled
+// because a component failed. It returns the errors of the components that
+// failed to stop or failed while running.
+func waitForShutdown(ctx context.Context, c container.Container) error {
 	// Setup signal handling
 	shutdown := make(chan os.Signal, 1)
 	signal.Notify(shutdown, syscall.SIGINT, syscall.SIGTERM, os.Interrupt)
@@ -57,7 +62,5 @@ func waitForShutdown(ctx context.Context, c container.Container) {
 		c.Logger().Info("Context cancelled, shutting down")
 	}
 
-	if err := c.Stop(); err != nil {
-		c.Logger().Error("Error stopping container", "error", err)
-	}
+	return c.Stop()
 }
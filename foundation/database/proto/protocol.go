@@ -7,7 +7,8 @@ import (
 )
 
 var (
-	ErrRowNotFound = errors.New("row not found")
+	ErrRowNotFound  = errors.New("row not found")
+	ErrDuplicateKey = errors.New("duplicate key")
 )
 
 type QueryExecutor interface {
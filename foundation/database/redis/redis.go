@@ -0,0 +1,48 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+type Config struct {
+	Address     string        `yaml:"address"      validate:"required"`
+	Password    string        `yaml:"password"`
+	DB          int           `yaml:"db"`
+	PingTimeout time.Duration `yaml:"ping_timeout"`
+}
+
+var defaultPingTimeout = 10 * time.Second
+
+// NewClient creates a new Redis client and checks that the server is reachable.
+func NewClient(cfg Config) (*goredis.Client, error) {
+	client := Open(cfg)
+
+	pingTimeout := cfg.PingTimeout
+	if pingTimeout == 0 {
+		pingTimeout = defaultPingTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), pingTimeout)
+	defer cancel()
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		_ = client.Close()
+		return nil, fmt.Errorf("error pinging redis: %w", err)
+	}
+
+	return client, nil
+}
+
+// Open creates a new Redis client without contacting the server. Use it when
+// the application must start while Redis is down.
+func Open(cfg Config) *goredis.Client {
+	return goredis.NewClient(&goredis.Options{
+		Addr:     cfg.Address,
+		Password: cfg.Password,
+		DB:       cfg.DB,
+	})
+}
@@ -16,11 +16,23 @@ var ErrPageValueTooSmall = errors.New("100008")
 var ErrRowsValueTooSmall = errors.New("100009")
 var ErrRowsValueTooLarge = errors.New("100010")
 var ErrRateLimitExceeded = errors.New("100011")
+var ErrInvalidCursor = errors.New("100012")
+var ErrUnauthenticated = errors.New("100013")
+var ErrInvalidStatusTransition = errors.New("100014")
+var ErrUnknownStatusFlag = errors.New("100015")
+var ErrInvalidID = errors.New("100016")
+var ErrInvalidRequest = errors.New("100017")
+var ErrUnsupportedVersion = errors.New("100018")
+var ErrInvalidIdempotencyKey = errors.New("100019")
+var ErrIdempotencyKeyInUse = errors.New("100020")
+var ErrIdempotencyKeyReused = errors.New("100021")
+var ErrForbidden = errors.New("100022")
 
 // user errors.
 var ErrUserIDRequired = errors.New("100100")
 var ErrUserNotFound = errors.New("100101")
 var ErrUserAlreadyExists = errors.New("100102")
+var ErrUserNotGuest = errors.New("100103")
 
 var ErrPasswordInvalid = errors.New("100200")
 var ErrPasswordIsWeak = errors.New("100201")
@@ -42,3 +54,5 @@ var ErrOrganizationIDRequired = errors.New("100400")
 var ErrOrganizationNotFound = errors.New("100401")
 
 var ErrAccountIDRequired = errors.New("100500")
+var ErrAccountLocked = errors.New("100501")
+var ErrTooManyAttempts = errors.New("100502")
@@ -0,0 +1,260 @@
+// Package export writes rows as downloadable files, CSV or NDJSON, one row at
+// a time so listings can be streamed rather than held in memory. The columns
+// of a row type are its fields as named in JSON, so files carry the same
+// fields as the JSON API.
+package export
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Format is a file format rows are exported in.
+type Format string
+
+const (
+	CSV    Format = "csv"
+	NDJSON Format = "ndjson"
+)
+
+// mediaTypes maps the formats to their media types.
+var mediaTypes = map[Format]string{
+	CSV:    "text/csv",
+	NDJSON: "application/x-ndjson",
+}
+
+// Formats returns every format, in a stable order.
+func Formats() []Format {
+	return []Format{CSV, NDJSON}
+}
+
+// ParseFormat returns the format named name, such as csv.
+func ParseFormat(name string) (Format, bool) {
+	format := Format(strings.ToLower(name))
+	_, ok := mediaTypes[format]
+
+	return format, ok
+}
+
+// FormatOf returns the format of a media type, such as text/csv.
+func FormatOf(mediaType string) (Format, bool) {
+	for format, formatMediaType := range mediaTypes {
+		if strings.EqualFold(mediaType, formatMediaType) {
+			return format, true
+		}
+	}
+
+	return "", false
+}
+
+// MediaType returns the media type of the format, such as text/csv.
+func (f Format) MediaType() string {
+	return mediaTypes[f]
+}
+
+// Column is a column of exported rows.
+type Column struct {
+	Name  string
+	index []int
+	// omitZero leaves the cell empty when the field is zero, as JSON omits it.
+	omitZero bool
+}
+
+// Columns returns the columns of rows of type T: its exported fields named by
+// their json tag, with the fields of embedded structs in place of them.
+// Fields tagged json:"-" are left out.
+func Columns[T any]() []Column {
+	return columns(reflect.TypeFor[T](), nil)
+}
+
+func columns(t reflect.Type, index []int) []Column {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var all []Column
+	for i := range t.NumField() {
+		field := t.Field(i)
+		fieldIndex := append(append([]int(nil), index...), i)
+
+		tag, hasTag := field.Tag.Lookup("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" {
+			all = append(all, columns(field.Type, fieldIndex)...)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if !hasTag || name == "" {
+			name = field.Name
+		}
+
+		omitZero := slices.Contains(strings.Split(options, ","), "omitzero")
+		all = append(all, Column{Name: name, index: fieldIndex, omitZero: omitZero})
+	}
+
+	return all
+}
+
+// Writer writes rows of type T to a file.
+type Writer[T any] struct {
+	format  Format
+	columns []Column
+	csv     *csv.Writer
+	json    *json.Encoder
+	header  bool
+}
+
+// NewWriter returns a writer of rows to w in the format.
+func NewWriter[T any](w io.Writer, format Format) *Writer[T] {
+	writer := &Writer[T]{format: format, columns: Columns[T]()}
+
+	switch format {
+	case CSV:
+		writer.csv = csv.NewWriter(w)
+	default:
+		writer.json = json.NewEncoder(w)
+	}
+
+	return writer
+}
+
+// Write writes row. Rows may be buffered until Flush or Close.
+func (w *Writer[T]) Write(row T) error {
+	if w.json != nil {
+		return w.json.Encode(row)
+	}
+
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+
+	value := reflect.ValueOf(row)
+	record := make([]string, 0, len(w.columns))
+	for _, column := range w.columns {
+		field, err := value.FieldByIndexErr(column.index)
+		if err != nil || (column.omitZero && isZero(field)) {
+			// The fields of nil embedded pointers are left empty, like zero
+			// fields that JSON omits.
+			record = append(record, "")
+			continue
+		}
+
+		cell, err := cell(field)
+		if err != nil {
+			return fmt.Errorf("column %s: %w", column.Name, err)
+		}
+		record = append(record, cell)
+	}
+
+	return w.csv.Write(record)
+}
+
+// Flush writes the buffered rows.
+func (w *Writer[T]) Flush() error {
+	if w.csv == nil {
+		return nil
+	}
+
+	w.csv.Flush()
+
+	return w.csv.Error()
+}
+
+// Close writes what is left of the file, such as the header of a CSV file
+// without rows, and flushes it.
+func (w *Writer[T]) Close() error {
+	if w.csv != nil {
+		if err := w.writeHeader(); err != nil {
+			return err
+		}
+	}
+
+	return w.Flush()
+}
+
+func (w *Writer[T]) writeHeader() error {
+	if w.header {
+		return nil
+	}
+	w.header = true
+
+	names := make([]string, 0, len(w.columns))
+	for _, column := range w.columns {
+		names = append(names, column.Name)
+	}
+
+	return w.csv.Write(names)
+}
+
+var textMarshaler = reflect.TypeFor[encoding.TextMarshaler]()
+
+// isZero reports whether field is zero the way the omitzero option of
+// encoding/json tells: by its IsZero method, if it has one.
+func isZero(field reflect.Value) bool {
+	if field.Kind() == reflect.Pointer && field.IsNil() {
+		return true
+	}
+
+	if zeroer, ok := field.Interface().(interface{ IsZero() bool }); ok {
+		return zeroer.IsZero()
+	}
+
+	return field.IsZero()
+}
+
+// cell renders a field as a CSV cell: its text form when it has one, and its
+// JSON form otherwise, with strings unquoted and null left empty.
+func cell(field reflect.Value) (string, error) {
+	if field.Kind() == reflect.Pointer && field.IsNil() {
+		return "", nil
+	}
+
+	if field.Type().Implements(textMarshaler) {
+		text, err := field.Interface().(encoding.TextMarshaler).MarshalText()
+		return string(text), err
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		return field.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(field.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(field.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(field.Uint(), 10), nil
+	}
+
+	encoded, err := json.Marshal(field.Interface())
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case bytes.Equal(encoded, []byte("null")):
+		return "", nil
+	case len(encoded) > 0 && encoded[0] == '"':
+		var s string
+		err := json.Unmarshal(encoded, &s)
+		return s, err
+	default:
+		return string(encoded), nil
+	}
+}
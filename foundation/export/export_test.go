@@ -0,0 +1,115 @@
+package export_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kianooshaz/skeleton/foundation/export"
+	"github.com/kianooshaz/skeleton/foundation/stat"
+	"github.com/kianooshaz/skeleton/foundation/types"
+)
+
+type audited struct {
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type row struct {
+	Name   string              `json:"name"`
+	Note   string              `json:"note,omitempty"`
+	Count  int                 `json:"count"`
+	Active bool                `json:"active"`
+	Status stat.Status         `json:"status"`
+	Score  types.Nullable[int] `json:"score"`
+	Data   json.RawMessage     `json:"data"`
+	Ends   time.Time           `json:"ends,omitzero"`
+	Secret string              `json:"-"`
+	audited
+}
+
+func TestColumns(t *testing.T) {
+	var names []string
+	for _, column := range export.Columns[row]() {
+		names = append(names, column.Name)
+	}
+
+	assert.Equal(t, []string{"name", "note", "count", "active", "status", "score", "data", "ends", "created_at"}, names)
+}
+
+func TestWriter(t *testing.T) {
+	rows := []row{
+		{
+			Name:    "first, with a comma",
+			Count:   3,
+			Active:  true,
+			Status:  stat.Primary | stat.Locked,
+			Score:   types.NewNullable(7),
+			Data:    json.RawMessage(`{"a":1}`),
+			Ends:    time.Date(2027, 1, 2, 3, 4, 5, 0, time.UTC),
+			Secret:  "hidden",
+			audited: audited{CreatedAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)},
+		},
+		{Name: "second"},
+	}
+
+	tests := []struct {
+		name   string
+		format export.Format
+		rows   []row
+		want   string
+	}{
+		{
+			name:   "csv",
+			format: export.CSV,
+			rows:   rows,
+			want: "name,note,count,active,status,score,data,ends,created_at\n" +
+				`"first, with a comma",,3,true,"locked,primary",7,"{""a"":1}",2027-01-02T03:04:05Z,2026-01-02T03:04:05Z` + "\n" +
+				"second,,0,false,,,,,0001-01-01T00:00:00Z\n",
+		},
+		{
+			name:   "csv without rows has a header",
+			format: export.CSV,
+			want:   "name,note,count,active,status,score,data,ends,created_at\n",
+		},
+		{
+			name:   "ndjson",
+			format: export.NDJSON,
+			rows:   rows[1:],
+			want:   `{"name":"second","count":0,"active":false,"status":[],"score":null,"data":null,"created_at":"0001-01-01T00:00:00Z"}` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out strings.Builder
+			w := export.NewWriter[row](&out, tt.format)
+
+			// Execute.
+			for _, r := range tt.rows {
+				require.NoError(t, w.Write(r))
+			}
+			require.NoError(t, w.Close())
+
+			// Assert.
+			assert.Equal(t, tt.want, out.String())
+		})
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	format, ok := export.ParseFormat("CSV")
+	require.True(t, ok)
+	assert.Equal(t, export.CSV, format)
+	assert.Equal(t, "text/csv", format.MediaType())
+
+	format, ok = export.FormatOf("application/x-ndjson")
+	require.True(t, ok)
+	assert.Equal(t, export.NDJSON, format)
+
+	_, ok = export.ParseFormat("xlsx")
+	assert.False(t, ok)
+}
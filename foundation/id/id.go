@@ -0,0 +1,142 @@
+// Package id defines typed identifiers. Every entity declares a Kind and its
+// ID is an ID of that kind, so IDs of different entities cannot be mixed up
+// while all of them behave the same in SQL, JSON, text and request binding.
+package id
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/kianooshaz/skeleton/foundation/derror"
+)
+
+// Kind is the kind of entity an ID identifies. Kinds are empty structs; their
+// Prefix, such as "usr", is put before the UUID in the external representation
+// of the ID. An empty prefix leaves the UUID bare.
+type Kind interface {
+	Prefix() string
+}
+
+// ID is a UUID identifying an entity of kind K. It is stored as a UUID and
+// written as "<prefix>_<uuid>" in JSON, text and request parameters. Parsing
+// accepts the bare UUID too, so clients that predate the prefix keep working.
+//
+// Being a UUID underneath, an ID converts to and from uuid.UUID.
+type ID[K Kind] uuid.UUID
+
+// New returns a new time-ordered (version 7) ID.
+func New[K Kind]() (ID[K], error) {
+	u, err := uuid.NewV7()
+	if err != nil {
+		return ID[K]{}, err
+	}
+
+	return ID[K](u), nil
+}
+
+// MustNew is like New but panics when no ID can be generated, which only
+// happens when the system runs out of randomness.
+func MustNew[K Kind]() ID[K] {
+	id, err := New[K]()
+	if err != nil {
+		panic(err)
+	}
+
+	return id
+}
+
+// Parse parses the external representation of an ID, with or without its prefix.
+func Parse[K Kind](s string) (ID[K], error) {
+	if prefix := prefix[K](); prefix != "" {
+		s = strings.TrimPrefix(s, prefix+"_")
+	}
+
+	u, err := uuid.Parse(s)
+	if err != nil {
+		return ID[K]{}, fmt.Errorf("%w: %q", derror.ErrInvalidID, s)
+	}
+
+	return ID[K](u), nil
+}
+
+// MustParse is like Parse but panics when s is not an ID. It is meant for
+// IDs known at compile time.
+func MustParse[K Kind](s string) ID[K] {
+	id, err := Parse[K](s)
+	if err != nil {
+		panic(err)
+	}
+
+	return id
+}
+
+// Valid reports whether s is the external representation of a non-zero ID of kind K.
+func Valid[K Kind](s string) bool {
+	id, err := Parse[K](s)
+	return err == nil && !id.IsZero()
+}
+
+func prefix[K Kind]() string {
+	var kind K
+	return kind.Prefix()
+}
+
+// UUID returns the UUID of the ID.
+func (i ID[K]) UUID() uuid.UUID {
+	return uuid.UUID(i)
+}
+
+// IsZero reports whether the ID is unset.
+func (i ID[K]) IsZero() bool {
+	return uuid.UUID(i) == uuid.Nil
+}
+
+// Compare returns -1, 0 or +1 as i sorts before, with or after other. IDs made
+// by New sort in the order they were made.
+func (i ID[K]) Compare(other ID[K]) int {
+	return bytes.Compare(i[:], other[:])
+}
+
+// String returns the bare UUID, as it is stored. Use MarshalText, or encode the
+// ID, for the external representation.
+func (i ID[K]) String() string {
+	return uuid.UUID(i).String()
+}
+
+// MarshalText implements encoding.TextMarshaler with the external representation.
+func (i ID[K]) MarshalText() ([]byte, error) {
+	if prefix := prefix[K](); prefix != "" {
+		return []byte(prefix + "_" + i.String()), nil
+	}
+
+	return []byte(i.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (i *ID[K]) UnmarshalText(text []byte) error {
+	parsed, err := Parse[K](string(text))
+	if err != nil {
+		return err
+	}
+	*i = parsed
+
+	return nil
+}
+
+// UnmarshalParam binds path, query and form parameters.
+func (i *ID[K]) UnmarshalParam(param string) error {
+	return i.UnmarshalText([]byte(param))
+}
+
+// Value implements driver.Valuer.
+func (i ID[K]) Value() (driver.Value, error) {
+	return uuid.UUID(i).Value()
+}
+
+// Scan implements sql.Scanner.
+func (i *ID[K]) Scan(value any) error {
+	return (*uuid.UUID)(i).Scan(value)
+}
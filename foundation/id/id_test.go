@@ -0,0 +1,138 @@
+package id_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kianooshaz/skeleton/foundation/derror"
+	"github.com/kianooshaz/skeleton/foundation/id"
+)
+
+type userKind struct{}
+
+func (userKind) Prefix() string { return "usr" }
+
+type recordKind struct{}
+
+func (recordKind) Prefix() string { return "" }
+
+type (
+	userID   = id.ID[userKind]
+	recordID = id.ID[recordKind]
+)
+
+func TestNew(t *testing.T) {
+	first, err := id.New[userKind]()
+	require.NoError(t, err)
+	second := id.MustNew[userKind]()
+
+	assert.False(t, first.IsZero())
+	assert.Equal(t, uuid.Version(7), first.UUID().Version())
+	assert.Less(t, first.String(), second.String(), "IDs are time-ordered")
+	assert.Equal(t, -1, first.Compare(second))
+	assert.Equal(t, 1, second.Compare(first))
+	assert.Equal(t, 0, first.Compare(first))
+}
+
+func TestParse(t *testing.T) {
+	u := uuid.MustParse("0190b0a2-7c3e-7000-8000-000000000001")
+
+	tests := []struct {
+		name    string
+		parse   func() (any, error)
+		want    any
+		wantErr error
+	}{
+		{
+			name:  "prefixed",
+			parse: func() (any, error) { return id.Parse[userKind]("usr_" + u.String()) },
+			want:  userID(u),
+		},
+		{
+			name:  "bare",
+			parse: func() (any, error) { return id.Parse[userKind](u.String()) },
+			want:  userID(u),
+		},
+		{
+			name:    "prefix of another kind",
+			parse:   func() (any, error) { return id.Parse[userKind]("org_" + u.String()) },
+			wantErr: derror.ErrInvalidID,
+		},
+		{
+			name:    "prefix on an unprefixed kind",
+			parse:   func() (any, error) { return id.Parse[recordKind]("usr_" + u.String()) },
+			wantErr: derror.ErrInvalidID,
+		},
+		{
+			name:    "not a uuid",
+			parse:   func() (any, error) { return id.Parse[userKind]("usr_42") },
+			wantErr: derror.ErrInvalidID,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.parse()
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	assert.True(t, id.Valid[userKind]("usr_"+u.String()))
+	assert.False(t, id.Valid[userKind](uuid.Nil.String()))
+	assert.Panics(t, func() { id.MustParse[userKind]("usr_42") })
+}
+
+func TestID_JSON(t *testing.T) {
+	u := uuid.MustParse("0190b0a2-7c3e-7000-8000-000000000001")
+
+	data, err := json.Marshal(struct {
+		User   userID   `json:"user"`
+		Record recordID `json:"record"`
+	}{userID(u), recordID(u)})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"user":"usr_`+u.String()+`","record":"`+u.String()+`"}`, string(data))
+
+	var decoded userID
+	require.NoError(t, json.Unmarshal([]byte(`"usr_`+u.String()+`"`), &decoded))
+	assert.Equal(t, userID(u), decoded)
+	assert.Equal(t, u.String(), decoded.String(), "String is the stored form")
+}
+
+func TestID_SQL(t *testing.T) {
+	original := id.MustNew[userKind]()
+
+	value, err := original.Value()
+	require.NoError(t, err)
+	assert.Equal(t, original.String(), value)
+
+	var scanned userID
+	require.NoError(t, scanned.Scan(value))
+	assert.Equal(t, original, scanned)
+}
+
+func TestID_Bind(t *testing.T) {
+	u := uuid.New()
+
+	var req struct {
+		User userID `query:"user"`
+	}
+	c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/?user=usr_"+u.String(), nil), httptest.NewRecorder())
+	require.NoError(t, (&echo.DefaultBinder{}).BindQueryParams(c, &req))
+	assert.Equal(t, userID(u), req.User)
+
+	c = echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/?user=usr_42", nil), httptest.NewRecorder())
+	require.ErrorIs(t, (&echo.DefaultBinder{}).BindQueryParams(c, &req), derror.ErrInvalidID)
+}
@@ -0,0 +1,14 @@
+package idempotency
+
+import "time"
+
+func (ms *MemoryStore) SetClock(now func() time.Time)   { ms.now = now }
+func (ps *PostgresStore) SetClock(now func() time.Time) { ps.now = now }
+
+// Keys returns the number of keys the store holds a record for.
+func (ms *MemoryStore) Keys() int {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	return len(ms.entries)
+}
@@ -0,0 +1,87 @@
+// Package idempotency stores the responses of requests made under an
+// idempotency key, so retries of a request can be answered with the response
+// of the first one instead of running it again.
+//
+// A request first reserves its key. The reservation fails when another request
+// holds the key, either still running or with its response stored; the record
+// of the key then tells which, and the fingerprint of the request that made it.
+package idempotency
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	dbproto "github.com/kianooshaz/skeleton/foundation/database/proto"
+)
+
+// Store keeps the records of idempotency keys.
+type Store interface {
+	// Reserve claims key for a request with the given fingerprint, for at most
+	// lockTimeout, unless another request holds it. It returns whether the key
+	// was claimed and, when it was not, the record of the key.
+	Reserve(ctx context.Context, key, fingerprint string, lockTimeout time.Duration) (Record, bool, error)
+	// Complete stores the response of the request that reserved key, and keeps
+	// it for ttl.
+	Complete(ctx context.Context, key string, record Record, ttl time.Duration) error
+	// Release gives up the reservation of key, so the request can be retried.
+	// Keys holding a response are kept.
+	Release(ctx context.Context, key string) error
+}
+
+// Record is what a store keeps for a key.
+type Record struct {
+	// Fingerprint identifies the request the key was reserved for.
+	Fingerprint string `json:"fingerprint"`
+	// Response is the response of the request, or nil while it is running.
+	Response *Response `json:"response,omitempty"`
+}
+
+// Response is a stored response.
+type Response struct {
+	Status int                 `json:"status"`
+	Header map[string][]string `json:"header,omitempty"`
+	Body   []byte              `json:"body,omitempty"`
+}
+
+// Backend names where a store keeps its records.
+type Backend string
+
+const (
+	// BackendMemory keeps the records in the process.
+	BackendMemory Backend = "memory"
+	// BackendRedis shares the records between instances through Redis.
+	BackendRedis Backend = "redis"
+	// BackendPostgres shares the records between instances through PostgreSQL.
+	BackendPostgres Backend = "postgres"
+)
+
+// NeedsRedis reports whether the backend uses Redis. An empty backend means BackendRedis.
+func (b Backend) NeedsRedis() bool {
+	return b == "" || b == BackendRedis
+}
+
+// New creates a store on the given backend. redisClient is only used by
+// BackendRedis, and conn by BackendPostgres.
+func New(backend Backend, redisClient goredis.Cmdable, conn dbproto.QueryExecutor) (Store, error) {
+	switch backend {
+	case "", BackendRedis:
+		if redisClient == nil {
+			return nil, fmt.Errorf("idempotency: backend %s needs a redis client", BackendRedis)
+		}
+
+		return NewRedisStore(redisClient), nil
+	case BackendMemory:
+		return NewMemoryStore(), nil
+	case BackendPostgres:
+		if conn == nil {
+			return nil, fmt.Errorf("idempotency: backend %s needs a database connection", backend)
+		}
+
+		return NewPostgresStore(conn), nil
+	default:
+		return nil, fmt.Errorf("idempotency: unknown backend %q", backend)
+	}
+}
@@ -0,0 +1,88 @@
+package idempotency
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// memorySweepInterval is how often a MemoryStore evicts expired keys.
+const memorySweepInterval = time.Minute
+
+// MemoryStore keeps the records in the process. Every instance of the
+// application has its own, so it suits single instances and tests.
+type MemoryStore struct {
+	mu        sync.Mutex
+	now       func() time.Time
+	entries   map[string]memoryEntry
+	lastSweep time.Time
+}
+
+type memoryEntry struct {
+	record    Record
+	expiresAt time.Time
+}
+
+// NewMemoryStore creates an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		now:     time.Now,
+		entries: make(map[string]memoryEntry),
+	}
+}
+
+func (ms *MemoryStore) Reserve(_ context.Context, key, fingerprint string, lockTimeout time.Duration) (Record, bool, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	now := ms.now()
+	ms.sweep(now)
+
+	if entry, ok := ms.entries[key]; ok && now.Before(entry.expiresAt) {
+		return entry.record, false, nil
+	}
+
+	ms.entries[key] = memoryEntry{
+		record:    Record{Fingerprint: fingerprint},
+		expiresAt: now.Add(lockTimeout),
+	}
+
+	return Record{}, true, nil
+}
+
+func (ms *MemoryStore) Complete(_ context.Context, key string, record Record, ttl time.Duration) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	ms.entries[key] = memoryEntry{
+		record:    record,
+		expiresAt: ms.now().Add(ttl),
+	}
+
+	return nil
+}
+
+func (ms *MemoryStore) Release(_ context.Context, key string) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	if entry, ok := ms.entries[key]; ok && entry.record.Response == nil {
+		delete(ms.entries, key)
+	}
+
+	return nil
+}
+
+// sweep evicts the expired keys, at most once per memorySweepInterval.
+func (ms *MemoryStore) sweep(now time.Time) {
+	if now.Sub(ms.lastSweep) < memorySweepInterval {
+		return
+	}
+	ms.lastSweep = now
+
+	for key, entry := range ms.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(ms.entries, key)
+		}
+	}
+}
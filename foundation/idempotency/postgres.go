@@ -0,0 +1,127 @@
+package idempotency
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"time"
+
+	dbproto "github.com/kianooshaz/skeleton/foundation/database/proto"
+	"github.com/kianooshaz/skeleton/foundation/session"
+)
+
+// PostgresStore keeps the records in the idempotency_keys table of schema.sql,
+// shared by every instance of the application. Expired rows are taken over by
+// the next request for their key, and removed by DeleteExpired.
+type PostgresStore struct {
+	conn dbproto.QueryExecutor
+	now  func() time.Time
+}
+
+//go:embed queries/reserve.sql
+var reserveQuery string
+
+//go:embed queries/get.sql
+var getQuery string
+
+//go:embed queries/complete.sql
+var completeQuery string
+
+//go:embed queries/release.sql
+var releaseQuery string
+
+//go:embed queries/delete_expired.sql
+var deleteExpiredQuery string
+
+// errKeyVanished is returned when the key held by another request keeps being
+// released between the attempts to reserve it and to read its record.
+var errKeyVanished = errors.New("idempotency: key released while being reserved")
+
+// reserveAttempts is how many times a key is tried before giving up with errKeyVanished.
+const reserveAttempts = 3
+
+// NewPostgresStore creates a PostgresStore on conn.
+func NewPostgresStore(conn dbproto.QueryExecutor) *PostgresStore {
+	return &PostgresStore{
+		conn: conn,
+		now:  time.Now,
+	}
+}
+
+func (ps *PostgresStore) Reserve(ctx context.Context, key, fingerprint string, lockTimeout time.Duration) (Record, bool, error) {
+	conn := session.GetDBConnection(ctx, ps.conn)
+
+	for range reserveAttempts {
+		now := ps.now().UTC()
+
+		result, err := conn.ExecContext(ctx, reserveQuery, key, fingerprint, now.Add(lockTimeout), now)
+		if err != nil {
+			return Record{}, false, err
+		}
+
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return Record{}, false, err
+		}
+		if rows == 1 {
+			return Record{}, true, nil
+		}
+
+		var (
+			record   Record
+			response []byte
+		)
+		err = conn.QueryRowContext(ctx, getQuery, key, now).Scan(&record.Fingerprint, &response)
+		if errors.Is(err, sql.ErrNoRows) {
+			// The key was released or expired after the reservation failed.
+			continue
+		}
+		if err != nil {
+			return Record{}, false, err
+		}
+
+		if response != nil {
+			if err := json.Unmarshal(response, &record.Response); err != nil {
+				return Record{}, false, err
+			}
+		}
+
+		return record, false, nil
+	}
+
+	return Record{}, false, errKeyVanished
+}
+
+func (ps *PostgresStore) Complete(ctx context.Context, key string, record Record, ttl time.Duration) error {
+	response, err := json.Marshal(record.Response)
+	if err != nil {
+		return err
+	}
+
+	conn := session.GetDBConnection(ctx, ps.conn)
+
+	_, err = conn.ExecContext(ctx, completeQuery, key, record.Fingerprint, string(response), ps.now().UTC().Add(ttl))
+	return err
+}
+
+func (ps *PostgresStore) Release(ctx context.Context, key string) error {
+	conn := session.GetDBConnection(ctx, ps.conn)
+
+	_, err := conn.ExecContext(ctx, releaseQuery, key)
+	return err
+}
+
+// DeleteExpired deletes the expired keys and returns how many there were.
+func (ps *PostgresStore) DeleteExpired(ctx context.Context) (int, error) {
+	conn := session.GetDBConnection(ctx, ps.conn)
+
+	result, err := conn.ExecContext(ctx, deleteExpiredQuery, ps.now().UTC())
+	if err != nil {
+		return 0, err
+	}
+
+	rows, err := result.RowsAffected()
+	return int(rows), err
+}
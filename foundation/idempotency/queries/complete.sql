@@ -0,0 +1,6 @@
+INSERT INTO idempotency_keys (key, fingerprint, response, expires_at)
+VALUES ($1, $2, $3, $4) ON CONFLICT (key) DO
+UPDATE
+SET fingerprint = EXCLUDED.fingerprint,
+    response = EXCLUDED.response,
+    expires_at = EXCLUDED.expires_at
\ No newline at end of file
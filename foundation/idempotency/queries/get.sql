@@ -0,0 +1,5 @@
+SELECT fingerprint,
+    response
+FROM idempotency_keys
+WHERE key = $1
+    AND expires_at > $2
\ No newline at end of file
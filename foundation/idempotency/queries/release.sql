@@ -0,0 +1,3 @@
+DELETE FROM idempotency_keys
+WHERE key = $1
+    AND response IS NULL
\ No newline at end of file
@@ -0,0 +1,7 @@
+INSERT INTO idempotency_keys (key, fingerprint, response, expires_at)
+VALUES ($1, $2, NULL, $3) ON CONFLICT (key) DO
+UPDATE
+SET fingerprint = EXCLUDED.fingerprint,
+    response = NULL,
+    expires_at = EXCLUDED.expires_at
+WHERE idempotency_keys.expires_at <= $4
\ No newline at end of file
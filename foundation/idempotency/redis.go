@@ -0,0 +1,92 @@
+package idempotency
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// RedisStore keeps the records in Redis, shared by every instance of the
+// application. Each key is a hash holding the fingerprint of its request and,
+// once the request is done, its response; Redis expires it.
+type RedisStore struct {
+	client goredis.Cmdable
+}
+
+var errUnexpectedReply = errors.New("idempotency: unexpected reply from redis")
+
+// reserveLua claims KEYS[1] for the fingerprint in ARGV[1] for ARGV[2]
+// milliseconds unless it exists. It returns an empty reply when it claimed the
+// key, otherwise the fingerprint and response of the key.
+var reserveLua = `
+if redis.call("EXISTS", KEYS[1]) == 1 then
+    return redis.call("HMGET", KEYS[1], "fingerprint", "response")
+end
+
+redis.call("HSET", KEYS[1], "fingerprint", ARGV[1])
+redis.call("PEXPIRE", KEYS[1], ARGV[2])
+
+return {}
+`
+
+// releaseLua deletes KEYS[1] unless it holds a response.
+var releaseLua = `
+if redis.call("HEXISTS", KEYS[1], "response") == 0 then
+    redis.call("DEL", KEYS[1])
+end
+
+return 0
+`
+
+// NewRedisStore creates a RedisStore on client.
+func NewRedisStore(client goredis.Cmdable) *RedisStore {
+	return &RedisStore{client: client}
+}
+
+func (rs *RedisStore) Reserve(ctx context.Context, key, fingerprint string, lockTimeout time.Duration) (Record, bool, error) {
+	reply, err := rs.client.Eval(ctx, reserveLua, []string{key}, fingerprint, lockTimeout.Milliseconds()).Slice()
+	if err != nil {
+		return Record{}, false, err
+	}
+
+	if len(reply) == 0 {
+		return Record{}, true, nil
+	}
+
+	stored, ok := reply[0].(string)
+	if len(reply) != 2 || !ok {
+		return Record{}, false, errUnexpectedReply
+	}
+
+	record := Record{Fingerprint: stored}
+	if response, ok := reply[1].(string); ok {
+		if err := json.Unmarshal([]byte(response), &record.Response); err != nil {
+			return Record{}, false, err
+		}
+	}
+
+	return record, false, nil
+}
+
+func (rs *RedisStore) Complete(ctx context.Context, key string, record Record, ttl time.Duration) error {
+	response, err := json.Marshal(record.Response)
+	if err != nil {
+		return err
+	}
+
+	_, err = rs.client.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+		pipe.HSet(ctx, key, "fingerprint", record.Fingerprint, "response", response)
+		pipe.PExpire(ctx, key, ttl)
+
+		return nil
+	})
+
+	return err
+}
+
+func (rs *RedisStore) Release(ctx context.Context, key string) error {
+	return rs.client.Eval(ctx, releaseLua, []string{key}).Err()
+}
@@ -0,0 +1,12 @@
+-- Idempotency Key Database Schema
+-- This file contains the SQL schema of the postgres idempotency store.
+-- Run this manually in your PostgreSQL database to create the required tables.
+-- Create idempotency_keys table
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    key TEXT PRIMARY KEY,
+    fingerprint TEXT NOT NULL,
+    response JSONB NULL,
+    expires_at TIMESTAMP NOT NULL
+);
+-- Create indexes for better performance
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON idempotency_keys (expires_at);
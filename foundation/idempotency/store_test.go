@@ -0,0 +1,257 @@
+package idempotency_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kianooshaz/skeleton/foundation/database/postgres/postgrestest"
+	"github.com/kianooshaz/skeleton/foundation/idempotency"
+)
+
+// envRedisAddress names the variable holding the address of the Redis server the
+// Redis store is tested against. Those tests are skipped without it.
+const envRedisAddress = "TEST_REDIS_ADDRESS"
+
+var response = &idempotency.Response{
+	Status: 201,
+	Header: map[string][]string{"Content-Type": {"application/json"}},
+	Body:   []byte(`{"id":"org_1"}`),
+}
+
+func TestMemoryStore(t *testing.T) {
+	runConformance(t, func(t *testing.T) (idempotency.Store, context.Context) {
+		return idempotency.NewMemoryStore(), t.Context()
+	})
+}
+
+func TestRedisStore(t *testing.T) {
+	runConformance(t, func(t *testing.T) (idempotency.Store, context.Context) {
+		return idempotency.NewRedisStore(redisClient(t)), t.Context()
+	})
+}
+
+func TestPostgresStore(t *testing.T) {
+	db := postgrestest.Open(t, "schema.sql")
+
+	runConformance(t, func(t *testing.T) (idempotency.Store, context.Context) {
+		return idempotency.NewPostgresStore(db), postgrestest.Context(t, db, "idempotency_keys")
+	})
+}
+
+// runConformance checks the behaviour every store shares. Keys are unique to
+// each test, so stores may be shared between tests.
+func runConformance(t *testing.T, setup func(t *testing.T) (idempotency.Store, context.Context)) {
+	t.Run("reserves a new key once", func(t *testing.T) {
+		store, ctx := setup(t)
+		key := uuid.NewString()
+
+		// Execute.
+		_, first, err := store.Reserve(ctx, key, "a", time.Minute)
+		require.NoError(t, err)
+		record, second, err := store.Reserve(ctx, key, "b", time.Minute)
+		require.NoError(t, err)
+
+		// Assert.
+		assert.True(t, first)
+		assert.False(t, second)
+		assert.Equal(t, idempotency.Record{Fingerprint: "a"}, record)
+	})
+
+	t.Run("returns the stored response", func(t *testing.T) {
+		store, ctx := setup(t)
+		key := uuid.NewString()
+		_, _, err := store.Reserve(ctx, key, "a", time.Minute)
+		require.NoError(t, err)
+
+		// Execute.
+		require.NoError(t, store.Complete(ctx, key, idempotency.Record{Fingerprint: "a", Response: response}, time.Hour))
+		record, reserved, err := store.Reserve(ctx, key, "a", time.Minute)
+		require.NoError(t, err)
+
+		// Assert.
+		assert.False(t, reserved)
+		assert.Equal(t, idempotency.Record{Fingerprint: "a", Response: response}, record)
+	})
+
+	t.Run("release frees a running key", func(t *testing.T) {
+		store, ctx := setup(t)
+		key := uuid.NewString()
+		_, _, err := store.Reserve(ctx, key, "a", time.Minute)
+		require.NoError(t, err)
+
+		// Execute.
+		require.NoError(t, store.Release(ctx, key))
+		_, reserved, err := store.Reserve(ctx, key, "b", time.Minute)
+		require.NoError(t, err)
+
+		// Assert.
+		assert.True(t, reserved)
+	})
+
+	t.Run("release keeps a stored response", func(t *testing.T) {
+		store, ctx := setup(t)
+		key := uuid.NewString()
+		_, _, err := store.Reserve(ctx, key, "a", time.Minute)
+		require.NoError(t, err)
+		require.NoError(t, store.Complete(ctx, key, idempotency.Record{Fingerprint: "a", Response: response}, time.Hour))
+
+		// Execute.
+		require.NoError(t, store.Release(ctx, key))
+		record, reserved, err := store.Reserve(ctx, key, "a", time.Minute)
+		require.NoError(t, err)
+
+		// Assert.
+		assert.False(t, reserved)
+		assert.Equal(t, response, record.Response)
+	})
+}
+
+func TestExpiry(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(t *testing.T, now func() time.Time) (idempotency.Store, context.Context)
+	}{
+		{
+			name: "memory",
+			setup: func(t *testing.T, now func() time.Time) (idempotency.Store, context.Context) {
+				store := idempotency.NewMemoryStore()
+				store.SetClock(now)
+
+				return store, t.Context()
+			},
+		},
+		{
+			name: "postgres",
+			setup: func(t *testing.T, now func() time.Time) (idempotency.Store, context.Context) {
+				db := postgrestest.Open(t, "schema.sql")
+				store := idempotency.NewPostgresStore(db)
+				store.SetClock(now)
+
+				return store, postgrestest.Context(t, db, "idempotency_keys")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Now()
+			store, ctx := tt.setup(t, func() time.Time { return now })
+
+			_, _, err := store.Reserve(ctx, "running", "a", time.Minute)
+			require.NoError(t, err)
+			_, _, err = store.Reserve(ctx, "done", "a", time.Minute)
+			require.NoError(t, err)
+			require.NoError(t, store.Complete(ctx, "done", idempotency.Record{Fingerprint: "a", Response: response}, time.Hour))
+
+			// Execute.
+			now = now.Add(time.Minute)
+			_, running, err := store.Reserve(ctx, "running", "b", time.Minute)
+			require.NoError(t, err)
+			_, done, err := store.Reserve(ctx, "done", "b", time.Minute)
+			require.NoError(t, err)
+			now = now.Add(time.Hour)
+			_, expired, err := store.Reserve(ctx, "done", "b", time.Minute)
+			require.NoError(t, err)
+
+			// Assert.
+			assert.True(t, running, "the lock of a running request expires")
+			assert.False(t, done, "a stored response outlives the lock")
+			assert.True(t, expired, "a stored response expires")
+		})
+	}
+}
+
+func TestMemoryStore_EvictsExpiredKeys(t *testing.T) {
+	now := time.Now()
+	store := idempotency.NewMemoryStore()
+	store.SetClock(func() time.Time { return now })
+
+	for _, key := range []string{"a", "b", "c"} {
+		_, _, err := store.Reserve(context.Background(), key, "a", time.Minute)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 3, store.Keys())
+
+	// Execute.
+	now = now.Add(time.Minute)
+	_, _, err := store.Reserve(context.Background(), "d", "a", time.Minute)
+	require.NoError(t, err)
+
+	// Assert.
+	assert.Equal(t, 1, store.Keys())
+}
+
+func TestPostgresStore_DeleteExpired(t *testing.T) {
+	db := postgrestest.Open(t, "schema.sql")
+	ctx := postgrestest.Context(t, db, "idempotency_keys")
+	now := time.Now()
+	store := idempotency.NewPostgresStore(db)
+	store.SetClock(func() time.Time { return now })
+
+	for _, key := range []string{"a", "b"} {
+		_, _, err := store.Reserve(ctx, key, "a", time.Minute)
+		require.NoError(t, err)
+	}
+	require.NoError(t, store.Complete(ctx, "b", idempotency.Record{Fingerprint: "a", Response: response}, time.Hour))
+
+	// Execute.
+	now = now.Add(time.Minute)
+	deleted, err := store.DeleteExpired(ctx)
+
+	// Assert.
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+}
+
+func TestNew(t *testing.T) {
+	client := redis.NewClient(&redis.Options{Addr: "127.0.0.1:1"})
+	t.Cleanup(func() { _ = client.Close() })
+
+	tests := []struct {
+		name    string
+		backend idempotency.Backend
+		wantErr bool
+	}{
+		{name: "default", backend: ""},
+		{name: "redis", backend: idempotency.BackendRedis},
+		{name: "memory", backend: idempotency.BackendMemory},
+		{name: "postgres without connection", backend: idempotency.BackendPostgres, wantErr: true},
+		{name: "unknown", backend: "disk", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Execute.
+			store, err := idempotency.New(tt.backend, client, nil)
+
+			// Assert.
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, store)
+		})
+	}
+}
+
+func redisClient(t *testing.T) redis.Cmdable {
+	t.Helper()
+
+	address := os.Getenv(envRedisAddress)
+	if address == "" {
+		t.Skipf("%s is not set", envRedisAddress)
+	}
+
+	client := redis.NewClient(&redis.Options{Addr: address})
+	t.Cleanup(func() { _ = client.Close() })
+
+	return client
+}
@@ -0,0 +1,307 @@
+// Package lifecycle starts the components of the application in the order of
+// their dependencies, stops them in reverse, and keeps track of their state
+// for the health endpoints.
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+)
+
+// Component is a part of the application with a lifetime, such as a server or
+// a connection pool.
+type Component interface {
+	// Start brings the component up, returning once it is ready to be used
+	// by the components depending on it.
+	Start(ctx context.Context) error
+	// Stop releases what the component holds, by the deadline of ctx.
+	Stop(ctx context.Context) error
+}
+
+// Hooks adapts a pair of functions to a Component. Either may be nil.
+type Hooks struct {
+	OnStart func(ctx context.Context) error
+	OnStop  func(ctx context.Context) error
+}
+
+func (h Hooks) Start(ctx context.Context) error {
+	if h.OnStart == nil {
+		return nil
+	}
+
+	return h.OnStart(ctx)
+}
+
+func (h Hooks) Stop(ctx context.Context) error {
+	if h.OnStop == nil {
+		return nil
+	}
+
+	return h.OnStop(ctx)
+}
+
+// State is where a component is in its lifetime.
+type State string
+
+const (
+	// StateIdle is the state of components not started yet.
+	StateIdle     State = "idle"
+	StateStarting State = "starting"
+	StateRunning  State = "running"
+	StateStopping State = "stopping"
+	StateStopped  State = "stopped"
+	// StateFailed is the state of components that failed to start or stop,
+	// or failed while running.
+	StateFailed State = "failed"
+)
+
+// Status is the state of a component.
+type Status struct {
+	Name  string `json:"name"`
+	State State  `json:"state"`
+	// Error is why the component failed.
+	Error string `json:"error,omitempty"`
+}
+
+// Reporter reports the state of the components of the application.
+type Reporter interface {
+	Statuses() []Status
+}
+
+var (
+	errAlreadyStarted = errors.New("lifecycle: components already started")
+	errCycle          = errors.New("lifecycle: dependency cycle")
+)
+
+// Registry starts and stops the components registered with it. It is safe for
+// concurrent use.
+type Registry struct {
+	mu         sync.Mutex
+	logger     *slog.Logger
+	components map[string]*entry
+	// names are the names of the components, in the order they were registered.
+	names []string
+	// started are the names of the started components, in the order they were started.
+	started  []string
+	starting bool
+	// failures are the errors of the components that failed while running.
+	failures []error
+}
+
+type entry struct {
+	component Component
+	dependsOn []string
+	state     State
+	err       error
+}
+
+// NewRegistry creates an empty Registry.
+func NewRegistry(logger *slog.Logger) *Registry {
+	return &Registry{
+		logger:     logger,
+		components: make(map[string]*entry),
+	}
+}
+
+// Register adds the named component, which is started after the components it
+// depends on and stopped before them. Components must be registered before
+// the registry is started, under unique names.
+func (r *Registry) Register(name string, component Component, dependsOn ...string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.starting {
+		return errAlreadyStarted
+	}
+	if _, ok := r.components[name]; ok {
+		return fmt.Errorf("lifecycle: component %s is already registered", name)
+	}
+
+	r.components[name] = &entry{component: component, dependsOn: dependsOn, state: StateIdle}
+	r.names = append(r.names, name)
+
+	return nil
+}
+
+// Start starts every component after those it depends on. When a component
+// fails to start, the ones already started are stopped and its error is
+// returned.
+func (r *Registry) Start(ctx context.Context) error {
+	r.mu.Lock()
+	if r.starting {
+		r.mu.Unlock()
+		return errAlreadyStarted
+	}
+	r.starting = true
+	order, err := r.order()
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	for _, name := range order {
+		r.setState(name, StateStarting, nil)
+		r.logger.InfoContext(ctx, "Starting component", slog.String("component", name))
+
+		if err := r.components[name].component.Start(ctx); err != nil {
+			r.setState(name, StateFailed, err)
+			r.logger.ErrorContext(
+				ctx,
+				"Error encountered while starting component",
+				slog.String("error", err.Error()),
+				slog.String("component", name),
+			)
+
+			if err := r.Stop(ctx); err != nil {
+				r.logger.ErrorContext(
+					ctx,
+					"Error encountered while stopping components after failed start",
+					slog.String("error", err.Error()),
+				)
+			}
+
+			return fmt.Errorf("starting %s: %w", name, err)
+		}
+
+		r.mu.Lock()
+		r.started = append(r.started, name)
+		r.mu.Unlock()
+		r.setState(name, StateRunning, nil)
+	}
+
+	return nil
+}
+
+// Stop stops the started components, in the reverse order they were started.
+// Every component is asked to stop, even when one fails or ctx is done; the
+// errors of those that failed to stop, or failed while running, are returned
+// together.
+func (r *Registry) Stop(ctx context.Context) error {
+	r.mu.Lock()
+	started := r.started
+	r.started = nil
+	errs := r.failures
+	r.failures = nil
+	r.mu.Unlock()
+
+	for i := len(started) - 1; i >= 0; i-- {
+		name := started[i]
+		r.setState(name, StateStopping, nil)
+		r.logger.InfoContext(ctx, "Stopping component", slog.String("component", name))
+
+		if err := r.components[name].component.Stop(ctx); err != nil {
+			r.setState(name, StateFailed, err)
+			r.logger.ErrorContext(
+				ctx,
+				"Error encountered while stopping component",
+				slog.String("error", err.Error()),
+				slog.String("component", name),
+			)
+			errs = append(errs, fmt.Errorf("stopping %s: %w", name, err))
+
+			continue
+		}
+
+		r.setState(name, StateStopped, nil)
+	}
+
+	return errors.Join(errs...)
+}
+
+// Fail records that the named component failed while running. The error is
+// returned by Stop.
+func (r *Registry) Fail(name string, err error) {
+	r.setState(name, StateFailed, err)
+
+	r.mu.Lock()
+	r.failures = append(r.failures, fmt.Errorf("running %s: %w", name, err))
+	r.mu.Unlock()
+}
+
+// Statuses returns the state of every component, in the order they were registered.
+func (r *Registry) Statuses() []Status {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	statuses := make([]Status, 0, len(r.names))
+	for _, name := range r.names {
+		entry := r.components[name]
+		status := Status{Name: name, State: entry.state}
+		if entry.err != nil {
+			status.Error = entry.err.Error()
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses
+}
+
+// Ready reports whether every component in statuses is running.
+func Ready(statuses []Status) bool {
+	for _, status := range statuses {
+		if status.State != StateRunning {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (r *Registry) setState(name string, state State, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if entry, ok := r.components[name]; ok {
+		entry.state, entry.err = state, err
+	}
+}
+
+// order returns the names of the components with every component after those
+// it depends on, keeping the order of registration otherwise.
+func (r *Registry) order() ([]string, error) {
+	const (
+		visiting = 1
+		visited  = 2
+	)
+
+	marks := make(map[string]int, len(r.names))
+	order := make([]string, 0, len(r.names))
+
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		switch marks[name] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("%w: %v", errCycle, append(slices.Clip(path), name))
+		}
+
+		entry, ok := r.components[name]
+		if !ok {
+			return fmt.Errorf("lifecycle: %s depends on unregistered component %s", path[len(path)-1], name)
+		}
+
+		marks[name] = visiting
+		for _, dependency := range entry.dependsOn {
+			if err := visit(dependency, append(slices.Clip(path), name)); err != nil {
+				return err
+			}
+		}
+		marks[name] = visited
+		order = append(order, name)
+
+		return nil
+	}
+
+	for _, name := range r.names {
+		if err := visit(name, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return order, nil
+}
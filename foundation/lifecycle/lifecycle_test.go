@@ -0,0 +1,193 @@
+package lifecycle_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kianooshaz/skeleton/foundation/lifecycle"
+)
+
+var discard = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+// journal records the calls made to the components of a test, in order.
+type journal struct {
+	calls []string
+}
+
+// component returns a component recording its calls, failing to start or stop
+// with the given errors.
+func (j *journal) component(name string, startErr, stopErr error) lifecycle.Component {
+	return lifecycle.Hooks{
+		OnStart: func(context.Context) error {
+			j.calls = append(j.calls, "start "+name)
+			return startErr
+		},
+		OnStop: func(context.Context) error {
+			j.calls = append(j.calls, "stop "+name)
+			return stopErr
+		},
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type registration struct {
+		name      string
+		dependsOn []string
+		startErr  error
+		stopErr   error
+	}
+
+	tests := []struct {
+		name          string
+		registrations []registration
+		wantStartErr  error
+		wantStopErr   error
+		wantCalls     []string
+		wantStates    map[string]lifecycle.State
+	}{
+		{
+			name: "dependency order",
+			registrations: []registration{
+				{name: "web", dependsOn: []string{"users", "db"}},
+				{name: "users", dependsOn: []string{"db"}},
+				{name: "db"},
+				{name: "cache"},
+			},
+			wantCalls: []string{
+				"start db", "start users", "start web", "start cache",
+				"stop cache", "stop web", "stop users", "stop db",
+			},
+			wantStates: map[string]lifecycle.State{
+				"web": lifecycle.StateStopped, "users": lifecycle.StateStopped,
+				"db": lifecycle.StateStopped, "cache": lifecycle.StateStopped,
+			},
+		},
+		{
+			name: "failed start stops the started components",
+			registrations: []registration{
+				{name: "db"},
+				{name: "users", dependsOn: []string{"db"}, startErr: errBoom},
+				{name: "web", dependsOn: []string{"users"}},
+			},
+			wantStartErr: errBoom,
+			wantCalls:    []string{"start db", "start users", "stop db"},
+			wantStates: map[string]lifecycle.State{
+				"db": lifecycle.StateStopped, "users": lifecycle.StateFailed, "web": lifecycle.StateIdle,
+			},
+		},
+		{
+			name: "failed stop stops the others",
+			registrations: []registration{
+				{name: "db"},
+				{name: "users", dependsOn: []string{"db"}, stopErr: errBoom},
+			},
+			wantStopErr: errBoom,
+			wantCalls:   []string{"start db", "start users", "stop users", "stop db"},
+			wantStates: map[string]lifecycle.State{
+				"db": lifecycle.StateStopped, "users": lifecycle.StateFailed,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := &journal{}
+			registry := lifecycle.NewRegistry(discard)
+			for _, r := range tt.registrations {
+				require.NoError(t, registry.Register(r.name, j.component(r.name, r.startErr, r.stopErr), r.dependsOn...))
+			}
+
+			// Execute.
+			startErr := registry.Start(t.Context())
+			var stopErr error
+			if startErr == nil {
+				stopErr = registry.Stop(t.Context())
+			}
+
+			// Assert.
+			assert.ErrorIs(t, startErr, tt.wantStartErr)
+			assert.ErrorIs(t, stopErr, tt.wantStopErr)
+			assert.Equal(t, tt.wantCalls, j.calls)
+			for _, status := range registry.Statuses() {
+				assert.Equal(t, tt.wantStates[status.Name], status.State, status.Name)
+			}
+		})
+	}
+}
+
+func TestRegistry_InvalidDependencies(t *testing.T) {
+	tests := []struct {
+		name     string
+		register func(r *lifecycle.Registry)
+	}{
+		{
+			name: "unregistered",
+			register: func(r *lifecycle.Registry) {
+				_ = r.Register("web", lifecycle.Hooks{}, "db")
+			},
+		},
+		{
+			name: "cycle",
+			register: func(r *lifecycle.Registry) {
+				_ = r.Register("a", lifecycle.Hooks{}, "b")
+				_ = r.Register("b", lifecycle.Hooks{}, "a")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := &journal{}
+			registry := lifecycle.NewRegistry(discard)
+			require.NoError(t, registry.Register("first", j.component("first", nil, nil)))
+			tt.register(registry)
+
+			// Execute.
+			err := registry.Start(t.Context())
+
+			// Assert.
+			assert.Error(t, err)
+			assert.Empty(t, j.calls, "no component starts")
+		})
+	}
+}
+
+func TestRegistry_Register(t *testing.T) {
+	registry := lifecycle.NewRegistry(discard)
+	require.NoError(t, registry.Register("db", lifecycle.Hooks{}))
+
+	assert.Error(t, registry.Register("db", lifecycle.Hooks{}), "duplicate name")
+
+	require.NoError(t, registry.Start(t.Context()))
+	assert.Error(t, registry.Register("web", lifecycle.Hooks{}), "after start")
+	assert.Error(t, registry.Start(t.Context()), "started twice")
+}
+
+func TestRegistry_Fail(t *testing.T) {
+	registry := lifecycle.NewRegistry(discard)
+	require.NoError(t, registry.Register("db", lifecycle.Hooks{}))
+	require.NoError(t, registry.Register("web", lifecycle.Hooks{}, "db"))
+	require.NoError(t, registry.Start(t.Context()))
+	require.True(t, lifecycle.Ready(registry.Statuses()))
+
+	errClosed := errors.New("listener closed")
+
+	// Execute.
+	registry.Fail("web", errClosed)
+
+	// Assert.
+	assert.Equal(t, []lifecycle.Status{
+		{Name: "db", State: lifecycle.StateRunning},
+		{Name: "web", State: lifecycle.StateFailed, Error: "listener closed"},
+	}, registry.Statuses())
+	assert.False(t, lifecycle.Ready(registry.Statuses()))
+	assert.ErrorIs(t, registry.Stop(t.Context()), errClosed, "failure reported by Stop")
+}
@@ -21,7 +21,7 @@ type SessionHandler struct {
 // The method performs the following operations:
 //   - Retrieves all session log attributes from the context
 //   - Adds each attribute to the log record
-//   - Adds the request ID as a separate attribute
+//   - Adds the request ID as a separate attribute, when there is one
 //   - Delegates to the wrapped handler for final processing
 //
 // Parameters:
@@ -36,8 +36,21 @@ func (h SessionHandler) Handle(ctx context.Context, r slog.Record) error {
 	}
 
 	// Add the request ID as a dedicated attribute for request tracing
-	r.Add(slog.String("request_id", session.GetRequestID(ctx)))
+	if id := session.GetRequestID(ctx); id != "" {
+		r.Add(slog.String("request_id", id))
+	}
 
 	// Delegate to the wrapped handler for final processing
 	return h.Handler.Handle(ctx, r)
 }
+
+// WithAttrs returns a SessionHandler whose wrapped handler has the given
+// attributes, so loggers derived with With keep enriching their records.
+func (h SessionHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return SessionHandler{h.Handler.WithAttrs(attrs)}
+}
+
+// WithGroup returns a SessionHandler whose wrapped handler opens the given group.
+func (h SessionHandler) WithGroup(name string) slog.Handler {
+	return SessionHandler{h.Handler.WithGroup(name)}
+}
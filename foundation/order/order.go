@@ -1,6 +1,7 @@
 // Package order provides functionality for parsing and constructing order-by clauses.
-// Initially designed for SQL, it allows extension to support other storage types
-// by utilizing custom string builder functions.
+// Orderings are parsed from specs such as "-age,created_at" and validated against a
+// per-resource whitelist that maps API field names to storage columns. Initially
+// designed for SQL, the resolved columns can be rendered for other storage types.
 
 package order
 
@@ -17,7 +18,7 @@ const (
 // directions maps valid direction strings for validation purposes.
 var directions = map[Direction]bool{
 	ASC:  true,
-	DESC: false,
+	DESC: true,
 }
 
 // OrderBy represents an order-by clause with a field and direction.
@@ -41,3 +42,6 @@ func NewBy(field string, direction Direction) OrderBy {
 		Direction: direction,
 	}
 }
+
+// Spec is an ordered list of order-by terms; earlier terms take precedence.
+type Spec []OrderBy
@@ -1,7 +1,96 @@
 package order
 
-type ParserFunc func(string) (OrderBy, error)
+import (
+	"strings"
 
-func Parse(parser ParserFunc, orderBy string) (OrderBy, error) {
-	return parser(orderBy)
+	"github.com/kianooshaz/skeleton/foundation/derror"
+)
+
+// Parse parses an ordering spec such as "-age,created_at" or "age:desc,created_at".
+// A leading '-' or a ":desc" suffix sorts a field descending; a leading '+', an
+// ":asc" suffix or no marker sorts it ascending. An empty value yields an empty spec.
+//
+// It returns derror.ErrUnknownOrder for empty or repeated fields and
+// derror.ErrUnknownOrderDirection for an unknown direction suffix. Whether the
+// fields exist is checked later against the resource's Whitelist.
+func Parse(value string) (Spec, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+
+	terms := strings.Split(value, ",")
+	spec := make(Spec, 0, len(terms))
+	seen := make(map[string]bool, len(terms))
+
+	for _, term := range terms {
+		by, err := parseTerm(strings.TrimSpace(term))
+		if err != nil {
+			return nil, err
+		}
+
+		if seen[by.Field] {
+			return nil, derror.ErrUnknownOrder
+		}
+		seen[by.Field] = true
+
+		spec = append(spec, by)
+	}
+
+	return spec, nil
+}
+
+func parseTerm(term string) (OrderBy, error) {
+	direction := ASC
+
+	switch {
+	case strings.HasPrefix(term, "-"):
+		direction = DESC
+		term = term[1:]
+	case strings.HasPrefix(term, "+"):
+		term = term[1:]
+	}
+
+	if field, suffix, ok := strings.Cut(term, ":"); ok {
+		switch strings.ToLower(suffix) {
+		case "asc", string(ASC):
+			direction = ASC
+		case "desc", string(DESC):
+			direction = DESC
+		default:
+			return OrderBy{}, derror.ErrUnknownOrderDirection
+		}
+		term = field
+	}
+
+	if term == "" {
+		return OrderBy{}, derror.ErrUnknownOrder
+	}
+
+	return OrderBy{Field: term, Direction: direction}, nil
+}
+
+// UnmarshalParam implements echo.BindUnmarshaler so a Spec can be bound from a query parameter.
+func (s *Spec) UnmarshalParam(param string) error {
+	spec, err := Parse(param)
+	if err != nil {
+		return err
+	}
+
+	*s = spec
+	return nil
+}
+
+// String formats the spec in the form accepted by Parse.
+func (s Spec) String() string {
+	terms := make([]string, 0, len(s))
+	for _, by := range s {
+		if by.Direction == DESC {
+			terms = append(terms, "-"+by.Field)
+			continue
+		}
+		terms = append(terms, by.Field)
+	}
+
+	return strings.Join(terms, ",")
 }
@@ -0,0 +1,92 @@
+package order_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kianooshaz/skeleton/foundation/derror"
+	"github.com/kianooshaz/skeleton/foundation/order"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    order.Spec
+		wantErr error
+	}{
+		{
+			name:  "empty",
+			value: "",
+			want:  nil,
+		},
+		{
+			name:  "prefix directions",
+			value: "-age, created_at,+id",
+			want: order.Spec{
+				{Field: "age", Direction: order.DESC},
+				{Field: "created_at", Direction: order.ASC},
+				{Field: "id", Direction: order.ASC},
+			},
+		},
+		{
+			name:  "suffix directions",
+			value: "age:desc,created_at:ascending",
+			want: order.Spec{
+				{Field: "age", Direction: order.DESC},
+				{Field: "created_at", Direction: order.ASC},
+			},
+		},
+		{
+			name:    "unknown direction",
+			value:   "age:sideways",
+			wantErr: derror.ErrUnknownOrderDirection,
+		},
+		{
+			name:    "empty field",
+			value:   "age,,created_at",
+			wantErr: derror.ErrUnknownOrder,
+		},
+		{
+			name:    "repeated field",
+			value:   "age,-age",
+			wantErr: derror.ErrUnknownOrder,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := order.Parse(tt.value)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestWhitelist_Resolve(t *testing.T) {
+	whitelist := order.Whitelist{
+		Columns: map[string]string{"age": "age", "created": "created_at"},
+		Default: order.Spec{{Field: "created", Direction: order.DESC}},
+	}
+
+	columns, err := whitelist.Resolve(nil)
+	require.NoError(t, err)
+	assert.Equal(t, []order.Column{{Name: "created_at", Desc: true}}, columns)
+
+	spec, err := order.Parse("-age,created")
+	require.NoError(t, err)
+
+	columns, err = whitelist.Resolve(spec)
+	require.NoError(t, err)
+	assert.Equal(t, " ORDER BY age DESC, created_at ASC", order.SQL(columns))
+
+	_, err = whitelist.Resolve(order.Spec{{Field: "password_hash"}})
+	require.ErrorIs(t, err, derror.ErrUnknownOrder)
+}
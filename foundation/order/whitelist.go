@@ -0,0 +1,65 @@
+package order
+
+import (
+	"strings"
+
+	"github.com/kianooshaz/skeleton/foundation/derror"
+)
+
+// Column is an order-by term resolved to a storage column.
+type Column struct {
+	Name string
+	Desc bool
+}
+
+// Whitelist declares the orderable fields of a resource.
+type Whitelist struct {
+	// Columns maps API field names to storage columns.
+	Columns map[string]string
+	// Default is used when the request does not specify an ordering.
+	Default Spec
+}
+
+// Resolve validates spec against the whitelist and maps it to storage columns.
+// An empty spec resolves to the whitelist's default. It returns
+// derror.ErrUnknownOrder for fields that are not whitelisted and
+// derror.ErrUnknownOrderDirection for unknown directions.
+func (w Whitelist) Resolve(spec Spec) ([]Column, error) {
+	if len(spec) == 0 {
+		spec = w.Default
+	}
+
+	columns := make([]Column, 0, len(spec))
+	for _, by := range spec {
+		name, ok := w.Columns[by.Field]
+		if !ok {
+			return nil, derror.ErrUnknownOrder
+		}
+
+		if by.Direction != "" && !directions[by.Direction] {
+			return nil, derror.ErrUnknownOrderDirection
+		}
+
+		columns = append(columns, Column{Name: name, Desc: by.Direction == DESC})
+	}
+
+	return columns, nil
+}
+
+// SQL renders the columns as an ORDER BY clause, or an empty string for no columns.
+func SQL(columns []Column) string {
+	if len(columns) == 0 {
+		return ""
+	}
+
+	terms := make([]string, 0, len(columns))
+	for _, column := range columns {
+		if column.Desc {
+			terms = append(terms, column.Name+" DESC")
+			continue
+		}
+		terms = append(terms, column.Name+" ASC")
+	}
+
+	return " ORDER BY " + strings.Join(terms, ", ")
+}
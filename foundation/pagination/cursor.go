@@ -0,0 +1,55 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/kianooshaz/skeleton/foundation/derror"
+)
+
+// Cursor is the decoded form of an opaque pagination cursor. It identifies
+// the boundary row of a page by the values of its ORDER BY key and its ID,
+// which breaks ties between rows sharing the same key.
+type Cursor struct {
+	// Order is the ordering the cursor was issued for, as the ORDER BY columns
+	// joined by commas, descending ones prefixed with "-", such as "-created_at".
+	Order string   `json:"o"`
+	Keys  []string `json:"k"`
+	ID    string   `json:"i"`
+	// Backward is true when the cursor leads to the rows before the boundary row.
+	Backward bool `json:"b,omitempty"`
+}
+
+// Cursors holds the encoded cursors leading to the neighbouring pages of a listing.
+// An empty cursor means there is no page in that direction.
+type Cursors struct {
+	Next string
+	Prev string
+}
+
+// Encode returns the opaque string representation of the cursor.
+func (c Cursor) Encode() string {
+	data, err := json.Marshal(c)
+	if err != nil {
+		// Marshaling a struct of strings and a bool cannot fail.
+		panic(err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor parses a cursor produced by Cursor.Encode.
+// It returns derror.ErrInvalidCursor if the value was not issued by Encode.
+func DecodeCursor(value string) (Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return Cursor{}, derror.ErrInvalidCursor
+	}
+
+	var c Cursor
+	if err := json.Unmarshal(data, &c); err != nil || c.ID == "" {
+		return Cursor{}, derror.ErrInvalidCursor
+	}
+
+	return c, nil
+}
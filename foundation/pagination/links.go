@@ -0,0 +1,64 @@
+package pagination
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Links returns the RFC 8288 Link header value pointing at the first, previous,
+// next and last pages of the listing served at u, or an empty string when there
+// is nothing to link to. The query of u is kept, so filters, ordering and the
+// page size carry over.
+//
+// Keyset pages link through their cursors and have no last page; offset pages
+// link by page number and link the last page when the total is known.
+func Links(u *url.URL, page Page, nav Navigation) string {
+	var links []string
+	link := func(rel string, set func(url.Values)) {
+		query := u.Query()
+		query.Del("cursor")
+		query.Del("page_number")
+		set(query)
+
+		target := url.URL{Path: u.Path, RawQuery: query.Encode()}
+		links = append(links, fmt.Sprintf("<%s>; rel=%q", target.String(), rel))
+	}
+	number := func(n uint) func(url.Values) {
+		return func(query url.Values) { query.Set("page_number", strconv.FormatUint(uint64(n), 10)) }
+	}
+	cursor := func(c string) func(url.Values) {
+		return func(query url.Values) { query.Set("cursor", c) }
+	}
+
+	if page.IsKeyset() {
+		link("first", func(url.Values) {})
+
+		if nav.PrevCursor != "" {
+			link("prev", cursor(nav.PrevCursor))
+		}
+
+		if nav.NextCursor != "" {
+			link("next", cursor(nav.NextCursor))
+		}
+
+		return strings.Join(links, ", ")
+	}
+
+	link("first", number(1))
+
+	if page.Number() > 1 {
+		link("prev", number(page.Number()-1))
+	}
+
+	if nav.HasMore {
+		link("next", number(page.Number()+1))
+	}
+
+	if nav.TotalPage > 0 {
+		link("last", number(uint(nav.TotalPage)))
+	}
+
+	return strings.Join(links, ", ")
+}
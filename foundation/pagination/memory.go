@@ -0,0 +1,88 @@
+package pagination
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/kianooshaz/skeleton/foundation/derror"
+	"github.com/kianooshaz/skeleton/foundation/order"
+)
+
+// MemoryKeyset pages rows held in memory the way SQLKeyset pages them in a
+// database, so in-memory storages order, offset and hand out cursors like their
+// SQL counterparts. Rows are ordered by the given columns and then by their ID.
+type MemoryKeyset[T any] struct {
+	// Compare orders two rows by a whitelisted column name.
+	Compare func(a, b T, column string) int
+	// Key and ID extract the cursor of a row, as for Window.
+	Key func(T) []string
+	ID  func(T) string
+	// Lookup returns the row with the given ID. It locates the boundary row of a
+	// cursor, which may have been filtered out of the listed rows.
+	Lookup func(id string) (T, bool)
+}
+
+// Page returns the page of rows requested by page and the cursors of the pages
+// around it. It returns derror.ErrInvalidCursor if the cursor is malformed, was
+// issued for a different ordering or points at a row that no longer exists.
+func (m MemoryKeyset[T]) Page(rows []T, page Page, columns ...order.Column) ([]T, Cursors, error) {
+	k, err := NewSQLKeyset(page, "id", columns...)
+	if err != nil {
+		return nil, Cursors{}, err
+	}
+
+	compare := m.compare(k)
+	rows = slices.SortedFunc(slices.Values(rows), compare)
+
+	backward := page.IsKeyset() && k.cursor.Backward
+	if backward {
+		slices.Reverse(rows)
+	}
+
+	if page.IsKeyset() {
+		boundary, ok := m.Lookup(k.cursor.ID)
+		if !ok {
+			return nil, Cursors{}, derror.ErrInvalidCursor
+		}
+
+		rows = slices.DeleteFunc(rows, func(row T) bool {
+			c := compare(row, boundary)
+			return (backward && c >= 0) || (!backward && c <= 0)
+		})
+	} else {
+		rows = rows[min(page.Offset(), uint(len(rows))):]
+	}
+
+	rows = rows[:min(k.rows+1, uint(len(rows)))]
+
+	rows, cursors := Window(k, rows, m.Key, m.ID)
+
+	return rows, cursors, nil
+}
+
+// Sort returns every row in the order of the listing, as SQLKeyset orders a
+// listing read whole.
+func (m MemoryKeyset[T]) Sort(rows []T, columns ...order.Column) []T {
+	return slices.SortedFunc(slices.Values(rows), m.compare(SQLKeyset{columns: columns}))
+}
+
+// compare orders rows by the columns of k and then by their ID.
+func (m MemoryKeyset[T]) compare(k SQLKeyset) func(a, b T) int {
+	return func(a, b T) int {
+		for _, column := range k.columns {
+			if c := m.Compare(a, b, column.Name); c != 0 {
+				return direct(c, column.Desc)
+			}
+		}
+
+		return direct(strings.Compare(m.ID(a), m.ID(b)), k.idDesc())
+	}
+}
+
+func direct(c int, desc bool) int {
+	if desc {
+		return -c
+	}
+
+	return c
+}
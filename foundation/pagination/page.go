@@ -1,10 +1,28 @@
 // Package pagination provides utilities for managing pagination logic.
+//
+// Two styles are supported. Offset pagination addresses a page by its number
+// and size. Keyset (cursor) pagination continues a listing from an opaque
+// cursor that points at the boundary row of the previous page, which keeps
+// deep pages as cheap as the first one.
 package pagination
 
-// Page holds pagination details: page number and rows per page.
+// Page holds pagination details: page number and rows per page, or the cursor
+// of a keyset-paginated listing.
 type Page struct {
-	PageNumber uint `query:"page_number"`
-	PageRows   uint `query:"page_rows"`
+	// PageNumber is 1-based; zero addresses the first page.
+	PageNumber uint `query:"page_number" json:"page_number"`
+	PageRows   uint `query:"page_rows" json:"page_rows"`
+	// Cursor continues a keyset listing; when set PageNumber is ignored.
+	Cursor string `query:"cursor" json:"cursor,omitempty"`
+	// WithTotal requests the total row count for a keyset listing. Offset
+	// listings always count, since the number of pages depends on it.
+	WithTotal bool `query:"with_total" json:"-"`
+}
+
+// Pageable is implemented by list requests embedding Page, so binders can set
+// the page after validating it.
+type Pageable interface {
+	Paging() *Page
 }
 
 type StringerFunc func(Page) string
@@ -12,3 +30,43 @@ type StringerFunc func(Page) string
 func (p Page) String(stringer StringerFunc) string {
 	return stringer(p)
 }
+
+// Paging returns the page itself; it makes requests embedding Page implement Pageable.
+func (p *Page) Paging() *Page {
+	return p
+}
+
+// CurrentPage returns the page itself; it makes responses embedding Page implement Listing.
+func (p Page) CurrentPage() Page {
+	return p
+}
+
+// IsKeyset reports whether the page continues a listing from a cursor.
+func (p Page) IsKeyset() bool {
+	return p.Cursor != ""
+}
+
+// NeedsTotal reports whether the total row count should be computed for the page.
+func (p Page) NeedsTotal() bool {
+	return !p.IsKeyset() || p.WithTotal
+}
+
+// Number returns the 1-based page number.
+func (p Page) Number() uint {
+	return max(p.PageNumber, 1)
+}
+
+// Rows returns the page size, falling back to DefaultPolicy for pages that were
+// not bound through a Policy.
+func (p Page) Rows() uint {
+	if p.PageRows == 0 {
+		return DefaultPolicy.DefaultRows
+	}
+
+	return p.PageRows
+}
+
+// Offset returns the number of rows before the page.
+func (p Page) Offset() uint {
+	return (p.Number() - 1) * p.Rows()
+}
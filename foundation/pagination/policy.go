@@ -0,0 +1,94 @@
+package pagination
+
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/kianooshaz/skeleton/foundation/derror"
+)
+
+// Policy bounds the page size of a resource's listings.
+type Policy struct {
+	// DefaultRows is used when the request does not specify page_rows.
+	DefaultRows uint `yaml:"default_rows"`
+	// MaxRows is the largest page_rows a request may ask for.
+	MaxRows uint `yaml:"max_rows"`
+}
+
+// DefaultPolicy applies to listings without a configured policy.
+var DefaultPolicy = Policy{
+	DefaultRows: 20,
+	MaxRows:     100,
+}
+
+// Or fills the unset fields of the policy from fallback.
+func (p Policy) Or(fallback Policy) Policy {
+	if p.DefaultRows == 0 {
+		p.DefaultRows = fallback.DefaultRows
+	}
+
+	if p.MaxRows == 0 {
+		p.MaxRows = fallback.MaxRows
+	}
+
+	p.DefaultRows = min(p.DefaultRows, p.MaxRows)
+
+	return p
+}
+
+// Bind parses the paging parameters of a query under the policy.
+//
+// It returns derror.ErrInvalidPage or derror.ErrInvalidRows for values that are
+// not numbers, derror.ErrPageValueTooSmall for a page_number below 1,
+// derror.ErrRowsValueTooSmall for a page_rows below 1 and
+// derror.ErrRowsValueTooLarge for a page_rows above MaxRows. A missing page_rows
+// is set to DefaultRows.
+func (p Policy) Bind(query url.Values) (Page, error) {
+	p = p.Or(DefaultPolicy)
+
+	page := Page{
+		PageRows: p.DefaultRows,
+		Cursor:   query.Get("cursor"),
+	}
+
+	if query.Has("page_number") {
+		number, err := strconv.ParseInt(query.Get("page_number"), 10, 64)
+		if err != nil {
+			return Page{}, derror.ErrInvalidPage
+		}
+
+		if number < 1 {
+			return Page{}, derror.ErrPageValueTooSmall
+		}
+
+		page.PageNumber = uint(number)
+	}
+
+	if query.Has("page_rows") {
+		rows, err := strconv.ParseInt(query.Get("page_rows"), 10, 64)
+		if err != nil {
+			return Page{}, derror.ErrInvalidRows
+		}
+
+		if rows < 1 {
+			return Page{}, derror.ErrRowsValueTooSmall
+		}
+
+		if uint64(rows) > uint64(p.MaxRows) {
+			return Page{}, derror.ErrRowsValueTooLarge
+		}
+
+		page.PageRows = uint(rows)
+	}
+
+	if query.Has("with_total") {
+		withTotal, err := strconv.ParseBool(query.Get("with_total"))
+		if err != nil {
+			return Page{}, derror.ErrInvalidQueryParameter
+		}
+
+		page.WithTotal = withTotal
+	}
+
+	return page, nil
+}
@@ -0,0 +1,79 @@
+package pagination_test
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kianooshaz/skeleton/foundation/derror"
+	"github.com/kianooshaz/skeleton/foundation/pagination"
+)
+
+func TestPolicy_Bind(t *testing.T) {
+	policy := pagination.Policy{DefaultRows: 10, MaxRows: 50}
+
+	tests := []struct {
+		name    string
+		query   string
+		want    pagination.Page
+		wantErr error
+	}{
+		{name: "defaults", query: "", want: pagination.Page{PageRows: 10}},
+		{name: "numbered page", query: "page_number=3&page_rows=50", want: pagination.Page{PageNumber: 3, PageRows: 50}},
+		{name: "cursor", query: "cursor=abc&with_total=true", want: pagination.Page{PageRows: 10, Cursor: "abc", WithTotal: true}},
+		{name: "page not a number", query: "page_number=x", wantErr: derror.ErrInvalidPage},
+		{name: "page too small", query: "page_number=0", wantErr: derror.ErrPageValueTooSmall},
+		{name: "negative page", query: "page_number=-1", wantErr: derror.ErrPageValueTooSmall},
+		{name: "rows not a number", query: "page_rows=x", wantErr: derror.ErrInvalidRows},
+		{name: "rows too small", query: "page_rows=0", wantErr: derror.ErrRowsValueTooSmall},
+		{name: "rows too large", query: "page_rows=51", wantErr: derror.ErrRowsValueTooLarge},
+		{name: "invalid with_total", query: "with_total=maybe", wantErr: derror.ErrInvalidQueryParameter},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := url.ParseQuery(tt.query)
+			require.NoError(t, err)
+
+			page, err := policy.Bind(query)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, page)
+		})
+	}
+}
+
+func TestPolicy_Or(t *testing.T) {
+	policy := pagination.Policy{MaxRows: 5}.Or(pagination.DefaultPolicy)
+
+	assert.Equal(t, pagination.Policy{DefaultRows: 5, MaxRows: 5}, policy)
+}
+
+func TestLinks(t *testing.T) {
+	u, err := url.Parse("/users?sort=-created_at&page_number=2&page_rows=10")
+	require.NoError(t, err)
+
+	page := pagination.Page{PageNumber: 2, PageRows: 10}
+	nav := pagination.Navigation{TotalRows: 35, TotalPage: 4, HasMore: true}
+
+	assert.Equal(t,
+		`</users?page_number=1&page_rows=10&sort=-created_at>; rel="first", `+
+			`</users?page_number=1&page_rows=10&sort=-created_at>; rel="prev", `+
+			`</users?page_number=3&page_rows=10&sort=-created_at>; rel="next", `+
+			`</users?page_number=4&page_rows=10&sort=-created_at>; rel="last"`,
+		pagination.Links(u, page, nav))
+
+	u, err = url.Parse("/users?cursor=abc")
+	require.NoError(t, err)
+
+	page = pagination.Page{Cursor: "abc"}
+	nav = pagination.Navigation{NextCursor: "def"}
+
+	assert.Equal(t, `</users>; rel="first", </users?cursor=def>; rel="next"`, pagination.Links(u, page, nav))
+}
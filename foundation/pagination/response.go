@@ -4,16 +4,71 @@ import "math"
 
 type Response[T any] struct {
 	Page
-	TotalRows int `json:"total_rows" bson:"total_rows"`
-	TotalPage int `json:"total_page" bson:"total_page"`
-	Data      []T `json:"data" bson:"data"`
+	Navigation
+	Data []T `json:"data" bson:"data"`
+}
+
+// Navigation describes where a page sits within its listing.
+type Navigation struct {
+	// TotalRows and TotalPage are omitted for keyset listings that did not ask for them.
+	TotalRows int `json:"total_rows,omitempty" bson:"total_rows,omitempty"`
+	TotalPage int `json:"total_page,omitempty" bson:"total_page,omitempty"`
+	// HasMore reports whether rows follow the page.
+	HasMore    bool   `json:"has_more" bson:"has_more"`
+	NextCursor string `json:"next_cursor,omitempty" bson:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty" bson:"prev_cursor,omitempty"`
+}
+
+// Listing is implemented by list responses, including the types defined from
+// Response, through the methods promoted from Page and Navigation.
+type Listing interface {
+	CurrentPage() Page
+	CurrentNavigation() Navigation
+}
+
+// CurrentNavigation returns the navigation itself; it makes responses embedding
+// Navigation implement Listing.
+func (n Navigation) CurrentNavigation() Navigation {
+	return n
 }
 
 func NewResponse[T any](page Page, totalRows int, rows []T) Response[T] {
 	return Response[T]{
-		Page:      page,
-		TotalRows: totalRows,
-		TotalPage: int(math.Ceil(float64(totalRows) / float64(page.PageRows))),
-		Data:      rows,
+		Page: page,
+		Navigation: Navigation{
+			TotalRows: totalRows,
+			TotalPage: totalPages(totalRows, page.Rows()),
+			HasMore:   page.Offset()+uint(len(rows)) < uint(max(totalRows, 0)),
+		},
+		Data: rows,
+	}
+}
+
+// NewCursorResponse creates a response carrying the cursors of the neighbouring pages.
+// totalRows is ignored unless the page asked for the total count.
+func NewCursorResponse[T any](page Page, totalRows int, rows []T, cursors Cursors) Response[T] {
+	response := Response[T]{
+		Page: page,
+		Navigation: Navigation{
+			HasMore:    cursors.Next != "",
+			NextCursor: cursors.Next,
+			PrevCursor: cursors.Prev,
+		},
+		Data: rows,
+	}
+
+	if page.NeedsTotal() {
+		response.TotalRows = totalRows
+		response.TotalPage = totalPages(totalRows, page.Rows())
+	}
+
+	return response
+}
+
+func totalPages(totalRows int, pageRows uint) int {
+	if pageRows == 0 {
+		return 0
 	}
+
+	return int(math.Ceil(float64(totalRows) / float64(pageRows)))
 }
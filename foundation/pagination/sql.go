@@ -1,22 +1,202 @@
 package pagination
 
-import "fmt"
-
-func SQLStringer(maxRows uint) StringerFunc {
-	return func(p Page) string {
-		offset := p.PageRows * p.PageNumber
-
-		rows := min(p.PageNumber, maxRows)
-
-		switch {
-		case p.PageNumber > 0 && p.PageRows > 0:
-			return fmt.Sprintf(" LIMIT %d OFFSET %d ", rows, offset)
-		case p.PageNumber > 0:
-			return fmt.Sprintf(" LIMIT %d OFFSET %d ", maxRows, offset)
-		case p.PageRows > 0:
-			return fmt.Sprintf(" LIMIT %d ", rows)
-		default:
-			return fmt.Sprintf(" LIMIT %d ", maxRows)
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/kianooshaz/skeleton/foundation/derror"
+	"github.com/kianooshaz/skeleton/foundation/order"
+)
+
+// SQLStringer renders the LIMIT/OFFSET clause of a numbered page. The page size
+// is expected to be bounded by a Policy already.
+func SQLStringer(p Page) string {
+	if p.Offset() == 0 {
+		return fmt.Sprintf(" LIMIT %d ", p.Rows())
+	}
+
+	return fmt.Sprintf(" LIMIT %d OFFSET %d ", p.Rows(), p.Offset())
+}
+
+// SQLKeyset builds the SQL clauses of a paginated listing ordered by a set of
+// columns with the row ID as final tiebreak.
+//
+// With a cursor it selects the rows after (or before) the boundary row using a
+// keyset predicate. Without one it falls back to LIMIT/OFFSET, so the first page
+// and numbered pages still hand out cursors for the pages around them. One row
+// beyond the page size is fetched to learn whether more rows follow.
+type SQLKeyset struct {
+	page     Page
+	cursor   Cursor
+	columns  []order.Column
+	idColumn string
+	rows     uint
+}
+
+// NewSQLKeyset creates an SQLKeyset for the page. The page size is expected to be
+// bounded by a Policy already. It returns derror.ErrInvalidCursor if the cursor is
+// malformed or was issued for a different ordering.
+func NewSQLKeyset(page Page, idColumn string, columns ...order.Column) (SQLKeyset, error) {
+	k := SQLKeyset{
+		page:     page,
+		columns:  columns,
+		idColumn: idColumn,
+		rows:     page.Rows(),
+	}
+
+	if !page.IsKeyset() {
+		return k, nil
+	}
+
+	cursor, err := DecodeCursor(page.Cursor)
+	if err != nil {
+		return SQLKeyset{}, err
+	}
+
+	if cursor.Order != ordering(columns) || len(cursor.Keys) != len(columns) {
+		return SQLKeyset{}, derror.ErrInvalidCursor
+	}
+
+	k.cursor = cursor
+
+	return k, nil
+}
+
+// Where returns the keyset predicate, without the WHERE keyword, and its arguments.
+// Placeholders are numbered from argIndex. It returns an empty predicate when the
+// listing has no cursor.
+func (k SQLKeyset) Where(argIndex int) (string, []any) {
+	if !k.page.IsKeyset() {
+		return "", nil
+	}
+
+	args := make([]any, 0, len(k.columns)+1)
+	placeholders := make([]string, 0, len(k.columns)+1)
+	for _, key := range k.cursor.Keys {
+		args = append(args, key)
+		placeholders = append(placeholders, fmt.Sprintf("$%d", argIndex))
+		argIndex++
+	}
+	args = append(args, k.cursor.ID)
+	placeholders = append(placeholders, fmt.Sprintf("$%d", argIndex))
+
+	columns := append(slices.Clone(k.columns), order.Column{Name: k.idColumn, Desc: k.idDesc()})
+
+	// (c1 > v1) OR (c1 = v1 AND c2 > v2) OR ... with the comparison flipped for
+	// descending columns and backward cursors. Unlike a row comparison this also
+	// holds for columns ordered in different directions.
+	terms := make([]string, 0, len(columns))
+	for i, column := range columns {
+		conditions := make([]string, 0, i+1)
+		for j := range i {
+			conditions = append(conditions, columns[j].Name+" = "+placeholders[j])
+		}
+
+		operator := ">"
+		if column.Desc != k.cursor.Backward {
+			operator = "<"
+		}
+		conditions = append(conditions, column.Name+" "+operator+" "+placeholders[i])
+
+		terms = append(terms, "("+strings.Join(conditions, " AND ")+")")
+	}
+
+	return "(" + strings.Join(terms, " OR ") + ")", args
+}
+
+// OrderBy returns the ORDER BY clause of the listing. Backward pages are read in
+// reverse and put back in order by Window.
+func (k SQLKeyset) OrderBy() string {
+	terms := make([]string, 0, len(k.columns)+1)
+	for _, column := range k.columns {
+		terms = append(terms, column.Name+" "+k.direction(column.Desc))
+	}
+	terms = append(terms, k.idColumn+" "+k.direction(k.idDesc()))
+
+	return " ORDER BY " + strings.Join(terms, ", ")
+}
+
+// Limit returns the LIMIT clause, and the OFFSET clause of a numbered page.
+func (k SQLKeyset) Limit() string {
+	if !k.page.IsKeyset() && k.page.Offset() > 0 {
+		return fmt.Sprintf(" LIMIT %d OFFSET %d", k.rows+1, k.page.Offset())
+	}
+
+	return fmt.Sprintf(" LIMIT %d", k.rows+1)
+}
+
+// Rows returns the effective page size.
+func (k SQLKeyset) Rows() uint {
+	return k.rows
+}
+
+func (k SQLKeyset) idDesc() bool {
+	if len(k.columns) == 0 {
+		return false
+	}
+
+	return k.columns[len(k.columns)-1].Desc
+}
+
+func (k SQLKeyset) direction(desc bool) string {
+	if desc != (k.page.IsKeyset() && k.cursor.Backward) {
+		return "DESC"
+	}
+
+	return "ASC"
+}
+
+// Window trims the look-ahead row fetched by an SQLKeyset query, restores the
+// natural order of a backward page and returns the cursors of the neighbouring
+// pages. key returns the ORDER BY key values of a row in the order of the keyset
+// columns and id returns its ID.
+func Window[T any](k SQLKeyset, rows []T, key func(T) []string, id func(T) string) ([]T, Cursors) {
+	hasMore := uint(len(rows)) > k.rows
+	if hasMore {
+		rows = rows[:k.rows]
+	}
+
+	backward := k.page.IsKeyset() && k.cursor.Backward
+	if backward {
+		slices.Reverse(rows)
+	}
+
+	if len(rows) == 0 {
+		return rows, Cursors{}
+	}
+
+	first, last := rows[0], rows[len(rows)-1]
+	issued := ordering(k.columns)
+
+	var cursors Cursors
+	if hasMore || backward {
+		cursors.Next = Cursor{Order: issued, Keys: key(last), ID: id(last)}.Encode()
+	}
+
+	if (backward && hasMore) || (!backward && (k.page.IsKeyset() || k.page.Number() > 1)) {
+		cursors.Prev = Cursor{Order: issued, Keys: key(first), ID: id(first), Backward: true}.Encode()
+	}
+
+	return rows, cursors
+}
+
+// ordering describes the ORDER BY columns of a listing for Cursor.Order.
+func ordering(columns []order.Column) string {
+	terms := make([]string, 0, len(columns))
+	for _, column := range columns {
+		if column.Desc {
+			terms = append(terms, "-"+column.Name)
+		} else {
+			terms = append(terms, column.Name)
 		}
 	}
+
+	return strings.Join(terms, ",")
+}
+
+// TimeKey formats a timestamp ORDER BY key for a cursor.
+func TimeKey(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
 }
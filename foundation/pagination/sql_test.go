@@ -0,0 +1,107 @@
+package pagination_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kianooshaz/skeleton/foundation/derror"
+	"github.com/kianooshaz/skeleton/foundation/order"
+	"github.com/kianooshaz/skeleton/foundation/pagination"
+)
+
+type row struct {
+	id  int
+	key int
+}
+
+func rowKey(r row) []string { return []string{strconv.Itoa(r.key)} }
+func rowID(r row) string    { return strconv.Itoa(r.id) }
+
+func TestSQLStringer(t *testing.T) {
+	assert.Equal(t, " LIMIT 5 OFFSET 5 ", pagination.SQLStringer(pagination.Page{PageNumber: 2, PageRows: 5}))
+	assert.Equal(t, " LIMIT 50 ", pagination.SQLStringer(pagination.Page{PageNumber: 1, PageRows: 50}))
+	assert.Equal(t, " LIMIT 20 ", pagination.SQLStringer(pagination.Page{}))
+}
+
+func TestSQLKeyset_FirstPage(t *testing.T) {
+	keyset, err := pagination.NewSQLKeyset(pagination.Page{PageRows: 2}, "id",
+		order.Column{Name: "created_at", Desc: true})
+	require.NoError(t, err)
+
+	where, args := keyset.Where(1)
+	assert.Empty(t, where)
+	assert.Empty(t, args)
+	assert.Equal(t, " ORDER BY created_at DESC, id DESC", keyset.OrderBy())
+	assert.Equal(t, " LIMIT 3", keyset.Limit())
+
+	rows, cursors := pagination.Window(keyset, []row{{1, 30}, {2, 20}, {3, 10}}, rowKey, rowID)
+	assert.Equal(t, []row{{1, 30}, {2, 20}}, rows)
+	assert.Empty(t, cursors.Prev)
+	require.NotEmpty(t, cursors.Next)
+
+	next, err := pagination.DecodeCursor(cursors.Next)
+	require.NoError(t, err)
+	assert.Equal(t, pagination.Cursor{Order: "-created_at", Keys: []string{"20"}, ID: "2"}, next)
+}
+
+func TestSQLKeyset_Forward(t *testing.T) {
+	cursor := pagination.Cursor{Order: "-created_at", Keys: []string{"20"}, ID: "2"}.Encode()
+
+	keyset, err := pagination.NewSQLKeyset(pagination.Page{Cursor: cursor, PageRows: 2}, "id",
+		order.Column{Name: "created_at", Desc: true})
+	require.NoError(t, err)
+
+	where, args := keyset.Where(3)
+	assert.Equal(t, "((created_at < $3) OR (created_at = $3 AND id < $4))", where)
+	assert.Equal(t, []any{"20", "2"}, args)
+	assert.Equal(t, " ORDER BY created_at DESC, id DESC", keyset.OrderBy())
+
+	rows, cursors := pagination.Window(keyset, []row{{3, 10}}, rowKey, rowID)
+	assert.Equal(t, []row{{3, 10}}, rows)
+	assert.Empty(t, cursors.Next)
+	assert.NotEmpty(t, cursors.Prev)
+}
+
+func TestSQLKeyset_Backward(t *testing.T) {
+	cursor := pagination.Cursor{Order: "-created_at", Keys: []string{"10"}, ID: "3", Backward: true}.Encode()
+
+	keyset, err := pagination.NewSQLKeyset(pagination.Page{Cursor: cursor, PageRows: 1}, "id",
+		order.Column{Name: "created_at", Desc: true})
+	require.NoError(t, err)
+
+	where, _ := keyset.Where(1)
+	assert.Equal(t, "((created_at > $1) OR (created_at = $1 AND id > $2))", where)
+	assert.Equal(t, " ORDER BY created_at ASC, id ASC", keyset.OrderBy())
+
+	rows, cursors := pagination.Window(keyset, []row{{2, 20}, {1, 30}}, rowKey, rowID)
+	assert.Equal(t, []row{{2, 20}}, rows)
+	assert.NotEmpty(t, cursors.Next)
+	assert.NotEmpty(t, cursors.Prev)
+}
+
+func TestSQLKeyset_InvalidCursor(t *testing.T) {
+	_, err := pagination.NewSQLKeyset(pagination.Page{Cursor: "not-a-cursor"}, "id")
+	require.ErrorIs(t, err, derror.ErrInvalidCursor)
+
+	// A cursor issued for a different ordering is rejected as well, even one
+	// with as many keys.
+	tests := []struct {
+		name   string
+		cursor pagination.Cursor
+	}{
+		{name: "other columns", cursor: pagination.Cursor{Order: "created_at,name", Keys: []string{"a", "b"}, ID: "1"}},
+		{name: "other direction", cursor: pagination.Cursor{Order: "-created_at", Keys: []string{"a"}, ID: "1"}},
+		{name: "no ordering", cursor: pagination.Cursor{Keys: []string{"a"}, ID: "1"}},
+		{name: "missing keys", cursor: pagination.Cursor{Order: "created_at", ID: "1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := pagination.NewSQLKeyset(pagination.Page{Cursor: tt.cursor.Encode()}, "id",
+				order.Column{Name: "created_at"})
+			require.ErrorIs(t, err, derror.ErrInvalidCursor)
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package pubsub
+
+import (
+	"context"
+	"sync"
+)
+
+// memoryBufferSize is the number of messages a subscription of a MemoryBroker
+// holds before newer ones are dropped.
+const memoryBufferSize = 256
+
+// MemoryBroker delivers messages within the process. It suits single
+// instances and tests.
+type MemoryBroker struct {
+	mu          sync.RWMutex
+	subscribers map[string]map[*memorySubscription]struct{}
+}
+
+// NewMemoryBroker creates a MemoryBroker.
+func NewMemoryBroker() *MemoryBroker {
+	return &MemoryBroker{subscribers: make(map[string]map[*memorySubscription]struct{})}
+}
+
+func (mb *MemoryBroker) Publish(_ context.Context, channel string, message []byte) error {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	for subscription := range mb.subscribers[channel] {
+		select {
+		case subscription.messages <- message:
+		default:
+			// The subscriber is behind; like Redis, drop the message.
+		}
+	}
+
+	return nil
+}
+
+func (mb *MemoryBroker) Subscribe(_ context.Context, channel string) (Subscription, error) {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	subscription := &memorySubscription{
+		broker:   mb,
+		channel:  channel,
+		messages: make(chan []byte, memoryBufferSize),
+	}
+	if mb.subscribers[channel] == nil {
+		mb.subscribers[channel] = make(map[*memorySubscription]struct{})
+	}
+	mb.subscribers[channel][subscription] = struct{}{}
+
+	return subscription, nil
+}
+
+type memorySubscription struct {
+	broker   *MemoryBroker
+	channel  string
+	messages chan []byte
+	once     sync.Once
+}
+
+func (ms *memorySubscription) Messages() <-chan []byte {
+	return ms.messages
+}
+
+func (ms *memorySubscription) Close() error {
+	ms.once.Do(func() {
+		ms.broker.mu.Lock()
+		defer ms.broker.mu.Unlock()
+
+		delete(ms.broker.subscribers[ms.channel], ms)
+		if len(ms.broker.subscribers[ms.channel]) == 0 {
+			delete(ms.broker.subscribers, ms.channel)
+		}
+		close(ms.messages)
+	})
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package pubsub_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kianooshaz/skeleton/foundation/pubsub"
+)
+
+func TestMemoryBroker(t *testing.T) {
+	ctx := context.Background()
+	broker := pubsub.NewMemoryBroker()
+
+	first, err := broker.Subscribe(ctx, "records")
+	require.NoError(t, err)
+	second, err := broker.Subscribe(ctx, "records")
+	require.NoError(t, err)
+	other, err := broker.Subscribe(ctx, "other")
+	require.NoError(t, err)
+
+	// Execute.
+	require.NoError(t, broker.Publish(ctx, "records", []byte("a")))
+	require.NoError(t, broker.Publish(ctx, "records", []byte("b")))
+
+	// Assert.
+	for _, subscription := range []pubsub.Subscription{first, second} {
+		assert.Equal(t, []byte("a"), <-subscription.Messages())
+		assert.Equal(t, []byte("b"), <-subscription.Messages())
+	}
+	assert.Empty(t, other.Messages(), "channels are apart")
+
+	require.NoError(t, first.Close())
+	require.NoError(t, first.Close(), "closing twice is harmless")
+	_, open := <-first.Messages()
+	assert.False(t, open)
+
+	require.NoError(t, broker.Publish(ctx, "records", []byte("c")), "publishing skips closed subscriptions")
+	assert.Equal(t, []byte("c"), <-second.Messages())
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		backend pubsub.Backend
+		wantErr bool
+	}{
+		{name: "default", backend: ""},
+		{name: "memory", backend: pubsub.BackendMemory},
+		{name: "redis without client", backend: pubsub.BackendRedis, wantErr: true},
+		{name: "unknown", backend: "kafka", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Execute.
+			broker, err := pubsub.New(tt.backend, nil)
+
+			// Assert.
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.IsType(t, &pubsub.MemoryBroker{}, broker)
+		})
+	}
+}
@@ -0,0 +1,64 @@
+// Package pubsub delivers the messages published on a channel to its
+// subscribers. The memory broker reaches the subscribers of the process, the
+// Redis broker those of every instance sharing the Redis server.
+//
+// Delivery is at most once: a subscriber that does not keep up with a channel
+// misses messages rather than slowing down publishers.
+package pubsub
+
+import (
+	"context"
+	"fmt"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// Broker publishes messages on channels and subscribes to them.
+type Broker interface {
+	// Publish sends message to the current subscribers of channel.
+	Publish(ctx context.Context, channel string, message []byte) error
+	// Subscribe returns a subscription to the messages published on channel
+	// from now on.
+	Subscribe(ctx context.Context, channel string) (Subscription, error)
+}
+
+// Subscription is a subscription to a channel.
+type Subscription interface {
+	// Messages returns the messages received, in the order they were
+	// published. It is closed when the subscription is.
+	Messages() <-chan []byte
+	// Close ends the subscription.
+	Close() error
+}
+
+// Backend names how a broker reaches subscribers.
+type Backend string
+
+const (
+	// BackendMemory reaches the subscribers of the process.
+	BackendMemory Backend = "memory"
+	// BackendRedis reaches the subscribers of every instance through Redis.
+	BackendRedis Backend = "redis"
+)
+
+// NeedsRedis reports whether the backend uses Redis.
+func (b Backend) NeedsRedis() bool {
+	return b == BackendRedis
+}
+
+// New creates a broker on the given backend; an empty backend means
+// BackendMemory. redisClient is only used by BackendRedis.
+func New(backend Backend, redisClient goredis.UniversalClient) (Broker, error) {
+	switch backend {
+	case "", BackendMemory:
+		return NewMemoryBroker(), nil
+	case BackendRedis:
+		if redisClient == nil {
+			return nil, fmt.Errorf("pubsub: backend %s needs a redis client", backend)
+		}
+
+		return NewRedisBroker(redisClient), nil
+	default:
+		return nil, fmt.Errorf("pubsub: unknown backend %q", backend)
+	}
+}
@@ -0,0 +1,82 @@
+package pubsub
+
+import (
+	"context"
+	"sync"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// RedisBroker delivers messages through Redis pub/sub, to the subscribers of
+// every instance using the same Redis server. Subscriptions reconnect on
+// their own when the connection drops, missing what was published meanwhile.
+type RedisBroker struct {
+	client goredis.UniversalClient
+}
+
+// NewRedisBroker creates a RedisBroker on client.
+func NewRedisBroker(client goredis.UniversalClient) *RedisBroker {
+	return &RedisBroker{client: client}
+}
+
+func (rb *RedisBroker) Publish(ctx context.Context, channel string, message []byte) error {
+	return rb.client.Publish(ctx, channel, message).Err()
+}
+
+func (rb *RedisBroker) Subscribe(ctx context.Context, channel string) (Subscription, error) {
+	pubsub := rb.client.Subscribe(ctx, channel)
+
+	subscription := &redisSubscription{
+		pubsub:   pubsub,
+		messages: make(chan []byte),
+		done:     make(chan struct{}),
+	}
+
+	go subscription.forward(pubsub.Channel())
+
+	return subscription, nil
+}
+
+type redisSubscription struct {
+	pubsub   *goredis.PubSub
+	messages chan []byte
+	done     chan struct{}
+	once     sync.Once
+}
+
+// forward passes the payloads received from Redis on until the subscription
+// is closed.
+func (rs *redisSubscription) forward(received <-chan *goredis.Message) {
+	defer close(rs.messages)
+
+	for {
+		select {
+		case message, ok := <-received:
+			if !ok {
+				return
+			}
+
+			select {
+			case rs.messages <- []byte(message.Payload):
+			case <-rs.done:
+				return
+			}
+		case <-rs.done:
+			return
+		}
+	}
+}
+
+func (rs *redisSubscription) Messages() <-chan []byte {
+	return rs.messages
+}
+
+func (rs *redisSubscription) Close() error {
+	var err error
+	rs.once.Do(func() {
+		close(rs.done)
+		err = rs.pubsub.Close()
+	})
+
+	return err
+}
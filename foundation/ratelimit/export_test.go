@@ -0,0 +1,19 @@
+package ratelimit
+
+import "time"
+
+func (rl *RateLimiter) SetClock(now func() time.Time)   { rl.now = now }
+func (ml *MemoryLimiter) SetClock(now func() time.Time) { ml.now = now }
+func (hl *HybridLimiter) SetClock(now func() time.Time) { hl.now = now }
+
+// Keys returns the number of keys the limiter holds a window for.
+func (ml *MemoryLimiter) Keys() int {
+	keys := 0
+	for i := range ml.shards {
+		ml.shards[i].mu.Lock()
+		keys += len(ml.shards[i].entries)
+		ml.shards[i].mu.Unlock()
+	}
+
+	return keys
+}
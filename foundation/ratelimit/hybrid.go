@@ -0,0 +1,62 @@
+package ratelimit
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+	"time"
+)
+
+// hybridRetryInterval is how long New's hybrid limiter stays on its local
+// windows before trying Redis again.
+const hybridRetryInterval = 5 * time.Second
+
+// HybridLimiter limits with a primary limiter, normally Redis, and switches to
+// a fallback limiter, normally in memory, while the primary fails. After a
+// failure the primary is left alone for the retry interval, so an unreachable
+// Redis does not slow down every request.
+//
+// While on the fallback, each instance limits on its own, so a client may get
+// up to the limit per instance.
+type HybridLimiter struct {
+	primary  Limiter
+	fallback Limiter
+	retry    time.Duration
+	now      func() time.Time
+	logger   *slog.Logger
+	// downUntil is the Unix nanosecond time until which the primary is skipped.
+	downUntil atomic.Int64
+}
+
+// NewHybridLimiter creates a HybridLimiter.
+func NewHybridLimiter(primary, fallback Limiter, retry time.Duration, logger *slog.Logger) *HybridLimiter {
+	return &HybridLimiter{
+		primary:  primary,
+		fallback: fallback,
+		retry:    retry,
+		now:      time.Now,
+		logger:   logger,
+	}
+}
+
+func (hl *HybridLimiter) Take(ctx context.Context, key string, cost int, windows ...Window) (Result, error) {
+	now := hl.now().UnixNano()
+
+	if now >= hl.downUntil.Load() {
+		result, err := hl.primary.Take(ctx, key, cost, windows...)
+		if err == nil {
+			return result, nil
+		}
+
+		hl.logger.WarnContext(
+			ctx,
+			"Error encountered while taking from primary rate limiter, falling back to local limits",
+			slog.String("error", err.Error()),
+			slog.Duration("retry", hl.retry),
+		)
+
+		hl.downUntil.Store(now + hl.retry.Nanoseconds())
+	}
+
+	return hl.fallback.Take(ctx, key, cost, windows...)
+}
@@ -0,0 +1,127 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Limiter limits the rate of requests made per key.
+type Limiter interface {
+	// Take charges cost requests for key against every window at once. The
+	// requests are recorded only when all windows have room for them; either
+	// way the returned result reports on the window that decided.
+	Take(ctx context.Context, key string, cost int, windows ...Window) (Result, error)
+}
+
+// Window is a sliding window allowing Limit requests per Period.
+type Window struct {
+	Limit  int           `yaml:"limit"`
+	Period time.Duration `yaml:"period"`
+}
+
+var errInvalidWindow = errors.New("ratelimit: window limit and period must be positive")
+
+// Validate reports whether the window can be enforced.
+func (w Window) Validate() error {
+	if w.Limit < 1 || w.Period.Milliseconds() < 1 {
+		return errInvalidWindow
+	}
+
+	return nil
+}
+
+// windowKey is the key the requests of key are counted under for window. The
+// braces keep every window of a key in the same Redis cluster slot, as one
+// script reads them all.
+func windowKey(key string, window Window) string {
+	return "{" + key + "}:" + strconv.FormatInt(window.Period.Milliseconds(), 10)
+}
+
+// Result describes the outcome of a Take call.
+type Result struct {
+	// Allowed reports whether the request fits in the window.
+	Allowed bool
+	// Limit is the number of requests allowed per window.
+	Limit int
+	// Remaining is the number of requests still allowed in the current window.
+	Remaining int
+	// Reset is how long until the window has room for the request again. When
+	// the request was allowed, it is how long until the oldest request in the
+	// window expires.
+	Reset time.Duration
+}
+
+// windowState is the state of one window after a Take call.
+type windowState struct {
+	window Window
+	// count is the number of requests in the window.
+	count int
+	// resetAt is the Unix millisecond time of the request whose expiry frees
+	// enough room: the oldest one when the request was allowed.
+	resetAt int64
+}
+
+// newResult reports on the window deciding a Take call made at nowMs: when the
+// request was rejected, the full window freeing up last, otherwise the one with
+// the fewest requests left.
+func newResult(allowed bool, cost int, nowMs int64, states []windowState) Result {
+	result := Result{Allowed: allowed}
+	found := false
+	for _, state := range states {
+		// A window that had room for a rejected request did not decide it.
+		if !allowed && state.count+cost <= state.window.Limit {
+			continue
+		}
+
+		candidate := Result{
+			Allowed:   allowed,
+			Limit:     state.window.Limit,
+			Remaining: max(state.window.Limit-state.count, 0),
+			Reset:     max(time.Duration(state.resetAt+state.window.Period.Milliseconds()-nowMs)*time.Millisecond, 0),
+		}
+
+		if !found || (!allowed && candidate.Reset > result.Reset) || (allowed && candidate.Remaining < result.Remaining) {
+			result, found = candidate, true
+		}
+	}
+
+	return result
+}
+
+// Backend names where a limiter keeps its windows.
+type Backend string
+
+const (
+	// BackendRedis shares the windows between instances through Redis.
+	BackendRedis Backend = "redis"
+	// BackendMemory keeps the windows in the process.
+	BackendMemory Backend = "memory"
+	// BackendHybrid uses Redis, and the process while Redis is unreachable.
+	BackendHybrid Backend = "hybrid"
+)
+
+// NeedsRedis reports whether the backend uses Redis. An empty backend means BackendRedis.
+func (b Backend) NeedsRedis() bool {
+	return b == "" || b == BackendRedis || b == BackendHybrid
+}
+
+// New creates a limiter on the given backend. redisClient is only used by the
+// backends that need Redis.
+func New(backend Backend, redisClient redis.Cmdable, logger *slog.Logger) (Limiter, error) {
+	switch backend {
+	case "", BackendRedis:
+		return NewRateLimiter(redisClient), nil
+	case BackendMemory:
+		return NewMemoryLimiter(), nil
+	case BackendHybrid:
+		return NewHybridLimiter(NewRateLimiter(redisClient), NewMemoryLimiter(), hybridRetryInterval, logger), nil
+	default:
+		return nil, fmt.Errorf("ratelimit: unknown backend %q", backend)
+	}
+}
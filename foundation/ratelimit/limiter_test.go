@@ -0,0 +1,370 @@
+package ratelimit_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kianooshaz/skeleton/foundation/ratelimit"
+)
+
+// envRedisAddress names the variable holding the address of the Redis server the
+// Redis backed limiters are tested against. Those tests are skipped without it.
+const envRedisAddress = "TEST_REDIS_ADDRESS"
+
+var (
+	discard = slog.New(slog.NewTextHandler(io.Discard, nil))
+	hourly  = ratelimit.Window{Limit: 1, Period: time.Hour}
+)
+
+// clock is a settable time source shared by a limiter and its test.
+type clock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newClock() *clock {
+	return &clock{now: time.Now().Truncate(time.Millisecond)}
+}
+
+func (c *clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+func (c *clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+}
+
+// setup returns an empty limiter driven by clock.
+type setup func(t *testing.T, clock *clock) ratelimit.Limiter
+
+func TestMemoryLimiter(t *testing.T) {
+	runConformance(t, func(t *testing.T, clock *clock) ratelimit.Limiter {
+		limiter := ratelimit.NewMemoryLimiter()
+		limiter.SetClock(clock.Now)
+
+		return limiter
+	})
+}
+
+func TestRateLimiter(t *testing.T) {
+	runConformance(t, func(t *testing.T, clock *clock) ratelimit.Limiter {
+		limiter := ratelimit.NewRateLimiter(redisClient(t))
+		limiter.SetClock(clock.Now)
+
+		return prefixed{limiter: limiter, prefix: uuid.NewString()}
+	})
+}
+
+func TestHybridLimiter(t *testing.T) {
+	t.Run("redis reachable", func(t *testing.T) {
+		runConformance(t, func(t *testing.T, clock *clock) ratelimit.Limiter {
+			return prefixed{limiter: newHybrid(clock, redisClient(t)), prefix: uuid.NewString()}
+		})
+	})
+
+	t.Run("redis unreachable", func(t *testing.T) {
+		runConformance(t, func(t *testing.T, clock *clock) ratelimit.Limiter {
+			return newHybrid(clock, unreachableRedis())
+		})
+	})
+
+	t.Run("returns to primary after retry interval", func(t *testing.T) {
+		clock := newClock()
+		primary := &failingLimiter{}
+		fallback := ratelimit.NewMemoryLimiter()
+		fallback.SetClock(clock.Now)
+		limiter := ratelimit.NewHybridLimiter(primary, fallback, time.Second, discard)
+		limiter.SetClock(clock.Now)
+
+		primary.fail.Store(true)
+		result, err := limiter.Take(context.Background(), "key", 1, hourly)
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+
+		// The primary recovered, but is not asked again before the retry interval.
+		primary.fail.Store(false)
+		result, err = limiter.Take(context.Background(), "key", 1, hourly)
+		require.NoError(t, err)
+		assert.False(t, result.Allowed)
+		assert.Equal(t, int64(1), primary.calls.Load())
+
+		clock.Advance(time.Second)
+		result, err = limiter.Take(context.Background(), "key", 1, hourly)
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, int64(2), primary.calls.Load())
+	})
+}
+
+func TestMemoryLimiter_EvictsIdleKeys(t *testing.T) {
+	clock := newClock()
+	limiter := ratelimit.NewMemoryLimiter()
+	limiter.SetClock(clock.Now)
+
+	window := ratelimit.Window{Limit: 5, Period: time.Minute}
+	for _, key := range []string{"a", "b", "c"} {
+		_, err := limiter.Take(context.Background(), key, 1, window)
+		require.NoError(t, err)
+	}
+	_, err := limiter.Take(context.Background(), "daily", 1, window, ratelimit.Window{Limit: 5, Period: 24 * time.Hour})
+	require.NoError(t, err)
+	assert.Equal(t, 4, limiter.Keys())
+
+	// Keys stay until their longest window is empty.
+	clock.Advance(time.Minute)
+	_, err = limiter.Take(context.Background(), "d", 1, window)
+	require.NoError(t, err)
+	assert.Equal(t, 2, limiter.Keys())
+}
+
+func TestNew(t *testing.T) {
+	for _, backend := range []ratelimit.Backend{"", ratelimit.BackendRedis, ratelimit.BackendMemory, ratelimit.BackendHybrid} {
+		limiter, err := ratelimit.New(backend, unreachableRedis(), discard)
+		require.NoError(t, err)
+		assert.NotNil(t, limiter)
+	}
+
+	_, err := ratelimit.New("memcached", nil, discard)
+	require.Error(t, err)
+}
+
+// runConformance runs the behaviour every limiter must share against the limiters returned by setup.
+func runConformance(t *testing.T, setup setup) {
+	ctx := context.Background()
+
+	t.Run("allows up to the limit", func(t *testing.T) {
+		clock := newClock()
+		limiter := setup(t, clock)
+		window := ratelimit.Window{Limit: 3, Period: time.Minute}
+
+		// The reset counts down to the expiry of the first request.
+		for i, remaining := range []int{2, 1, 0} {
+			result, err := limiter.Take(ctx, "key", 1, window)
+			require.NoError(t, err)
+			assert.Equal(t, ratelimit.Result{
+				Allowed: true, Limit: 3, Remaining: remaining, Reset: time.Minute - time.Duration(i)*time.Second,
+			}, result)
+			clock.Advance(time.Second)
+		}
+
+		result, err := limiter.Take(ctx, "key", 1, window)
+		require.NoError(t, err)
+		assert.Equal(t, ratelimit.Result{Allowed: false, Limit: 3, Remaining: 0, Reset: 57 * time.Second}, result)
+	})
+
+	t.Run("without windows everything is allowed", func(t *testing.T) {
+		limiter := setup(t, newClock())
+
+		result, err := limiter.Take(ctx, "key", 1)
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+	})
+
+	t.Run("keys are independent", func(t *testing.T) {
+		limiter := setup(t, newClock())
+		window := ratelimit.Window{Limit: 1, Period: time.Minute}
+
+		first, err := limiter.Take(ctx, "first", 1, window)
+		require.NoError(t, err)
+		assert.True(t, first.Allowed)
+
+		second, err := limiter.Take(ctx, "second", 1, window)
+		require.NoError(t, err)
+		assert.True(t, second.Allowed)
+
+		first, err = limiter.Take(ctx, "first", 1, window)
+		require.NoError(t, err)
+		assert.False(t, first.Allowed)
+	})
+
+	t.Run("window slides", func(t *testing.T) {
+		clock := newClock()
+		limiter := setup(t, clock)
+		window := ratelimit.Window{Limit: 2, Period: time.Minute}
+
+		for range 2 {
+			result, err := limiter.Take(ctx, "key", 1, window)
+			require.NoError(t, err)
+			assert.True(t, result.Allowed)
+			clock.Advance(30 * time.Second)
+		}
+
+		// The first request left the window, the second is still in it.
+		result, err := limiter.Take(ctx, "key", 1, window)
+		require.NoError(t, err)
+		assert.Equal(t, ratelimit.Result{Allowed: true, Limit: 2, Remaining: 0, Reset: 30 * time.Second}, result)
+
+		result, err = limiter.Take(ctx, "key", 1, window)
+		require.NoError(t, err)
+		assert.False(t, result.Allowed)
+	})
+
+	t.Run("rejected requests are not counted", func(t *testing.T) {
+		clock := newClock()
+		limiter := setup(t, clock)
+		window := ratelimit.Window{Limit: 1, Period: time.Minute}
+
+		for range 5 {
+			_, err := limiter.Take(ctx, "key", 1, window)
+			require.NoError(t, err)
+		}
+
+		clock.Advance(time.Minute)
+		result, err := limiter.Take(ctx, "key", 1, window)
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+	})
+
+	t.Run("cost", func(t *testing.T) {
+		clock := newClock()
+		limiter := setup(t, clock)
+		window := ratelimit.Window{Limit: 10, Period: time.Minute}
+
+		result, err := limiter.Take(ctx, "key", 4, window)
+		require.NoError(t, err)
+		assert.Equal(t, ratelimit.Result{Allowed: true, Limit: 10, Remaining: 6, Reset: time.Minute}, result)
+
+		clock.Advance(10 * time.Second)
+		result, err = limiter.Take(ctx, "key", 4, window)
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.Remaining)
+
+		// Room for 2 more only frees up when the first 4 expire.
+		clock.Advance(10 * time.Second)
+		result, err = limiter.Take(ctx, "key", 4, window)
+		require.NoError(t, err)
+		assert.Equal(t, ratelimit.Result{Allowed: false, Limit: 10, Remaining: 2, Reset: 40 * time.Second}, result)
+
+		result, err = limiter.Take(ctx, "key", 2, window)
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+	})
+
+	t.Run("all windows must have room", func(t *testing.T) {
+		clock := newClock()
+		limiter := setup(t, clock)
+		minutely := ratelimit.Window{Limit: 2, Period: time.Minute}
+		daily := ratelimit.Window{Limit: 3, Period: 24 * time.Hour}
+
+		result, err := limiter.Take(ctx, "key", 1, minutely, daily)
+		require.NoError(t, err)
+		assert.Equal(t, ratelimit.Result{Allowed: true, Limit: 2, Remaining: 1, Reset: time.Minute}, result)
+
+		result, err = limiter.Take(ctx, "key", 1, minutely, daily)
+		require.NoError(t, err)
+		assert.Equal(t, ratelimit.Result{Allowed: true, Limit: 2, Remaining: 0, Reset: time.Minute}, result)
+
+		result, err = limiter.Take(ctx, "key", 1, minutely, daily)
+		require.NoError(t, err)
+		assert.Equal(t, ratelimit.Result{Allowed: false, Limit: 2, Remaining: 0, Reset: time.Minute}, result)
+
+		clock.Advance(time.Minute)
+		result, err = limiter.Take(ctx, "key", 1, minutely, daily)
+		require.NoError(t, err)
+		assert.Equal(t, ratelimit.Result{Allowed: true, Limit: 3, Remaining: 0, Reset: 23*time.Hour + 59*time.Minute}, result)
+
+		// The minute window has room again, but the day is used up. The rejected
+		// request above was not counted against the day either.
+		result, err = limiter.Take(ctx, "key", 1, minutely, daily)
+		require.NoError(t, err)
+		assert.Equal(t, ratelimit.Result{Allowed: false, Limit: 3, Remaining: 0, Reset: 23*time.Hour + 59*time.Minute}, result)
+	})
+
+	t.Run("concurrent requests", func(t *testing.T) {
+		limiter := setup(t, newClock())
+		window := ratelimit.Window{Limit: 10, Period: time.Minute}
+
+		var allowed atomic.Int64
+		var wg sync.WaitGroup
+		for range 50 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				result, err := limiter.Take(ctx, "key", 1, window)
+				if assert.NoError(t, err) && result.Allowed {
+					allowed.Add(1)
+				}
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, int64(10), allowed.Load())
+	})
+}
+
+func newHybrid(clock *clock, client redis.Cmdable) *ratelimit.HybridLimiter {
+	primary := ratelimit.NewRateLimiter(client)
+	primary.SetClock(clock.Now)
+	fallback := ratelimit.NewMemoryLimiter()
+	fallback.SetClock(clock.Now)
+
+	limiter := ratelimit.NewHybridLimiter(primary, fallback, time.Minute, discard)
+	limiter.SetClock(clock.Now)
+
+	return limiter
+}
+
+func redisClient(t *testing.T) redis.Cmdable {
+	t.Helper()
+
+	address := os.Getenv(envRedisAddress)
+	if address == "" {
+		t.Skipf("%s is not set", envRedisAddress)
+	}
+
+	client := redis.NewClient(&redis.Options{Addr: address})
+	t.Cleanup(func() { _ = client.Close() })
+
+	return client
+}
+
+func unreachableRedis() redis.Cmdable {
+	return redis.NewClient(&redis.Options{
+		Addr:        "127.0.0.1:1",
+		DialTimeout: 10 * time.Millisecond,
+		MaxRetries:  -1,
+	})
+}
+
+// prefixed keeps the keys of a test apart from those of other tests on the same Redis server.
+type prefixed struct {
+	limiter ratelimit.Limiter
+	prefix  string
+}
+
+func (p prefixed) Take(ctx context.Context, key string, cost int, windows ...ratelimit.Window) (ratelimit.Result, error) {
+	return p.limiter.Take(ctx, p.prefix+":"+key, cost, windows...)
+}
+
+type failingLimiter struct {
+	fail  atomic.Bool
+	calls atomic.Int64
+}
+
+func (f *failingLimiter) Take(context.Context, string, int, ...ratelimit.Window) (ratelimit.Result, error) {
+	f.calls.Add(1)
+	if f.fail.Load() {
+		return ratelimit.Result{}, errors.New("unreachable")
+	}
+
+	return ratelimit.Result{Allowed: true, Limit: 1, Remaining: 0}, nil
+}
@@ -0,0 +1,148 @@
+package ratelimit
+
+import (
+	"context"
+	"hash/maphash"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// memoryShards is the number of independently locked parts the keys of a
+// MemoryLimiter are spread over, so requests for different keys rarely wait on
+// each other.
+const memoryShards = 64
+
+// MemoryLimiter is a sliding window rate limiter kept in the process. It makes
+// the same decisions as RateLimiter but every instance of the application has
+// its own windows, so it suits single instances and tests.
+//
+// Keys that made no request for their longest window are evicted.
+type MemoryLimiter struct {
+	now    func() time.Time
+	seed   maphash.Seed
+	shards [memoryShards]memoryShard
+	// lastSweep is the Unix millisecond time idle keys were last evicted.
+	lastSweep atomic.Int64
+}
+
+type memoryShard struct {
+	mu      sync.Mutex
+	entries map[string]*memoryEntry
+}
+
+type memoryEntry struct {
+	// windows holds the times, in Unix milliseconds, of the requests in each
+	// window of the key by period, oldest first.
+	windows map[time.Duration][]int64
+	// idleAt is the Unix millisecond time at which every window of the key is empty.
+	idleAt int64
+}
+
+// memorySweepInterval is how often a MemoryLimiter evicts idle keys.
+const memorySweepInterval = time.Minute
+
+// NewMemoryLimiter creates a MemoryLimiter.
+func NewMemoryLimiter() *MemoryLimiter {
+	ml := &MemoryLimiter{
+		now:  time.Now,
+		seed: maphash.MakeSeed(),
+	}
+
+	for i := range ml.shards {
+		ml.shards[i].entries = make(map[string]*memoryEntry)
+	}
+
+	return ml
+}
+
+func (ml *MemoryLimiter) Take(_ context.Context, key string, cost int, windows ...Window) (Result, error) {
+	if len(windows) == 0 {
+		return Result{Allowed: true}, nil
+	}
+
+	nowMs := ml.now().UnixMilli()
+
+	// Once per interval, one of the callers evicts the idle keys of every shard.
+	if last := ml.lastSweep.Load(); nowMs-last >= memorySweepInterval.Milliseconds() &&
+		ml.lastSweep.CompareAndSwap(last, nowMs) {
+		for i := range ml.shards {
+			ml.shards[i].sweep(nowMs)
+		}
+	}
+
+	shard := &ml.shards[maphash.String(ml.seed, key)%memoryShards]
+
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+
+	entry, ok := shard.entries[key]
+	if !ok {
+		entry = &memoryEntry{windows: make(map[time.Duration][]int64, len(windows))}
+		shard.entries[key] = entry
+	}
+
+	allowed := true
+	for _, window := range windows {
+		requests := expire(entry.windows[window.Period], nowMs-window.Period.Milliseconds())
+		entry.windows[window.Period] = requests
+
+		if len(requests)+cost > window.Limit {
+			allowed = false
+		}
+	}
+
+	states := make([]windowState, 0, len(windows))
+	for _, window := range windows {
+		requests := entry.windows[window.Period]
+
+		index := 0
+		if allowed {
+			for range cost {
+				requests = append(requests, nowMs)
+			}
+			entry.windows[window.Period] = requests
+			entry.idleAt = max(entry.idleAt, nowMs+window.Period.Milliseconds())
+		} else if len(requests)+cost > window.Limit {
+			index = min(len(requests)+cost-window.Limit, len(requests)) - 1
+		}
+
+		resetAt := nowMs
+		if index >= 0 && index < len(requests) {
+			resetAt = requests[index]
+		}
+
+		states = append(states, windowState{window: window, count: len(requests), resetAt: resetAt})
+	}
+
+	if entry.idleAt <= nowMs {
+		delete(shard.entries, key)
+	}
+
+	return newResult(allowed, cost, nowMs, states), nil
+}
+
+// sweep evicts the keys whose windows are all empty at nowMs.
+func (s *memoryShard) sweep(nowMs int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, entry := range s.entries {
+		if entry.idleAt <= nowMs {
+			delete(s.entries, key)
+		}
+	}
+}
+
+// expire drops the requests made at or before boundary, the same ones the Redis
+// script removes.
+func expire(requests []int64, boundary int64) []int64 {
+	i := 0
+	for i < len(requests) && requests[i] <= boundary {
+		i++
+	}
+
+	n := copy(requests, requests[i:])
+
+	return requests[:n]
+}
@@ -0,0 +1,164 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// KeyPart names a property of a request that rate limits can be keyed by.
+type KeyPart string
+
+const (
+	KeyIP           KeyPart = "ip"
+	KeyUser         KeyPart = "user"
+	KeyAccount      KeyPart = "account"
+	KeyOrganization KeyPart = "organization"
+	KeyRoute        KeyPart = "route"
+)
+
+// Identity holds the properties of a request that rate limits are keyed by.
+// Fields the request does not have are left empty.
+type Identity struct {
+	IP           string
+	User         string
+	Account      string
+	Organization string
+	// Route is the method and path pattern of the matched route.
+	Route string
+}
+
+func (id Identity) part(part KeyPart) string {
+	switch part {
+	case KeyIP:
+		return id.IP
+	case KeyUser:
+		return id.User
+	case KeyAccount:
+		return id.Account
+	case KeyOrganization:
+		return id.Organization
+	case KeyRoute:
+		return id.Route
+	default:
+		return ""
+	}
+}
+
+// Policy is a named set of windows every request it applies to is charged
+// against, such as 5 login attempts per minute and 100 per day per account.
+type Policy struct {
+	// Key lists the parts requests are grouped by; every group has its own
+	// windows. Without parts, all requests share the same windows.
+	Key []KeyPart `yaml:"key"`
+	// Cost is the number of requests each request counts as. It defaults to 1.
+	Cost    int      `yaml:"cost"`
+	Windows []Window `yaml:"windows"`
+	// Tiers replaces the windows for the organizations of the named tiers.
+	Tiers map[string][]Window `yaml:"tiers"`
+}
+
+var (
+	errUnknownKeyPart   = errors.New("ratelimit: unknown key part")
+	errNegativeCost     = errors.New("ratelimit: cost must not be negative")
+	errDuplicatePeriods = errors.New("ratelimit: windows must have distinct periods")
+)
+
+// Validate reports whether the policy can be enforced.
+func (p Policy) Validate() error {
+	for _, part := range p.Key {
+		switch part {
+		case KeyIP, KeyUser, KeyAccount, KeyOrganization, KeyRoute:
+		default:
+			return fmt.Errorf("%w: %q", errUnknownKeyPart, part)
+		}
+	}
+
+	if p.Cost < 0 {
+		return errNegativeCost
+	}
+
+	if err := validateWindows(p.Windows); err != nil {
+		return err
+	}
+
+	for tier, windows := range p.Tiers {
+		if err := validateWindows(windows); err != nil {
+			return fmt.Errorf("tier %s: %w", tier, err)
+		}
+	}
+
+	return nil
+}
+
+func validateWindows(windows []Window) error {
+	periods := make(map[int64]struct{}, len(windows))
+	for _, window := range windows {
+		if err := window.Validate(); err != nil {
+			return err
+		}
+
+		if _, ok := periods[window.Period.Milliseconds()]; ok {
+			return errDuplicatePeriods
+		}
+		periods[window.Period.Milliseconds()] = struct{}{}
+	}
+
+	return nil
+}
+
+// KeyFor returns the key the requests of id are limited under by the policy of
+// the given name. Identity parts id lacks, such as the user of an anonymous
+// request, are replaced by its IP, so that anonymous clients do not share one
+// window.
+func (p Policy) KeyFor(name string, id Identity) string {
+	var b strings.Builder
+	b.WriteString("ratelimit:")
+	b.WriteString(name)
+
+	for _, part := range p.Key {
+		value := id.part(part)
+		if value == "" {
+			part, value = KeyIP, id.IP
+		}
+
+		b.WriteString(":")
+		b.WriteString(string(part))
+		b.WriteString("=")
+		b.WriteString(value)
+	}
+
+	return b.String()
+}
+
+// WindowsFor returns the windows of the policy for an organization of tier.
+func (p Policy) WindowsFor(tier string) []Window {
+	if windows, ok := p.Tiers[tier]; ok && tier != "" {
+		return windows
+	}
+
+	return p.Windows
+}
+
+// CostOrDefault returns the cost of a request under the policy.
+func (p Policy) CostOrDefault() int {
+	if p.Cost == 0 {
+		return 1
+	}
+
+	return p.Cost
+}
+
+// Tiers finds the tier of an organization.
+type Tiers interface {
+	// Tier returns the tier of the organization, or "" when it has none.
+	Tier(ctx context.Context, organizationID string) (string, error)
+}
+
+// StaticTiers is a Tiers kept in configuration, mapping organization IDs to tiers.
+type StaticTiers map[string]string
+
+func (st StaticTiers) Tier(_ context.Context, organizationID string) (string, error) {
+	return st[organizationID], nil
+}
@@ -0,0 +1,134 @@
+package ratelimit_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kianooshaz/skeleton/foundation/ratelimit"
+)
+
+func TestPolicy_KeyFor(t *testing.T) {
+	signedIn := ratelimit.Identity{
+		IP:           "192.0.2.1",
+		User:         "u1",
+		Account:      "a1",
+		Organization: "o1",
+		Route:        "GET /user",
+	}
+	anonymous := ratelimit.Identity{IP: "192.0.2.1", Route: "GET /user"}
+
+	tests := []struct {
+		name     string
+		key      []ratelimit.KeyPart
+		identity ratelimit.Identity
+		want     string
+	}{
+		{
+			name:     "shared",
+			identity: signedIn,
+			want:     "ratelimit:reads",
+		},
+		{
+			name:     "composed",
+			key:      []ratelimit.KeyPart{ratelimit.KeyOrganization, ratelimit.KeyRoute},
+			identity: signedIn,
+			want:     "ratelimit:reads:organization=o1:route=GET /user",
+		},
+		{
+			name:     "missing parts fall back to ip",
+			key:      []ratelimit.KeyPart{ratelimit.KeyAccount, ratelimit.KeyRoute},
+			identity: anonymous,
+			want:     "ratelimit:reads:ip=192.0.2.1:route=GET /user",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := ratelimit.Policy{Key: tt.key}
+
+			assert.Equal(t, tt.want, policy.KeyFor("reads", tt.identity))
+		})
+	}
+}
+
+func TestPolicy_WindowsFor(t *testing.T) {
+	standard := []ratelimit.Window{{Limit: 1000, Period: time.Minute}}
+	enterprise := []ratelimit.Window{{Limit: 10000, Period: time.Minute}}
+	policy := ratelimit.Policy{Windows: standard, Tiers: map[string][]ratelimit.Window{"enterprise": enterprise}}
+
+	assert.Equal(t, standard, policy.WindowsFor(""))
+	assert.Equal(t, standard, policy.WindowsFor("startup"))
+	assert.Equal(t, enterprise, policy.WindowsFor("enterprise"))
+}
+
+func TestPolicy_Validate(t *testing.T) {
+	minute := ratelimit.Window{Limit: 5, Period: time.Minute}
+
+	tests := []struct {
+		name    string
+		policy  ratelimit.Policy
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			policy: ratelimit.Policy{
+				Key:     []ratelimit.KeyPart{ratelimit.KeyAccount},
+				Windows: []ratelimit.Window{minute, {Limit: 100, Period: 24 * time.Hour}},
+			},
+		},
+		{
+			name:    "unknown key part",
+			policy:  ratelimit.Policy{Key: []ratelimit.KeyPart{"country"}, Windows: []ratelimit.Window{minute}},
+			wantErr: true,
+		},
+		{
+			name:    "negative cost",
+			policy:  ratelimit.Policy{Cost: -1, Windows: []ratelimit.Window{minute}},
+			wantErr: true,
+		},
+		{
+			name:    "empty window",
+			policy:  ratelimit.Policy{Windows: []ratelimit.Window{{Period: time.Minute}}},
+			wantErr: true,
+		},
+		{
+			name:    "duplicate periods",
+			policy:  ratelimit.Policy{Windows: []ratelimit.Window{minute, {Limit: 10, Period: time.Minute}}},
+			wantErr: true,
+		},
+		{
+			name: "invalid tier",
+			policy: ratelimit.Policy{
+				Windows: []ratelimit.Window{minute},
+				Tiers:   map[string][]ratelimit.Window{"free": {{Limit: 1}}},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Validate()
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestStaticTiers(t *testing.T) {
+	tiers := ratelimit.StaticTiers{"o1": "enterprise"}
+
+	tier, err := tiers.Tier(t.Context(), "o1")
+	require.NoError(t, err)
+	assert.Equal(t, "enterprise", tier)
+
+	tier, err = tiers.Tier(t.Context(), "o2")
+	require.NoError(t, err)
+	assert.Empty(t, tier)
+}
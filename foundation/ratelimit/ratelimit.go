@@ -2,113 +2,138 @@ package ratelimit
 
 import (
 	"context"
-	"log/slog"
+	"errors"
 	"time"
 
-	"github.com/kianooshaz/skeleton/foundation/config"
+	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
 )
 
-// RateLimiter is the main struct for the sliding window rate limiter.
+// RateLimiter is the sliding window rate limiter backed by Redis. It shares
+// its windows between every instance of the application.
 type RateLimiter struct {
 	redisClient redis.Cmdable
-	limit       int
-	window      time.Duration
-	ttl         time.Duration
+	now         func() time.Time
 }
 
-var SlidingWindowRateLimiter *RateLimiter
+var errUnexpectedReply = errors.New("ratelimit: unexpected reply from redis")
 
+// slidingWindowLua charges a cost against the windows in KEYS when all of them
+// have room for it. ARGV holds the current time in milliseconds, the cost, a
+// unique member prefix, and then the period in milliseconds and the limit of
+// each window.
+//
+// It returns whether the request was allowed, followed by the number of
+// requests in each window and the time of the request whose expiry frees
+// enough room in it.
 var slidingWindowLua = `
-local key = KEYS[1]
 local now = tonumber(ARGV[1])
-local window = tonumber(ARGV[2])
-local limit = tonumber(ARGV[3])
-
--- Remove old entries
-redis.call("ZREMRANGEBYSCORE", key, 0, now - window)
-
--- Count current entries
-local count = redis.call("ZCARD", key)
-
-if count < limit then
-    -- Add new entry
-    redis.call("ZADD", key, now, tostring(now))
-    -- Set expire for safety
-    redis.call("PEXPIRE", key, window)
-    return 1
-else
-    return 0
+local cost = tonumber(ARGV[2])
+local member = ARGV[3]
+
+local counts = {}
+local allowed = 1
+for i, key in ipairs(KEYS) do
+    local period = tonumber(ARGV[2 + 2 * i])
+    local limit = tonumber(ARGV[3 + 2 * i])
+
+    -- Remove old entries
+    redis.call("ZREMRANGEBYSCORE", key, 0, now - period)
+
+    -- Count current entries
+    counts[i] = redis.call("ZCARD", key)
+    if counts[i] + cost > limit then
+        allowed = 0
+    end
 end
-`
 
-type RateLimiterConfig struct {
-	Limit  int           `yaml:"limit" validate:"required"`
-	Window time.Duration `yaml:"window" validate:"required"`
-	TTL    time.Duration `yaml:"ttl" validate:"required"`
-}
+local reply = {allowed}
+for i, key in ipairs(KEYS) do
+    local period = tonumber(ARGV[2 + 2 * i])
+    local limit = tonumber(ARGV[3 + 2 * i])
+
+    local index = 0
+    if allowed == 1 then
+        -- Add new entries
+        for n = 1, cost do
+            redis.call("ZADD", key, now, member .. ":" .. n)
+        end
+        -- Set expire for safety
+        redis.call("PEXPIRE", key, period)
+        counts[i] = counts[i] + cost
+    elseif counts[i] + cost > limit then
+        index = math.min(counts[i] + cost - limit, counts[i]) - 1
+    end
+
+    local resetAt = now
+    if index >= 0 then
+        local entry = redis.call("ZRANGE", key, index, index, "WITHSCORES")
+        if entry[2] then
+            resetAt = tonumber(entry[2])
+        end
+    end
+
+    table.insert(reply, counts[i])
+    table.insert(reply, resetAt)
+end
+
+return reply
+`
 
 // NewRateLimiter creates a new Sliding Window RateLimiter instance using dependency injection.
 // The redisClient parameter is the Redis client to use for rate limiting.
-// The cfg parameter contains the rate limiter configuration.
 // Returns a configured RateLimiter instance.
-func NewRateLimiter(redisClient redis.Cmdable, cfg RateLimiterConfig) *RateLimiter {
+func NewRateLimiter(redisClient redis.Cmdable) *RateLimiter {
 	return &RateLimiter{
 		redisClient: redisClient,
-		limit:       cfg.Limit,
-		window:      cfg.Window,
-		ttl:         cfg.TTL,
+		now:         time.Now,
 	}
 }
 
-// NewRateLimiterFromConfig creates a new RateLimiter instance by loading configuration.
-// The redisClient parameter is the Redis client to use.
-// The configLoader parameter is a function that loads the rate limiter configuration.
-// Returns a configured RateLimiter instance or an error.
-func NewRateLimiterFromConfig(redisClient redis.Cmdable, configLoader func() (RateLimiterConfig, error)) (*RateLimiter, error) {
-	cfg, err := configLoader()
+// Allow checks if a new request is allowed under the given sliding windows.
+// Returns true if allowed, false otherwise.
+func (rl *RateLimiter) Allow(ctx context.Context, key string, windows ...Window) (bool, error) {
+	result, err := rl.Take(ctx, key, 1, windows...)
 	if err != nil {
-		return nil, err
+		return false, err
 	}
-	return NewRateLimiter(redisClient, cfg), nil
-}
 
-// Init creates a new Sliding Window RateLimiter instance and sets it globally.
-// Deprecated: Use NewRateLimiter or NewRateLimiterFromConfig with dependency injection instead.
-func Init(redisClient redis.Cmdable) {
-	cfg, err := config.Load[RateLimiterConfig]("ratelimit")
-	if err != nil {
-		slog.Error("failed to load rate limiter config", "error", err)
-		return
-	}
+	return result.Allowed, nil
+}
 
-	SlidingWindowRateLimiter = &RateLimiter{
-		redisClient: redisClient,
-		limit:       cfg.Limit,
-		window:      cfg.Window,
-		ttl:         cfg.TTL,
+func (rl *RateLimiter) Take(ctx context.Context, key string, cost int, windows ...Window) (Result, error) {
+	if len(windows) == 0 {
+		return Result{Allowed: true}, nil
 	}
-}
 
-// Allow checks if a new request is allowed under the current sliding window rate limit.
-// Returns true if allowed, false otherwise.
-func (rl *RateLimiter) Allow(ctx context.Context, key string) (bool, error) {
-	nowMs := time.Now().UnixMilli()
+	nowMs := rl.now().UnixMilli()
 
-	result, err := rl.redisClient.Eval(ctx, slidingWindowLua, []string{key},
-		nowMs,
-		rl.window.Milliseconds(),
-		rl.limit,
-	).Result()
+	keys := make([]string, 0, len(windows))
+	// Requests made in the same millisecond need distinct members, or they
+	// would overwrite each other in the sorted sets.
+	args := []any{nowMs, cost, uuid.NewString()}
+	for _, window := range windows {
+		keys = append(keys, windowKey(key, window))
+		args = append(args, window.Period.Milliseconds(), window.Limit)
+	}
 
+	reply, err := rl.redisClient.Eval(ctx, slidingWindowLua, keys, args...).Int64Slice()
 	if err != nil {
-		return false, err
+		return Result{}, err
+	}
+
+	if len(reply) != 1+2*len(windows) {
+		return Result{}, errUnexpectedReply
 	}
 
-	allowed, ok := result.(int64)
-	if !ok {
-		return false, nil
+	states := make([]windowState, 0, len(windows))
+	for i, window := range windows {
+		states = append(states, windowState{
+			window:  window,
+			count:   int(reply[1+2*i]),
+			resetAt: reply[2+2*i],
+		})
 	}
 
-	return allowed == 1, nil
+	return newResult(reply[0] == 1, cost, nowMs, states), nil
 }
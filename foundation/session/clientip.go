@@ -0,0 +1,17 @@
+package session
+
+import "context"
+
+type clientIPKey struct{}
+
+// GetClientIP retrieves the IP address of the client stored in the context.
+// It returns an empty string when the context has none.
+func GetClientIP(ctx context.Context) string {
+	clientIP, _ := ctx.Value(clientIPKey{}).(string)
+	return clientIP
+}
+
+// SetClientIP stores the IP address of the client in the context.
+func SetClientIP(ctx context.Context, clientIP string) context.Context {
+	return context.WithValue(ctx, clientIPKey{}, clientIP)
+}
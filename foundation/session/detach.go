@@ -0,0 +1,26 @@
+package session
+
+import "context"
+
+// Detach returns a context for work that outlives the request ctx belongs to,
+// such as a goroutine started by a handler. It carries the request ID, the
+// principal, the client IP and the log attributes of ctx, but neither its
+// cancellation nor its database transaction, which end with the request.
+func Detach(ctx context.Context) context.Context {
+	detached := context.Background()
+
+	if id := GetRequestID(ctx); id != "" {
+		detached = SetRequestID(detached, id)
+	}
+	if principal, ok := GetPrincipal(ctx); ok {
+		detached = SetPrincipal(detached, principal)
+	}
+	if ip := GetClientIP(ctx); ip != "" {
+		detached = SetClientIP(detached, ip)
+	}
+	if attrs := GetLogAttributes(ctx); len(attrs) > 0 {
+		detached = SetLogAttributes(detached, attrs...)
+	}
+
+	return detached
+}
@@ -0,0 +1,61 @@
+package session_test
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kianooshaz/skeleton/foundation/session"
+)
+
+func TestDetach(t *testing.T) {
+	principal := session.Principal{UserID: uuid.New(), AuthMethod: session.AuthMethodToken}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ctx = session.SetRequestID(ctx, "request")
+	ctx = session.SetPrincipal(ctx, principal)
+	ctx = session.SetClientIP(ctx, "192.0.2.1")
+	ctx = session.SetLogAttributes(ctx, slog.String("key", "value"))
+
+	// Execute.
+	detached := session.Detach(ctx)
+	cancel()
+
+	// Assert.
+	require.Error(t, ctx.Err())
+	assert.NoError(t, detached.Err(), "the detached context outlives its parent")
+	assert.Equal(t, "request", session.GetRequestID(detached))
+	assert.Equal(t, "192.0.2.1", session.GetClientIP(detached))
+	assert.Equal(t, []slog.Attr{slog.String("key", "value")}, session.GetLogAttributes(detached))
+
+	got, ok := session.GetPrincipal(detached)
+	require.True(t, ok)
+	assert.Equal(t, principal, got)
+}
+
+func TestAfterCommit(t *testing.T) {
+	ctx, cancel := context.WithCancel(session.SetRequestID(context.Background(), "request"))
+	defer cancel()
+
+	tx, txCtx, err := session.NopTransactor{}.Begin(ctx)
+	require.NoError(t, err)
+
+	var hookCtx context.Context
+	session.AfterCommit(txCtx, func(ctx context.Context) {
+		hookCtx = ctx
+	})
+	require.Nil(t, hookCtx, "the hook waits for the commit")
+
+	// Execute.
+	cancel()
+	require.NoError(t, tx.Commit())
+
+	// Assert.
+	require.NotNil(t, hookCtx)
+	assert.NoError(t, hookCtx.Err(), "the hook runs after the request ends")
+	assert.Equal(t, "request", session.GetRequestID(hookCtx))
+}
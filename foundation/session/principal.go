@@ -0,0 +1,151 @@
+package session
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kianooshaz/skeleton/foundation/derror"
+)
+
+// AuthMethod is how the principal of a request was authenticated.
+type AuthMethod string
+
+const (
+	AuthMethodToken   AuthMethod = "token"
+	AuthMethodSession AuthMethod = "session"
+	AuthMethodAPIKey  AuthMethod = "api_key"
+	// AuthMethodGateway marks principals asserted by a trusted gateway in front
+	// of the application rather than verified by it.
+	AuthMethodGateway AuthMethod = "gateway"
+	// AuthMethodGuest marks guests, who are identified by the signed cookie
+	// issued on their first visit.
+	AuthMethodGuest AuthMethod = "guest"
+)
+
+// RoleAdmin is the role of principals that may act for any account or user.
+const RoleAdmin = "admin"
+
+// Principal is the authenticated identity a request acts on behalf of. It is
+// only stored in the context by the authenticators, after they verified the
+// credentials of the request.
+type Principal struct {
+	UserID          uuid.UUID
+	AccountID       uuid.UUID
+	OrganizationID  uuid.UUID
+	Roles           []string
+	Scopes          []string
+	AuthMethod      AuthMethod
+	AuthenticatedAt time.Time
+}
+
+// HasRole reports whether the principal was granted role.
+func (p Principal) HasRole(role string) bool {
+	return slices.Contains(p.Roles, role)
+}
+
+// HasScope reports whether the credentials of the principal cover scope.
+func (p Principal) HasScope(scope string) bool {
+	return slices.Contains(p.Scopes, scope)
+}
+
+// ActsForAccount reports whether the principal may act on the resources of the
+// account: those of its own account, or of any account for admins.
+func (p Principal) ActsForAccount(accountID uuid.UUID) bool {
+	return p.HasRole(RoleAdmin) || (accountID != uuid.Nil && p.AccountID == accountID)
+}
+
+// ActsForUser reports whether the principal may act on the resources of the
+// user: those of its own user, or of any user for admins.
+func (p Principal) ActsForUser(userID uuid.UUID) bool {
+	return p.HasRole(RoleAdmin) || (userID != uuid.Nil && p.UserID == userID)
+}
+
+// AuthorizeAccount returns derror.ErrUnauthenticated when ctx holds no
+// principal, and derror.ErrForbidden when its principal may not act for the account.
+func AuthorizeAccount(ctx context.Context, accountID uuid.UUID) error {
+	principal, ok := GetPrincipal(ctx)
+	if !ok {
+		return derror.ErrUnauthenticated
+	}
+	if !principal.ActsForAccount(accountID) {
+		return derror.ErrForbidden
+	}
+
+	return nil
+}
+
+// AuthorizeUser returns derror.ErrUnauthenticated when ctx holds no principal,
+// and derror.ErrForbidden when its principal may not act for the user.
+func AuthorizeUser(ctx context.Context, userID uuid.UUID) error {
+	principal, ok := GetPrincipal(ctx)
+	if !ok {
+		return derror.ErrUnauthenticated
+	}
+	if !principal.ActsForUser(userID) {
+		return derror.ErrForbidden
+	}
+
+	return nil
+}
+
+// AuthorizeRole returns derror.ErrUnauthenticated when ctx holds no principal,
+// and derror.ErrForbidden when its principal was not granted role.
+func AuthorizeRole(ctx context.Context, role string) error {
+	principal, ok := GetPrincipal(ctx)
+	if !ok {
+		return derror.ErrUnauthenticated
+	}
+	if !principal.HasRole(role) {
+		return derror.ErrForbidden
+	}
+
+	return nil
+}
+
+type principalKey struct{}
+
+// GetPrincipal retrieves the principal stored in the context.
+// It returns the principal and a boolean indicating whether the request was authenticated.
+func GetPrincipal(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(Principal)
+	return principal, ok
+}
+
+// MustPrincipal retrieves the principal stored in the context. It panics when
+// there is none, so it is meant for code only reachable by authenticated requests.
+func MustPrincipal(ctx context.Context) Principal {
+	principal, ok := GetPrincipal(ctx)
+	if !ok {
+		panic("session: no principal in context")
+	}
+
+	return principal
+}
+
+// SetPrincipal stores the provided principal in the context.
+func SetPrincipal(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// GetUserID retrieves the user ID of the principal stored in the context.
+// It returns the user ID and a boolean indicating whether the user ID was found.
+func GetUserID(ctx context.Context) (uuid.UUID, bool) {
+	principal, ok := GetPrincipal(ctx)
+	return principal.UserID, ok && principal.UserID != uuid.Nil
+}
+
+// GetAccountID retrieves the account ID of the principal stored in the context.
+// It returns the account ID and a boolean indicating whether the account ID was found.
+func GetAccountID(ctx context.Context) (uuid.UUID, bool) {
+	principal, ok := GetPrincipal(ctx)
+	return principal.AccountID, ok && principal.AccountID != uuid.Nil
+}
+
+// GetOrganizationID retrieves the organization ID of the principal stored in the context.
+// It returns the organization ID and a boolean indicating whether the organization ID was found.
+func GetOrganizationID(ctx context.Context) (uuid.UUID, bool) {
+	principal, ok := GetPrincipal(ctx)
+	return principal.OrganizationID, ok && principal.OrganizationID != uuid.Nil
+}
@@ -1,33 +1,24 @@
 package session
 
-import (
-	"context"
+import "context"
 
-	"github.com/labstack/echo/v4"
-)
+// RequestIDHeader is the header carrying the request ID between services.
+const RequestIDHeader = "X-Request-ID"
 
-var requestIDKey = "session_request_id"
+type requestIDKey struct{}
 
 // SetRequestID stores the provided request ID in the context.
-func SetRequestIDEcho() func(c echo.Context, id string) {
-	return func(c echo.Context, id string) {
-		c.Set(requestIDKey, id)
-	}
-}
-
 func SetRequestID(ctx context.Context, id string) context.Context {
-	return context.WithValue(ctx, requestIDKey, id)
+	return context.WithValue(ctx, requestIDKey{}, id)
 }
 
 // GetRequestID retrieves the request ID stored in the context.
+// It returns an empty string when the context has none.
 func GetRequestID(ctx context.Context) string {
 	if ctx == nil {
 		return ""
 	}
 
-	if id, ok := ctx.Value(requestIDKey).(string); ok {
-		return id
-	}
-
-	return ""
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
 }
@@ -3,6 +3,8 @@ package session
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"sync"
 
 	dbproto "github.com/kianooshaz/skeleton/foundation/database/proto"
 )
@@ -32,3 +34,132 @@ func BeginTransaction(ctx context.Context, db *sql.DB) (*sql.Tx, context.Context
 
 	return tx, SetDBConnection(ctx, tx), nil
 }
+
+// Tx is a transaction begun by a Transactor.
+type Tx interface {
+	// Commit commits the transaction, then runs the functions registered with
+	// AfterCommit.
+	Commit() error
+	// Rollback aborts the transaction. It is a no-op after Commit, so it can be deferred.
+	Rollback() error
+}
+
+// Transactor begins transactions and stores them in the context, where the
+// storages pick them up through GetDBConnection.
+type Transactor interface {
+	// Begin returns the transaction and a context holding it. On error it
+	// returns ctx unchanged.
+	Begin(ctx context.Context) (Tx, context.Context, error)
+}
+
+type commitHooksKey struct{}
+
+// commitHooks are the functions to run once a transaction commits.
+type commitHooks struct {
+	mu    sync.Mutex
+	hooks []func()
+}
+
+func (h *commitHooks) add(fn func()) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.hooks = append(h.hooks, fn)
+}
+
+func (h *commitHooks) run() {
+	h.mu.Lock()
+	hooks := h.hooks
+	h.hooks = nil
+	h.mu.Unlock()
+
+	for _, fn := range hooks {
+		fn()
+	}
+}
+
+// withCommitHooks returns ctx holding the hooks of a transaction being begun.
+func withCommitHooks(ctx context.Context) (context.Context, *commitHooks) {
+	hooks := &commitHooks{}
+
+	return context.WithValue(ctx, commitHooksKey{}, hooks), hooks
+}
+
+// AfterCommit runs fn once the transaction begun by a Transactor and held by
+// ctx commits, or never if it is rolled back. Without such a transaction fn
+// runs at once. It is meant for side effects that must not outlive a rolled
+// back change, such as publishing an audit record of it. fn is given ctx
+// detached, since the transaction is over and the request may be too.
+func AfterCommit(ctx context.Context, fn func(ctx context.Context)) {
+	detached := Detach(ctx)
+
+	hooks, ok := ctx.Value(commitHooksKey{}).(*commitHooks)
+	if !ok {
+		fn(detached)
+		return
+	}
+
+	hooks.add(func() { fn(detached) })
+}
+
+// SQLTransactor begins transactions on a database.
+type SQLTransactor struct {
+	DB *sql.DB
+}
+
+func (t SQLTransactor) Begin(ctx context.Context) (Tx, context.Context, error) {
+	tx, txCtx, err := BeginTransaction(ctx, t.DB)
+	if err != nil {
+		return nil, ctx, err
+	}
+
+	txCtx, hooks := withCommitHooks(txCtx)
+
+	return sqlTx{Tx: tx, hooks: hooks}, txCtx, nil
+}
+
+type sqlTx struct {
+	*sql.Tx
+	hooks *commitHooks
+}
+
+func (tx sqlTx) Commit() error {
+	if err := tx.Tx.Commit(); err != nil {
+		return err
+	}
+
+	tx.hooks.run()
+
+	return nil
+}
+
+func (tx sqlTx) Rollback() error {
+	if err := tx.Tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+		return err
+	}
+
+	return nil
+}
+
+// NopTransactor begins transactions that do nothing, for storages without
+// transaction support such as the in-memory ones.
+type NopTransactor struct{}
+
+func (NopTransactor) Begin(ctx context.Context) (Tx, context.Context, error) {
+	ctx, hooks := withCommitHooks(ctx)
+
+	return nopTx{hooks: hooks}, ctx, nil
+}
+
+// nopTx runs the functions registered with AfterCommit on Commit, like a
+// transaction of a database would.
+type nopTx struct {
+	hooks *commitHooks
+}
+
+func (tx nopTx) Commit() error {
+	tx.hooks.run()
+	return nil
+}
+
+func (nopTx) Rollback() error { return nil }
@@ -0,0 +1,55 @@
+package signed_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kianooshaz/skeleton/foundation/signed"
+)
+
+func TestSigner_Verify(t *testing.T) {
+	signer := signed.NewSigner("secret")
+	token := signer.Sign("user.1700000000")
+
+	tests := []struct {
+		name      string
+		signed    string
+		wantValue string
+		wantOK    bool
+	}{
+		{
+			name:      "signed",
+			signed:    token,
+			wantValue: "user.1700000000",
+			wantOK:    true,
+		},
+		{
+			name:   "tampered value",
+			signed: "admin" + token[len("user"):],
+		},
+		{
+			name:   "signed with another secret",
+			signed: signed.NewSigner("other").Sign("user.1700000000"),
+		},
+		{
+			name:   "unsigned",
+			signed: "user",
+		},
+		{
+			name:   "malformed signature",
+			signed: "user.!!!",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Execute.
+			value, ok := signer.Verify(tt.signed)
+
+			// Assert.
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantValue, value)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package stat
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Filter selects statuses by their flags. A zero Filter selects every status.
+type Filter struct {
+	// All holds the flags a status must all carry.
+	All Status `query:"status_all"`
+	// Any holds the flags a status must carry at least one of.
+	Any Status `query:"status_any"`
+	// None holds the flags a status must not carry.
+	None Status `query:"status_none"`
+}
+
+// IsZero reports whether f selects every status.
+func (f Filter) IsZero() bool {
+	return f == Filter{}
+}
+
+// Match reports whether s passes the filter.
+func (f Filter) Match(s Status) bool {
+	return s&f.All == f.All &&
+		(f.Any == Unset || s&f.Any != 0) &&
+		s&f.None == 0
+}
+
+// Where returns the bitwise predicate of the filter on column, without the
+// WHERE keyword, and its arguments. Placeholders are numbered from argIndex.
+// It returns an empty predicate when the filter selects every status.
+func (f Filter) Where(column string, argIndex int) (string, []any) {
+	conditions := make([]string, 0, 3)
+	args := make([]any, 0, 3)
+
+	add := func(format string, flags Status) {
+		if flags == Unset {
+			return
+		}
+
+		placeholder := fmt.Sprintf("$%d::BIGINT", argIndex)
+		conditions = append(conditions, fmt.Sprintf(format, column, placeholder))
+		args = append(args, int64(flags))
+		argIndex++
+	}
+
+	add("%[1]s & %[2]s = %[2]s", f.All)
+	add("%[1]s & %[2]s <> 0", f.Any)
+	add("%[1]s & %[2]s = 0", f.None)
+
+	return strings.Join(conditions, " AND "), args
+}
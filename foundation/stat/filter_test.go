@@ -0,0 +1,46 @@
+package stat_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kianooshaz/skeleton/foundation/stat"
+)
+
+func TestFilter_Match(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter stat.Filter
+		status stat.Status
+		want   bool
+	}{
+		{name: "zero filter", status: stat.Blocked, want: true},
+		{name: "has all", filter: stat.Filter{All: stat.Primary | stat.Locked}, status: stat.Primary | stat.Locked | stat.Verified, want: true},
+		{name: "misses one of all", filter: stat.Filter{All: stat.Primary | stat.Locked}, status: stat.Primary},
+		{name: "has any", filter: stat.Filter{Any: stat.Locked | stat.Blocked}, status: stat.Blocked, want: true},
+		{name: "has none of any", filter: stat.Filter{Any: stat.Locked | stat.Blocked}, status: stat.Primary},
+		{name: "has none", filter: stat.Filter{None: stat.Locked | stat.Blocked}, status: stat.Primary, want: true},
+		{name: "has one of none", filter: stat.Filter{None: stat.Locked | stat.Blocked}, status: stat.Locked},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.filter.Match(tt.status))
+		})
+	}
+}
+
+func TestFilter_Where(t *testing.T) {
+	where, args := stat.Filter{}.Where("status", 1)
+	assert.Empty(t, where)
+	assert.Empty(t, args)
+
+	where, args = stat.Filter{All: stat.Primary, Any: stat.Locked | stat.Blocked, None: stat.Reserved}.Where("status", 2)
+	assert.Equal(t, "status & $2::BIGINT = $2::BIGINT AND status & $3::BIGINT <> 0 AND status & $4::BIGINT = 0", where)
+	assert.Equal(t, []any{int64(stat.Primary), int64(stat.Locked | stat.Blocked), int64(stat.Reserved)}, args)
+
+	where, args = stat.Filter{None: stat.Guest}.Where("u.status", 1)
+	assert.Equal(t, "u.status & $1::BIGINT = 0", where)
+	assert.Equal(t, []any{int64(stat.Guest)}, args)
+}
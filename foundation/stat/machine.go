@@ -0,0 +1,80 @@
+package stat
+
+import (
+	"fmt"
+
+	"github.com/kianooshaz/skeleton/foundation/derror"
+)
+
+// Transition is a named change of status, such as locking or unlocking.
+type Transition struct {
+	Name   string
+	Add    Status
+	Remove Status
+	// Requires holds the flags the status must all carry for the transition to apply.
+	Requires Status
+	// Forbids holds the flags the status must not carry for the transition to apply.
+	Forbids Status
+	// Err is returned when Requires or Forbids rule the transition out. It
+	// defaults to derror.ErrInvalidStatusTransition.
+	Err error
+}
+
+// Machine declares the status flags an entity may carry and the transitions
+// allowed between them. Statuses of the entity should only change through Next.
+type Machine struct {
+	entity      string
+	flags       Status
+	transitions map[string]Transition
+}
+
+// NewMachine declares the machine of entity. It panics when a transition
+// names a flag outside flags or is declared twice, since machines are
+// declared once at startup.
+func NewMachine(entity string, flags Status, transitions ...Transition) Machine {
+	m := Machine{
+		entity:      entity,
+		flags:       flags,
+		transitions: make(map[string]Transition, len(transitions)),
+	}
+
+	for _, t := range transitions {
+		if _, ok := m.transitions[t.Name]; ok {
+			panic(fmt.Sprintf("stat: %s transition %s declared twice", entity, t.Name))
+		}
+		if (t.Add|t.Remove|t.Requires|t.Forbids)&^flags != 0 {
+			panic(fmt.Sprintf("stat: %s transition %s uses undeclared flags", entity, t.Name))
+		}
+		if t.Err == nil {
+			t.Err = derror.ErrInvalidStatusTransition
+		}
+
+		m.transitions[t.Name] = t
+	}
+
+	return m
+}
+
+// Entity returns the name of the entity the machine belongs to.
+func (m Machine) Entity() string {
+	return m.entity
+}
+
+// Next returns the status current becomes through the named transition, or
+// the error of the transition when its guards rule it out.
+func (m Machine) Next(current Status, transition string) (Status, error) {
+	t, ok := m.transitions[transition]
+	if !ok {
+		return current, fmt.Errorf("%w: %s has no transition %s", derror.ErrInvalidStatusTransition, m.entity, transition)
+	}
+
+	if current&t.Requires != t.Requires || current&t.Forbids != 0 {
+		return current, t.Err
+	}
+
+	next := current
+	next.Remove(t.Remove)
+	next.Add(t.Add)
+
+	return next, nil
+}
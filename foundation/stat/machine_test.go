@@ -0,0 +1,96 @@
+package stat_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kianooshaz/skeleton/foundation/derror"
+	"github.com/kianooshaz/skeleton/foundation/stat"
+)
+
+var errLocked = errors.New("locked")
+
+var machine = stat.NewMachine("username", stat.Primary|stat.Locked,
+	stat.Transition{Name: "set_primary", Add: stat.Primary, Forbids: stat.Primary},
+	stat.Transition{Name: "lock", Add: stat.Locked, Forbids: stat.Locked},
+	stat.Transition{Name: "unlock", Remove: stat.Locked, Requires: stat.Locked},
+	stat.Transition{Name: "unassign", Forbids: stat.Locked, Err: errLocked},
+)
+
+func TestMachine_Next(t *testing.T) {
+	tests := []struct {
+		name       string
+		current    stat.Status
+		transition string
+		want       stat.Status
+		wantErr    error
+	}{
+		{
+			name:       "adds a flag",
+			current:    stat.Unset,
+			transition: "set_primary",
+			want:       stat.Primary,
+		},
+		{
+			name:       "keeps the other flags",
+			current:    stat.Primary,
+			transition: "lock",
+			want:       stat.Primary | stat.Locked,
+		},
+		{
+			name:       "removes a flag",
+			current:    stat.Primary | stat.Locked,
+			transition: "unlock",
+			want:       stat.Primary,
+		},
+		{
+			name:       "required flag missing",
+			current:    stat.Primary,
+			transition: "unlock",
+			wantErr:    derror.ErrInvalidStatusTransition,
+		},
+		{
+			name:       "forbidden flag present",
+			current:    stat.Locked,
+			transition: "lock",
+			wantErr:    derror.ErrInvalidStatusTransition,
+		},
+		{
+			name:       "guard with its own error",
+			current:    stat.Locked,
+			transition: "unassign",
+			wantErr:    errLocked,
+		},
+		{
+			name:       "unknown transition",
+			current:    stat.Unset,
+			transition: "block",
+			wantErr:    derror.ErrInvalidStatusTransition,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Execute.
+			got, err := machine.Next(tt.current, tt.transition)
+
+			// Assert.
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				assert.Equal(t, tt.current, got)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNewMachine_UndeclaredFlag(t *testing.T) {
+	assert.Panics(t, func() {
+		stat.NewMachine("username", stat.Primary, stat.Transition{Name: "lock", Add: stat.Locked})
+	})
+}
@@ -0,0 +1,175 @@
+package stat
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+
+	"github.com/kianooshaz/skeleton/foundation/derror"
+)
+
+// flagNames names each flag in APIs, in the order the flags are declared.
+var flagNames = []struct {
+	flag Status
+	name string
+}{
+	{Registered, "registered"},
+	{Pending, "pending"},
+	{Inactive, "inactive"},
+	{Locked, "locked"},
+	{Blocked, "blocked"},
+	{Suspended, "suspended"},
+	{Hidden, "hidden"},
+	{UnderReview, "under_review"},
+	{Flagged, "flagged"},
+	{Verified, "verified"},
+	{ManuallyAdded, "manually_added"},
+	{ManuallyVerified, "manually_verified"},
+	{Primary, "primary"},
+	{Reserved, "reserved"},
+	{Guest, "guest"},
+}
+
+// FlagNames returns the name of every flag, in the order the flags are declared.
+func FlagNames() []string {
+	names := make([]string, 0, len(flagNames))
+	for _, f := range flagNames {
+		names = append(names, f.name)
+	}
+
+	return names
+}
+
+// Names returns the names of the flags set in s. Flags without a name are
+// returned as their decimal value so that no flag is lost on the way out.
+func (s Status) Names() []string {
+	names := make([]string, 0, bits.OnesCount(uint(s)))
+
+	rest := s
+	for _, f := range flagNames {
+		if s&f.flag != 0 {
+			names = append(names, f.name)
+			rest &^= f.flag
+		}
+	}
+
+	for rest != 0 {
+		flag := rest & -rest
+		names = append(names, strconv.FormatUint(uint64(flag), 10))
+		rest &^= flag
+	}
+
+	return names
+}
+
+// String returns the comma-separated names of the flags set in s.
+func (s Status) String() string {
+	return strings.Join(s.Names(), ",")
+}
+
+// ParseFlag returns the flag named name. A decimal value is accepted for
+// flags without a name.
+func ParseFlag(name string) (Status, error) {
+	name = strings.TrimSpace(name)
+	for _, f := range flagNames {
+		if f.name == name {
+			return f.flag, nil
+		}
+	}
+
+	if value, err := strconv.ParseUint(name, 10, 0); err == nil && bits.OnesCount64(value) == 1 {
+		return Status(value), nil
+	}
+
+	return Unset, fmt.Errorf("%w: %q", derror.ErrUnknownStatusFlag, name)
+}
+
+// ParseStatus parses a comma-separated list of flag names, such as
+// "primary,locked". An empty string is Unset.
+func ParseStatus(names string) (Status, error) {
+	if strings.TrimSpace(names) == "" {
+		return Unset, nil
+	}
+
+	return parseNames(strings.Split(names, ","))
+}
+
+func parseNames(names []string) (Status, error) {
+	var s Status
+	for _, name := range names {
+		flag, err := ParseFlag(name)
+		if err != nil {
+			return Unset, err
+		}
+		s.Add(flag)
+	}
+
+	return s, nil
+}
+
+// MarshalJSON encodes s as the list of its flag names.
+func (s Status) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Names())
+}
+
+// UnmarshalJSON decodes a list of flag names. The integer form is still
+// accepted for clients written before statuses were named.
+func (s *Status) UnmarshalJSON(data []byte) error {
+	var names []string
+	if err := json.Unmarshal(data, &names); err == nil {
+		parsed, err := parseNames(names)
+		if err != nil {
+			return err
+		}
+		*s = parsed
+		return nil
+	}
+
+	var value uint
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("%w: %s", derror.ErrUnknownStatusFlag, data)
+	}
+	*s = Status(value)
+
+	return nil
+}
+
+// MarshalText encodes s as its comma-separated flag names.
+func (s Status) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText decodes comma-separated flag names.
+func (s *Status) UnmarshalText(text []byte) error {
+	parsed, err := ParseStatus(string(text))
+	if err != nil {
+		return err
+	}
+	*s = parsed
+
+	return nil
+}
+
+// UnmarshalParam decodes a query or form parameter holding comma-separated
+// flag names.
+func (s *Status) UnmarshalParam(param string) error {
+	return s.UnmarshalText([]byte(param))
+}
+
+// UnmarshalParams decodes a repeated query or form parameter, as in
+// ?status_all=primary&status_all=locked, into the union of its flags.
+func (s *Status) UnmarshalParams(params []string) error {
+	var union Status
+	for _, param := range params {
+		parsed, err := ParseStatus(param)
+		if err != nil {
+			return err
+		}
+		union.Add(parsed)
+	}
+	*s = union
+
+	return nil
+}
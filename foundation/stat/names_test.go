@@ -0,0 +1,122 @@
+package stat_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kianooshaz/skeleton/foundation/derror"
+	"github.com/kianooshaz/skeleton/foundation/stat"
+)
+
+func TestStatus_JSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		status stat.Status
+		json   string
+	}{
+		{name: "unset", status: stat.Unset, json: `[]`},
+		{name: "single flag", status: stat.Primary, json: `["primary"]`},
+		{name: "flags in declaration order", status: stat.Reserved | stat.Locked | stat.UnderReview, json: `["locked","under_review","reserved"]`},
+		{name: "unnamed flag", status: stat.Guest | 1<<20, json: `["guest","1048576"]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.status)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.json, string(data))
+
+			var decoded stat.Status
+			require.NoError(t, json.Unmarshal(data, &decoded))
+			assert.Equal(t, tt.status, decoded)
+		})
+	}
+}
+
+func TestStatus_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    stat.Status
+		wantErr error
+	}{
+		{name: "names", data: `["primary","locked"]`, want: stat.Primary | stat.Locked},
+		{name: "integer", data: `4098`, want: stat.Primary | stat.Pending},
+		{name: "unknown name", data: `["primary","sleeping"]`, wantErr: derror.ErrUnknownStatusFlag},
+		{name: "not a status", data: `"primary"`, wantErr: derror.ErrUnknownStatusFlag},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got stat.Status
+			err := json.Unmarshal([]byte(tt.data), &got)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		want    stat.Status
+		wantErr error
+	}{
+		{name: "empty", text: "", want: stat.Unset},
+		{name: "names", text: "primary, locked", want: stat.Primary | stat.Locked},
+		{name: "unnamed flag", text: "1048576", want: 1 << 20},
+		{name: "unknown name", text: "primary,sleeping", wantErr: derror.ErrUnknownStatusFlag},
+		{name: "value of several flags", text: "3", wantErr: derror.ErrUnknownStatusFlag},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := stat.ParseStatus(tt.text)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.want, mustParse(t, got.String()))
+		})
+	}
+}
+
+func mustParse(t *testing.T, text string) stat.Status {
+	t.Helper()
+
+	status, err := stat.ParseStatus(text)
+	require.NoError(t, err)
+
+	return status
+}
+
+func TestFilter_Bind(t *testing.T) {
+	var req struct {
+		Status stat.Filter
+	}
+
+	query := "/?status_all=primary&status_all=locked&status_any=verified,manually_verified&status_none=blocked"
+	c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, query, nil), httptest.NewRecorder())
+	require.NoError(t, (&echo.DefaultBinder{}).BindQueryParams(c, &req))
+
+	assert.Equal(t, stat.Filter{
+		All:  stat.Primary | stat.Locked,
+		Any:  stat.Verified | stat.ManuallyVerified,
+		None: stat.Blocked,
+	}, req.Status)
+}
@@ -35,6 +35,8 @@ const (
 	Primary
 
 	Reserved
+
+	Guest
 )
 
 // Has checks if a specific status (ss) is present in the current status.
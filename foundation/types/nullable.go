@@ -1,25 +1,36 @@
 package types
 
 import (
+	"bytes"
 	"database/sql"
+	"database/sql/driver"
+	"encoding"
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"reflect"
 	"strconv"
-	"time"
 )
 
+// null is the JSON literal of a missing value.
+var null = []byte("null")
+
 // Nullable is a generic type that can represent a value that may be null (invalid).
 // T is the type of the value being wrapped.
+//
+// Nullable round-trips through JSON (as null or the JSON of the value), query
+// parameters (an empty parameter or "null" is null) and SQL (as NULL or the
+// value). Like sql.Null, the value is held in V since Value is the method
+// implementing driver.Valuer.
 type Nullable[T any] struct {
-	Value T    // The actual value
-	Valid bool // Valid is true if Value is not null
+	V     T    // The actual value
+	Valid bool // Valid is true if V is not null
 }
 
 // NewNullable creates a new Nullable with a valid value.
 func NewNullable[T any](value T) Nullable[T] {
 	return Nullable[T]{
-		Value: value,
+		V:     value,
 		Valid: true,
 	}
 }
@@ -35,247 +46,148 @@ func (n Nullable[T]) Get() T {
 		var zero T
 		return zero
 	}
-	return n.Value
+	return n.V
 }
 
 // Set assigns a new value and marks the Nullable as valid.
 func (n *Nullable[T]) Set(value T) {
-	n.Value = value
+	n.V = value
 	n.Valid = true
 }
 
-func (n *Nullable[T]) UnmarshalParam(param string) error {
-	defer func() {
-		if r := recover(); r != nil {
-			slog.Error("panic in UnmarshalParam",
-				slog.String("param", param),
-				slog.Any("recovered", r),
-			)
-			n.Valid = false
-		}
-	}()
+// SetNull clears the value and marks the Nullable as null.
+func (n *Nullable[T]) SetNull() {
+	var zero T
+	n.V = zero
+	n.Valid = false
+}
 
-	if len(param) == 0 || string(param) == "null" {
-		n.Valid = false
-		var zero T
-		n.Value = zero
+// UnmarshalParam decodes a query or form parameter. An empty parameter or
+// "null" is null.
+func (n *Nullable[T]) UnmarshalParam(param string) error {
+	if len(param) == 0 || param == "null" {
+		n.SetNull()
 		return nil
 	}
 
-	value, err := n.parseParam(param)
+	value, err := parseParam[T](param)
 	if err != nil {
-		n.Valid = false
+		n.SetNull()
 		return err
 	}
 
-	n.Value = value
-	n.Valid = true
+	n.Set(value)
 	return nil
 }
 
-func (n *Nullable[T]) parseParam(param string) (T, error) {
+// parseParam parses param into a T. Types implementing encoding.TextUnmarshaler,
+// such as time.Time (RFC 3339) and IDs, parse themselves; other types are
+// parsed according to their kind, so named strings and numbers work too.
+func parseParam[T any](param string) (T, error) {
 	var v T
 
-	switch any(v).(type) {
-	case string:
-		return any(param).(T), nil
-	case int8:
-		return n.parseInt8(param)
-	case int16:
-		return n.parseInt16(param)
-	case int32:
-		return n.parseInt32(param)
-	case int64:
-		return n.parseInt64(param)
-	case int:
-		return n.parseInt(param)
-	case uint8:
-		return n.parseUint8(param)
-	case uint16:
-		return n.parseUint16(param)
-	case uint32:
-		return n.parseUint32(param)
-	case uint64:
-		return n.parseUint64(param)
-	case float32:
-		return n.parseFloat32(param)
-	case float64:
-		return n.parseFloat64(param)
-	case bool:
-		return n.parseBool(param)
-	case time.Time:
-		return n.parseTime(param)
-	default:
-		return v, fmt.Errorf("unsupported type %T for Nullable", v)
-	}
-}
-
-func (n *Nullable[T]) parseInt8(param string) (T, error) {
-	parsed, err := strconv.ParseInt(param, 10, 8)
-	if err != nil {
-		var zero T
-		return zero, fmt.Errorf("failed to parse param %q to int8: %w", param, err)
-	}
-	return any(int8(parsed)).(T), nil
-}
-
-func (n *Nullable[T]) parseInt16(param string) (T, error) {
-	parsed, err := strconv.ParseInt(param, 10, 16)
-	if err != nil {
-		var zero T
-		return zero, fmt.Errorf("failed to parse param %q to int16: %w", param, err)
-	}
-	return any(int16(parsed)).(T), nil
-}
-
-func (n *Nullable[T]) parseInt32(param string) (T, error) {
-	parsed, err := strconv.ParseInt(param, 10, 32)
-	if err != nil {
-		var zero T
-		return zero, fmt.Errorf("failed to parse param %q to int32: %w", param, err)
-	}
-	return any(int32(parsed)).(T), nil
-}
-
-func (n *Nullable[T]) parseInt64(param string) (T, error) {
-	parsed, err := strconv.ParseInt(param, 10, 64)
-	if err != nil {
-		var zero T
-		return zero, fmt.Errorf("failed to parse param %q to int64: %w", param, err)
-	}
-	return any(parsed).(T), nil
-}
-
-func (n *Nullable[T]) parseInt(param string) (T, error) {
-	parsed, err := strconv.ParseInt(param, 10, 0)
-	if err != nil {
-		var zero T
-		return zero, fmt.Errorf("failed to parse param %q to int: %w", param, err)
-	}
-	return any(int(parsed)).(T), nil
-}
-
-func (n *Nullable[T]) parseUint8(param string) (T, error) {
-	parsed, err := strconv.ParseUint(param, 10, 8)
-	if err != nil {
-		var zero T
-		return zero, fmt.Errorf("failed to parse param %q to uint8: %w", param, err)
+	if unmarshaler, ok := any(&v).(encoding.TextUnmarshaler); ok {
+		if err := unmarshaler.UnmarshalText([]byte(param)); err != nil {
+			return v, fmt.Errorf("failed to parse param %q to %T: %w", param, v, err)
+		}
+		return v, nil
 	}
-	return any(uint8(parsed)).(T), nil
-}
 
-func (n *Nullable[T]) parseUint16(param string) (T, error) {
-	parsed, err := strconv.ParseUint(param, 10, 16)
-	if err != nil {
-		var zero T
-		return zero, fmt.Errorf("failed to parse param %q to uint16: %w", param, err)
+	field := reflect.ValueOf(&v).Elem()
+	switch kind := field.Kind(); kind {
+	case reflect.String:
+		field.SetString(param)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err := strconv.ParseInt(param, 10, field.Type().Bits())
+		if err != nil {
+			return v, fmt.Errorf("failed to parse param %q to %T: %w", param, v, err)
+		}
+		field.SetInt(parsed)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		parsed, err := strconv.ParseUint(param, 10, field.Type().Bits())
+		if err != nil {
+			return v, fmt.Errorf("failed to parse param %q to %T: %w", param, v, err)
+		}
+		field.SetUint(parsed)
+	case reflect.Float32, reflect.Float64:
+		parsed, err := strconv.ParseFloat(param, field.Type().Bits())
+		if err != nil {
+			return v, fmt.Errorf("failed to parse param %q to %T: %w", param, v, err)
+		}
+		field.SetFloat(parsed)
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(param)
+		if err != nil {
+			return v, fmt.Errorf("failed to parse param %q to %T: %w", param, v, err)
+		}
+		field.SetBool(parsed)
+	default:
+		return v, fmt.Errorf("unsupported type %T for Nullable", v)
 	}
-	return any(uint16(parsed)).(T), nil
-}
 
-func (n *Nullable[T]) parseUint32(param string) (T, error) {
-	parsed, err := strconv.ParseUint(param, 10, 32)
-	if err != nil {
-		var zero T
-		return zero, fmt.Errorf("failed to parse param %q to uint32: %w", param, err)
-	}
-	return any(uint32(parsed)).(T), nil
+	return v, nil
 }
 
-func (n *Nullable[T]) parseUint64(param string) (T, error) {
-	parsed, err := strconv.ParseUint(param, 10, 64)
-	if err != nil {
-		var zero T
-		return zero, fmt.Errorf("failed to parse param %q to uint64: %w", param, err)
+// UnmarshalJSON decodes null or the JSON of a T.
+func (n *Nullable[T]) UnmarshalJSON(data []byte) error {
+	if len(data) == 0 || bytes.Equal(data, null) {
+		n.SetNull()
+		return nil
 	}
-	return any(parsed).(T), nil
-}
 
-func (n *Nullable[T]) parseFloat32(param string) (T, error) {
-	parsed, err := strconv.ParseFloat(param, 32)
-	if err != nil {
-		var zero T
-		return zero, fmt.Errorf("failed to parse param %q to float32: %w", param, err)
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		n.SetNull()
+		return fmt.Errorf("failed to unmarshal JSON to %T: %w", v, err)
 	}
-	return any(float32(parsed)).(T), nil
-}
 
-func (n *Nullable[T]) parseFloat64(param string) (T, error) {
-	parsed, err := strconv.ParseFloat(param, 64)
-	if err != nil {
-		var zero T
-		return zero, fmt.Errorf("failed to parse param %q to float64: %w", param, err)
-	}
-	return any(parsed).(T), nil
+	n.Set(v)
+	return nil
 }
 
-func (n *Nullable[T]) parseBool(param string) (T, error) {
-	parsed, err := strconv.ParseBool(param)
-	if err != nil {
-		var zero T
-		return zero, fmt.Errorf("failed to parse param %q to bool: %w", param, err)
+// MarshalJSON encodes null or the JSON of the value.
+func (n Nullable[T]) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return null, nil
 	}
-	return any(parsed).(T), nil
-}
 
-func (n *Nullable[T]) parseTime(param string) (T, error) {
-	parsed, err := time.Parse(time.RFC3339, param)
-	if err != nil {
-		var zero T
-		return zero, fmt.Errorf("failed to parse param %q to time.Time: %w", param, err)
-	}
-	return any(parsed).(T), nil
+	return json.Marshal(n.V)
 }
 
-func (n *Nullable[T]) UnmarshalJSON(data []byte) error {
-	defer func() {
-		if r := recover(); r != nil {
-			slog.Error("panic in UnmarshalJSON",
-				slog.Any("data", string(data)),
-				slog.Any("recovered", r),
-			)
-			n.Valid = false
-		}
-	}()
-
-	if len(data) == 0 || string(data) == "null" {
-		n.Valid = false
-		var zero T
-		n.Value = zero
-		return nil
-	}
-
-	var v T
-	err := json.Unmarshal(data, &v)
-	if err != nil {
-		n.Valid = false
-		return fmt.Errorf("failed to unmarshal JSON to %T: %w", v, err)
+// Scan implements sql.Scanner. NULL is null; other values are converted to T
+// the way database/sql converts scanned columns, so types implementing
+// sql.Scanner scan themselves.
+func (n *Nullable[T]) Scan(value any) error {
+	var scanned sql.Null[T]
+	if err := scanned.Scan(value); err != nil {
+		return err
 	}
 
-	n.Value = v
-	n.Valid = true
+	n.V, n.Valid = scanned.V, scanned.Valid
 	return nil
 }
 
-func (n Nullable[T]) MarshalJSON() ([]byte, error) {
+// Value implements driver.Valuer. Null is NULL; other values are converted the
+// way database/sql converts query arguments, so types implementing
+// driver.Valuer, as well as named strings and numbers, are accepted.
+func (n Nullable[T]) Value() (driver.Value, error) {
 	if !n.Valid {
-		// TODO : Handle null value in a way that fits your application
-		return []byte{}, nil
+		return nil, nil
 	}
 
-	return fmt.Appendf([]byte{}, "%v", n.Value), nil
+	return driver.DefaultParameterConverter.ConvertValue(n.V)
 }
 
-// FromSQLNullInt64 converts a sql.NullInt64 to a Nullable[int64].
+// FromSQLNullString converts a sql.NullString to a Nullable[T].
 func FromSQLNullString[T any](value sql.NullString) Nullable[T] {
 	if !value.Valid {
 		var zero T
-		return Nullable[T]{Value: zero, Valid: false}
+		return Nullable[T]{V: zero, Valid: false}
 	}
 
 	var val T
 	if v, ok := any(value.String).(T); ok {
-		return Nullable[T]{Value: v, Valid: true}
+		return Nullable[T]{V: v, Valid: true}
 	} else {
 		slog.Error("Type assertion failed in FromSQLNullString",
 			slog.String("value", value.String),
@@ -283,7 +195,7 @@ func FromSQLNullString[T any](value sql.NullString) Nullable[T] {
 			slog.String("actual_type", "string"),
 		)
 		var zero T
-		return Nullable[T]{Value: zero, Valid: false}
+		return Nullable[T]{V: zero, Valid: false}
 	}
 }
 
@@ -291,12 +203,12 @@ func FromSQLNullString[T any](value sql.NullString) Nullable[T] {
 func FromSQLNullTime[T any](value sql.NullTime) Nullable[T] {
 	if !value.Valid {
 		var zero T
-		return Nullable[T]{Value: zero, Valid: false}
+		return Nullable[T]{V: zero, Valid: false}
 	}
 
 	var val T
 	if v, ok := any(value.Time).(T); ok {
-		return Nullable[T]{Value: v, Valid: true}
+		return Nullable[T]{V: v, Valid: true}
 	} else {
 		slog.Error("Type assertion failed in FromSQLNullTime",
 			slog.String("value", value.Time.String()),
@@ -304,6 +216,6 @@ func FromSQLNullTime[T any](value sql.NullTime) Nullable[T] {
 			slog.String("actual_type", "time.Time"),
 		)
 		var zero T
-		return Nullable[T]{Value: zero, Valid: false}
+		return Nullable[T]{V: zero, Valid: false}
 	}
 }
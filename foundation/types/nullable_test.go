@@ -0,0 +1,149 @@
+package types_test
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kianooshaz/skeleton/foundation/types"
+)
+
+type name string
+
+func TestNullable_JSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		json  string
+	}{
+		{name: "null", value: types.Nullable[string]{}, json: `null`},
+		{name: "string", value: types.NewNullable("skeleton"), json: `"skeleton"`},
+		{name: "int", value: types.NewNullable(42), json: `42`},
+		{name: "time", value: types.NewNullable(time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)), json: `"2025-06-01T00:00:00Z"`},
+		{name: "in a struct", value: struct {
+			Name types.Nullable[string] `json:"name"`
+		}{}, json: `{"name":null}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.value)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.json, string(data))
+		})
+	}
+
+	var decoded types.Nullable[string]
+	require.NoError(t, json.Unmarshal([]byte(`"skeleton"`), &decoded))
+	assert.Equal(t, types.NewNullable("skeleton"), decoded)
+
+	require.NoError(t, json.Unmarshal([]byte(`null`), &decoded))
+	assert.False(t, decoded.IsValid())
+
+	require.Error(t, json.Unmarshal([]byte(`42`), &decoded))
+}
+
+func TestNullable_UnmarshalParam(t *testing.T) {
+	id := uuid.New()
+
+	t.Run("named string", func(t *testing.T) {
+		var n types.Nullable[name]
+		require.NoError(t, n.UnmarshalParam("skeleton"))
+		assert.Equal(t, types.NewNullable(name("skeleton")), n)
+	})
+
+	t.Run("unsigned", func(t *testing.T) {
+		var n types.Nullable[uint8]
+		require.NoError(t, n.UnmarshalParam("200"))
+		assert.Equal(t, types.NewNullable(uint8(200)), n)
+		require.Error(t, n.UnmarshalParam("300"))
+		assert.False(t, n.IsValid())
+	})
+
+	t.Run("text unmarshaler", func(t *testing.T) {
+		var n types.Nullable[uuid.UUID]
+		require.NoError(t, n.UnmarshalParam(id.String()))
+		assert.Equal(t, types.NewNullable(id), n)
+		require.Error(t, n.UnmarshalParam("not-a-uuid"))
+	})
+
+	t.Run("time", func(t *testing.T) {
+		var n types.Nullable[time.Time]
+		require.NoError(t, n.UnmarshalParam("2025-06-01T00:00:00Z"))
+		assert.True(t, n.Get().Equal(time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)))
+	})
+
+	t.Run("null", func(t *testing.T) {
+		n := types.NewNullable(42)
+		require.NoError(t, n.UnmarshalParam("null"))
+		assert.False(t, n.IsValid())
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		var n types.Nullable[[]string]
+		require.Error(t, n.UnmarshalParam("a,b"))
+	})
+}
+
+func TestNullable_SQL(t *testing.T) {
+	id := uuid.New()
+
+	tests := []struct {
+		name    string
+		valuer  driver.Valuer
+		want    driver.Value
+		scanned any
+		scan    func(value any) (any, error)
+	}{
+		{
+			name:   "null",
+			valuer: types.Nullable[string]{},
+			want:   nil,
+			scan: func(value any) (any, error) {
+				n := types.NewNullable("stale")
+				err := n.Scan(value)
+				return n, err
+			},
+			scanned: types.Nullable[string]{},
+		},
+		{
+			name:   "named string",
+			valuer: types.NewNullable(name("skeleton")),
+			want:   "skeleton",
+			scan: func(value any) (any, error) {
+				var n types.Nullable[name]
+				err := n.Scan(value)
+				return n, err
+			},
+			scanned: types.NewNullable(name("skeleton")),
+		},
+		{
+			name:   "valuer and scanner",
+			valuer: types.NewNullable(id),
+			want:   id.String(),
+			scan: func(value any) (any, error) {
+				var n types.Nullable[uuid.UUID]
+				err := n.Scan(value)
+				return n, err
+			},
+			scanned: types.NewNullable(id),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := tt.valuer.Value()
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, value)
+
+			scanned, err := tt.scan(value)
+			require.NoError(t, err)
+			assert.Equal(t, tt.scanned, scanned)
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package types
+
+import "encoding/json"
+
+// Optional is a field of a PATCH request. Unlike Nullable it tells an absent
+// field, which leaves the stored value untouched, from an explicit null, which
+// clears it:
+//
+//	{}                       -> untouched
+//	{"nickname": null}       -> cleared
+//	{"nickname": "skeleton"} -> set
+//
+// Declare Optional fields with `json:",omitzero"` so absent fields stay absent
+// when the request is encoded again.
+type Optional[T any] struct {
+	value   Nullable[T]
+	present bool
+}
+
+// Some returns an Optional that sets value.
+func Some[T any](value T) Optional[T] {
+	return Optional[T]{value: NewNullable(value), present: true}
+}
+
+// Null returns an Optional that clears the value.
+func Null[T any]() Optional[T] {
+	return Optional[T]{present: true}
+}
+
+// IsPresent reports whether the field was given, null included.
+func (o Optional[T]) IsPresent() bool {
+	return o.present
+}
+
+// IsNull reports whether the field was given as null.
+func (o Optional[T]) IsNull() bool {
+	return o.present && !o.value.Valid
+}
+
+// IsZero reports whether the field was absent. It lets omitzero drop absent fields.
+func (o Optional[T]) IsZero() bool {
+	return !o.present
+}
+
+// Get returns the value and whether the field sets one.
+func (o Optional[T]) Get() (T, bool) {
+	return o.value.V, o.value.Valid
+}
+
+// Nullable returns the value the field asks for: null when it clears the value.
+func (o Optional[T]) Nullable() Nullable[T] {
+	return o.value
+}
+
+// Apply updates dst as the field asks: it is set, cleared or left untouched.
+func (o Optional[T]) Apply(dst *Nullable[T]) {
+	if o.present {
+		*dst = o.value
+	}
+}
+
+// UnmarshalJSON is only called for fields present in the document, so it
+// marks the field present whatever its value.
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	if err := o.value.UnmarshalJSON(data); err != nil {
+		return err
+	}
+
+	o.present = true
+	return nil
+}
+
+// MarshalJSON encodes the value the field asks for, null when it is cleared or absent.
+func (o Optional[T]) MarshalJSON() ([]byte, error) {
+	if !o.present {
+		return null, nil
+	}
+
+	return json.Marshal(o.value)
+}
+
+// UnmarshalParam is only called for parameters present in the request. An
+// empty parameter or "null" clears the value.
+func (o *Optional[T]) UnmarshalParam(param string) error {
+	if err := o.value.UnmarshalParam(param); err != nil {
+		return err
+	}
+
+	o.present = true
+	return nil
+}
@@ -0,0 +1,78 @@
+package types_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kianooshaz/skeleton/foundation/types"
+)
+
+type patch struct {
+	Nickname types.Optional[string] `json:"nickname,omitzero"`
+}
+
+func TestOptional_JSON(t *testing.T) {
+	tests := []struct {
+		name        string
+		json        string
+		wantPresent bool
+		wantNull    bool
+		wantApplied types.Nullable[string]
+	}{
+		{
+			name:        "absent leaves untouched",
+			json:        `{}`,
+			wantApplied: types.NewNullable("stored"),
+		},
+		{
+			name:        "null clears",
+			json:        `{"nickname":null}`,
+			wantPresent: true,
+			wantNull:    true,
+			wantApplied: types.Nullable[string]{},
+		},
+		{
+			name:        "value sets",
+			json:        `{"nickname":"skeleton"}`,
+			wantPresent: true,
+			wantApplied: types.NewNullable("skeleton"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var req patch
+			require.NoError(t, json.Unmarshal([]byte(tt.json), &req))
+
+			assert.Equal(t, tt.wantPresent, req.Nickname.IsPresent())
+			assert.Equal(t, tt.wantNull, req.Nickname.IsNull())
+
+			stored := types.NewNullable("stored")
+			req.Nickname.Apply(&stored)
+			assert.Equal(t, tt.wantApplied, stored)
+
+			encoded, err := json.Marshal(req)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.json, string(encoded))
+		})
+	}
+}
+
+func TestOptional_UnmarshalParam(t *testing.T) {
+	var o types.Optional[int]
+	require.NoError(t, o.UnmarshalParam("null"))
+	assert.True(t, o.IsNull())
+
+	require.NoError(t, o.UnmarshalParam("7"))
+	value, ok := o.Get()
+	assert.True(t, ok)
+	assert.Equal(t, 7, value)
+
+	assert.Equal(t, types.Some(7), o)
+
+	require.NoError(t, o.UnmarshalParam(""))
+	assert.Equal(t, types.Null[int](), o)
+}
@@ -0,0 +1,123 @@
+package grpc
+
+import (
+	"context"
+
+	gogrpc "google.golang.org/grpc"
+
+	skeletonv1 "github.com/kianooshaz/skeleton/api/skeleton/v1"
+	"github.com/kianooshaz/skeleton/foundation/id"
+	"github.com/kianooshaz/skeleton/foundation/order"
+	orgproto "github.com/kianooshaz/skeleton/services/organization/organization/proto"
+	auditproto "github.com/kianooshaz/skeleton/services/risk/audit/proto"
+)
+
+type audit struct {
+	skeletonv1.UnimplementedAuditServiceServer
+	server  *server
+	service auditproto.AuditService
+}
+
+func (a *audit) GetAuditRecord(
+	ctx context.Context, req *skeletonv1.GetAuditRecordRequest,
+) (*skeletonv1.GetAuditRecordResponse, error) {
+	recordID, err := id.Parse[auditproto.RecordKind](req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := a.service.Get(ctx, auditproto.GetRequest{ID: recordID})
+	if err != nil {
+		return nil, err
+	}
+
+	return &skeletonv1.GetAuditRecordResponse{Record: toAuditRecord(res.Data)}, nil
+}
+
+func (a *audit) ListAuditRecords(
+	ctx context.Context, req *skeletonv1.ListAuditRecordsRequest,
+) (*skeletonv1.ListAuditRecordsResponse, error) {
+	page, err := a.server.page(req.GetPage())
+	if err != nil {
+		return nil, err
+	}
+
+	sort, err := order.Parse(req.GetSort())
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := a.service.List(ctx, auditproto.ListRequest{Page: page, Sort: sort})
+	if err != nil {
+		return nil, err
+	}
+
+	return &skeletonv1.ListAuditRecordsResponse{
+		Records:    convertAll(res.Data, toAuditRecord),
+		Page:       toPage(res.Page),
+		Navigation: toNavigation(res.Navigation),
+	}, nil
+}
+
+// StreamAuditRecords sends the records as the audit service streams them. The
+// stream ends when the service ends it, the client goes away or the server
+// shuts down.
+func (a *audit) StreamAuditRecords(
+	req *skeletonv1.StreamAuditRecordsRequest,
+	stream gogrpc.ServerStreamingServer[skeletonv1.StreamAuditRecordsResponse],
+) error {
+	organizationID, err := parseOptionalID[orgproto.OrganizationKind](req.GetOrganizationId())
+	if err != nil {
+		return err
+	}
+
+	lastRecordID, err := parseOptionalID[auditproto.RecordKind](req.GetLastRecordId())
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	records, err := a.service.Stream(ctx, auditproto.StreamRequest{
+		Actions:        convertAll(req.GetActions(), func(action string) auditproto.Action { return auditproto.Action(action) }),
+		ResourceTypes:  req.GetResourceTypes(),
+		OrganizationID: organizationID,
+		LastEventID:    lastRecordID,
+	})
+	if err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case record, ok := <-records:
+			if !ok {
+				return nil
+			}
+
+			if err := stream.Send(&skeletonv1.StreamAuditRecordsResponse{Record: toAuditRecord(record)}); err != nil {
+				return err
+			}
+		case <-a.server.closing:
+			return nil
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func toAuditRecord(record auditproto.Record) *skeletonv1.AuditRecord {
+	return &skeletonv1.AuditRecord{
+		Id:             idText(record.ID),
+		RequestId:      record.RequestID,
+		Action:         string(record.Action),
+		CreatedAt:      timestamp(record.CreatedAt),
+		Data:           record.Data,
+		OriginIp:       record.OriginIP,
+		ResourceId:     int64(record.ResourceID),
+		ResourceType:   record.ResourceType,
+		UserId:         int64(record.UserID),
+		OrganizationId: idText(record.OrganizationID),
+	}
+}
@@ -0,0 +1,117 @@
+package grpc
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/kianooshaz/skeleton/foundation/derror"
+	"github.com/kianooshaz/skeleton/foundation/session"
+	"github.com/kianooshaz/skeleton/internal/app/web/rest/middleware"
+)
+
+// Authenticator verifies the credentials carried by the metadata of a call and
+// returns the principal they prove. It returns middleware.ErrNoCredentials when
+// the call carries no credentials of its kind, so the next one is tried.
+type Authenticator interface {
+	Authenticate(ctx context.Context, md metadata.MD) (session.Principal, error)
+}
+
+// AuthenticatorFunc adapts a function to an Authenticator.
+type AuthenticatorFunc func(ctx context.Context, md metadata.MD) (session.Principal, error)
+
+func (f AuthenticatorFunc) Authenticate(ctx context.Context, md metadata.MD) (session.Principal, error) {
+	return f(ctx, md)
+}
+
+// BearerToken authenticates calls carrying "authorization: Bearer" metadata.
+func BearerToken(verify middleware.CredentialVerifier) Authenticator {
+	return AuthenticatorFunc(func(ctx context.Context, md metadata.MD) (session.Principal, error) {
+		token, ok := strings.CutPrefix(first(md, "authorization"), "Bearer ")
+		if !ok || token == "" {
+			return session.Principal{}, middleware.ErrNoCredentials
+		}
+
+		return verified(ctx, verify, token, session.AuthMethodToken)
+	})
+}
+
+// APIKey authenticates calls carrying an API key under the named metadata key.
+func APIKey(key string, verify middleware.CredentialVerifier) Authenticator {
+	return AuthenticatorFunc(func(ctx context.Context, md metadata.MD) (session.Principal, error) {
+		apiKey := first(md, key)
+		if apiKey == "" {
+			return session.Principal{}, middleware.ErrNoCredentials
+		}
+
+		return verified(ctx, verify, apiKey, session.AuthMethodAPIKey)
+	})
+}
+
+func verified(
+	ctx context.Context, verify middleware.CredentialVerifier, credential string, method session.AuthMethod,
+) (session.Principal, error) {
+	principal, err := verify(ctx, credential)
+	if err != nil {
+		return session.Principal{}, err
+	}
+
+	principal.AuthMethod = method
+	if principal.AuthenticatedAt.IsZero() {
+		principal.AuthenticatedAt = time.Now()
+	}
+
+	return principal, nil
+}
+
+// TrustedGateway takes the principal from the x-user-id, x-account-id and
+// x-organization-id metadata set by a gateway that already authenticated the
+// call. Any client can set these keys, so it must only be enabled when the
+// server is unreachable except through such a gateway.
+func TrustedGateway() Authenticator {
+	return AuthenticatorFunc(func(_ context.Context, md metadata.MD) (session.Principal, error) {
+		if first(md, "x-user-id") == "" {
+			return session.Principal{}, middleware.ErrNoCredentials
+		}
+
+		principal := session.Principal{
+			AuthMethod:      session.AuthMethodGateway,
+			AuthenticatedAt: time.Now(),
+		}
+
+		ids := []struct {
+			key string
+			id  *uuid.UUID
+		}{
+			{"x-user-id", &principal.UserID},
+			{"x-account-id", &principal.AccountID},
+			{"x-organization-id", &principal.OrganizationID},
+		}
+		for _, id := range ids {
+			value := first(md, id.key)
+			if value == "" {
+				continue
+			}
+
+			parsed, err := uuid.Parse(value)
+			if err != nil {
+				return session.Principal{}, derror.ErrUnauthenticated
+			}
+			*id.id = parsed
+		}
+
+		return principal, nil
+	})
+}
+
+// first returns the first value of key in md, or an empty string.
+func first(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+
+	return ""
+}
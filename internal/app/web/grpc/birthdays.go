@@ -0,0 +1,217 @@
+package grpc
+
+import (
+	"context"
+
+	skeletonv1 "github.com/kianooshaz/skeleton/api/skeleton/v1"
+	"github.com/kianooshaz/skeleton/foundation/id"
+	"github.com/kianooshaz/skeleton/foundation/order"
+	"github.com/kianooshaz/skeleton/foundation/types"
+	birthdayproto "github.com/kianooshaz/skeleton/services/user/birthday/proto"
+	userproto "github.com/kianooshaz/skeleton/services/user/user/proto"
+)
+
+type birthdays struct {
+	skeletonv1.UnimplementedBirthdayServiceServer
+	server  *server
+	service birthdayproto.BirthdayService
+}
+
+func (b *birthdays) CreateBirthday(
+	ctx context.Context, req *skeletonv1.CreateBirthdayRequest,
+) (*skeletonv1.CreateBirthdayResponse, error) {
+	userID, err := parseOptionalID[userproto.UserKind](req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	create := birthdayproto.CreateRequest{
+		UserID:       userID,
+		DateOfBirth:  timeOf(req.GetDateOfBirth()),
+		PlaceOfBirth: nullableString(req.PlaceOfBirth),
+	}
+	if err := validate(&create); err != nil {
+		return nil, err
+	}
+
+	res, err := b.service.Create(ctx, create)
+	if err != nil {
+		return nil, err
+	}
+
+	return &skeletonv1.CreateBirthdayResponse{Birthday: toBirthday(res.Data)}, nil
+}
+
+func (b *birthdays) GetBirthday(
+	ctx context.Context, req *skeletonv1.GetBirthdayRequest,
+) (*skeletonv1.GetBirthdayResponse, error) {
+	birthdayID, err := id.Parse[birthdayproto.BirthdayKind](req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	get := birthdayproto.GetRequest{ID: birthdayID}
+	if err := validate(&get); err != nil {
+		return nil, err
+	}
+
+	res, err := b.service.Get(ctx, get)
+	if err != nil {
+		return nil, err
+	}
+
+	return &skeletonv1.GetBirthdayResponse{Birthday: toBirthday(res.Data)}, nil
+}
+
+func (b *birthdays) GetUserBirthday(
+	ctx context.Context, req *skeletonv1.GetUserBirthdayRequest,
+) (*skeletonv1.GetUserBirthdayResponse, error) {
+	userID, err := id.Parse[userproto.UserKind](req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	get := birthdayproto.GetByUserIDRequest{UserID: userID}
+	if err := validate(&get); err != nil {
+		return nil, err
+	}
+
+	res, err := b.service.GetByUserID(ctx, get)
+	if err != nil {
+		return nil, err
+	}
+
+	return &skeletonv1.GetUserBirthdayResponse{Birthday: toBirthday(res.Data)}, nil
+}
+
+func (b *birthdays) UpdateBirthday(
+	ctx context.Context, req *skeletonv1.UpdateBirthdayRequest,
+) (*skeletonv1.UpdateBirthdayResponse, error) {
+	birthdayID, err := id.Parse[birthdayproto.BirthdayKind](req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	update := birthdayproto.UpdateRequest{ID: birthdayID}
+	if req.DateOfBirth != nil {
+		update.DateOfBirth = types.Some(timeOf(req.GetDateOfBirth()))
+	}
+	if req.PlaceOfBirth != nil {
+		update.PlaceOfBirth = types.Null[string]()
+		if place := req.GetPlaceOfBirth(); place != "" {
+			update.PlaceOfBirth = types.Some(place)
+		}
+	}
+	if err := validate(&update); err != nil {
+		return nil, err
+	}
+
+	res, err := b.service.Update(ctx, update)
+	if err != nil {
+		return nil, err
+	}
+
+	return &skeletonv1.UpdateBirthdayResponse{Birthday: toBirthday(res.Data)}, nil
+}
+
+func (b *birthdays) DeleteBirthday(
+	ctx context.Context, req *skeletonv1.DeleteBirthdayRequest,
+) (*skeletonv1.DeleteBirthdayResponse, error) {
+	birthdayID, err := id.Parse[birthdayproto.BirthdayKind](req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	remove := birthdayproto.DeleteRequest{ID: birthdayID}
+	if err := validate(&remove); err != nil {
+		return nil, err
+	}
+
+	if err := b.service.Delete(ctx, remove); err != nil {
+		return nil, err
+	}
+
+	return &skeletonv1.DeleteBirthdayResponse{}, nil
+}
+
+func (b *birthdays) ListBirthdays(
+	ctx context.Context, req *skeletonv1.ListBirthdaysRequest,
+) (*skeletonv1.ListBirthdaysResponse, error) {
+	page, err := b.server.page(req.GetPage())
+	if err != nil {
+		return nil, err
+	}
+
+	sort, err := order.Parse(req.GetSort())
+	if err != nil {
+		return nil, err
+	}
+
+	list := birthdayproto.ListRequest{
+		Page:       page,
+		Sort:       sort,
+		MinAge:     optionalInt(req.MinAge),
+		MaxAge:     optionalInt(req.MaxAge),
+		BirthMonth: optionalInt(req.BirthMonth),
+	}
+	if req.UserId != nil {
+		userID, err := id.Parse[userproto.UserKind](req.GetUserId())
+		if err != nil {
+			return nil, err
+		}
+		list.UserID = &userID
+	}
+	if err := validate(&list); err != nil {
+		return nil, err
+	}
+
+	res, err := b.service.List(ctx, list)
+	if err != nil {
+		return nil, err
+	}
+
+	return &skeletonv1.ListBirthdaysResponse{
+		Birthdays:  convertAll(res.Data, toBirthday),
+		Page:       toPage(res.Page),
+		Navigation: toNavigation(res.Navigation),
+	}, nil
+}
+
+func toBirthday(birthday birthdayproto.Birthday) *skeletonv1.Birthday {
+	res := &skeletonv1.Birthday{
+		Id:          idText(birthday.ID),
+		UserId:      idText(birthday.UserID),
+		DateOfBirth: timestamp(birthday.DateOfBirth),
+		Age:         int32(birthday.Age),
+		CreatedAt:   timestamp(birthday.CreatedAt),
+		UpdatedAt:   timestamp(birthday.UpdatedAt),
+	}
+	if birthday.PlaceOfBirth.IsValid() {
+		place := birthday.PlaceOfBirth.Get()
+		res.PlaceOfBirth = &place
+	}
+
+	return res
+}
+
+// nullableString returns the value of an optional field as a Nullable, null
+// when the field is unset.
+func nullableString(value *string) types.Nullable[string] {
+	if value == nil {
+		return types.Nullable[string]{}
+	}
+
+	return types.NewNullable(*value)
+}
+
+// optionalInt returns the value of an optional field as the pointer requests
+// leave nil for unset filters.
+func optionalInt(value *int32) *int {
+	if value == nil {
+		return nil
+	}
+
+	i := int(*value)
+
+	return &i
+}
@@ -0,0 +1,127 @@
+package grpc
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	skeletonv1 "github.com/kianooshaz/skeleton/api/skeleton/v1"
+	"github.com/kianooshaz/skeleton/foundation/derror"
+	"github.com/kianooshaz/skeleton/foundation/id"
+	"github.com/kianooshaz/skeleton/foundation/pagination"
+	"github.com/kianooshaz/skeleton/foundation/stat"
+	"github.com/kianooshaz/skeleton/internal/app/web/rest"
+)
+
+// validate checks req as the REST server checks the requests it binds.
+func validate(req any) error {
+	return rest.ValidateRequest(req)
+}
+
+// page returns the page addressed by p under the pagination policy of the
+// server, failing as the REST server does for page sizes above its maximum.
+func (s *server) page(p *skeletonv1.Page) (pagination.Page, error) {
+	page := pagination.Page{
+		PageNumber: uint(p.GetPageNumber()),
+		PageRows:   s.pagination.DefaultRows,
+		Cursor:     p.GetCursor(),
+		WithTotal:  p.GetWithTotal(),
+	}
+
+	if rows := p.GetPageRows(); rows != 0 {
+		if uint64(rows) > uint64(s.pagination.MaxRows) {
+			return pagination.Page{}, derror.ErrRowsValueTooLarge
+		}
+		page.PageRows = uint(rows)
+	}
+
+	return page, nil
+}
+
+func toPage(page pagination.Page) *skeletonv1.Page {
+	return &skeletonv1.Page{
+		PageNumber: uint32(page.PageNumber),
+		PageRows:   uint32(page.PageRows),
+		Cursor:     page.Cursor,
+		WithTotal:  page.WithTotal,
+	}
+}
+
+func toNavigation(navigation pagination.Navigation) *skeletonv1.Navigation {
+	return &skeletonv1.Navigation{
+		TotalRows:  int64(navigation.TotalRows),
+		TotalPage:  int64(navigation.TotalPage),
+		HasMore:    navigation.HasMore,
+		NextCursor: navigation.NextCursor,
+		PrevCursor: navigation.PrevCursor,
+	}
+}
+
+// convertAll converts every item with convert.
+func convertAll[T any, P any](items []T, convert func(T) P) []P {
+	converted := make([]P, 0, len(items))
+	for _, item := range items {
+		converted = append(converted, convert(item))
+	}
+
+	return converted
+}
+
+// idText returns the external representation of i, or an empty string for
+// the zero ID.
+func idText[K id.Kind](i id.ID[K]) string {
+	if i.IsZero() {
+		return ""
+	}
+
+	text, _ := i.MarshalText()
+
+	return string(text)
+}
+
+// parseOptionalID parses s as an ID, leaving it zero when s is empty.
+func parseOptionalID[K id.Kind](s string) (id.ID[K], error) {
+	if s == "" {
+		return id.ID[K]{}, nil
+	}
+
+	return id.Parse[K](s)
+}
+
+func parseUUID(s string) (uuid.UUID, error) {
+	u, err := uuid.Parse(s)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%w: %q", derror.ErrInvalidID, s)
+	}
+
+	return u, nil
+}
+
+// timestamp returns t as a timestamp, or nil for the zero time.
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+
+	return timestamppb.New(t)
+}
+
+// timeOf returns the time of ts, or the zero time when ts is unset, which the
+// required rule rejects.
+func timeOf(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+
+	return ts.AsTime()
+}
+
+// parseStatus returns the union of the named flags.
+func parseStatus(names []string) (stat.Status, error) {
+	var status stat.Status
+	err := status.UnmarshalParams(names)
+
+	return status, err
+}
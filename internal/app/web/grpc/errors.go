@@ -0,0 +1,122 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/kianooshaz/skeleton/foundation/derror"
+	"github.com/kianooshaz/skeleton/internal/app/web/rest"
+)
+
+// statusOf returns the gRPC status answering err: the code of its derror, with
+// the derror as the message, as the REST server answers it in the error field.
+// Validation errors carry their fields as BadRequest details. Errors that are
+// statuses already, such as those of grpc itself, are kept.
+func statusOf(ctx context.Context, logger *slog.Logger, method string, err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+
+	known := knownError(err)
+	if known == nil {
+		logger.ErrorContext(
+			ctx,
+			"Error encountered while converting error to grpc status",
+			slog.String("error", err.Error()),
+			slog.String("method", method),
+		)
+
+		return status.Error(codes.Internal, derror.ErrInternalSystem.Error())
+	}
+
+	st := status.New(DerrorToCode[known], known.Error())
+
+	var invalid *rest.ValidationError
+	if errors.As(err, &invalid) {
+		violations := make([]*errdetails.BadRequest_FieldViolation, 0, len(invalid.Fields))
+		for _, field := range invalid.Fields {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{
+				Field:       field.Field,
+				Description: field.Rule,
+				Reason:      field.Rule,
+			})
+		}
+
+		if detailed, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: violations}); err == nil {
+			st = detailed
+		}
+	}
+
+	return st.Err()
+}
+
+// knownError returns the first error in err's chain that has a gRPC code, or nil.
+func knownError(err error) error {
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		if _, ok := DerrorToCode[e]; ok {
+			return e
+		}
+	}
+
+	return nil
+}
+
+var DerrorToCode = map[error]codes.Code{
+	derror.ErrInternalSystem:          codes.Internal,
+	derror.ErrInvalidQueryParameter:   codes.InvalidArgument,
+	derror.ErrUnknownOrder:            codes.InvalidArgument,
+	derror.ErrUnknownOrderDirection:   codes.InvalidArgument,
+	derror.ErrInvalidPage:             codes.InvalidArgument,
+	derror.ErrInvalidRows:             codes.InvalidArgument,
+	derror.ErrPageValueTooSmall:       codes.InvalidArgument,
+	derror.ErrRowsValueTooSmall:       codes.InvalidArgument,
+	derror.ErrRowsValueTooLarge:       codes.InvalidArgument,
+	derror.ErrInvalidCursor:           codes.InvalidArgument,
+	derror.ErrRateLimitExceeded:       codes.ResourceExhausted,
+	derror.ErrUnauthenticated:         codes.Unauthenticated,
+	derror.ErrForbidden:               codes.PermissionDenied,
+	derror.ErrInvalidStatusTransition: codes.FailedPrecondition,
+	derror.ErrUnknownStatusFlag:       codes.InvalidArgument,
+	derror.ErrInvalidID:               codes.InvalidArgument,
+	derror.ErrInvalidRequest:          codes.InvalidArgument,
+
+	derror.ErrUserIDRequired:    codes.InvalidArgument,
+	derror.ErrUserNotFound:      codes.NotFound,
+	derror.ErrUserAlreadyExists: codes.AlreadyExists,
+	derror.ErrUserNotGuest:      codes.FailedPrecondition,
+
+	derror.ErrUsernameNotFound:           codes.NotFound,
+	derror.ErrUsernameAlreadyExists:      codes.AlreadyExists,
+	derror.ErrUsernameInvalid:            codes.InvalidArgument,
+	derror.ErrUsernameMaxPerUser:         codes.FailedPrecondition,
+	derror.ErrUsernameMaxPerOrganization: codes.FailedPrecondition,
+	derror.ErrUsernameNotReserved:        codes.FailedPrecondition,
+	derror.ErrUsernameCannotBeAssigned:   codes.FailedPrecondition,
+	derror.ErrUsernameLocked:             codes.FailedPrecondition,
+	derror.ErrUsernameRequired:           codes.InvalidArgument,
+
+	derror.ErrOrganizationIDRequired: codes.InvalidArgument,
+	derror.ErrOrganizationNotFound:   codes.NotFound,
+
+	derror.ErrPasswordInvalid:    codes.InvalidArgument,
+	derror.ErrPasswordIsWeak:     codes.InvalidArgument,
+	derror.ErrPasswordIsCommon:   codes.InvalidArgument,
+	derror.ErrPasswordUsedBefore: codes.InvalidArgument,
+	derror.ErrPasswordNotFound:   codes.NotFound,
+
+	derror.ErrAccountIDRequired: codes.InvalidArgument,
+	derror.ErrAccountLocked:     codes.FailedPrecondition,
+	derror.ErrTooManyAttempts:   codes.ResourceExhausted,
+}
@@ -0,0 +1,12 @@
+package grpc
+
+import (
+	"net"
+
+	"github.com/kianooshaz/skeleton/internal/app/web/protocol"
+)
+
+// Serve serves a web service created by New on lis.
+func Serve(ws protocol.WebService, lis net.Listener) error {
+	return ws.(*server).core.Serve(lis)
+}
@@ -0,0 +1,233 @@
+// Package grpc serves the services over gRPC, alongside the REST server. Its
+// methods are defined in api/skeleton/v1 and delegate to the same service
+// interfaces as the REST routes, behind interceptors equivalent to the REST
+// middleware.
+package grpc
+
+import (
+	"context"
+	"log/slog"
+	"net"
+	"sync"
+
+	gogrpc "google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+
+	skeletonv1 "github.com/kianooshaz/skeleton/api/skeleton/v1"
+	"github.com/kianooshaz/skeleton/foundation/lifecycle"
+	"github.com/kianooshaz/skeleton/foundation/pagination"
+	"github.com/kianooshaz/skeleton/foundation/ratelimit"
+	"github.com/kianooshaz/skeleton/internal/app/web/protocol"
+	"github.com/kianooshaz/skeleton/internal/app/web/rest/middleware"
+	usernameproto "github.com/kianooshaz/skeleton/services/account/username/proto"
+	lockoutproto "github.com/kianooshaz/skeleton/services/authentication/lockout/proto"
+	passwordproto "github.com/kianooshaz/skeleton/services/authentication/password/proto"
+	tokenproto "github.com/kianooshaz/skeleton/services/authentication/token/proto"
+	orgproto "github.com/kianooshaz/skeleton/services/organization/organization/proto"
+	auditproto "github.com/kianooshaz/skeleton/services/risk/audit/proto"
+	birthdayproto "github.com/kianooshaz/skeleton/services/user/birthday/proto"
+	userproto "github.com/kianooshaz/skeleton/services/user/user/proto"
+)
+
+type Config struct {
+	Enable  bool   `yaml:"enable"`
+	Address string `yaml:"address" validate:"required_if=Enable true"`
+	// Reflection serves the reflection service, for tools such as grpcurl to
+	// discover the methods.
+	Reflection bool            `yaml:"reflection"`
+	RequestID  RequestIDConfig `yaml:"request_id"`
+	Auth       AuthConfig      `yaml:"auth"`
+	RateLimit  RateLimitConfig `yaml:"rate_limit"`
+	// Pagination bounds the page size of every listing.
+	Pagination pagination.Policy `yaml:"pagination"`
+}
+
+// RequestIDConfig holds where request IDs may come from.
+type RequestIDConfig struct {
+	// TrustedKeys are the metadata keys, in order of preference, whose request
+	// ID is kept. Only list keys set by proxies or services in front of the
+	// server; calls without them get a new ID.
+	TrustedKeys []string `yaml:"trusted_keys"`
+}
+
+// AuthConfig holds how calls are attributed to a principal. Calls authenticate
+// with a bearer token of the token service, or an API key.
+type AuthConfig struct {
+	// APIKeyKey is the metadata key API keys are read from; it defaults to x-api-key.
+	APIKeyKey string `yaml:"api_key_key"`
+	// APIKeys are the keys accepted, each with the principal it authenticates as.
+	APIKeys []middleware.APIKeyConfig `yaml:"api_keys"`
+	// TrustedGateway takes the principal from the x-user-id, x-account-id and
+	// x-organization-id metadata. Only enable it when every call reaches the
+	// server through a gateway that authenticates it and sets those keys.
+	TrustedGateway bool `yaml:"trusted_gateway"`
+}
+
+// RateLimitConfig holds the rate limit policy applied to every call. Calls are
+// counted by the limiter of the REST server, on its backend.
+type RateLimitConfig struct {
+	Enable bool             `yaml:"enable"`
+	Policy ratelimit.Policy `yaml:"policy"`
+	// OrganizationTiers maps organization IDs to the tier whose overrides apply to them.
+	OrganizationTiers map[string]string `yaml:"organization_tiers"`
+}
+
+// authenticatedMethods are the methods that need a principal: those that
+// change something, besides signing up and issuing tokens, and those of the
+// audit trail. They match the authenticated routes of the REST server.
+var authenticatedMethods = []string{
+	skeletonv1.OrganizationService_CreateOrganization_FullMethodName,
+	skeletonv1.UsernameService_AssignUsername_FullMethodName,
+	skeletonv1.UsernameService_UnassignUsername_FullMethodName,
+	skeletonv1.UsernameService_SetPrimaryUsername_FullMethodName,
+	skeletonv1.PasswordService_UpdatePassword_FullMethodName,
+	skeletonv1.LockoutService_UnlockAccount_FullMethodName,
+	skeletonv1.AuditService_GetAuditRecord_FullMethodName,
+	skeletonv1.AuditService_ListAuditRecords_FullMethodName,
+	skeletonv1.AuditService_StreamAuditRecords_FullMethodName,
+	skeletonv1.BirthdayService_CreateBirthday_FullMethodName,
+	skeletonv1.BirthdayService_UpdateBirthday_FullMethodName,
+	skeletonv1.BirthdayService_DeleteBirthday_FullMethodName,
+}
+
+type server struct {
+	core       *gogrpc.Server
+	address    string
+	listener   net.Listener
+	logger     *slog.Logger
+	pagination pagination.Policy
+	// closing is closed when the server shuts down, to end the streams it
+	// serves, which would otherwise hold the shutdown up.
+	closing   chan struct{}
+	closeOnce sync.Once
+}
+
+func New(
+	cfg Config,
+	logger *slog.Logger,
+	limiter ratelimit.Limiter,
+	healthReporter lifecycle.Reporter,
+	userService userproto.UserService,
+	organizationService orgproto.OrganizationService,
+	passwordService passwordproto.PasswordService,
+	usernameService usernameproto.UsernameService,
+	auditService auditproto.AuditService,
+	birthdayService birthdayproto.BirthdayService,
+	tokenService tokenproto.TokenService,
+	lockoutService lockoutproto.LockoutService,
+) (protocol.WebService, error) {
+	if cfg.RateLimit.Enable {
+		if err := cfg.RateLimit.Policy.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	steps := []step{
+		requestID(cfg.RequestID.TrustedKeys...),
+		clientIP(),
+	}
+
+	authenticators := []Authenticator{BearerToken(tokenService.Verify)}
+	if len(cfg.Auth.APIKeys) > 0 {
+		verifyAPIKey, err := middleware.StaticAPIKeys(cfg.Auth.APIKeys)
+		if err != nil {
+			return nil, err
+		}
+		if cfg.Auth.APIKeyKey == "" {
+			cfg.Auth.APIKeyKey = "x-api-key"
+		}
+		authenticators = append(authenticators, APIKey(cfg.Auth.APIKeyKey, verifyAPIKey))
+	}
+	if cfg.Auth.TrustedGateway {
+		logger.Warn("Trusting the principal metadata set by the gateway")
+		authenticators = append(authenticators, TrustedGateway())
+	}
+	steps = append(steps, authenticate(logger, authenticators...), requireAuthentication(authenticatedMethods...))
+
+	if cfg.RateLimit.Enable && limiter != nil {
+		tiers := ratelimit.StaticTiers(cfg.RateLimit.OrganizationTiers)
+		steps = append(steps, rateLimit(limiter, cfg.RateLimit.Policy, tiers, logger))
+	}
+
+	core := gogrpc.NewServer(
+		gogrpc.ChainUnaryInterceptor(unaryErrorStatus(logger), unaryRecover(logger), unarySteps(steps...)),
+		gogrpc.ChainStreamInterceptor(streamErrorStatus(logger), streamRecover(logger), streamSteps(steps...)),
+	)
+
+	s := &server{
+		core:       core,
+		address:    cfg.Address,
+		logger:     logger,
+		pagination: cfg.Pagination.Or(pagination.DefaultPolicy),
+		closing:    make(chan struct{}),
+	}
+
+	skeletonv1.RegisterUserServiceServer(core, &users{server: s, service: userService})
+	skeletonv1.RegisterOrganizationServiceServer(core, &organizations{server: s, service: organizationService})
+	skeletonv1.RegisterUsernameServiceServer(core, &usernames{server: s, service: usernameService})
+	skeletonv1.RegisterPasswordServiceServer(core, &passwords{service: passwordService})
+	skeletonv1.RegisterAuditServiceServer(core, &audit{server: s, service: auditService})
+	skeletonv1.RegisterBirthdayServiceServer(core, &birthdays{server: s, service: birthdayService})
+	skeletonv1.RegisterTokenServiceServer(core, &tokens{service: tokenService})
+	skeletonv1.RegisterLockoutServiceServer(core, &lockouts{service: lockoutService})
+	healthpb.RegisterHealthServer(core, &health{reporter: healthReporter})
+
+	if cfg.Reflection {
+		reflection.Register(core)
+	}
+
+	return s, nil
+}
+
+// Listen binds the address of the server, which Start then serves on.
+func (s *server) Listen() error {
+	listener, err := net.Listen("tcp", s.address)
+	if err != nil {
+		return err
+	}
+	s.listener = listener
+
+	return nil
+}
+
+func (s *server) Start() error {
+	if s.listener == nil {
+		if err := s.Listen(); err != nil {
+			return err
+		}
+	}
+
+	return s.core.Serve(s.listener)
+}
+
+// Shutdown ends the streams, then waits for the calls under way to finish, or
+// for ctx to be done, in which case they are cut off.
+func (s *server) Shutdown(ctx context.Context) error {
+	s.closeStreams()
+
+	done := make(chan struct{})
+	go func() {
+		s.core.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		s.core.Stop()
+		return ctx.Err()
+	}
+}
+
+func (s *server) Close() error {
+	s.closeStreams()
+	s.core.Stop()
+
+	return nil
+}
+
+func (s *server) closeStreams() {
+	s.closeOnce.Do(func() { close(s.closing) })
+}
@@ -0,0 +1,526 @@
+package grpc_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	gogrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	skeletonv1 "github.com/kianooshaz/skeleton/api/skeleton/v1"
+	"github.com/kianooshaz/skeleton/foundation/derror"
+	"github.com/kianooshaz/skeleton/foundation/lifecycle"
+	"github.com/kianooshaz/skeleton/foundation/ratelimit"
+	"github.com/kianooshaz/skeleton/foundation/session"
+	"github.com/kianooshaz/skeleton/internal/app/web/grpc"
+	"github.com/kianooshaz/skeleton/internal/app/web/protocol"
+	usernameproto "github.com/kianooshaz/skeleton/services/account/username/proto"
+	lockoutproto "github.com/kianooshaz/skeleton/services/authentication/lockout/proto"
+	passwordproto "github.com/kianooshaz/skeleton/services/authentication/password/proto"
+	tokenproto "github.com/kianooshaz/skeleton/services/authentication/token/proto"
+	orgproto "github.com/kianooshaz/skeleton/services/organization/organization/proto"
+	auditproto "github.com/kianooshaz/skeleton/services/risk/audit/proto"
+	birthdayproto "github.com/kianooshaz/skeleton/services/user/birthday/proto"
+	userproto "github.com/kianooshaz/skeleton/services/user/user/proto"
+)
+
+// The fakes keep the last request they were called with.
+
+type fakeUsers struct {
+	userproto.UserService
+	get       userproto.GetRequest
+	principal session.Principal
+}
+
+func (f *fakeUsers) Get(ctx context.Context, req userproto.GetRequest) (userproto.GetResponse, error) {
+	f.get = req
+	f.principal, _ = session.GetPrincipal(ctx)
+	if req.ID.IsZero() {
+		return userproto.GetResponse{}, derror.ErrUserNotFound
+	}
+
+	return userproto.GetResponse{Data: userproto.User{ID: req.ID}}, nil
+}
+
+type fakePasswords struct {
+	passwordproto.PasswordService
+	update passwordproto.UpdateRequest
+}
+
+func (f *fakePasswords) Update(_ context.Context, req passwordproto.UpdateRequest) error {
+	f.update = req
+	return nil
+}
+
+type fakeAuditStream struct {
+	auditproto.AuditService
+	records chan auditproto.Record
+}
+
+func (f *fakeAuditStream) Stream(context.Context, auditproto.StreamRequest) (<-chan auditproto.Record, error) {
+	return f.records, nil
+}
+
+type fakeLockouts struct {
+	lockoutproto.LockoutService
+	unlock lockoutproto.UnlockRequest
+}
+
+func (f *fakeLockouts) Unlock(_ context.Context, req lockoutproto.UnlockRequest) error {
+	f.unlock = req
+	return nil
+}
+
+// fakeTokens accepts the token "valid", of the account in principal.
+type fakeTokens struct {
+	tokenproto.TokenService
+	principal session.Principal
+}
+
+func (f *fakeTokens) Issue(context.Context, tokenproto.IssueRequest) (tokenproto.IssueResponse, error) {
+	return tokenproto.IssueResponse{Data: tokenproto.Token{Token: "valid"}}, nil
+}
+
+func (f *fakeTokens) Verify(_ context.Context, token string) (session.Principal, error) {
+	if token != "valid" {
+		return session.Principal{}, derror.ErrUnauthenticated
+	}
+
+	return f.principal, nil
+}
+
+type services struct {
+	users     *fakeUsers
+	passwords *fakePasswords
+	audit     *fakeAuditStream
+	tokens    *fakeTokens
+	lockouts  *fakeLockouts
+}
+
+// newClient serves a server created by New with cfg, limiter and reporter over an
+// in-memory connection and returns a connection to it.
+func newClient(
+	t *testing.T, cfg grpc.Config, limiter ratelimit.Limiter, reporter lifecycle.Reporter,
+) (*gogrpc.ClientConn, protocol.WebService, services) {
+	t.Helper()
+
+	fakes := services{
+		users:     &fakeUsers{},
+		passwords: &fakePasswords{},
+		audit:     &fakeAuditStream{records: make(chan auditproto.Record, 1)},
+		tokens:    &fakeTokens{principal: session.Principal{AccountID: uuid.New()}},
+		lockouts:  &fakeLockouts{},
+	}
+
+	ws, err := grpc.New(
+		cfg,
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+		limiter,
+		reporter,
+		fakes.users,
+		struct{ orgproto.OrganizationService }{},
+		fakes.passwords,
+		struct{ usernameproto.UsernameService }{},
+		fakes.audit,
+		struct{ birthdayproto.BirthdayService }{},
+		fakes.tokens,
+		fakes.lockouts,
+	)
+	require.NoError(t, err)
+
+	lis := bufconn.Listen(1 << 20)
+	go func() { _ = grpc.Serve(ws, lis) }()
+	t.Cleanup(func() { _ = ws.Close() })
+
+	conn, err := gogrpc.NewClient(
+		"passthrough:///bufconn",
+		gogrpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		gogrpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return conn, ws, fakes
+}
+
+func TestUnary(t *testing.T) {
+	conn, _, fakes := newClient(t, grpc.Config{}, nil, nil)
+	users := skeletonv1.NewUserServiceClient(conn)
+	passwords := skeletonv1.NewPasswordServiceClient(conn)
+	lockouts := skeletonv1.NewLockoutServiceClient(conn)
+	userID := uuid.New()
+
+	tests := []struct {
+		name     string
+		call     func(ctx context.Context) error
+		wantCode codes.Code
+		wantMsg  string
+		assert   func(t *testing.T, err error)
+	}{
+		{
+			name: "get user by prefixed id",
+			call: func(ctx context.Context) error {
+				res, err := users.GetUser(ctx, &skeletonv1.GetUserRequest{Id: "usr_" + userID.String()})
+				if err == nil {
+					assert.Equal(t, "usr_"+userID.String(), res.GetUser().GetId())
+				}
+				return err
+			},
+			wantCode: codes.OK,
+			assert: func(t *testing.T, _ error) {
+				assert.Equal(t, userproto.UserID(userID), fakes.users.get.ID)
+			},
+		},
+		{
+			name: "get user by malformed id",
+			call: func(ctx context.Context) error {
+				_, err := users.GetUser(ctx, &skeletonv1.GetUserRequest{Id: "42"})
+				return err
+			},
+			wantCode: codes.InvalidArgument,
+			wantMsg:  derror.ErrInvalidID.Error(),
+		},
+		{
+			name: "service error",
+			call: func(ctx context.Context) error {
+				_, err := users.GetUser(ctx, &skeletonv1.GetUserRequest{Id: uuid.Nil.String()})
+				return err
+			},
+			wantCode: codes.NotFound,
+			wantMsg:  derror.ErrUserNotFound.Error(),
+		},
+		{
+			name: "update password",
+			call: func(ctx context.Context) error {
+				_, err := passwords.UpdatePassword(ctx, &skeletonv1.UpdatePasswordRequest{
+					AccountId:       userID.String(),
+					CurrentPassword: "current",
+					NewPassword:     "secret",
+				})
+				return err
+			},
+			wantCode: codes.OK,
+			assert: func(t *testing.T, _ error) {
+				assert.Equal(t, "current", fakes.passwords.update.CurrentPassword)
+				assert.Equal(t, "secret", fakes.passwords.update.NewPassword)
+			},
+		},
+		{
+			name: "update password without credentials",
+			call: func(ctx context.Context) error {
+				_, err := passwords.UpdatePassword(metadata.NewOutgoingContext(ctx, metadata.MD{}), &skeletonv1.UpdatePasswordRequest{
+					AccountId:   userID.String(),
+					NewPassword: "secret",
+				})
+				return err
+			},
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name: "unlock account",
+			call: func(ctx context.Context) error {
+				_, err := lockouts.UnlockAccount(ctx, &skeletonv1.UnlockAccountRequest{AccountId: "acc_" + userID.String()})
+				return err
+			},
+			wantCode: codes.OK,
+			assert: func(t *testing.T, _ error) {
+				assert.Equal(t, userID, uuid.UUID(fakes.lockouts.unlock.AccountID))
+			},
+		},
+		{
+			name: "invalid request",
+			call: func(ctx context.Context) error {
+				_, err := passwords.UpdatePassword(ctx, &skeletonv1.UpdatePasswordRequest{
+					AccountId:       userID.String(),
+					CurrentPassword: "current",
+				})
+				return err
+			},
+			wantCode: codes.InvalidArgument,
+			wantMsg:  derror.ErrInvalidRequest.Error(),
+			assert: func(t *testing.T, err error) {
+				details := status.Convert(err).Details()
+				require.Len(t, details, 1)
+				badRequest, ok := details[0].(*errdetails.BadRequest)
+				require.True(t, ok)
+				require.Len(t, badRequest.GetFieldViolations(), 1)
+				assert.Equal(t, "new_password", badRequest.GetFieldViolations()[0].GetField())
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.AppendToOutgoingContext(t.Context(), "authorization", "Bearer valid")
+
+			// Execute.
+			err := tt.call(ctx)
+
+			// Assert.
+			st := status.Convert(err)
+			assert.Equal(t, tt.wantCode, st.Code())
+			if tt.wantMsg != "" {
+				assert.Equal(t, tt.wantMsg, st.Message())
+			}
+			if tt.assert != nil {
+				tt.assert(t, err)
+			}
+		})
+	}
+}
+
+func TestRequestID(t *testing.T) {
+	conn, _, _ := newClient(t, grpc.Config{
+		RequestID: grpc.RequestIDConfig{TrustedKeys: []string{"x-request-id"}},
+	}, nil, nil)
+	users := skeletonv1.NewUserServiceClient(conn)
+
+	tests := []struct {
+		name   string
+		md     metadata.MD
+		wantID string
+	}{
+		{
+			name:   "trusted",
+			md:     metadata.Pairs("x-request-id", "req-1"),
+			wantID: "req-1",
+		},
+		{
+			name: "generated",
+			md:   metadata.MD{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.NewOutgoingContext(t.Context(), tt.md)
+			var header metadata.MD
+
+			// Execute.
+			_, err := users.GetUser(ctx, &skeletonv1.GetUserRequest{Id: uuid.NewString()}, gogrpc.Header(&header))
+
+			// Assert.
+			require.NoError(t, err)
+			got := header.Get("x-request-id")
+			require.Len(t, got, 1)
+			if tt.wantID != "" {
+				assert.Equal(t, tt.wantID, got[0])
+			} else {
+				assert.NotEmpty(t, got[0])
+			}
+		})
+	}
+}
+
+func TestBearerToken(t *testing.T) {
+	conn, _, fakes := newClient(t, grpc.Config{}, nil, nil)
+	users := skeletonv1.NewUserServiceClient(conn)
+	tokens := skeletonv1.NewTokenServiceClient(conn)
+
+	res, err := tokens.IssueToken(t.Context(), &skeletonv1.IssueTokenRequest{
+		AccountId: uuid.NewString(),
+		Password:  "secret",
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		md            metadata.MD
+		wantCode      codes.Code
+		wantAccountID uuid.UUID
+	}{
+		{
+			name:          "issued token",
+			md:            metadata.Pairs("authorization", "Bearer "+res.GetToken()),
+			wantCode:      codes.OK,
+			wantAccountID: fakes.tokens.principal.AccountID,
+		},
+		{
+			name:     "forged token",
+			md:       metadata.Pairs("authorization", "Bearer forged"),
+			wantCode: codes.Unauthenticated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakes.users.principal = session.Principal{}
+			ctx := metadata.NewOutgoingContext(t.Context(), tt.md)
+
+			// Execute.
+			_, err := users.GetUser(ctx, &skeletonv1.GetUserRequest{Id: uuid.NewString()})
+
+			// Assert.
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			assert.Equal(t, tt.wantAccountID, fakes.users.principal.AccountID)
+		})
+	}
+}
+
+func TestTrustedGateway(t *testing.T) {
+	conn, _, fakes := newClient(t, grpc.Config{Auth: grpc.AuthConfig{TrustedGateway: true}}, nil, nil)
+	users := skeletonv1.NewUserServiceClient(conn)
+	userID := uuid.New()
+
+	tests := []struct {
+		name       string
+		md         metadata.MD
+		wantCode   codes.Code
+		wantUserID uuid.UUID
+	}{
+		{
+			name:       "principal",
+			md:         metadata.Pairs("x-user-id", userID.String()),
+			wantCode:   codes.OK,
+			wantUserID: userID,
+		},
+		{
+			name:     "anonymous",
+			md:       metadata.MD{},
+			wantCode: codes.OK,
+		},
+		{
+			name:     "malformed",
+			md:       metadata.Pairs("x-user-id", "42"),
+			wantCode: codes.Unauthenticated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakes.users.principal = session.Principal{}
+			ctx := metadata.NewOutgoingContext(t.Context(), tt.md)
+
+			// Execute.
+			_, err := users.GetUser(ctx, &skeletonv1.GetUserRequest{Id: uuid.NewString()})
+
+			// Assert.
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			assert.Equal(t, tt.wantUserID, fakes.users.principal.UserID)
+		})
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	conn, _, _ := newClient(t, grpc.Config{
+		RateLimit: grpc.RateLimitConfig{
+			Enable: true,
+			Policy: ratelimit.Policy{
+				Key:     []ratelimit.KeyPart{ratelimit.KeyRoute},
+				Windows: []ratelimit.Window{{Limit: 1, Period: time.Minute}},
+			},
+		},
+	}, ratelimit.NewMemoryLimiter(), nil)
+	users := skeletonv1.NewUserServiceClient(conn)
+
+	var header metadata.MD
+	_, err := users.GetUser(t.Context(), &skeletonv1.GetUserRequest{Id: uuid.NewString()}, gogrpc.Header(&header))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1"}, header.Get(grpc.MetadataRateLimitLimit))
+	assert.Equal(t, []string{"0"}, header.Get(grpc.MetadataRateLimitRemaining))
+
+	// Execute.
+	_, err = users.GetUser(t.Context(), &skeletonv1.GetUserRequest{Id: uuid.NewString()}, gogrpc.Header(&header))
+
+	// Assert.
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Equal(t, derror.ErrRateLimitExceeded.Error(), status.Convert(err).Message())
+	assert.NotEmpty(t, header.Get(grpc.MetadataRetryAfter))
+}
+
+func TestStreamAuditRecords(t *testing.T) {
+	conn, ws, fakes := newClient(t, grpc.Config{}, nil, nil)
+	audit := skeletonv1.NewAuditServiceClient(conn)
+	recordID := auditproto.RecordID(uuid.Must(uuid.NewV7()))
+	fakes.audit.records <- auditproto.Record{ID: recordID, Action: "created"}
+
+	anonymous, err := audit.StreamAuditRecords(t.Context(), &skeletonv1.StreamAuditRecordsRequest{})
+	require.NoError(t, err)
+	_, err = anonymous.Recv()
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	ctx := metadata.AppendToOutgoingContext(t.Context(), "authorization", "Bearer valid")
+	stream, err := audit.StreamAuditRecords(ctx, &skeletonv1.StreamAuditRecordsRequest{})
+	require.NoError(t, err)
+
+	// Execute.
+	res, err := stream.Recv()
+	require.NoError(t, err)
+	require.NoError(t, ws.Shutdown(t.Context()))
+	_, end := stream.Recv()
+
+	// Assert.
+	text, err := recordID.MarshalText()
+	require.NoError(t, err)
+	assert.Equal(t, string(text), res.GetRecord().GetId())
+	assert.Equal(t, "created", res.GetRecord().GetAction())
+	assert.True(t, errors.Is(end, io.EOF), "stream ended with %v", end)
+}
+
+type fakeReporter []lifecycle.Status
+
+func (f fakeReporter) Statuses() []lifecycle.Status {
+	return f
+}
+
+func TestHealth(t *testing.T) {
+	conn, _, _ := newClient(t, grpc.Config{}, nil, fakeReporter{
+		{Name: "postgres", State: lifecycle.StateRunning},
+		{Name: "audit", State: lifecycle.StateFailed, Error: "boom"},
+	})
+	client := healthpb.NewHealthClient(conn)
+
+	tests := []struct {
+		name       string
+		service    string
+		wantCode   codes.Code
+		wantStatus healthpb.HealthCheckResponse_ServingStatus
+	}{
+		{
+			name:       "application",
+			wantStatus: healthpb.HealthCheckResponse_NOT_SERVING,
+		},
+		{
+			name:       "running component",
+			service:    "postgres",
+			wantStatus: healthpb.HealthCheckResponse_SERVING,
+		},
+		{
+			name:       "failed component",
+			service:    "audit",
+			wantStatus: healthpb.HealthCheckResponse_NOT_SERVING,
+		},
+		{
+			name:     "unknown component",
+			service:  "mongo",
+			wantCode: codes.NotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Execute.
+			res, err := client.Check(t.Context(), &healthpb.HealthCheckRequest{Service: tt.service})
+
+			// Assert.
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			assert.Equal(t, tt.wantStatus, res.GetStatus())
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package grpc
+
+import (
+	"context"
+	"slices"
+
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	"github.com/kianooshaz/skeleton/foundation/lifecycle"
+)
+
+// health answers the standard gRPC health checks from the state of the
+// components of the application, as the health endpoints of the REST server
+// do. The empty service name stands for the application, and the name of a
+// component for the component alone.
+type health struct {
+	healthpb.UnimplementedHealthServer
+	reporter lifecycle.Reporter
+}
+
+func (h *health) Check(_ context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	var statuses []lifecycle.Status
+	if h.reporter != nil {
+		statuses = h.reporter.Statuses()
+	}
+
+	if service := req.GetService(); service != "" {
+		i := slices.IndexFunc(statuses, func(s lifecycle.Status) bool { return s.Name == service })
+		if i < 0 {
+			return nil, status.Errorf(codes.NotFound, "unknown service %q", service)
+		}
+		statuses = statuses[i : i+1]
+	}
+
+	if !lifecycle.Ready(statuses) {
+		return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_NOT_SERVING}, nil
+	}
+
+	return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}, nil
+}
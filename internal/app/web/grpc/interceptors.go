@@ -0,0 +1,303 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"net"
+	"runtime/debug"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	gogrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"github.com/kianooshaz/skeleton/foundation/derror"
+	"github.com/kianooshaz/skeleton/foundation/ratelimit"
+	"github.com/kianooshaz/skeleton/foundation/session"
+	"github.com/kianooshaz/skeleton/internal/app/web/rest/middleware"
+)
+
+// Metadata keys of the rate limit of a call, the counterparts of the
+// X-RateLimit-* headers of the REST server.
+const (
+	MetadataRateLimitLimit     = "x-ratelimit-limit"
+	MetadataRateLimitRemaining = "x-ratelimit-remaining"
+	MetadataRateLimitReset     = "x-ratelimit-reset"
+	MetadataRetryAfter         = "retry-after"
+)
+
+// step prepares the context of a call to the method, the full name of which is
+// given, or fails the call. Steps run in order for unary and streaming calls alike.
+type step func(ctx context.Context, method string) (context.Context, error)
+
+func unarySteps(steps ...step) gogrpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *gogrpc.UnaryServerInfo, handler gogrpc.UnaryHandler) (any, error) {
+		for _, step := range steps {
+			var err error
+			if ctx, err = step(ctx, info.FullMethod); err != nil {
+				return nil, err
+			}
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func streamSteps(steps ...step) gogrpc.StreamServerInterceptor {
+	return func(srv any, ss gogrpc.ServerStream, info *gogrpc.StreamServerInfo, handler gogrpc.StreamHandler) error {
+		ctx := ss.Context()
+		for _, step := range steps {
+			var err error
+			if ctx, err = step(ctx, info.FullMethod); err != nil {
+				return err
+			}
+		}
+
+		return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// contextStream is a stream whose handler gets the context prepared by the steps.
+type contextStream struct {
+	gogrpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
+
+// unaryErrorStatus answers the errors of calls with the gRPC status of their
+// derror, as statusOf does.
+func unaryErrorStatus(logger *slog.Logger) gogrpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *gogrpc.UnaryServerInfo, handler gogrpc.UnaryHandler) (any, error) {
+		res, err := handler(ctx, req)
+		if err != nil {
+			return nil, statusOf(ctx, logger, info.FullMethod, err)
+		}
+
+		return res, nil
+	}
+}
+
+func streamErrorStatus(logger *slog.Logger) gogrpc.StreamServerInterceptor {
+	return func(srv any, ss gogrpc.ServerStream, info *gogrpc.StreamServerInfo, handler gogrpc.StreamHandler) error {
+		if err := handler(srv, ss); err != nil {
+			return statusOf(ss.Context(), logger, info.FullMethod, err)
+		}
+
+		return nil
+	}
+}
+
+// unaryRecover turns the panics of calls into derror.ErrInternalSystem, so one
+// call cannot take the server down.
+func unaryRecover(logger *slog.Logger) gogrpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *gogrpc.UnaryServerInfo, handler gogrpc.UnaryHandler) (res any, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				res, err = nil, recovered(ctx, logger, info.FullMethod, r)
+			}
+		}()
+
+		return handler(ctx, req)
+	}
+}
+
+func streamRecover(logger *slog.Logger) gogrpc.StreamServerInterceptor {
+	return func(srv any, ss gogrpc.ServerStream, info *gogrpc.StreamServerInfo, handler gogrpc.StreamHandler) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = recovered(ss.Context(), logger, info.FullMethod, r)
+			}
+		}()
+
+		return handler(srv, ss)
+	}
+}
+
+func recovered(ctx context.Context, logger *slog.Logger, method string, r any) error {
+	logger.ErrorContext(
+		ctx,
+		"Recovered from panic while handling call",
+		slog.String("error", fmt.Sprint(r)),
+		slog.String("method", method),
+		slog.String("stack", string(debug.Stack())),
+	)
+
+	return derror.ErrInternalSystem
+}
+
+// requestID stores the ID of the call in its context and sends it back in the
+// x-request-id header. The ID is taken from the first of the trusted metadata
+// keys set by an upstream proxy or service; otherwise a new one is generated.
+func requestID(trustedKeys ...string) step {
+	return func(ctx context.Context, _ string) (context.Context, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+
+		var id string
+		for _, key := range trustedKeys {
+			if value := first(md, key); middleware.ValidRequestID(value) {
+				id = value
+				break
+			}
+		}
+		if id == "" {
+			id = uuid.NewString()
+		}
+
+		// The header is sent along with the response, or the error, of the call.
+		_ = gogrpc.SetHeader(ctx, metadata.Pairs(strings.ToLower(session.RequestIDHeader), id))
+
+		return session.SetRequestID(ctx, id), nil
+	}
+}
+
+// clientIP stores the IP address of the peer of the call in its context.
+func clientIP() step {
+	return func(ctx context.Context, _ string) (context.Context, error) {
+		p, ok := peer.FromContext(ctx)
+		if !ok || p.Addr == nil {
+			return ctx, nil
+		}
+
+		ip := p.Addr.String()
+		if host, _, err := net.SplitHostPort(ip); err == nil {
+			ip = host
+		}
+
+		return session.SetClientIP(ctx, ip), nil
+	}
+}
+
+// authenticate stores the principal proven by the first authenticator that
+// finds credentials in the metadata of the call. Calls without credentials go
+// through unauthenticated; calls with credentials that fail verification are
+// rejected.
+func authenticate(logger *slog.Logger, authenticators ...Authenticator) step {
+	return func(ctx context.Context, method string) (context.Context, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+
+		for _, authenticator := range authenticators {
+			principal, err := authenticator.Authenticate(ctx, md)
+			if errors.Is(err, middleware.ErrNoCredentials) {
+				continue
+			}
+			if err != nil {
+				logger.InfoContext(
+					ctx,
+					"Rejected call with invalid credentials",
+					slog.String("error", err.Error()),
+					slog.String("method", method),
+					slog.String("ip", session.GetClientIP(ctx)),
+				)
+				return nil, derror.ErrUnauthenticated
+			}
+
+			return session.SetPrincipal(ctx, principal), nil
+		}
+
+		return ctx, nil
+	}
+}
+
+// requireAuthentication rejects calls to the given methods that no
+// authenticator could attribute to a principal, as the REST server does for its
+// authenticated routes. It must run after authenticate.
+func requireAuthentication(methods ...string) step {
+	authenticated := make(map[string]bool, len(methods))
+	for _, method := range methods {
+		authenticated[method] = true
+	}
+
+	return func(ctx context.Context, method string) (context.Context, error) {
+		if !authenticated[method] {
+			return ctx, nil
+		}
+
+		principal, ok := session.GetPrincipal(ctx)
+		if !ok || principal.AuthMethod == session.AuthMethodGuest {
+			return nil, derror.ErrUnauthenticated
+		}
+
+		return ctx, nil
+	}
+}
+
+// rateLimit enforces policy, as the REST server does for its routes, with the
+// full method name of the call as its route. The call gets the x-ratelimit-*
+// headers of the deciding window; rejected calls also get retry-after. When
+// the limiter fails the call is let through.
+func rateLimit(limiter ratelimit.Limiter, policy ratelimit.Policy, tiers ratelimit.Tiers, logger *slog.Logger) step {
+	return func(ctx context.Context, method string) (context.Context, error) {
+		identity := callIdentity(ctx, method)
+		key := policy.KeyFor("grpc", identity)
+
+		windows := policy.Windows
+		if identity.Organization != "" && len(policy.Tiers) > 0 {
+			tier, err := tiers.Tier(ctx, identity.Organization)
+			if err != nil {
+				logger.ErrorContext(
+					ctx,
+					"Error encountered while finding organization tier for rate limit",
+					slog.String("error", err.Error()),
+					slog.String("organization_id", identity.Organization),
+				)
+			}
+			windows = policy.WindowsFor(tier)
+		}
+
+		result, err := limiter.Take(ctx, key, policy.CostOrDefault(), windows...)
+		if err != nil {
+			logger.ErrorContext(
+				ctx,
+				"Error encountered while checking rate limit",
+				slog.String("error", err.Error()),
+				slog.String("key", key),
+			)
+
+			return ctx, nil
+		}
+
+		reset := strconv.Itoa(int(math.Ceil(result.Reset.Seconds())))
+		header := metadata.Pairs(
+			MetadataRateLimitLimit, strconv.Itoa(result.Limit),
+			MetadataRateLimitRemaining, strconv.Itoa(result.Remaining),
+			MetadataRateLimitReset, reset,
+		)
+		if !result.Allowed {
+			header.Set(MetadataRetryAfter, reset)
+		}
+		_ = gogrpc.SetHeader(ctx, header)
+
+		if !result.Allowed {
+			return nil, derror.ErrRateLimitExceeded
+		}
+
+		return ctx, nil
+	}
+}
+
+func callIdentity(ctx context.Context, method string) ratelimit.Identity {
+	identity := ratelimit.Identity{
+		IP:    session.GetClientIP(ctx),
+		Route: method,
+	}
+
+	if userID, ok := session.GetUserID(ctx); ok {
+		identity.User = userID.String()
+	}
+	if accountID, ok := session.GetAccountID(ctx); ok {
+		identity.Account = accountID.String()
+	}
+	if organizationID, ok := session.GetOrganizationID(ctx); ok {
+		identity.Organization = organizationID.String()
+	}
+
+	return identity
+}
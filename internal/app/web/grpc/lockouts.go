@@ -0,0 +1,30 @@
+package grpc
+
+import (
+	"context"
+
+	skeletonv1 "github.com/kianooshaz/skeleton/api/skeleton/v1"
+	"github.com/kianooshaz/skeleton/foundation/id"
+	accproto "github.com/kianooshaz/skeleton/services/account/accounts/proto"
+	lockoutproto "github.com/kianooshaz/skeleton/services/authentication/lockout/proto"
+)
+
+type lockouts struct {
+	skeletonv1.UnimplementedLockoutServiceServer
+	service lockoutproto.LockoutService
+}
+
+func (l *lockouts) UnlockAccount(
+	ctx context.Context, req *skeletonv1.UnlockAccountRequest,
+) (*skeletonv1.UnlockAccountResponse, error) {
+	accountID, err := id.Parse[accproto.AccountKind](req.GetAccountId())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := l.service.Unlock(ctx, lockoutproto.UnlockRequest{AccountID: accountID}); err != nil {
+		return nil, err
+	}
+
+	return &skeletonv1.UnlockAccountResponse{}, nil
+}
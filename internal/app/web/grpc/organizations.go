@@ -0,0 +1,75 @@
+package grpc
+
+import (
+	"context"
+
+	skeletonv1 "github.com/kianooshaz/skeleton/api/skeleton/v1"
+	"github.com/kianooshaz/skeleton/foundation/id"
+	"github.com/kianooshaz/skeleton/foundation/order"
+	orgproto "github.com/kianooshaz/skeleton/services/organization/organization/proto"
+)
+
+type organizations struct {
+	skeletonv1.UnimplementedOrganizationServiceServer
+	server  *server
+	service orgproto.OrganizationService
+}
+
+func (o *organizations) CreateOrganization(
+	ctx context.Context, _ *skeletonv1.CreateOrganizationRequest,
+) (*skeletonv1.CreateOrganizationResponse, error) {
+	res, err := o.service.Create(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &skeletonv1.CreateOrganizationResponse{Organization: toOrganization(res.Data)}, nil
+}
+
+func (o *organizations) GetOrganization(
+	ctx context.Context, req *skeletonv1.GetOrganizationRequest,
+) (*skeletonv1.GetOrganizationResponse, error) {
+	organizationID, err := id.Parse[orgproto.OrganizationKind](req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := o.service.Get(ctx, orgproto.GetRequest{ID: organizationID})
+	if err != nil {
+		return nil, err
+	}
+
+	return &skeletonv1.GetOrganizationResponse{Organization: toOrganization(res.Data)}, nil
+}
+
+func (o *organizations) ListOrganizations(
+	ctx context.Context, req *skeletonv1.ListOrganizationsRequest,
+) (*skeletonv1.ListOrganizationsResponse, error) {
+	page, err := o.server.page(req.GetPage())
+	if err != nil {
+		return nil, err
+	}
+
+	sort, err := order.Parse(req.GetSort())
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := o.service.List(ctx, orgproto.ListRequest{Page: page, Sort: sort})
+	if err != nil {
+		return nil, err
+	}
+
+	return &skeletonv1.ListOrganizationsResponse{
+		Organizations: convertAll(res.Data, toOrganization),
+		Page:          toPage(res.Page),
+		Navigation:    toNavigation(res.Navigation),
+	}, nil
+}
+
+func toOrganization(organization orgproto.Organization) *skeletonv1.Organization {
+	return &skeletonv1.Organization{
+		Id:        idText(organization.ID),
+		CreatedAt: timestamp(organization.CreatedAt),
+	}
+}
@@ -0,0 +1,53 @@
+package grpc
+
+import (
+	"context"
+
+	skeletonv1 "github.com/kianooshaz/skeleton/api/skeleton/v1"
+	"github.com/kianooshaz/skeleton/foundation/id"
+	accproto "github.com/kianooshaz/skeleton/services/account/accounts/proto"
+	passwordproto "github.com/kianooshaz/skeleton/services/authentication/password/proto"
+)
+
+type passwords struct {
+	skeletonv1.UnimplementedPasswordServiceServer
+	service passwordproto.PasswordService
+}
+
+func (p *passwords) UpdatePassword(
+	ctx context.Context, req *skeletonv1.UpdatePasswordRequest,
+) (*skeletonv1.UpdatePasswordResponse, error) {
+	accountID, err := id.Parse[accproto.AccountKind](req.GetAccountId())
+	if err != nil {
+		return nil, err
+	}
+
+	update := passwordproto.UpdateRequest{
+		CurrentPassword: req.GetCurrentPassword(),
+		NewPassword:     req.GetNewPassword(),
+		AccountID:       accountID,
+	}
+	if err := validate(&update); err != nil {
+		return nil, err
+	}
+
+	if err := p.service.Update(ctx, update); err != nil {
+		return nil, err
+	}
+
+	return &skeletonv1.UpdatePasswordResponse{}, nil
+}
+
+func (p *passwords) GetPasswordGuidelines(
+	context.Context, *skeletonv1.GetPasswordGuidelinesRequest,
+) (*skeletonv1.GetPasswordGuidelinesResponse, error) {
+	res, err := p.service.Guidelines()
+	if err != nil {
+		return nil, err
+	}
+
+	return &skeletonv1.GetPasswordGuidelinesResponse{
+		Required:   res.Data.Required,
+		BetterHave: res.Data.BetterHave,
+	}, nil
+}
@@ -0,0 +1,37 @@
+package grpc
+
+import (
+	"context"
+
+	skeletonv1 "github.com/kianooshaz/skeleton/api/skeleton/v1"
+	"github.com/kianooshaz/skeleton/foundation/id"
+	accproto "github.com/kianooshaz/skeleton/services/account/accounts/proto"
+	tokenproto "github.com/kianooshaz/skeleton/services/authentication/token/proto"
+)
+
+type tokens struct {
+	skeletonv1.UnimplementedTokenServiceServer
+	service tokenproto.TokenService
+}
+
+func (t *tokens) IssueToken(ctx context.Context, req *skeletonv1.IssueTokenRequest) (*skeletonv1.IssueTokenResponse, error) {
+	accountID, err := id.Parse[accproto.AccountKind](req.GetAccountId())
+	if err != nil {
+		return nil, err
+	}
+
+	issue := tokenproto.IssueRequest{AccountID: accountID, Password: req.GetPassword()}
+	if err := validate(&issue); err != nil {
+		return nil, err
+	}
+
+	res, err := t.service.Issue(ctx, issue)
+	if err != nil {
+		return nil, err
+	}
+
+	return &skeletonv1.IssueTokenResponse{
+		Token:     res.Data.Token,
+		ExpiresAt: timestamp(res.Data.ExpiresAt),
+	}, nil
+}
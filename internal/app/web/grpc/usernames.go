@@ -0,0 +1,184 @@
+package grpc
+
+import (
+	"context"
+
+	skeletonv1 "github.com/kianooshaz/skeleton/api/skeleton/v1"
+	"github.com/kianooshaz/skeleton/foundation/id"
+	"github.com/kianooshaz/skeleton/foundation/order"
+	"github.com/kianooshaz/skeleton/foundation/types"
+	accproto "github.com/kianooshaz/skeleton/services/account/accounts/proto"
+	usernameproto "github.com/kianooshaz/skeleton/services/account/username/proto"
+)
+
+type usernames struct {
+	skeletonv1.UnimplementedUsernameServiceServer
+	server  *server
+	service usernameproto.UsernameService
+}
+
+func (u *usernames) AssignUsername(
+	ctx context.Context, req *skeletonv1.AssignUsernameRequest,
+) (*skeletonv1.AssignUsernameResponse, error) {
+	accountID, err := parseOptionalID[accproto.AccountKind](req.GetAccountId())
+	if err != nil {
+		return nil, err
+	}
+
+	assign := usernameproto.AssignRequest{AccountID: accountID, Username: req.GetUsername()}
+	if err := validate(&assign); err != nil {
+		return nil, err
+	}
+
+	username, err := u.service.Assign(ctx, assign)
+	if err != nil {
+		return nil, err
+	}
+
+	return &skeletonv1.AssignUsernameResponse{Username: toUsername(username)}, nil
+}
+
+func (u *usernames) GetUsername(
+	ctx context.Context, req *skeletonv1.GetUsernameRequest,
+) (*skeletonv1.GetUsernameResponse, error) {
+	usernameID, err := parseUUID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	username, err := u.service.Get(ctx, usernameproto.GetRequest{ID: usernameID})
+	if err != nil {
+		return nil, err
+	}
+
+	return &skeletonv1.GetUsernameResponse{Username: toUsername(username)}, nil
+}
+
+func (u *usernames) ListUsernames(
+	ctx context.Context, req *skeletonv1.ListUsernamesRequest,
+) (*skeletonv1.ListUsernamesResponse, error) {
+	page, err := u.server.page(req.GetPage())
+	if err != nil {
+		return nil, err
+	}
+
+	sort, err := order.Parse(req.GetSort())
+	if err != nil {
+		return nil, err
+	}
+
+	list := usernameproto.ListRequest{Page: page, Sort: sort}
+	if req.Username != nil {
+		list.Username = types.NewNullable(req.GetUsername())
+	}
+	if req.AccountId != nil {
+		accountID, err := id.Parse[accproto.AccountKind](req.GetAccountId())
+		if err != nil {
+			return nil, err
+		}
+		list.AccountID = types.NewNullable(accountID)
+	}
+	if list.Status.All, err = parseStatus(req.GetStatusAll()); err != nil {
+		return nil, err
+	}
+	if list.Status.Any, err = parseStatus(req.GetStatusAny()); err != nil {
+		return nil, err
+	}
+	if list.Status.None, err = parseStatus(req.GetStatusNone()); err != nil {
+		return nil, err
+	}
+
+	res, err := u.service.List(ctx, list)
+	if err != nil {
+		return nil, err
+	}
+
+	return &skeletonv1.ListUsernamesResponse{
+		Usernames:  convertAll(res.Data, toListedUsername),
+		Page:       toPage(res.Page),
+		Navigation: toNavigation(res.Navigation),
+	}, nil
+}
+
+func (u *usernames) ListAssignedUsernames(
+	ctx context.Context, req *skeletonv1.ListAssignedUsernamesRequest,
+) (*skeletonv1.ListAssignedUsernamesResponse, error) {
+	accountID, err := id.Parse[accproto.AccountKind](req.GetAccountId())
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := u.server.page(req.GetPage())
+	if err != nil {
+		return nil, err
+	}
+
+	sort, err := order.Parse(req.GetSort())
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := u.service.ListAssigned(ctx, usernameproto.ListAssignedRequest{AccountID: accountID, Page: page, Sort: sort})
+	if err != nil {
+		return nil, err
+	}
+
+	return &skeletonv1.ListAssignedUsernamesResponse{
+		Usernames:  convertAll(res.Data, toListedUsername),
+		Page:       toPage(res.Page),
+		Navigation: toNavigation(res.Navigation),
+	}, nil
+}
+
+func (u *usernames) UnassignUsername(
+	ctx context.Context, req *skeletonv1.UnassignUsernameRequest,
+) (*skeletonv1.UnassignUsernameResponse, error) {
+	usernameID, err := parseUUID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.service.Unassign(ctx, usernameproto.UnassignRequest{ID: usernameID}); err != nil {
+		return nil, err
+	}
+
+	return &skeletonv1.UnassignUsernameResponse{}, nil
+}
+
+func (u *usernames) SetPrimaryUsername(
+	ctx context.Context, req *skeletonv1.SetPrimaryUsernameRequest,
+) (*skeletonv1.SetPrimaryUsernameResponse, error) {
+	usernameID, err := parseUUID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.service.BePrimary(ctx, usernameproto.BePrimaryRequest{ID: usernameID}); err != nil {
+		return nil, err
+	}
+
+	return &skeletonv1.SetPrimaryUsernameResponse{}, nil
+}
+
+func toUsername(username usernameproto.Username) *skeletonv1.Username {
+	return &skeletonv1.Username{
+		Id:        username.ID.String(),
+		Username:  username.Username,
+		AccountId: idText(username.AccountID),
+		Status:    username.Status.Names(),
+		CreatedAt: timestamp(username.CreatedAt),
+		UpdatedAt: timestamp(username.UpdatedAt),
+	}
+}
+
+func toListedUsername(username usernameproto.ListUsername) *skeletonv1.ListedUsername {
+	return &skeletonv1.ListedUsername{
+		Id:        username.ID.String(),
+		Username:  username.Username,
+		AccountId: idText(username.AccountID),
+		Primary:   username.Primary,
+		Locked:    username.Locked,
+		Blocked:   username.Blocked,
+		Reserved:  username.Reserved,
+	}
+}
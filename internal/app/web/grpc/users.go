@@ -0,0 +1,71 @@
+package grpc
+
+import (
+	"context"
+
+	skeletonv1 "github.com/kianooshaz/skeleton/api/skeleton/v1"
+	"github.com/kianooshaz/skeleton/foundation/id"
+	"github.com/kianooshaz/skeleton/foundation/order"
+	userproto "github.com/kianooshaz/skeleton/services/user/user/proto"
+)
+
+type users struct {
+	skeletonv1.UnimplementedUserServiceServer
+	server  *server
+	service userproto.UserService
+}
+
+func (u *users) CreateUser(ctx context.Context, _ *skeletonv1.CreateUserRequest) (*skeletonv1.CreateUserResponse, error) {
+	res, err := u.service.Create(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &skeletonv1.CreateUserResponse{User: toUser(res.Data)}, nil
+}
+
+func (u *users) GetUser(ctx context.Context, req *skeletonv1.GetUserRequest) (*skeletonv1.GetUserResponse, error) {
+	userID, err := id.Parse[userproto.UserKind](req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := u.service.Get(ctx, userproto.GetRequest{ID: userID})
+	if err != nil {
+		return nil, err
+	}
+
+	return &skeletonv1.GetUserResponse{User: toUser(res.Data)}, nil
+}
+
+func (u *users) ListUsers(ctx context.Context, req *skeletonv1.ListUsersRequest) (*skeletonv1.ListUsersResponse, error) {
+	page, err := u.server.page(req.GetPage())
+	if err != nil {
+		return nil, err
+	}
+
+	sort, err := order.Parse(req.GetSort())
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := u.service.List(ctx, userproto.ListRequest{Page: page, Sort: sort})
+	if err != nil {
+		return nil, err
+	}
+
+	return &skeletonv1.ListUsersResponse{
+		Users:      convertAll(res.Data, toUser),
+		Page:       toPage(res.Page),
+		Navigation: toNavigation(res.Navigation),
+	}, nil
+}
+
+func toUser(user userproto.User) *skeletonv1.User {
+	return &skeletonv1.User{
+		Id:         idText(user.ID),
+		Status:     user.Status.Names(),
+		LastSeenAt: timestamp(user.LastSeenAt),
+		CreatedAt:  timestamp(user.CreatedAt),
+	}
+}
@@ -3,6 +3,10 @@ package protocol
 import "context"
 
 type WebService interface {
+	// Listen binds the address of the service, so a taken address fails here
+	// rather than in Start. Calling it is optional.
+	Listen() error
+	// Start serves requests until the service is shut down.
 	Start() error
 	Shutdown(ctx context.Context) error
 	Close() error
@@ -0,0 +1,96 @@
+package rest
+
+import (
+	_ "embed"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/kianooshaz/skeleton/foundation/derror"
+	"github.com/kianooshaz/skeleton/internal/app/web/rest/middleware"
+	"github.com/kianooshaz/skeleton/internal/app/web/rest/openapi"
+	"github.com/labstack/echo/v4"
+)
+
+// DocsConfig holds how the server documents its API.
+type DocsConfig struct {
+	// Enable serves the OpenAPI document at /openapi.json and Swagger UI at /docs.
+	Enable  bool   `yaml:"enable"`
+	Title   string `yaml:"title"`
+	Version string `yaml:"version"`
+}
+
+//go:embed static/swagger.html
+var swaggerUI []byte
+
+// doc documents a route.
+type doc struct {
+	summary string
+	// errors are the domain errors the route may answer with, besides those
+	// of binding its request.
+	errors []error
+	// authenticated routes answer 401 to requests without a principal.
+	authenticated bool
+	deprecated    bool
+}
+
+// route registers e at method and path, and records it for the OpenAPI document.
+// It panics if the route documents an error without an HTTP status.
+func (s *server) route(method, path string, e endpoint, d doc, middlewares ...echo.MiddlewareFunc) {
+	if d.authenticated {
+		middlewares = append([]echo.MiddlewareFunc{middleware.RequireAuthentication()}, middlewares...)
+	}
+	s.core.Add(method, path, e.handler, middlewares...)
+
+	errs := slices.Concat(e.errors, d.errors, []error{derror.ErrInternalSystem})
+	if d.authenticated {
+		errs = append(errs, derror.ErrUnauthenticated)
+	}
+	if s.rateLimit.Enable {
+		errs = append(errs, derror.ErrRateLimitExceeded)
+	}
+
+	route := openapi.Route{
+		Method:        method,
+		Path:          path,
+		Summary:       d.summary,
+		Request:       e.request,
+		Response:      e.response,
+		Status:        e.status,
+		Authenticated: d.authenticated,
+		Deprecated:    d.deprecated,
+		ExportFormats: e.exports,
+		Events:        e.events,
+	}
+	tag, remainder, _ := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+	if versionSegment.MatchString(tag) {
+		tag, _, _ = strings.Cut(remainder, "/")
+	}
+	if tag != "" {
+		route.Tags = []string{tag}
+	}
+	for _, err := range errs {
+		status, ok := DerrorToHTTPStatus[err]
+		if !ok {
+			// It would be answered with 500, so it belongs in DerrorToHTTPStatus.
+			panic(fmt.Sprintf("%s %s documents error %s, which has no HTTP status", method, path, err))
+		}
+		route.Errors = append(route.Errors, openapi.Error{Status: status, Code: err.Error()})
+	}
+
+	s.routes = append(s.routes, route)
+}
+
+// registerDocs serves the OpenAPI document of the routes registered so far,
+// and Swagger UI to browse it.
+func (s *server) registerDocs(cfg DocsConfig) {
+	document := openapi.Build(openapi.Info{Title: cfg.Title, Version: cfg.Version}, s.routes)
+
+	s.core.GET("/openapi.json", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, document)
+	})
+	s.core.GET("/docs", func(c echo.Context) error {
+		return c.HTMLBlob(http.StatusOK, swaggerUI)
+	})
+}
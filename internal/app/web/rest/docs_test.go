@@ -0,0 +1,123 @@
+package rest_test
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kianooshaz/skeleton/internal/app/web/rest"
+	"github.com/kianooshaz/skeleton/internal/app/web/rest/openapi"
+	usernameproto "github.com/kianooshaz/skeleton/services/account/username/proto"
+	lockoutproto "github.com/kianooshaz/skeleton/services/authentication/lockout/proto"
+	passwordproto "github.com/kianooshaz/skeleton/services/authentication/password/proto"
+	tokenproto "github.com/kianooshaz/skeleton/services/authentication/token/proto"
+	orgproto "github.com/kianooshaz/skeleton/services/organization/organization/proto"
+	auditproto "github.com/kianooshaz/skeleton/services/risk/audit/proto"
+	birthdayproto "github.com/kianooshaz/skeleton/services/user/birthday/proto"
+	userproto "github.com/kianooshaz/skeleton/services/user/user/proto"
+)
+
+func TestDocs(t *testing.T) {
+	ws, err := rest.New(
+		rest.Config{Docs: rest.DocsConfig{Enable: true, Title: "Skeleton API", Version: "1.0.0"}},
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+		nil,
+		nil,
+		nil,
+		struct{ userproto.UserService }{},
+		struct{ orgproto.OrganizationService }{},
+		struct{ passwordproto.PasswordService }{},
+		struct{ usernameproto.UsernameService }{},
+		struct{ auditproto.AuditService }{},
+		struct{ birthdayproto.BirthdayService }{},
+		struct{ tokenproto.TokenService }{},
+		struct{ lockoutproto.LockoutService }{},
+	)
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	rest.Handler(ws).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var document openapi.Document
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &document))
+	assert.Equal(t, openapi.Version, document.OpenAPI)
+	assert.Equal(t, "Skeleton API", document.Info.Title)
+
+	t.Run("every route is documented", func(t *testing.T) {
+		for _, route := range rest.Routes(ws) {
+			if route.Path == "/openapi.json" || route.Path == "/docs" || route.Path == "/debug/vars" {
+				continue
+			}
+
+			path := route.Path
+			for _, segment := range strings.Split(path, "/") {
+				if name, ok := strings.CutPrefix(segment, ":"); ok {
+					path = strings.Replace(path, segment, "{"+name+"}", 1)
+				}
+			}
+
+			operation := document.Paths[path][strings.ToLower(route.Method)]
+			if !assert.NotNil(t, operation, "%s %s is not documented", route.Method, route.Path) {
+				continue
+			}
+			assert.NotEmpty(t, operation.Summary, "%s %s has no summary", route.Method, route.Path)
+			assert.Contains(t, operation.Responses, "500", "%s %s", route.Method, route.Path)
+		}
+	})
+
+	t.Run("every documented error has its status", func(t *testing.T) {
+		statuses := make(map[string]int, len(rest.DerrorToHTTPStatus))
+		for err, status := range rest.DerrorToHTTPStatus {
+			statuses[err.Error()] = status
+		}
+
+		for path, operations := range document.Paths {
+			for method, operation := range operations {
+				for status, response := range operation.Responses {
+					if status < "400" {
+						continue
+					}
+
+					_, codes, ok := strings.Cut(response.Description, ": ")
+					require.True(t, ok, "%s %s %s: %s", method, path, status, response.Description)
+					for _, code := range strings.Split(codes, ", ") {
+						want, ok := statuses[code]
+						if assert.True(t, ok, "%s %s documents %s, which has no HTTP status", method, path, code) {
+							assert.Equal(t, strconv.Itoa(want), status, "%s %s documents %s", method, path, code)
+						}
+					}
+				}
+			}
+		}
+	})
+
+	t.Run("path parameters and error codes", func(t *testing.T) {
+		operation := document.Paths["/v1/users/{id}"]["get"]
+		require.NotNil(t, operation)
+
+		require.Len(t, operation.Parameters, 1)
+		assert.Equal(t, "id", operation.Parameters[0].Name)
+		assert.Equal(t, "path", operation.Parameters[0].In)
+		assert.Equal(t, []any{"usr_00000000-0000-0000-0000-000000000000"}, operation.Parameters[0].Schema.Examples)
+
+		assert.Contains(t, operation.Responses["400"].Description, "100101", "user not found")
+		assert.Equal(t, "#/components/schemas/userproto.GetResponse", operation.Responses["200"].Content["application/json"].Schema.Ref)
+	})
+
+	t.Run("swagger ui", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		rest.Handler(ws).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/docs", nil))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `url: "/openapi.json"`)
+	})
+}
@@ -0,0 +1,229 @@
+package rest_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kianooshaz/skeleton/foundation/derror"
+	"github.com/kianooshaz/skeleton/foundation/id"
+	"github.com/kianooshaz/skeleton/foundation/order"
+	"github.com/kianooshaz/skeleton/internal/app/web/rest"
+	accproto "github.com/kianooshaz/skeleton/services/account/accounts/proto"
+	usernameproto "github.com/kianooshaz/skeleton/services/account/username/proto"
+	lockoutproto "github.com/kianooshaz/skeleton/services/authentication/lockout/proto"
+	passwordproto "github.com/kianooshaz/skeleton/services/authentication/password/proto"
+	orgproto "github.com/kianooshaz/skeleton/services/organization/organization/proto"
+	auditproto "github.com/kianooshaz/skeleton/services/risk/audit/proto"
+	userproto "github.com/kianooshaz/skeleton/services/user/user/proto"
+)
+
+// fakeAudit exports its records, or fails with err before the first of them.
+type fakeAudit struct {
+	auditproto.AuditService
+	records []auditproto.Record
+	err     error
+	export  auditproto.ListRequest
+}
+
+func (f *fakeAudit) List(context.Context, auditproto.ListRequest) (auditproto.ListResponse, error) {
+	return auditproto.ListResponse{}, nil
+}
+
+func (f *fakeAudit) Export(_ context.Context, req auditproto.ListRequest, yield func(auditproto.Record) error) error {
+	f.export = req
+	if f.err != nil {
+		return f.err
+	}
+
+	for _, record := range f.records {
+		if err := yield(record); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// fakeUsernameExport exports its usernames.
+type fakeUsernameExport struct {
+	usernameproto.UsernameService
+	usernames []usernameproto.ListUsername
+	export    usernameproto.ListRequest
+}
+
+func (f *fakeUsernameExport) List(context.Context, usernameproto.ListRequest) (usernameproto.ListResponse, error) {
+	return usernameproto.ListResponse{}, nil
+}
+
+func (f *fakeUsernameExport) Export(
+	_ context.Context, req usernameproto.ListRequest, yield func(usernameproto.ListUsername) error,
+) error {
+	f.export = req
+	for _, username := range f.usernames {
+		if err := yield(username); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func TestDownloads(t *testing.T) {
+	record := auditproto.Record{
+		ID:           id.MustNew[auditproto.RecordKind](),
+		RequestID:    "req-1",
+		Action:       auditproto.Update,
+		CreatedAt:    time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC),
+		Data:         json.RawMessage(`{"name":"a, b"}`),
+		OriginIP:     "10.0.0.1",
+		ResourceID:   7,
+		ResourceType: "user",
+		UserID:       3,
+	}
+	accountID := accproto.AccountID(uuid.New())
+	accountText, err := accountID.MarshalText()
+	require.NoError(t, err)
+	username := usernameproto.ListUsername{ID: uuid.New(), Username: "kianoosh", AccountID: accountID, Primary: true}
+
+	tests := []struct {
+		name            string
+		target          string
+		accept          string
+		records         []auditproto.Record
+		err             error
+		wantStatus      int
+		wantContentType string
+		wantFilename    string
+		wantBody        string
+		assert          func(t *testing.T, audit *fakeAudit, usernames *fakeUsernameExport)
+	}{
+		{
+			name:            "audit records as csv",
+			target:          "/audit/records?format=csv&sort=-action",
+			records:         []auditproto.Record{record},
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/csv",
+			wantFilename:    "audit-records.csv",
+			wantBody: "id,request_id,action,created_at,data,origin_ip,resource_id,resource_type,user_id,organization_id\n" +
+				record.ID.String() + `,req-1,update,2026-03-04T05:06:07Z,"{""name"":""a, b""}",10.0.0.1,7,user,3,` + "\n",
+			assert: func(t *testing.T, audit *fakeAudit, _ *fakeUsernameExport) {
+				assert.Equal(t, order.Spec{{Field: "action", Direction: order.DESC}}, audit.export.Sort)
+			},
+		},
+		{
+			name:            "audit records as csv without rows",
+			target:          "/v2/audit/records",
+			accept:          "text/csv",
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/csv",
+			wantFilename:    "audit-records.csv",
+			wantBody:        "id,request_id,action,created_at,data,origin_ip,resource_id,resource_type,user_id,organization_id\n",
+		},
+		{
+			name:            "usernames as ndjson",
+			target:          "/v2/usernames?account_id=" + string(accountText) + "&page_rows=1",
+			accept:          "application/x-ndjson",
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/x-ndjson",
+			wantFilename:    "usernames.ndjson",
+			wantBody: `{"id":"` + username.ID.String() + `","username":"kianoosh","account_id":"` + string(accountText) +
+				`","primary":true,"locked":false,"blocked":false,"reserved":false}` + "\n",
+			assert: func(t *testing.T, _ *fakeAudit, usernames *fakeUsernameExport) {
+				assert.Equal(t, accountID, usernames.export.AccountID.Get())
+			},
+		},
+		{
+			name:            "json is preferred by accept",
+			target:          "/audit/records",
+			accept:          "application/json, text/csv",
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/json",
+		},
+		{
+			name:            "format overrides accept",
+			target:          "/audit/records?format=json",
+			accept:          "text/csv",
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/json",
+		},
+		{
+			name:            "unknown format",
+			target:          "/audit/records?format=xml",
+			wantStatus:      http.StatusBadRequest,
+			wantContentType: "application/json",
+			wantBody:        `{"error":"` + derror.ErrInvalidQueryParameter.Error() + `"}`,
+		},
+		{
+			name:            "error before the first row",
+			target:          "/audit/records?format=ndjson",
+			err:             derror.ErrUnknownOrder,
+			wantStatus:      http.StatusBadRequest,
+			wantContentType: "application/json",
+			wantBody:        `{"error":"` + derror.ErrUnknownOrder.Error() + `"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			audit := &fakeAudit{records: tt.records, err: tt.err}
+			usernames := &fakeUsernameExport{usernames: []usernameproto.ListUsername{username}}
+			ws, err := rest.New(
+				rest.Config{},
+				slog.New(slog.NewTextHandler(io.Discard, nil)),
+				nil,
+				nil,
+				nil,
+				struct{ userproto.UserService }{},
+				struct{ orgproto.OrganizationService }{},
+				struct{ passwordproto.PasswordService }{},
+				usernames,
+				audit,
+				&fakeBirthdays{},
+				&fakeTokens{},
+				struct{ lockoutproto.LockoutService }{},
+			)
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			req.Header.Set("Authorization", "Bearer valid")
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			rec := httptest.NewRecorder()
+
+			// Execute.
+			rest.Handler(ws).ServeHTTP(rec, req)
+
+			// Assert.
+			require.Equal(t, tt.wantStatus, rec.Code, rec.Body.String())
+			assert.True(t, strings.HasPrefix(rec.Header().Get("Content-Type"), tt.wantContentType),
+				rec.Header().Get("Content-Type"))
+			if tt.wantFilename != "" {
+				assert.Equal(t, `attachment; filename="`+tt.wantFilename+`"`, rec.Header().Get("Content-Disposition"))
+			} else {
+				assert.Empty(t, rec.Header().Get("Content-Disposition"))
+			}
+			if tt.wantBody != "" {
+				if strings.HasPrefix(tt.wantContentType, "application/json") {
+					assert.JSONEq(t, tt.wantBody, rec.Body.String())
+				} else {
+					assert.Equal(t, tt.wantBody, rec.Body.String())
+				}
+			}
+			if tt.assert != nil {
+				tt.assert(t, audit, usernames)
+			}
+		})
+	}
+}
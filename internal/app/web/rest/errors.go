@@ -1,6 +1,7 @@
 package rest
 
 import (
+	"errors"
 	"log/slog"
 	"net/http"
 
@@ -9,6 +10,27 @@ import (
 )
 
 func ErrorResponse(err error, c echo.Context) {
+	if c.Response().Committed {
+		// The response, such as a streamed export, is already under way.
+		slog.Error(
+			"Error encountered while writing response",
+			slog.String("error", err.Error()),
+			slog.String("path", c.Path()),
+			slog.String("package", "rest"),
+		)
+		return
+	}
+
+	body := echo.Map{}
+	var invalid *ValidationError
+	if errors.As(err, &invalid) {
+		body["fields"] = invalid.Fields
+	}
+
+	if known := knownError(err); known != nil {
+		err = known
+	}
+
 	status, ok := DerrorToHTTPStatus[err]
 	if !ok {
 		slog.Error(
@@ -21,9 +43,9 @@ func ErrorResponse(err error, c echo.Context) {
 		err = derror.ErrInternalSystem
 	}
 
-	if err := c.JSON(status, echo.Map{
-		"error": err.Error(),
-	}); err != nil {
+	body["error"] = err.Error()
+
+	if err := c.JSON(status, body); err != nil {
 		slog.Error(
 			"Error encountered while sending response of error",
 			slog.String("error", err.Error()),
@@ -33,33 +55,67 @@ func ErrorResponse(err error, c echo.Context) {
 	}
 }
 
+// knownError returns the first error in err's chain that has an HTTP status, or nil.
+// It lets domain errors wrapped by services or by echo's binder keep their status.
+func knownError(err error) error {
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		if _, ok := DerrorToHTTPStatus[e]; ok {
+			return e
+		}
+	}
+
+	return nil
+}
+
 var DerrorToHTTPStatus = map[error]int{
-	derror.ErrInternalSystem:         http.StatusInternalServerError,
-	derror.ErrUndefinedPathAndMethod: http.StatusBadRequest,
-	derror.ErrInvalidJsonFormat:      http.StatusBadRequest,
-	derror.ErrInvalidQueryParameter:  http.StatusBadRequest,
-	derror.ErrUnknownOrder:           http.StatusBadRequest,
-	derror.ErrUnknownOrderDirection:  http.StatusBadRequest,
-	derror.ErrUnknownOrder:           http.StatusBadRequest,
-	derror.ErrUnknownOrderDirection:  http.StatusBadRequest,
-	derror.ErrInvalidPage:            http.StatusBadRequest,
-	derror.ErrInvalidRows:            http.StatusBadRequest,
-	derror.ErrPageValueTooSmall:      http.StatusBadRequest,
-	derror.ErrRowsValueTooSmall:      http.StatusBadRequest,
-	derror.ErrRowsValueTooLarge:      http.StatusBadRequest,
+	derror.ErrInternalSystem:          http.StatusInternalServerError,
+	derror.ErrUndefinedPathAndMethod:  http.StatusBadRequest,
+	derror.ErrInvalidJsonFormat:       http.StatusBadRequest,
+	derror.ErrInvalidQueryParameter:   http.StatusBadRequest,
+	derror.ErrUnknownOrder:            http.StatusBadRequest,
+	derror.ErrUnknownOrderDirection:   http.StatusBadRequest,
+	derror.ErrInvalidPage:             http.StatusBadRequest,
+	derror.ErrInvalidRows:             http.StatusBadRequest,
+	derror.ErrPageValueTooSmall:       http.StatusBadRequest,
+	derror.ErrRowsValueTooSmall:       http.StatusBadRequest,
+	derror.ErrRowsValueTooLarge:       http.StatusBadRequest,
+	derror.ErrInvalidCursor:           http.StatusBadRequest,
+	derror.ErrRateLimitExceeded:       http.StatusTooManyRequests,
+	derror.ErrUnauthenticated:         http.StatusUnauthorized,
+	derror.ErrForbidden:               http.StatusForbidden,
+	derror.ErrInvalidStatusTransition: http.StatusConflict,
+	derror.ErrUnknownStatusFlag:       http.StatusBadRequest,
+	derror.ErrInvalidID:               http.StatusBadRequest,
+	derror.ErrInvalidRequest:          http.StatusBadRequest,
+	derror.ErrUnsupportedVersion:      http.StatusNotAcceptable,
+	derror.ErrInvalidIdempotencyKey:   http.StatusBadRequest,
+	derror.ErrIdempotencyKeyInUse:     http.StatusConflict,
+	derror.ErrIdempotencyKeyReused:    http.StatusUnprocessableEntity,
 
+	derror.ErrUserIDRequired:             http.StatusBadRequest,
 	derror.ErrUserNotFound:               http.StatusBadRequest,
 	derror.ErrUserAlreadyExists:          http.StatusBadRequest,
+	derror.ErrUserNotGuest:               http.StatusConflict,
 	derror.ErrUsernameNotFound:           http.StatusBadRequest,
 	derror.ErrUsernameAlreadyExists:      http.StatusBadRequest,
 	derror.ErrUsernameInvalid:            http.StatusBadRequest,
 	derror.ErrUsernameMaxPerUser:         http.StatusBadRequest,
 	derror.ErrUsernameMaxPerOrganization: http.StatusBadRequest,
 	derror.ErrUsernameNotReserved:        http.StatusBadRequest,
+	derror.ErrUsernameCannotBeAssigned:   http.StatusConflict,
+	derror.ErrUsernameLocked:             http.StatusLocked,
+	derror.ErrUsernameRequired:           http.StatusBadRequest,
+
+	derror.ErrOrganizationIDRequired: http.StatusBadRequest,
+	derror.ErrOrganizationNotFound:   http.StatusNotFound,
 
 	derror.ErrPasswordInvalid:    http.StatusBadRequest,
 	derror.ErrPasswordIsWeak:     http.StatusBadRequest,
 	derror.ErrPasswordIsCommon:   http.StatusBadRequest,
 	derror.ErrPasswordUsedBefore: http.StatusBadRequest,
 	derror.ErrPasswordNotFound:   http.StatusNotFound,
+
+	derror.ErrAccountIDRequired: http.StatusBadRequest,
+	derror.ErrAccountLocked:     http.StatusLocked,
+	derror.ErrTooManyAttempts:   http.StatusTooManyRequests,
 }
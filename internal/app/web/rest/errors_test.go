@@ -2,6 +2,7 @@ package rest_test
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -24,6 +25,18 @@ func Test_ErrorResponse(t *testing.T) {
 			wantStatus: http.StatusBadRequest,
 			wantBody:   `{"error":"100101"}`,
 		},
+		{
+			name:       "wrapped error maps to status",
+			err:        fmt.Errorf("listing: %w", derror.ErrUnknownOrder),
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"error":"100004"}`,
+		},
+		{
+			name:       "rate limit maps to 429",
+			err:        derror.ErrRateLimitExceeded,
+			wantStatus: http.StatusTooManyRequests,
+			wantBody:   `{"error":"100011"}`,
+		},
 		{
 			name:       "unknown error maps to 500",
 			err:        errors.New("some unknown error"),
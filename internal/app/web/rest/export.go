@@ -0,0 +1,148 @@
+package rest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/kianooshaz/skeleton/foundation/derror"
+	"github.com/kianooshaz/skeleton/foundation/export"
+	"github.com/kianooshaz/skeleton/foundation/pagination"
+	"github.com/labstack/echo/v4"
+)
+
+// exportFlushRows is how many rows of an export are written between flushes,
+// so clients receive rows as they are read rather than at the end.
+const exportFlushRows = 100
+
+// registerExportableListHandler is registerListHandler for listings that can
+// also be downloaded. Requests asking for an export format, through the format
+// query parameter or the Accept header, get every row matching the request,
+// paging aside, streamed by download in that format.
+func registerExportableListHandler[T any, S pagination.Listing, R any](
+	policy pagination.Policy,
+	list func(ctx context.Context, req T) (S, error),
+	download func(ctx context.Context, req T, yield func(R) error) error,
+) endpoint {
+	e := registerListHandler(policy, list)
+	listing := e.handler
+
+	e.handler = func(c echo.Context) error {
+		format, ok, err := exportFormat(c.Request())
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return listing(c)
+		}
+
+		var req T
+		if err := bind(c, &req); err != nil {
+			return err
+		}
+
+		return streamExport(c, format, func(yield func(R) error) error {
+			return download(c.Request().Context(), req, yield)
+		})
+	}
+	e.exports = export.Formats()
+
+	return e
+}
+
+// exportFormat returns the export format a request asks for, if any. The
+// format query parameter, which may also be json, takes precedence over the
+// Accept header, of which the first media type naming JSON or an export
+// format counts.
+func exportFormat(r *http.Request) (export.Format, bool, error) {
+	if name := r.URL.Query().Get("format"); name != "" {
+		if strings.EqualFold(name, "json") {
+			return "", false, nil
+		}
+
+		format, ok := export.ParseFormat(name)
+		if !ok {
+			return "", false, derror.ErrInvalidQueryParameter
+		}
+
+		return format, true, nil
+	}
+
+	for _, accepted := range strings.Split(r.Header.Get(echo.HeaderAccept), ",") {
+		mediaType, _, _ := strings.Cut(accepted, ";")
+		mediaType = strings.TrimSpace(mediaType)
+
+		if format, ok := export.FormatOf(mediaType); ok {
+			return format, true, nil
+		}
+		if strings.EqualFold(mediaType, echo.MIMEApplicationJSON) {
+			break
+		}
+	}
+
+	return "", false, nil
+}
+
+// streamExport writes the rows passed by stream to the response as a file in
+// format. The headers are sent along with the first row, so an error before it
+// is answered as usual; an error after it cuts the file short.
+func streamExport[R any](c echo.Context, format export.Format, stream func(yield func(R) error) error) error {
+	res := c.Response()
+	writer := export.NewWriter[R](res, format)
+
+	commit := func() {
+		res.Header().Set(echo.HeaderContentType, format.MediaType())
+		res.Header().Set(echo.HeaderContentDisposition,
+			fmt.Sprintf("attachment; filename=%q", exportName(c.Path())+"."+string(format)))
+		res.WriteHeader(http.StatusOK)
+	}
+
+	rows := 0
+	err := stream(func(row R) error {
+		if rows == 0 {
+			commit()
+		}
+
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+
+		rows++
+		if rows%exportFlushRows == 0 {
+			if err := writer.Flush(); err != nil {
+				return err
+			}
+			res.Flush()
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		commit()
+	}
+
+	return writer.Close()
+}
+
+// exportName names the files exported from a route after its static path
+// segments, as audit-records for /v1/audit/records.
+func exportName(path string) string {
+	var segments []string
+	for _, segment := range strings.Split(path, "/") {
+		if segment == "" || strings.HasPrefix(segment, ":") || versionSegment.MatchString(segment) {
+			continue
+		}
+		segments = append(segments, segment)
+	}
+
+	if len(segments) == 0 {
+		return "export"
+	}
+
+	return strings.Join(segments, "-")
+}
@@ -0,0 +1,18 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/kianooshaz/skeleton/internal/app/web/protocol"
+	"github.com/labstack/echo/v4"
+)
+
+// Handler returns the HTTP handler of a web service created by New.
+func Handler(ws protocol.WebService) http.Handler {
+	return ws.(*server).core
+}
+
+// Routes returns the routes registered on a web service created by New.
+func Routes(ws protocol.WebService) []*echo.Route {
+	return ws.(*server).core.Routes()
+}
@@ -3,30 +3,172 @@ package rest
 import (
 	"context"
 	"net/http"
+	"reflect"
+	"slices"
 
+	"github.com/kianooshaz/skeleton/foundation/derror"
+	"github.com/kianooshaz/skeleton/foundation/export"
+	"github.com/kianooshaz/skeleton/foundation/pagination"
 	"github.com/labstack/echo/v4"
 )
 
-func registerHandler[T any, S any](handler func(ctx context.Context, req T) (S, error)) echo.HandlerFunc {
-	return func(c echo.Context) error {
+// endpoint is a handler along with what it binds, answers and may fail with,
+// for the route registering it to be documented.
+type endpoint struct {
+	handler  echo.HandlerFunc
+	request  reflect.Type
+	response reflect.Type
+	status   int
+	errors   []error
+	// exports are the formats the response may also be downloaded in.
+	exports []export.Format
+	// events answers a stream of Server-Sent Events, each carrying a response.
+	events bool
+}
+
+// bindErrors are the errors of binding and validating a request.
+var bindErrors = []error{
+	derror.ErrInvalidJsonFormat,
+	derror.ErrInvalidQueryParameter,
+	derror.ErrInvalidID,
+	derror.ErrInvalidRequest,
+}
+
+// listErrors are the errors of binding the paging and ordering of a listing.
+var listErrors = []error{
+	derror.ErrInvalidPage,
+	derror.ErrInvalidRows,
+	derror.ErrPageValueTooSmall,
+	derror.ErrRowsValueTooSmall,
+	derror.ErrRowsValueTooLarge,
+	derror.ErrInvalidCursor,
+	derror.ErrUnknownOrder,
+	derror.ErrUnknownOrderDirection,
+	derror.ErrUnknownStatusFlag,
+}
+
+// bind binds req from the request of c, then checks its validate tags and its
+// Validate method, if any.
+func bind(c echo.Context, req any) error {
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	return c.Validate(req)
+}
+
+func registerHandler[T any, S any](handler func(ctx context.Context, req T) (S, error)) endpoint {
+	fn := func(c echo.Context) error {
+		var req T
+		if err := bind(c, &req); err != nil {
+			return err
+		}
+
+		res, err := handler(c.Request().Context(), req)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, res)
+	}
+
+	return endpoint{
+		handler:  fn,
+		request:  reflect.TypeFor[T](),
+		response: reflect.TypeFor[S](),
+		status:   http.StatusOK,
+		errors:   bindErrors,
+	}
+}
+
+// registerListHandler is registerHandler for paginated listings. The paging
+// parameters are bound under policy before the rest of the request, and the
+// response carries RFC 8288 Link headers to the neighbouring pages.
+func registerListHandler[T any, S pagination.Listing](
+	policy pagination.Policy,
+	handler func(ctx context.Context, req T) (S, error),
+) endpoint {
+	fn := func(c echo.Context) error {
+		page, err := policy.Bind(c.QueryParams())
+		if err != nil {
+			return err
+		}
+
 		var req T
-		if err := c.Bind(&req); err != nil {
+		if err := bind(c, &req); err != nil {
 			return err
 		}
 
+		if pageable, ok := any(&req).(pagination.Pageable); ok {
+			*pageable.Paging() = page
+		}
+
 		res, err := handler(c.Request().Context(), req)
 		if err != nil {
 			return err
 		}
 
+		if links := pagination.Links(c.Request().URL, res.CurrentPage(), res.CurrentNavigation()); links != "" {
+			c.Response().Header().Add("Link", links)
+		}
+
 		return c.JSON(http.StatusOK, res)
 	}
+
+	return endpoint{
+		handler:  fn,
+		request:  reflect.TypeFor[T](),
+		response: reflect.TypeFor[S](),
+		status:   http.StatusOK,
+		errors:   append(slices.Clone(bindErrors), listErrors...),
+	}
+}
+
+// registerCreateHandler is registerHandler for routes creating a resource; it
+// answers 201 Created.
+func registerCreateHandler[T any, S any](handler func(ctx context.Context, req T) (S, error)) endpoint {
+	fn := func(c echo.Context) error {
+		var req T
+		if err := bind(c, &req); err != nil {
+			return err
+		}
+
+		res, err := handler(c.Request().Context(), req)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusCreated, res)
+	}
+
+	return endpoint{
+		handler:  fn,
+		request:  reflect.TypeFor[T](),
+		response: reflect.TypeFor[S](),
+		status:   http.StatusCreated,
+		errors:   bindErrors,
+	}
 }
 
-func registerHandlerNoResponse[T any](handler func(ctx context.Context, req T) error) echo.HandlerFunc {
-	return func(c echo.Context) error {
+// registerCreateHandlerNoRequest is registerCreateHandler for resources created
+// without input.
+func registerCreateHandlerNoRequest[S any](handler func(ctx context.Context) (S, error)) endpoint {
+	fn := func(c echo.Context) error {
+		res, err := handler(c.Request().Context())
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusCreated, res)
+	}
+
+	return endpoint{handler: fn, response: reflect.TypeFor[S](), status: http.StatusCreated}
+}
+
+func registerHandlerNoResponse[T any](handler func(ctx context.Context, req T) error) endpoint {
+	fn := func(c echo.Context) error {
 		var req T
-		if err := c.Bind(&req); err != nil {
+		if err := bind(c, &req); err != nil {
 			return err
 		}
 
@@ -35,12 +177,14 @@ func registerHandlerNoResponse[T any](handler func(ctx context.Context, req T) e
 			return err
 		}
 
-		return c.NoContent(http.StatusOK)
+		return c.NoContent(http.StatusNoContent)
 	}
+
+	return endpoint{handler: fn, request: reflect.TypeFor[T](), status: http.StatusNoContent, errors: bindErrors}
 }
 
-func registerHandlerNoRequest[T any, S any](handler func(ctx context.Context) (S, error)) echo.HandlerFunc {
-	return func(c echo.Context) error {
+func registerHandlerNoRequest[S any](handler func(ctx context.Context) (S, error)) endpoint {
+	fn := func(c echo.Context) error {
 		res, err := handler(c.Request().Context())
 		if err != nil {
 			return err
@@ -48,16 +192,19 @@ func registerHandlerNoRequest[T any, S any](handler func(ctx context.Context) (S
 
 		return c.JSON(http.StatusOK, res)
 	}
+
+	return endpoint{handler: fn, response: reflect.TypeFor[S](), status: http.StatusOK}
 }
 
-func registerHandlerNoRequestNoResponse(handler func(ctx context.Context) error) echo.HandlerFunc {
-	return func(c echo.Context) error {
+func registerHandlerNoRequestNoResponse(handler func(ctx context.Context) error) endpoint {
+	fn := func(c echo.Context) error {
 		err := handler(c.Request().Context())
 		if err != nil {
 			return err
 		}
 
-		return c.NoContent(http.StatusOK)
+		return c.NoContent(http.StatusNoContent)
 	}
 
+	return endpoint{handler: fn, status: http.StatusNoContent}
 }
@@ -3,11 +3,37 @@ package rest
 import (
 	"net/http"
 
+	"github.com/kianooshaz/skeleton/foundation/lifecycle"
 	"github.com/labstack/echo/v4"
 )
 
-func HealthCheck(c echo.Context) error {
-	return c.JSON(http.StatusOK, map[string]string{
-		"status": "UP",
-	})
+// HealthResponse reports whether the server is up, along with the state of
+// the components of the application.
+type HealthResponse struct {
+	Status     string             `json:"status"`
+	Components []lifecycle.Status `json:"components,omitempty"`
+}
+
+// healthCheck reports that the server is up, whatever the state of the components.
+func (s *server) healthCheck(c echo.Context) error {
+	return c.JSON(http.StatusOK, HealthResponse{Status: "UP", Components: s.components()})
+}
+
+// readinessCheck reports whether every component is running, answering 503
+// Service Unavailable otherwise so load balancers hold traffic back.
+func (s *server) readinessCheck(c echo.Context) error {
+	components := s.components()
+	if !lifecycle.Ready(components) {
+		return c.JSON(http.StatusServiceUnavailable, HealthResponse{Status: "DOWN", Components: components})
+	}
+
+	return c.JSON(http.StatusOK, HealthResponse{Status: "UP", Components: components})
+}
+
+func (s *server) components() []lifecycle.Status {
+	if s.health == nil {
+		return nil
+	}
+
+	return s.health.Statuses()
 }
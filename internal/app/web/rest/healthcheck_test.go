@@ -0,0 +1,109 @@
+package rest_test
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kianooshaz/skeleton/foundation/lifecycle"
+	"github.com/kianooshaz/skeleton/internal/app/web/rest"
+	usernameproto "github.com/kianooshaz/skeleton/services/account/username/proto"
+	lockoutproto "github.com/kianooshaz/skeleton/services/authentication/lockout/proto"
+	passwordproto "github.com/kianooshaz/skeleton/services/authentication/password/proto"
+	tokenproto "github.com/kianooshaz/skeleton/services/authentication/token/proto"
+	orgproto "github.com/kianooshaz/skeleton/services/organization/organization/proto"
+	auditproto "github.com/kianooshaz/skeleton/services/risk/audit/proto"
+	birthdayproto "github.com/kianooshaz/skeleton/services/user/birthday/proto"
+	userproto "github.com/kianooshaz/skeleton/services/user/user/proto"
+)
+
+type fakeReporter []lifecycle.Status
+
+func (f fakeReporter) Statuses() []lifecycle.Status {
+	return f
+}
+
+func TestHealthCheck(t *testing.T) {
+	running := fakeReporter{
+		{Name: "postgres", State: lifecycle.StateRunning},
+		{Name: "rest", State: lifecycle.StateRunning},
+	}
+	starting := fakeReporter{
+		{Name: "postgres", State: lifecycle.StateRunning},
+		{Name: "rest", State: lifecycle.StateStarting},
+	}
+
+	tests := []struct {
+		name       string
+		reporter   lifecycle.Reporter
+		target     string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "live without components",
+			target:     "/health",
+			wantStatus: http.StatusOK,
+			wantBody:   `{"status":"UP"}`,
+		},
+		{
+			name:       "live while starting",
+			reporter:   starting,
+			target:     "/health",
+			wantStatus: http.StatusOK,
+			wantBody: `{"status":"UP","components":[` +
+				`{"name":"postgres","state":"running"},{"name":"rest","state":"starting"}]}`,
+		},
+		{
+			name:       "ready",
+			reporter:   running,
+			target:     "/health/ready",
+			wantStatus: http.StatusOK,
+			wantBody: `{"status":"UP","components":[` +
+				`{"name":"postgres","state":"running"},{"name":"rest","state":"running"}]}`,
+		},
+		{
+			name:       "not ready while starting",
+			reporter:   starting,
+			target:     "/health/ready",
+			wantStatus: http.StatusServiceUnavailable,
+			wantBody: `{"status":"DOWN","components":[` +
+				`{"name":"postgres","state":"running"},{"name":"rest","state":"starting"}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ws, err := rest.New(
+				rest.Config{},
+				slog.New(slog.NewTextHandler(io.Discard, nil)),
+				nil,
+				nil,
+				tt.reporter,
+				struct{ userproto.UserService }{},
+				struct{ orgproto.OrganizationService }{},
+				struct{ passwordproto.PasswordService }{},
+				struct{ usernameproto.UsernameService }{},
+				struct{ auditproto.AuditService }{},
+				struct{ birthdayproto.BirthdayService }{},
+				struct{ tokenproto.TokenService }{},
+				struct{ lockoutproto.LockoutService }{},
+			)
+			require.NoError(t, err)
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			rec := httptest.NewRecorder()
+
+			// Execute.
+			rest.Handler(ws).ServeHTTP(rec, req)
+
+			// Assert.
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.JSONEq(t, tt.wantBody, rec.Body.String())
+		})
+	}
+}
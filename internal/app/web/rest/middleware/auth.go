@@ -0,0 +1,234 @@
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kianooshaz/skeleton/foundation/derror"
+	"github.com/kianooshaz/skeleton/foundation/session"
+	"github.com/labstack/echo/v4"
+)
+
+// ErrNoCredentials is returned by an Authenticator when the request carries no
+// credentials of its kind, so the next authenticator is tried.
+var ErrNoCredentials = errors.New("no credentials")
+
+// Authenticator verifies the credentials carried by a request and returns the
+// principal they prove.
+type Authenticator interface {
+	Authenticate(c echo.Context) (session.Principal, error)
+}
+
+// AuthenticatorFunc adapts a function to an Authenticator.
+type AuthenticatorFunc func(c echo.Context) (session.Principal, error)
+
+func (f AuthenticatorFunc) Authenticate(c echo.Context) (session.Principal, error) {
+	return f(c)
+}
+
+// CredentialVerifier checks a credential, such as a token or an API key, and
+// returns the principal it belongs to.
+type CredentialVerifier func(ctx context.Context, credential string) (session.Principal, error)
+
+// BearerToken authenticates requests carrying an "Authorization: Bearer" header.
+func BearerToken(verify CredentialVerifier) Authenticator {
+	return AuthenticatorFunc(func(c echo.Context) (session.Principal, error) {
+		token, ok := strings.CutPrefix(c.Request().Header.Get(echo.HeaderAuthorization), "Bearer ")
+		if !ok || token == "" {
+			return session.Principal{}, ErrNoCredentials
+		}
+
+		return verified(c, verify, token, session.AuthMethodToken)
+	})
+}
+
+// SessionCookie authenticates requests carrying the named session cookie.
+func SessionCookie(name string, verify CredentialVerifier) Authenticator {
+	return AuthenticatorFunc(func(c echo.Context) (session.Principal, error) {
+		cookie, err := c.Cookie(name)
+		if err != nil || cookie.Value == "" {
+			return session.Principal{}, ErrNoCredentials
+		}
+
+		return verified(c, verify, cookie.Value, session.AuthMethodSession)
+	})
+}
+
+// APIKey authenticates requests carrying an API key in the named header.
+func APIKey(header string, verify CredentialVerifier) Authenticator {
+	return AuthenticatorFunc(func(c echo.Context) (session.Principal, error) {
+		key := c.Request().Header.Get(header)
+		if key == "" {
+			return session.Principal{}, ErrNoCredentials
+		}
+
+		return verified(c, verify, key, session.AuthMethodAPIKey)
+	})
+}
+
+// APIKeyConfig holds an API key and the principal it authenticates as.
+type APIKeyConfig struct {
+	Name string `yaml:"name"`
+	// Hash is the hex encoded SHA-256 of the key, so the key itself is not kept
+	// in the configuration.
+	Hash           string   `yaml:"hash"`
+	UserID         string   `yaml:"user_id"`
+	AccountID      string   `yaml:"account_id"`
+	OrganizationID string   `yaml:"organization_id"`
+	Roles          []string `yaml:"roles"`
+	Scopes         []string `yaml:"scopes"`
+}
+
+// StaticAPIKeys verifies API keys against the configured keys.
+func StaticAPIKeys(keys []APIKeyConfig) (CredentialVerifier, error) {
+	principals := make(map[string]session.Principal, len(keys))
+	for _, key := range keys {
+		hash, err := hex.DecodeString(key.Hash)
+		if err != nil || len(hash) != sha256.Size {
+			return nil, fmt.Errorf("api key %s: hash is not a hex encoded SHA-256", key.Name)
+		}
+
+		principal := session.Principal{Roles: key.Roles, Scopes: key.Scopes}
+		ids := []struct {
+			value string
+			id    *uuid.UUID
+		}{
+			{key.UserID, &principal.UserID},
+			{key.AccountID, &principal.AccountID},
+			{key.OrganizationID, &principal.OrganizationID},
+		}
+		for _, id := range ids {
+			if id.value == "" {
+				continue
+			}
+
+			parsed, err := uuid.Parse(id.value)
+			if err != nil {
+				return nil, fmt.Errorf("api key %s: %w", key.Name, err)
+			}
+			*id.id = parsed
+		}
+
+		principals[string(hash)] = principal
+	}
+
+	return func(_ context.Context, credential string) (session.Principal, error) {
+		hash := sha256.Sum256([]byte(credential))
+
+		principal, ok := principals[string(hash[:])]
+		if !ok {
+			return session.Principal{}, derror.ErrUnauthenticated
+		}
+
+		return principal, nil
+	}, nil
+}
+
+func verified(c echo.Context, verify CredentialVerifier, credential string, method session.AuthMethod) (session.Principal, error) {
+	principal, err := verify(c.Request().Context(), credential)
+	if err != nil {
+		return session.Principal{}, err
+	}
+
+	principal.AuthMethod = method
+	if principal.AuthenticatedAt.IsZero() {
+		principal.AuthenticatedAt = time.Now()
+	}
+
+	return principal, nil
+}
+
+// TrustedGateway takes the principal from the X-User-ID, X-Account-ID and
+// X-Organization-ID headers set by a gateway that already authenticated the
+// request. Any client can set these headers, so it must only be enabled when
+// the application is unreachable except through such a gateway.
+func TrustedGateway() Authenticator {
+	return AuthenticatorFunc(func(c echo.Context) (session.Principal, error) {
+		header := c.Request().Header
+		if header.Get("X-User-ID") == "" {
+			return session.Principal{}, ErrNoCredentials
+		}
+
+		principal := session.Principal{
+			AuthMethod:      session.AuthMethodGateway,
+			AuthenticatedAt: time.Now(),
+		}
+
+		ids := []struct {
+			header string
+			id     *uuid.UUID
+		}{
+			{"X-User-ID", &principal.UserID},
+			{"X-Account-ID", &principal.AccountID},
+			{"X-Organization-ID", &principal.OrganizationID},
+		}
+		for _, id := range ids {
+			value := header.Get(id.header)
+			if value == "" {
+				continue
+			}
+
+			parsed, err := uuid.Parse(value)
+			if err != nil {
+				return session.Principal{}, derror.ErrUnauthenticated
+			}
+			*id.id = parsed
+		}
+
+		return principal, nil
+	})
+}
+
+// Authenticate stores the principal proven by the first authenticator that
+// finds credentials in the request. Requests without credentials go through
+// unauthenticated; requests with credentials that fail verification are
+// rejected.
+func Authenticate(logger *slog.Logger, authenticators ...Authenticator) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			for _, authenticator := range authenticators {
+				principal, err := authenticator.Authenticate(c)
+				if errors.Is(err, ErrNoCredentials) {
+					continue
+				}
+				if err != nil {
+					logger.InfoContext(
+						c.Request().Context(),
+						"Rejected request with invalid credentials",
+						slog.String("error", err.Error()),
+						slog.String("ip", c.RealIP()),
+					)
+					return derror.ErrUnauthenticated
+				}
+
+				ctx := session.SetPrincipal(c.Request().Context(), principal)
+				c.SetRequest(c.Request().WithContext(ctx))
+				break
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// RequireAuthentication rejects requests that no authenticator could attribute
+// to a principal. Guests are not authenticated, so they are rejected too.
+func RequireAuthentication() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			principal, ok := session.GetPrincipal(c.Request().Context())
+			if !ok || principal.AuthMethod == session.AuthMethodGuest {
+				return derror.ErrUnauthenticated
+			}
+
+			return next(c)
+		}
+	}
+}
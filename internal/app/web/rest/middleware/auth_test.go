@@ -0,0 +1,177 @@
+package middleware_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kianooshaz/skeleton/foundation/derror"
+	"github.com/kianooshaz/skeleton/foundation/session"
+	"github.com/kianooshaz/skeleton/internal/app/web/rest/middleware"
+	"github.com/labstack/echo/v4"
+)
+
+func TestAuthenticate(t *testing.T) {
+	userID := uuid.New()
+	organizationID := uuid.New()
+
+	verifyToken := func(_ context.Context, token string) (session.Principal, error) {
+		if token != "valid" {
+			return session.Principal{}, errors.New("unknown token")
+		}
+
+		return session.Principal{UserID: userID, Scopes: []string{"users:read"}}, nil
+	}
+
+	tests := []struct {
+		name           string
+		trustedGateway bool
+		headers        map[string]string
+		wantErr        error
+		wantPrincipal  bool
+		wantUserID     uuid.UUID
+		wantOrgID      uuid.UUID
+		wantMethod     session.AuthMethod
+	}{
+		{
+			name: "no credentials",
+		},
+		{
+			name:          "valid token",
+			headers:       map[string]string{"Authorization": "Bearer valid"},
+			wantPrincipal: true,
+			wantUserID:    userID,
+			wantMethod:    session.AuthMethodToken,
+		},
+		{
+			name:    "invalid token",
+			headers: map[string]string{"Authorization": "Bearer forged"},
+			wantErr: derror.ErrUnauthenticated,
+		},
+		{
+			name:    "gateway headers ignored without a trusted gateway",
+			headers: map[string]string{"X-User-ID": userID.String()},
+		},
+		{
+			name:           "gateway headers",
+			trustedGateway: true,
+			headers: map[string]string{
+				"X-User-ID":         userID.String(),
+				"X-Organization-ID": organizationID.String(),
+			},
+			wantPrincipal: true,
+			wantUserID:    userID,
+			wantOrgID:     organizationID,
+			wantMethod:    session.AuthMethodGateway,
+		},
+		{
+			name:           "malformed gateway header",
+			trustedGateway: true,
+			headers:        map[string]string{"X-User-ID": "not-a-uuid"},
+			wantErr:        derror.ErrUnauthenticated,
+		},
+		{
+			name:           "token takes precedence over gateway headers",
+			trustedGateway: true,
+			headers: map[string]string{
+				"Authorization": "Bearer valid",
+				"X-User-ID":     uuid.NewString(),
+			},
+			wantPrincipal: true,
+			wantUserID:    userID,
+			wantMethod:    session.AuthMethodToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authenticators := []middleware.Authenticator{middleware.BearerToken(verifyToken)}
+			if tt.trustedGateway {
+				authenticators = append(authenticators, middleware.TrustedGateway())
+			}
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			c := echo.New().NewContext(req, httptest.NewRecorder())
+
+			var principal session.Principal
+			var ok bool
+			handler := middleware.Authenticate(logger, authenticators...)(func(c echo.Context) error {
+				principal, ok = session.GetPrincipal(c.Request().Context())
+				return nil
+			})
+
+			// Execute.
+			err := handler(c)
+
+			// Assert.
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.wantPrincipal, ok)
+			if !tt.wantPrincipal {
+				return
+			}
+			assert.Equal(t, tt.wantUserID, principal.UserID)
+			assert.Equal(t, tt.wantOrgID, principal.OrganizationID)
+			assert.Equal(t, tt.wantMethod, principal.AuthMethod)
+			assert.False(t, principal.AuthenticatedAt.IsZero())
+		})
+	}
+}
+
+func TestRequireAuthentication(t *testing.T) {
+	handler := middleware.RequireAuthentication()(func(c echo.Context) error {
+		assert.Equal(t, session.AuthMethodToken, session.MustPrincipal(c.Request().Context()).AuthMethod)
+		return nil
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	require.ErrorIs(t, handler(echo.New().NewContext(req, httptest.NewRecorder())), derror.ErrUnauthenticated)
+
+	guest := session.SetPrincipal(req.Context(), session.Principal{UserID: uuid.New(), AuthMethod: session.AuthMethodGuest})
+	require.ErrorIs(t, handler(echo.New().NewContext(req.WithContext(guest), httptest.NewRecorder())),
+		derror.ErrUnauthenticated, "guests are not authenticated")
+
+	ctx := session.SetPrincipal(req.Context(), session.Principal{UserID: uuid.New(), AuthMethod: session.AuthMethodToken})
+	require.NoError(t, handler(echo.New().NewContext(req.WithContext(ctx), httptest.NewRecorder())))
+}
+
+func TestStaticAPIKeys(t *testing.T) {
+	accountID := uuid.New()
+	hash := sha256.Sum256([]byte("key"))
+
+	verify, err := middleware.StaticAPIKeys([]middleware.APIKeyConfig{{
+		Name:      "ops",
+		Hash:      hex.EncodeToString(hash[:]),
+		AccountID: accountID.String(),
+		Roles:     []string{session.RoleAdmin},
+	}})
+	require.NoError(t, err)
+
+	principal, err := verify(context.Background(), "key")
+	require.NoError(t, err)
+	assert.Equal(t, accountID, principal.AccountID)
+	assert.True(t, principal.HasRole(session.RoleAdmin))
+
+	_, err = verify(context.Background(), "other key")
+	require.ErrorIs(t, err, derror.ErrUnauthenticated)
+
+	_, err = middleware.StaticAPIKeys([]middleware.APIKeyConfig{{Name: "plain", Hash: "key"}})
+	require.Error(t, err, "keys must be configured by their hash")
+}
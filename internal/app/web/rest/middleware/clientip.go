@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"github.com/kianooshaz/skeleton/foundation/session"
+	"github.com/labstack/echo/v4"
+)
+
+// ClientIP stores the IP address of the client in the request context, so
+// services can track it without depending on echo.
+func ClientIP() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ctx := session.SetClientIP(c.Request().Context(), c.RealIP())
+			c.SetRequest(c.Request().WithContext(ctx))
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package middleware
+
+import (
+	"expvar"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	HeaderDeprecation = "Deprecation"
+	HeaderSunset      = "Sunset"
+)
+
+// DeprecatedUsage counts the requests served by deprecated versions, keyed by
+// version and client, such as "v1 MobileApp/3.2.0", for tracking which clients
+// are yet to migrate. It is published by expvar as deprecated_api_usage.
+//
+// Clients name themselves, so at most MaxDeprecatedClients keys are kept; the
+// requests of clients seen after that are counted under the version and
+// "other", such as "v1 other".
+var DeprecatedUsage = expvar.NewMap("deprecated_api_usage")
+
+// MaxDeprecatedClients bounds the keys of DeprecatedUsage.
+const MaxDeprecatedClients = 100
+
+// maxClientLength bounds the client names counted in DeprecatedUsage.
+const maxClientLength = 64
+
+// deprecatedClients counts the keys of DeprecatedUsage, as expvar.Map does not.
+var deprecatedClients struct {
+	sync.Mutex
+	count int
+}
+
+// Deprecation describes the deprecation of a version of the API.
+type Deprecation struct {
+	// Since is when the version was deprecated.
+	Since time.Time `yaml:"since"`
+	// Sunset is when the version stops being served, if planned.
+	Sunset time.Time `yaml:"sunset"`
+	// Link points at the documentation of the migration, if any.
+	Link string `yaml:"link"`
+}
+
+// Deprecated marks the responses of a deprecated version with the Deprecation
+// header of RFC 9745, the Sunset header of RFC 8594 and a Link to the migration
+// guide, and counts the request in DeprecatedUsage.
+func Deprecated(version string, deprecation Deprecation) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			header := c.Response().Header()
+			header.Set(HeaderDeprecation, "@"+strconv.FormatInt(deprecation.Since.Unix(), 10))
+			if !deprecation.Sunset.IsZero() {
+				header.Set(HeaderSunset, deprecation.Sunset.UTC().Format(http.TimeFormat))
+			}
+			if deprecation.Link != "" {
+				header.Add("Link", "<"+deprecation.Link+`>; rel="deprecation"; type="text/html"`)
+			}
+
+			countDeprecatedUsage(version, client(c.Request()))
+
+			return next(c)
+		}
+	}
+}
+
+// countDeprecatedUsage counts a request of client to version in
+// DeprecatedUsage, under "other" once MaxDeprecatedClients keys are kept.
+func countDeprecatedUsage(version, client string) {
+	key := version + " " + client
+	if DeprecatedUsage.Get(key) != nil {
+		DeprecatedUsage.Add(key, 1)
+		return
+	}
+
+	deprecatedClients.Lock()
+	defer deprecatedClients.Unlock()
+
+	if DeprecatedUsage.Get(key) == nil {
+		if deprecatedClients.count >= MaxDeprecatedClients {
+			key = version + " other"
+		} else {
+			deprecatedClients.count++
+		}
+	}
+	DeprecatedUsage.Add(key, 1)
+}
+
+// client names the client of a request by the product of its User-Agent, such
+// as MobileApp/3.2.0, which is what tells apart the builds still to migrate.
+func client(req *http.Request) string {
+	product, _, _ := strings.Cut(req.UserAgent(), " ")
+	if product == "" {
+		return "unknown"
+	}
+
+	return product[:min(len(product), maxClientLength)]
+}
@@ -0,0 +1,84 @@
+package middleware_test
+
+import (
+	"expvar"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kianooshaz/skeleton/internal/app/web/rest/middleware"
+	"github.com/labstack/echo/v4"
+)
+
+func TestDeprecated(t *testing.T) {
+	deprecation := middleware.Deprecation{
+		Since:  time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		Sunset: time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC),
+		Link:   "https://example.com/migrate-to-v2",
+	}
+	handler := middleware.Deprecated("v1", deprecation)(func(c echo.Context) error {
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	serve := func(userAgent string) http.Header {
+		req := httptest.NewRequest(http.MethodGet, "/v1/users", nil)
+		req.Header.Set("User-Agent", userAgent)
+		rec := httptest.NewRecorder()
+		require.NoError(t, handler(echo.New().NewContext(req, rec)))
+
+		return rec.Header()
+	}
+
+	header := serve("MobileApp/3.2.0 (iOS 18)")
+	serve("MobileApp/3.2.0 (Android 15)")
+	serve("")
+
+	assert.Equal(t, "@1767225600", header.Get(middleware.HeaderDeprecation))
+	assert.Equal(t, "Wed, 01 Jul 2026 00:00:00 GMT", header.Get(middleware.HeaderSunset))
+	assert.Equal(t, `<https://example.com/migrate-to-v2>; rel="deprecation"; type="text/html"`, header.Get("Link"))
+
+	assert.Equal(t, "2", counted(t, "v1 MobileApp/3.2.0"))
+	assert.Equal(t, "1", counted(t, "v1 unknown"))
+}
+
+func TestDeprecated_ClientsBounded(t *testing.T) {
+	handler := middleware.Deprecated("v0", middleware.Deprecation{})(func(c echo.Context) error {
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	// Execute.
+	for i := range middleware.MaxDeprecatedClients + 10 {
+		req := httptest.NewRequest(http.MethodGet, "/v0/users", nil)
+		req.Header.Set("User-Agent", "Scraper/"+strconv.Itoa(i))
+		require.NoError(t, handler(echo.New().NewContext(req, httptest.NewRecorder())))
+	}
+
+	// Assert.
+	var clients, requests int64
+	middleware.DeprecatedUsage.Do(func(kv expvar.KeyValue) {
+		if !strings.HasSuffix(kv.Key, " other") {
+			clients++
+		}
+		if strings.HasPrefix(kv.Key, "v0 ") {
+			requests += kv.Value.(*expvar.Int).Value()
+		}
+	})
+	assert.LessOrEqual(t, clients, int64(middleware.MaxDeprecatedClients))
+	assert.Equal(t, int64(middleware.MaxDeprecatedClients+10), requests, "every request counted")
+	assert.NotEqual(t, "0", counted(t, "v0 other"))
+}
+
+func counted(t *testing.T, key string) string {
+	t.Helper()
+
+	count := middleware.DeprecatedUsage.Get(key)
+	require.NotNil(t, count, key)
+
+	return count.(*expvar.Int).String()
+}
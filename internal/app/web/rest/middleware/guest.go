@@ -0,0 +1,142 @@
+package middleware
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kianooshaz/skeleton/foundation/derror"
+	"github.com/kianooshaz/skeleton/foundation/session"
+	"github.com/kianooshaz/skeleton/foundation/signed"
+	userproto "github.com/kianooshaz/skeleton/services/user/user/proto"
+	"github.com/labstack/echo/v4"
+)
+
+// GuestConfig holds how guests are issued and recognized.
+type GuestConfig struct {
+	Enable     bool   `yaml:"enable"`
+	CookieName string `yaml:"cookie_name"`
+	// Secret signs the guest cookies. It is required when guests are enabled.
+	Secret string `yaml:"secret"`
+	// MaxAge is how long the cookie of an idle guest lives. It should not
+	// outlive the guest TTL of the user service.
+	MaxAge time.Duration `yaml:"max_age"`
+	// TouchInterval is how often the last visit of a guest is recorded, so not
+	// every request writes to the database.
+	TouchInterval time.Duration `yaml:"touch_interval"`
+	Secure        bool          `yaml:"secure"`
+}
+
+// Guest gives requests that no authenticator attributed to a principal the
+// principal of a guest. The guest is created on the first visit and recognized
+// afterwards by a signed cookie. It must run after Authenticate and the rate
+// limits, and only on the routes that act for a guest, since every anonymous
+// request it sees may create one.
+func Guest(userService userproto.UserService, cfg GuestConfig, logger *slog.Logger) echo.MiddlewareFunc {
+	if cfg.CookieName == "" {
+		cfg.CookieName = "guest"
+	}
+	if cfg.MaxAge == 0 {
+		cfg.MaxAge = 30 * 24 * time.Hour
+	}
+	if cfg.TouchInterval == 0 {
+		cfg.TouchInterval = time.Hour
+	}
+
+	signer := signed.NewSigner(cfg.Secret)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ctx := c.Request().Context()
+			if _, ok := session.GetPrincipal(ctx); ok {
+				return next(c)
+			}
+
+			now := time.Now()
+			id, seenAt, ok := readGuestCookie(c, cfg.CookieName, signer)
+			if ok && now.Sub(seenAt) >= cfg.TouchInterval {
+				err := userService.Touch(ctx, userproto.TouchRequest{ID: id})
+				switch {
+				case err == nil:
+					writeGuestCookie(c, cfg, signer, id, now)
+				case errors.Is(err, derror.ErrUserNotFound), errors.Is(err, derror.ErrUserNotGuest):
+					// The guest was purged or signed up, so the visitor becomes a new guest.
+					ok = false
+				default:
+					return err
+				}
+			}
+
+			if !ok {
+				guest, err := userService.CreateGuest(ctx)
+				if err != nil {
+					logger.ErrorContext(
+						ctx,
+						"Error encountered while creating guest in Guest middleware",
+						slog.String("error", err.Error()),
+					)
+					return derror.ErrInternalSystem
+				}
+
+				id = guest.Data.ID
+				writeGuestCookie(c, cfg, signer, id, guest.Data.LastSeenAt)
+			}
+
+			ctx = session.SetPrincipal(ctx, session.Principal{
+				UserID:          uuid.UUID(id),
+				AuthMethod:      session.AuthMethodGuest,
+				AuthenticatedAt: now,
+			})
+			c.SetRequest(c.Request().WithContext(ctx))
+
+			return next(c)
+		}
+	}
+}
+
+// readGuestCookie returns the guest and the time of its last recorded visit
+// held by a validly signed guest cookie.
+func readGuestCookie(c echo.Context, name string, signer signed.Signer) (userproto.UserID, time.Time, bool) {
+	cookie, err := c.Cookie(name)
+	if err != nil {
+		return userproto.UserID{}, time.Time{}, false
+	}
+
+	value, ok := signer.Verify(cookie.Value)
+	if !ok {
+		return userproto.UserID{}, time.Time{}, false
+	}
+
+	rawID, rawSeenAt, ok := strings.Cut(value, ".")
+	if !ok {
+		return userproto.UserID{}, time.Time{}, false
+	}
+
+	id, err := uuid.Parse(rawID)
+	if err != nil {
+		return userproto.UserID{}, time.Time{}, false
+	}
+
+	seenAt, err := strconv.ParseInt(rawSeenAt, 10, 64)
+	if err != nil {
+		return userproto.UserID{}, time.Time{}, false
+	}
+
+	return userproto.UserID(id), time.Unix(seenAt, 0), true
+}
+
+func writeGuestCookie(c echo.Context, cfg GuestConfig, signer signed.Signer, id userproto.UserID, seenAt time.Time) {
+	c.SetCookie(&http.Cookie{
+		Name:     cfg.CookieName,
+		Value:    signer.Sign(id.String() + "." + strconv.FormatInt(seenAt.Unix(), 10)),
+		Path:     "/",
+		MaxAge:   int(cfg.MaxAge.Seconds()),
+		Secure:   cfg.Secure,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
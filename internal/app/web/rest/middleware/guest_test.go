@@ -0,0 +1,141 @@
+package middleware_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kianooshaz/skeleton/foundation/derror"
+	"github.com/kianooshaz/skeleton/foundation/session"
+	"github.com/kianooshaz/skeleton/foundation/stat"
+	"github.com/kianooshaz/skeleton/internal/app/web/rest/middleware"
+	userproto "github.com/kianooshaz/skeleton/services/user/user/proto"
+	"github.com/labstack/echo/v4"
+)
+
+// fakeUsers keeps the guests it created and counts how often they were touched.
+type fakeUsers struct {
+	userproto.UserService
+
+	guests  map[userproto.UserID]bool
+	touches int
+}
+
+func (f *fakeUsers) CreateGuest(context.Context) (userproto.CreateResponse, error) {
+	id := userproto.UserID(uuid.New())
+	f.guests[id] = true
+
+	return userproto.CreateResponse{Data: userproto.User{ID: id, Status: stat.Guest, LastSeenAt: time.Now()}}, nil
+}
+
+func (f *fakeUsers) Touch(_ context.Context, req userproto.TouchRequest) error {
+	f.touches++
+	if !f.guests[req.ID] {
+		return derror.ErrUserNotFound
+	}
+
+	return nil
+}
+
+func TestGuest(t *testing.T) {
+	cfg := middleware.GuestConfig{
+		Enable:        true,
+		Secret:        "secret",
+		TouchInterval: time.Hour,
+	}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	// serve runs a request carrying cookies through the middleware and returns
+	// the principal it got and the cookie it was given, if any.
+	serve := func(t *testing.T, users *fakeUsers, cfg middleware.GuestConfig, cookies ...*http.Cookie) (session.Principal, *http.Cookie) {
+		t.Helper()
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		for _, cookie := range cookies {
+			req.AddCookie(cookie)
+		}
+		rec := httptest.NewRecorder()
+
+		var principal session.Principal
+		handler := middleware.Guest(users, cfg, logger)(func(c echo.Context) error {
+			principal = session.MustPrincipal(c.Request().Context())
+			return nil
+		})
+		require.NoError(t, handler(echo.New().NewContext(req, rec)))
+
+		issued := rec.Result().Cookies()
+		if len(issued) == 0 {
+			return principal, nil
+		}
+
+		return principal, issued[0]
+	}
+
+	t.Run("first visit issues a guest", func(t *testing.T) {
+		users := &fakeUsers{guests: map[userproto.UserID]bool{}}
+
+		principal, cookie := serve(t, users, cfg)
+
+		require.NotNil(t, cookie)
+		assert.Equal(t, "guest", cookie.Name)
+		assert.True(t, cookie.HttpOnly)
+		assert.Equal(t, session.AuthMethodGuest, principal.AuthMethod)
+		assert.True(t, users.guests[userproto.UserID(principal.UserID)])
+	})
+
+	t.Run("returning guest keeps its identity", func(t *testing.T) {
+		users := &fakeUsers{guests: map[userproto.UserID]bool{}}
+		first, cookie := serve(t, users, cfg)
+
+		again, reissued := serve(t, users, cfg, cookie)
+
+		assert.Equal(t, first.UserID, again.UserID)
+		assert.Nil(t, reissued, "touched less than an interval ago")
+		assert.Len(t, users.guests, 1)
+		assert.Zero(t, users.touches)
+	})
+
+	t.Run("idle guest is touched", func(t *testing.T) {
+		users := &fakeUsers{guests: map[userproto.UserID]bool{}}
+		touchAlways := cfg
+		touchAlways.TouchInterval = time.Nanosecond
+		first, cookie := serve(t, users, touchAlways)
+
+		again, reissued := serve(t, users, touchAlways, cookie)
+
+		assert.Equal(t, first.UserID, again.UserID)
+		assert.NotNil(t, reissued)
+		assert.Equal(t, 1, users.touches)
+	})
+
+	t.Run("purged guest gets a new identity", func(t *testing.T) {
+		users := &fakeUsers{guests: map[userproto.UserID]bool{}}
+		touchAlways := cfg
+		touchAlways.TouchInterval = time.Nanosecond
+		first, cookie := serve(t, users, touchAlways)
+		delete(users.guests, userproto.UserID(first.UserID))
+
+		again, reissued := serve(t, users, touchAlways, cookie)
+
+		assert.NotEqual(t, first.UserID, again.UserID)
+		assert.NotNil(t, reissued)
+	})
+
+	t.Run("forged cookie is ignored", func(t *testing.T) {
+		users := &fakeUsers{guests: map[userproto.UserID]bool{}}
+		forged := &http.Cookie{Name: "guest", Value: uuid.NewString() + ".1700000000.forged"}
+
+		principal, cookie := serve(t, users, cfg, forged)
+
+		require.NotNil(t, cookie)
+		assert.True(t, users.guests[userproto.UserID(principal.UserID)])
+	})
+}
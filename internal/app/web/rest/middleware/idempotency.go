@@ -0,0 +1,258 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/kianooshaz/skeleton/foundation/derror"
+	"github.com/kianooshaz/skeleton/foundation/idempotency"
+	"github.com/kianooshaz/skeleton/foundation/session"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	HeaderIdempotencyKey = "Idempotency-Key"
+	// HeaderIdempotentReplayed marks the responses replayed from an earlier request.
+	HeaderIdempotentReplayed = "Idempotent-Replayed"
+)
+
+// maxIdempotencyKeyLength is the longest idempotency key accepted.
+const maxIdempotencyKeyLength = 255
+
+// IdempotencyConfig holds how the responses of requests made under an
+// idempotency key are kept.
+type IdempotencyConfig struct {
+	Enable bool `yaml:"enable"`
+	// Backend is where the responses are kept: redis (the default), postgres or memory.
+	Backend idempotency.Backend `yaml:"backend"`
+	// TTL is how long a response is replayed to the retries of its request.
+	TTL time.Duration `yaml:"ttl"`
+	// LockTimeout is how long a request holds its key at most. Once it passes,
+	// for instance because the instance handling the request died, the key may
+	// be used again.
+	LockTimeout time.Duration `yaml:"lock_timeout"`
+}
+
+// Idempotency answers retries of mutating requests carrying an Idempotency-Key
+// header with the response of the first request, instead of running them
+// again. Keys belong to the principal of the request, or to its IP address
+// when it has none, so clients cannot replay each other's responses.
+//
+// A retry made while the first request runs is rejected with
+// derror.ErrIdempotencyKeyInUse, and a key reused for a request with another
+// method, URL or body with derror.ErrIdempotencyKeyReused. Responses that do
+// not settle the request, a 5xx status or one of retryableStatuses, are not
+// kept, so the request can be retried. When the store fails the request is
+// handled as if it carried no key.
+//
+// It must run after Authenticate. It runs before Guest, which is only attached
+// to the routes acting for a guest, so the keys of guests are scoped to their
+// IP address like those of anonymous requests.
+func Idempotency(store idempotency.Store, cfg IdempotencyConfig, logger *slog.Logger) echo.MiddlewareFunc {
+	if cfg.TTL == 0 {
+		cfg.TTL = 24 * time.Hour
+	}
+	if cfg.LockTimeout == 0 {
+		cfg.LockTimeout = time.Minute
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			idempotencyKey := req.Header.Get(HeaderIdempotencyKey)
+			if idempotencyKey == "" || !mutating(req.Method) {
+				return next(c)
+			}
+			if !validIdempotencyKey(idempotencyKey) {
+				return derror.ErrInvalidIdempotencyKey
+			}
+
+			body, err := io.ReadAll(req.Body)
+			if err != nil {
+				return err
+			}
+			req.Body = io.NopCloser(bytes.NewReader(body))
+
+			ctx := req.Context()
+			key := "idempotency:" + idempotencyScope(c) + ":" + idempotencyKey
+			fingerprint := requestFingerprint(req, body)
+
+			record, reserved, err := store.Reserve(ctx, key, fingerprint, cfg.LockTimeout)
+			if err != nil {
+				logger.ErrorContext(
+					ctx,
+					"Error encountered while reserving idempotency key",
+					slog.String("error", err.Error()),
+					slog.String("key", key),
+				)
+
+				return next(c)
+			}
+
+			if !reserved {
+				switch {
+				case record.Fingerprint != fingerprint:
+					return derror.ErrIdempotencyKeyReused
+				case record.Response == nil:
+					return derror.ErrIdempotencyKeyInUse
+				default:
+					return replay(c, record.Response)
+				}
+			}
+
+			res := c.Response()
+			before := res.Header().Clone()
+			recorder := &bodyRecorder{ResponseWriter: res.Writer}
+			res.Writer = recorder
+
+			if err := next(c); err != nil {
+				// Handle the error here, so its response is recorded too.
+				c.Error(err)
+			}
+
+			// The client may be gone, but the outcome of its request still
+			// has to be recorded for its retries.
+			ctx = context.WithoutCancel(ctx)
+
+			if res.Status >= http.StatusInternalServerError || retryableStatuses[res.Status] {
+				if err := store.Release(ctx, key); err != nil {
+					logger.ErrorContext(
+						ctx,
+						"Error encountered while releasing idempotency key",
+						slog.String("error", err.Error()),
+						slog.String("key", key),
+					)
+				}
+
+				return nil
+			}
+
+			// Only the headers set by the handler are kept; those set on the way
+			// in, such as the request ID, belong to each request.
+			header := make(map[string][]string)
+			for name, values := range res.Header() {
+				if _, ok := before[name]; !ok && !perRequestHeaders[name] {
+					header[name] = values
+				}
+			}
+
+			err = store.Complete(ctx, key, idempotency.Record{
+				Fingerprint: fingerprint,
+				Response: &idempotency.Response{
+					Status: res.Status,
+					Header: header,
+					Body:   recorder.body.Bytes(),
+				},
+			}, cfg.TTL)
+			if err != nil {
+				logger.ErrorContext(
+					ctx,
+					"Error encountered while storing idempotent response",
+					slog.String("error", err.Error()),
+					slog.String("key", key),
+				)
+			}
+
+			return nil
+		}
+	}
+}
+
+// retryableStatuses are the client error statuses telling the request may
+// succeed when made again, such as when it was throttled, timed out or hit a
+// lock that clears after a cooldown.
+var retryableStatuses = map[int]bool{
+	http.StatusRequestTimeout:  true,
+	http.StatusConflict:        true,
+	http.StatusLocked:          true,
+	http.StatusTooManyRequests: true,
+}
+
+// perRequestHeaders are the headers the middleware of routes set to describe
+// the request rather than its response, which are not replayed.
+var perRequestHeaders = map[string]bool{
+	HeaderRateLimitLimit:     true,
+	HeaderRateLimitRemaining: true,
+	HeaderRateLimitReset:     true,
+	echo.HeaderRetryAfter:    true,
+}
+
+// mutating reports whether requests with method may change state.
+func mutating(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return false
+	default:
+		return true
+	}
+}
+
+// validIdempotencyKey reports whether key is short and made of printable ASCII.
+func validIdempotencyKey(key string) bool {
+	if len(key) > maxIdempotencyKeyLength {
+		return false
+	}
+
+	for i := range len(key) {
+		if key[i] < 0x21 || key[i] > 0x7e {
+			return false
+		}
+	}
+
+	return true
+}
+
+// idempotencyScope returns who the idempotency keys of the request belong to.
+func idempotencyScope(c echo.Context) string {
+	if userID, ok := session.GetUserID(c.Request().Context()); ok {
+		return "user:" + userID.String()
+	}
+	if accountID, ok := session.GetAccountID(c.Request().Context()); ok {
+		return "account:" + accountID.String()
+	}
+
+	return "ip:" + c.RealIP()
+}
+
+// requestFingerprint identifies the method, URL and body of req.
+func requestFingerprint(req *http.Request, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(req.Method + " " + req.URL.RequestURI() + "\n"))
+	hash.Write(body)
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func replay(c echo.Context, response *idempotency.Response) error {
+	header := c.Response().Header()
+	for name, values := range response.Header {
+		header[name] = values
+	}
+	header.Set(HeaderIdempotentReplayed, "true")
+
+	c.Response().WriteHeader(response.Status)
+	_, err := c.Response().Write(response.Body)
+
+	return err
+}
+
+// bodyRecorder keeps a copy of the body written through it.
+type bodyRecorder struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *bodyRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+func (r *bodyRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
@@ -0,0 +1,241 @@
+package middleware_test
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kianooshaz/skeleton/foundation/derror"
+	"github.com/kianooshaz/skeleton/foundation/idempotency"
+	"github.com/kianooshaz/skeleton/foundation/session"
+	"github.com/kianooshaz/skeleton/internal/app/web/rest/middleware"
+	"github.com/labstack/echo/v4"
+)
+
+// idempotentRequest describes a request made to the handler guarded by Idempotency.
+type idempotentRequest struct {
+	method string
+	key    string
+	body   string
+	userID uuid.UUID
+}
+
+func TestIdempotency(t *testing.T) {
+	alice := uuid.New()
+	bob := uuid.New()
+
+	tests := []struct {
+		name string
+		// status is the status the handler answers with, or fails with an
+		// unknown error when it is 500.
+		status      int
+		first       idempotentRequest
+		retry       idempotentRequest
+		wantCalls   int32
+		wantErr     error
+		wantReplay  bool
+		wantStatus  int
+		wantBody    string
+		wantHeaders map[string]string
+	}{
+		{
+			name:        "retry replays the response",
+			status:      http.StatusCreated,
+			first:       idempotentRequest{method: http.MethodPost, key: "k1", body: `{"name":"a"}`, userID: alice},
+			retry:       idempotentRequest{method: http.MethodPost, key: "k1", body: `{"name":"a"}`, userID: alice},
+			wantCalls:   1,
+			wantReplay:  true,
+			wantStatus:  http.StatusCreated,
+			wantBody:    `{"calls":1}`,
+			wantHeaders: map[string]string{"Location": "/widgets/1", "X-Request-ID": "second"},
+		},
+		{
+			name:       "client errors are replayed",
+			status:     http.StatusBadRequest,
+			first:      idempotentRequest{method: http.MethodPost, key: "k1", body: "{}", userID: alice},
+			retry:      idempotentRequest{method: http.MethodPost, key: "k1", body: "{}", userID: alice},
+			wantCalls:  1,
+			wantReplay: true,
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"calls":1}`,
+		},
+		{
+			name:       "server errors are retried",
+			status:     http.StatusInternalServerError,
+			first:      idempotentRequest{method: http.MethodPost, key: "k1", body: "{}", userID: alice},
+			retry:      idempotentRequest{method: http.MethodPost, key: "k1", body: "{}", userID: alice},
+			wantCalls:  2,
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:       "throttled requests are retried",
+			status:     http.StatusTooManyRequests,
+			first:      idempotentRequest{method: http.MethodPost, key: "k1", body: "{}", userID: alice},
+			retry:      idempotentRequest{method: http.MethodPost, key: "k1", body: "{}", userID: alice},
+			wantCalls:  2,
+			wantStatus: http.StatusTooManyRequests,
+			wantBody:   `{"calls":2}`,
+		},
+		{
+			name:       "conflicts are retried",
+			status:     http.StatusConflict,
+			first:      idempotentRequest{method: http.MethodPost, key: "k1", body: "{}", userID: alice},
+			retry:      idempotentRequest{method: http.MethodPost, key: "k1", body: "{}", userID: alice},
+			wantCalls:  2,
+			wantStatus: http.StatusConflict,
+			wantBody:   `{"calls":2}`,
+		},
+		{
+			name:      "key reused with another body",
+			status:    http.StatusCreated,
+			first:     idempotentRequest{method: http.MethodPost, key: "k1", body: `{"name":"a"}`, userID: alice},
+			retry:     idempotentRequest{method: http.MethodPost, key: "k1", body: `{"name":"b"}`, userID: alice},
+			wantCalls: 1,
+			wantErr:   derror.ErrIdempotencyKeyReused,
+		},
+		{
+			name:      "key reused with another method",
+			status:    http.StatusCreated,
+			first:     idempotentRequest{method: http.MethodPost, key: "k1", body: "{}", userID: alice},
+			retry:     idempotentRequest{method: http.MethodPut, key: "k1", body: "{}", userID: alice},
+			wantCalls: 1,
+			wantErr:   derror.ErrIdempotencyKeyReused,
+		},
+		{
+			name:       "keys belong to their principal",
+			status:     http.StatusCreated,
+			first:      idempotentRequest{method: http.MethodPost, key: "k1", body: "{}", userID: alice},
+			retry:      idempotentRequest{method: http.MethodPost, key: "k1", body: "{}", userID: bob},
+			wantCalls:  2,
+			wantStatus: http.StatusCreated,
+			wantBody:   `{"calls":2}`,
+		},
+		{
+			name:       "requests without key run again",
+			status:     http.StatusCreated,
+			first:      idempotentRequest{method: http.MethodPost, body: "{}", userID: alice},
+			retry:      idempotentRequest{method: http.MethodPost, body: "{}", userID: alice},
+			wantCalls:  2,
+			wantStatus: http.StatusCreated,
+			wantBody:   `{"calls":2}`,
+		},
+		{
+			name:       "safe methods are ignored",
+			status:     http.StatusOK,
+			first:      idempotentRequest{method: http.MethodGet, key: "k1", userID: alice},
+			retry:      idempotentRequest{method: http.MethodGet, key: "k1", userID: alice},
+			wantCalls:  2,
+			wantStatus: http.StatusOK,
+			wantBody:   `{"calls":2}`,
+		},
+		{
+			name:      "invalid key",
+			status:    http.StatusCreated,
+			first:     idempotentRequest{method: http.MethodGet, userID: alice},
+			retry:     idempotentRequest{method: http.MethodPost, key: "not valid", body: "{}", userID: alice},
+			wantCalls: 1,
+			wantErr:   derror.ErrInvalidIdempotencyKey,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			handler := func(c echo.Context) error {
+				n := calls.Add(1)
+				if tt.status == http.StatusInternalServerError {
+					return errors.New("boom")
+				}
+
+				c.Response().Header().Set("Location", "/widgets/1")
+				return c.String(tt.status, `{"calls":`+strconv.Itoa(int(n))+`}`)
+			}
+			serve := newIdempotent(handler)
+
+			_, err := serve(tt.first, "first")
+			require.NoError(t, err)
+
+			// Execute.
+			rec, err := serve(tt.retry, "second")
+
+			// Assert.
+			assert.Equal(t, tt.wantCalls, calls.Load())
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantBody != "" {
+				assert.Equal(t, tt.wantBody, rec.Body.String())
+			}
+			if tt.wantReplay {
+				assert.Equal(t, "true", rec.Header().Get(middleware.HeaderIdempotentReplayed))
+			} else {
+				assert.Empty(t, rec.Header().Get(middleware.HeaderIdempotentReplayed))
+			}
+			for name, value := range tt.wantHeaders {
+				assert.Equal(t, value, rec.Header().Get(name), name)
+			}
+		})
+	}
+}
+
+func TestIdempotency_InProgress(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	handler := func(c echo.Context) error {
+		close(started)
+		<-release
+		return c.NoContent(http.StatusCreated)
+	}
+	serve := newIdempotent(handler)
+	request := idempotentRequest{method: http.MethodPost, key: "k1", body: "{}", userID: uuid.New()}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := serve(request, "first")
+		done <- err
+	}()
+	<-started
+
+	// Execute.
+	_, err := serve(request, "second")
+	close(release)
+
+	// Assert.
+	assert.ErrorIs(t, err, derror.ErrIdempotencyKeyInUse)
+	assert.NoError(t, <-done)
+}
+
+// newIdempotent returns a function serving requests with handler behind
+// Idempotency on a memory store. Each request gets the given request ID
+// header, as set by the middleware running before.
+func newIdempotent(handler echo.HandlerFunc) func(r idempotentRequest, requestID string) (*httptest.ResponseRecorder, error) {
+	e := echo.New()
+	store := idempotency.NewMemoryStore()
+	h := middleware.Idempotency(store, middleware.IdempotencyConfig{}, slog.New(slog.NewTextHandler(io.Discard, nil)))(handler)
+
+	return func(r idempotentRequest, requestID string) (*httptest.ResponseRecorder, error) {
+		req := httptest.NewRequest(r.method, "/widgets", strings.NewReader(r.body))
+		if r.key != "" {
+			req.Header.Set(middleware.HeaderIdempotencyKey, r.key)
+		}
+		req = req.WithContext(session.SetPrincipal(req.Context(), session.Principal{UserID: r.userID}))
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.Response().Header().Set(echo.HeaderXRequestID, requestID)
+
+		return rec, h(c)
+	}
+}
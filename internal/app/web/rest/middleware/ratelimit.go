@@ -2,25 +2,77 @@ package middleware
 
 import (
 	"log/slog"
+	"math"
+	"strconv"
 
 	"github.com/kianooshaz/skeleton/foundation/derror"
 	"github.com/kianooshaz/skeleton/foundation/ratelimit"
+	"github.com/kianooshaz/skeleton/foundation/session"
 	"github.com/labstack/echo/v4"
 )
 
-// This middleware enforces rate limiting per user. For each request, a userID is determined: if the user is signed in, their userID is used; otherwise, a guest user is created with status 'guest'. Rate limiting is then applied based on this userID.
-func RateLimit() echo.MiddlewareFunc {
+const (
+	HeaderRateLimitLimit     = "X-RateLimit-Limit"
+	HeaderRateLimitRemaining = "X-RateLimit-Remaining"
+	HeaderRateLimitReset     = "X-RateLimit-Reset"
+)
+
+// RateLimit enforces the named policy. Requests are grouped by the key parts
+// of the policy, taken from the request and the IDs in its context. The
+// windows of organizations in a tier come from the tier's override.
+//
+// Every response carries the X-RateLimit-* headers of the deciding window,
+// with the reset given in seconds. Rejected requests also get a Retry-After
+// header. When the limiter fails the request is let through, so a Redis outage
+// does not take the API down with it.
+func RateLimit(
+	limiter ratelimit.Limiter,
+	name string,
+	policy ratelimit.Policy,
+	tiers ratelimit.Tiers,
+	logger *slog.Logger,
+) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
+			ctx := c.Request().Context()
+			identity := requestIdentity(c)
+			key := policy.KeyFor(name, identity)
+
+			windows := policy.Windows
+			if identity.Organization != "" && len(policy.Tiers) > 0 {
+				tier, err := tiers.Tier(ctx, identity.Organization)
+				if err != nil {
+					logger.ErrorContext(
+						ctx,
+						"Error encountered while finding organization tier for rate limit",
+						slog.String("error", err.Error()),
+						slog.String("organization_id", identity.Organization),
+					)
+				}
+				windows = policy.WindowsFor(tier)
+			}
 
-			// TODO: Replace c.RealIP() with logic to extract userID if signed in, otherwise assign/create a guest user with status 'guest'.
-			allow, err := ratelimit.SlidingWindowRateLimiter.Allow(c.Request().Context(), c.RealIP())
+			result, err := limiter.Take(ctx, key, policy.CostOrDefault(), windows...)
 			if err != nil {
-				slog.Error("failed to check rate limit", slog.String("error", err.Error()), slog.String("ip", c.RealIP()))
-				return err
+				logger.ErrorContext(
+					ctx,
+					"Error encountered while checking rate limit",
+					slog.String("error", err.Error()),
+					slog.String("key", key),
+				)
+
+				return next(c)
 			}
 
-			if !allow {
+			reset := strconv.Itoa(int(math.Ceil(result.Reset.Seconds())))
+
+			header := c.Response().Header()
+			header.Set(HeaderRateLimitLimit, strconv.Itoa(result.Limit))
+			header.Set(HeaderRateLimitRemaining, strconv.Itoa(result.Remaining))
+			header.Set(HeaderRateLimitReset, reset)
+
+			if !result.Allowed {
+				header.Set(echo.HeaderRetryAfter, reset)
 				return derror.ErrRateLimitExceeded
 			}
 
@@ -28,3 +80,24 @@ func RateLimit() echo.MiddlewareFunc {
 		}
 	}
 }
+
+func requestIdentity(c echo.Context) ratelimit.Identity {
+	ctx := c.Request().Context()
+
+	identity := ratelimit.Identity{
+		IP:    c.RealIP(),
+		Route: c.Request().Method + " " + c.Path(),
+	}
+
+	if userID, ok := session.GetUserID(ctx); ok {
+		identity.User = userID.String()
+	}
+	if accountID, ok := session.GetAccountID(ctx); ok {
+		identity.Account = accountID.String()
+	}
+	if organizationID, ok := session.GetOrganizationID(ctx); ok {
+		identity.Organization = organizationID.String()
+	}
+
+	return identity
+}
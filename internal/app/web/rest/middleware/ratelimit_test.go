@@ -0,0 +1,180 @@
+package middleware_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kianooshaz/skeleton/foundation/derror"
+	"github.com/kianooshaz/skeleton/foundation/ratelimit"
+	"github.com/kianooshaz/skeleton/foundation/session"
+	"github.com/kianooshaz/skeleton/internal/app/web/rest/middleware"
+	"github.com/labstack/echo/v4"
+)
+
+// fakeRedis answers the sliding window script with a fixed reply and remembers the keys and arguments it was called with.
+type fakeRedis struct {
+	redis.Cmdable
+	reply []any
+	err   error
+	keys  []string
+	args  []any
+}
+
+func (f *fakeRedis) Eval(ctx context.Context, _ string, keys []string, args ...any) *redis.Cmd {
+	f.keys, f.args = keys, args
+
+	cmd := redis.NewCmd(ctx)
+	if f.err != nil {
+		cmd.SetErr(f.err)
+		return cmd
+	}
+	cmd.SetVal(f.reply)
+
+	return cmd
+}
+
+func TestRateLimit(t *testing.T) {
+	userID := uuid.New()
+	organizationID := uuid.New()
+	now := time.Now().UnixMilli()
+
+	policy := ratelimit.Policy{
+		Key:     []ratelimit.KeyPart{ratelimit.KeyUser},
+		Cost:    2,
+		Windows: []ratelimit.Window{{Limit: 10, Period: time.Minute}},
+		Tiers: map[string][]ratelimit.Window{
+			"enterprise": {{Limit: 100, Period: time.Minute}, {Limit: 1000, Period: time.Hour}},
+		},
+	}
+	tiers := ratelimit.StaticTiers{organizationID.String(): "enterprise"}
+
+	tests := []struct {
+		name           string
+		redis          *fakeRedis
+		userID         *uuid.UUID
+		organizationID *uuid.UUID
+		wantErr        error
+		wantKeys       []string
+		wantWindows    []any
+		wantLimit      string
+		wantRemaining  string
+		wantReset      string
+		wantRetry      string
+	}{
+		{
+			name:          "anonymous keyed by ip",
+			redis:         &fakeRedis{reply: []any{int64(1), int64(4), now}},
+			wantKeys:      []string{"{ratelimit:test:ip=192.0.2.1}:60000"},
+			wantWindows:   []any{int64(60000), 10},
+			wantLimit:     "10",
+			wantRemaining: "6",
+			wantReset:     "60",
+		},
+		{
+			name:          "signed in keyed by user",
+			redis:         &fakeRedis{reply: []any{int64(1), int64(2), now}},
+			userID:        &userID,
+			wantKeys:      []string{"{ratelimit:test:user=" + userID.String() + "}:60000"},
+			wantWindows:   []any{int64(60000), 10},
+			wantLimit:     "10",
+			wantRemaining: "8",
+			wantReset:     "60",
+		},
+		{
+			name:           "organization tier overrides windows",
+			redis:          &fakeRedis{reply: []any{int64(1), int64(2), now, int64(2), now}},
+			userID:         &userID,
+			organizationID: &organizationID,
+			wantKeys: []string{
+				"{ratelimit:test:user=" + userID.String() + "}:60000",
+				"{ratelimit:test:user=" + userID.String() + "}:3600000",
+			},
+			wantWindows:   []any{int64(60000), 100, int64(3600000), 1000},
+			wantLimit:     "100",
+			wantRemaining: "98",
+			wantReset:     "60",
+		},
+		{
+			name:          "rejected",
+			redis:         &fakeRedis{reply: []any{int64(0), int64(10), now - 45_000}},
+			wantErr:       derror.ErrRateLimitExceeded,
+			wantKeys:      []string{"{ratelimit:test:ip=192.0.2.1}:60000"},
+			wantWindows:   []any{int64(60000), 10},
+			wantLimit:     "10",
+			wantRemaining: "0",
+			wantReset:     "15",
+			wantRetry:     "15",
+		},
+		{
+			name:        "limiter unavailable lets the request through",
+			redis:       &fakeRedis{err: errors.New("connection refused")},
+			wantKeys:    []string{"{ratelimit:test:ip=192.0.2.1}:60000"},
+			wantWindows: []any{int64(60000), 10},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limiter := ratelimit.NewRateLimiter(tt.redis)
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = "192.0.2.1:1234"
+			ctx := req.Context()
+			if tt.userID != nil || tt.organizationID != nil {
+				var principal session.Principal
+				if tt.userID != nil {
+					principal.UserID = *tt.userID
+				}
+				if tt.organizationID != nil {
+					principal.OrganizationID = *tt.organizationID
+				}
+				ctx = session.SetPrincipal(ctx, principal)
+			}
+			req = req.WithContext(ctx)
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(req, rec)
+
+			called := false
+			handler := middleware.RateLimit(limiter, "test", policy, tiers, logger)(func(c echo.Context) error {
+				called = true
+				return c.NoContent(http.StatusOK)
+			})
+
+			// Execute.
+			err := handler(c)
+
+			// Assert.
+			assert.Equal(t, tt.wantKeys, tt.redis.keys)
+			// The script gets the time, the cost and a member prefix before the windows.
+			require.Len(t, tt.redis.args, 3+len(tt.wantWindows))
+			assert.Equal(t, 2, tt.redis.args[1])
+			assert.Equal(t, tt.wantWindows, tt.redis.args[3:])
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				assert.False(t, called)
+			} else {
+				require.NoError(t, err)
+				assert.True(t, called)
+			}
+
+			header := rec.Header()
+			assert.Equal(t, tt.wantLimit, header.Get(middleware.HeaderRateLimitLimit))
+			assert.Equal(t, tt.wantRemaining, header.Get(middleware.HeaderRateLimitRemaining))
+			assert.Equal(t, tt.wantReset, header.Get(middleware.HeaderRateLimitReset))
+			assert.Equal(t, tt.wantRetry, header.Get(echo.HeaderRetryAfter))
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"github.com/google/uuid"
+	"github.com/kianooshaz/skeleton/foundation/session"
+	"github.com/labstack/echo/v4"
+)
+
+// maxRequestIDLength bounds the request IDs taken from upstream, which end up
+// in every log line and audit record of the request.
+const maxRequestIDLength = 128
+
+// RequestID stores the ID of the request in its context and echoes it in the
+// X-Request-ID response header. The ID is taken from the first of the trusted
+// headers set by an upstream proxy or service; otherwise a new one is generated.
+func RequestID(trustedHeaders ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			var id string
+			for _, header := range trustedHeaders {
+				if value := c.Request().Header.Get(header); ValidRequestID(value) {
+					id = value
+					break
+				}
+			}
+			if id == "" {
+				id = uuid.NewString()
+			}
+
+			ctx := session.SetRequestID(c.Request().Context(), id)
+			c.SetRequest(c.Request().WithContext(ctx))
+			c.Response().Header().Set(session.RequestIDHeader, id)
+
+			return next(c)
+		}
+	}
+}
+
+// ValidRequestID reports whether id is short and made of characters that are
+// safe to log and to forward in headers.
+func ValidRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+
+	for _, r := range id {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '-', r == '_', r == '.', r == ':':
+		default:
+			return false
+		}
+	}
+
+	return true
+}
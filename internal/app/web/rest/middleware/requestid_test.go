@@ -0,0 +1,83 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kianooshaz/skeleton/foundation/session"
+	"github.com/kianooshaz/skeleton/internal/app/web/rest/middleware"
+	"github.com/labstack/echo/v4"
+)
+
+func TestRequestID(t *testing.T) {
+	tests := []struct {
+		name           string
+		trustedHeaders []string
+		headers        map[string]string
+		wantID         string
+	}{
+		{
+			name: "generated without a header",
+		},
+		{
+			name:    "untrusted header is ignored",
+			headers: map[string]string{"X-Request-ID": "upstream-id"},
+		},
+		{
+			name:           "trusted header",
+			trustedHeaders: []string{"X-Request-ID"},
+			headers:        map[string]string{"X-Request-ID": "upstream-id"},
+			wantID:         "upstream-id",
+		},
+		{
+			name:           "first trusted header present wins",
+			trustedHeaders: []string{"X-Amzn-Trace-Id", "X-Request-ID"},
+			headers:        map[string]string{"X-Request-ID": "upstream-id"},
+			wantID:         "upstream-id",
+		},
+		{
+			name:           "unsafe characters are rejected",
+			trustedHeaders: []string{"X-Request-ID"},
+			headers:        map[string]string{"X-Request-ID": "id\nforged log line"},
+		},
+		{
+			name:           "overlong ID is rejected",
+			trustedHeaders: []string{"X-Request-ID"},
+			headers:        map[string]string{"X-Request-ID": strings.Repeat("a", 129)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+
+			var id string
+			handler := middleware.RequestID(tt.trustedHeaders...)(func(c echo.Context) error {
+				id = session.GetRequestID(c.Request().Context())
+				return nil
+			})
+
+			// Execute.
+			require.NoError(t, handler(echo.New().NewContext(req, rec)))
+
+			// Assert.
+			assert.Equal(t, id, rec.Header().Get(session.RequestIDHeader))
+			if tt.wantID != "" {
+				assert.Equal(t, tt.wantID, id)
+				return
+			}
+			_, err := uuid.Parse(id)
+			assert.NoError(t, err, "generated ID")
+		})
+	}
+}
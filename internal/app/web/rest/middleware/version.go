@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"mime"
+	"slices"
+	"strings"
+
+	"github.com/kianooshaz/skeleton/foundation/derror"
+	"github.com/labstack/echo/v4"
+)
+
+// Version routes requests to unversioned paths, such as /users, to a version of
+// the API, such as /v1/users. The version is taken from the version parameter
+// of the Accept header, as in application/json; version=2, and defaults to
+// fallback. Paths already starting with a version are left alone, as are the
+// unversioned ones. Requests asking for a version not in versions fail with
+// derror.ErrUnsupportedVersion.
+//
+// It rewrites the path before routing, so it is to be added with echo.Pre.
+func Version(versions []string, fallback string, unversioned func(path string) bool) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			if first, _, _ := strings.Cut(strings.TrimPrefix(req.URL.Path, "/"), "/"); slices.Contains(versions, first) {
+				return next(c)
+			}
+			if unversioned(req.URL.Path) {
+				return next(c)
+			}
+
+			c.Response().Header().Add(echo.HeaderVary, echo.HeaderAccept)
+
+			version, ok := acceptedVersion(req.Header.Get(echo.HeaderAccept))
+			if !ok {
+				version = fallback
+			}
+			if !slices.Contains(versions, version) {
+				return derror.ErrUnsupportedVersion
+			}
+
+			req.URL.Path = "/" + version + req.URL.Path
+			if req.URL.RawPath != "" {
+				req.URL.RawPath = "/" + version + req.URL.RawPath
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// acceptedVersion returns the version parameter of the first media range of an
+// Accept header carrying one, as v2 for both version=2 and version=v2.
+func acceptedVersion(accept string) (string, bool) {
+	for _, mediaRange := range strings.Split(accept, ",") {
+		_, params, err := mime.ParseMediaType(mediaRange)
+		if err != nil {
+			continue
+		}
+
+		if version, ok := params["version"]; ok && version != "" {
+			return "v" + strings.TrimPrefix(version, "v"), true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,89 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kianooshaz/skeleton/foundation/derror"
+	"github.com/kianooshaz/skeleton/internal/app/web/rest/middleware"
+	"github.com/labstack/echo/v4"
+)
+
+func TestVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		accept   string
+		wantPath string
+		wantErr  error
+	}{
+		{
+			name:     "unversioned path goes to the fallback",
+			path:     "/users",
+			wantPath: "/v1/users",
+		},
+		{
+			name:     "accept names the version",
+			path:     "/users",
+			accept:   "application/json; version=2",
+			wantPath: "/v2/users",
+		},
+		{
+			name:     "accept names the version with its prefix",
+			path:     "/users",
+			accept:   "text/html, application/json;version=v2",
+			wantPath: "/v2/users",
+		},
+		{
+			name:     "versioned path wins over accept",
+			path:     "/v1/users",
+			accept:   "application/json; version=2",
+			wantPath: "/v1/users",
+		},
+		{
+			name:     "unversioned route is left alone",
+			path:     "/health",
+			accept:   "application/json; version=2",
+			wantPath: "/health",
+		},
+		{
+			name:    "unknown version",
+			path:    "/users",
+			accept:  "application/json; version=9",
+			wantErr: derror.ErrUnsupportedVersion,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.accept != "" {
+				req.Header.Set(echo.HeaderAccept, tt.accept)
+			}
+			c := echo.New().NewContext(req, httptest.NewRecorder())
+
+			var path string
+			handler := middleware.Version([]string{"v1", "v2"}, "v1", func(path string) bool {
+				return path == "/health"
+			})(func(c echo.Context) error {
+				path = c.Request().URL.Path
+				return nil
+			})
+
+			// Execute.
+			err := handler(c)
+
+			// Assert.
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantPath, path)
+		})
+	}
+}
@@ -0,0 +1,347 @@
+// Package openapi builds an OpenAPI 3.1 document from the routes of the REST
+// server. Routes describe themselves with Go types; their parameters, bodies
+// and responses are found by reflecting over the json, param, query and
+// validate tags of those types.
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/kianooshaz/skeleton/foundation/export"
+)
+
+// Version is the OpenAPI version of the documents built by Build.
+const Version = "3.1.0"
+
+// Route describes a route of the server.
+type Route struct {
+	Method string
+	// Path is the route path in echo syntax, such as /users/:id.
+	Path    string
+	Summary string
+	Tags    []string
+	// Request is the type the request is bound into, nil when there is none.
+	Request reflect.Type
+	// Response is the type answered on success, nil when there is no body.
+	Response reflect.Type
+	// Status is the status answered on success.
+	Status int
+	Errors []Error
+	// Authenticated routes need credentials.
+	Authenticated bool
+	Deprecated    bool
+	// ExportFormats are the formats the response may also be downloaded in,
+	// chosen with the format query parameter or the Accept header.
+	ExportFormats []export.Format
+	// Events routes answer a stream of Server-Sent Events, each carrying a
+	// Response as JSON.
+	Events bool
+}
+
+// Error is an error code a route may answer with, along with its status.
+type Error struct {
+	Status int
+	Code   string
+}
+
+// Document is an OpenAPI document.
+type Document struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       Info                `json:"info"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components Components          `json:"components"`
+}
+
+// Info holds the metadata of the API.
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// PathItem holds the operations of a path by lowercase method.
+type PathItem map[string]*Operation
+
+// Operation documents a route.
+type Operation struct {
+	OperationID string                `json:"operationId"`
+	Summary     string                `json:"summary"`
+	Tags        []string              `json:"tags,omitempty"`
+	Parameters  []Parameter           `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]Response   `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+	Deprecated  bool                  `json:"deprecated,omitempty"`
+}
+
+// Parameter is a path or query parameter.
+type Parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required,omitempty"`
+	Schema   *Schema `json:"schema"`
+}
+
+// RequestBody is the JSON body of a request.
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+// Response is a response of an operation.
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// MediaType holds the schema of a body.
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Components holds the schemas referenced by the document.
+type Components struct {
+	Schemas         map[string]*Schema        `json:"schemas"`
+	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+// SecurityScheme is a way of authenticating requests.
+type SecurityScheme struct {
+	Type   string `json:"type"`
+	Scheme string `json:"scheme,omitempty"`
+}
+
+// errorSchema is the component name of the body of error responses.
+const errorSchema = "Error"
+
+// bearerAuth is the name of the security scheme of authenticated routes.
+const bearerAuth = "bearerAuth"
+
+// Build returns the document of routes.
+func Build(info Info, routes []Route) Document {
+	g := newGenerator()
+	g.schemas[errorSchema] = &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"error": {Type: "string", Description: "Error code."},
+			"fields": {
+				Type:        "array",
+				Description: "Rules the fields of an invalid request broke.",
+				Items: &Schema{
+					Type: "object",
+					Properties: map[string]*Schema{
+						"field": {Type: "string"},
+						"rule":  {Type: "string"},
+						"param": {Type: "string"},
+					},
+					Required: []string{"field", "rule"},
+				},
+			},
+		},
+		Required: []string{"error"},
+	}
+
+	doc := Document{
+		OpenAPI: Version,
+		Info:    info,
+		Paths:   make(map[string]PathItem),
+		Components: Components{
+			Schemas:         g.schemas,
+			SecuritySchemes: map[string]SecurityScheme{bearerAuth: {Type: "http", Scheme: "bearer"}},
+		},
+	}
+
+	for _, route := range routes {
+		path := openAPIPath(route.Path)
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = PathItem{}
+		}
+		doc.Paths[path][strings.ToLower(route.Method)] = g.operation(route)
+	}
+
+	return doc
+}
+
+func (g *generator) operation(route Route) *Operation {
+	op := &Operation{
+		OperationID: operationID(route.Method, route.Path),
+		Summary:     route.Summary,
+		Tags:        route.Tags,
+		Responses:   make(map[string]Response),
+		Deprecated:  route.Deprecated,
+	}
+
+	if route.Request != nil {
+		op.Parameters = g.parameters(route.Request)
+		if hasBody(route.Method) && hasJSONFields(route.Request) {
+			op.RequestBody = &RequestBody{
+				Required: true,
+				Content:  map[string]MediaType{"application/json": {Schema: g.schema(route.Request)}},
+			}
+		}
+	}
+
+	success := Response{Description: http.StatusText(route.Status)}
+	switch {
+	case route.Response != nil && route.Events:
+		success.Description = "A stream of events, each carrying the schema as JSON in its data."
+		success.Content = map[string]MediaType{"text/event-stream": {Schema: g.schema(route.Response)}}
+	case route.Response != nil:
+		success.Content = map[string]MediaType{"application/json": {Schema: g.schema(route.Response)}}
+	}
+	if len(route.ExportFormats) > 0 {
+		enum := []any{"json"}
+		if success.Content == nil {
+			success.Content = make(map[string]MediaType)
+		}
+		for _, format := range route.ExportFormats {
+			enum = append(enum, string(format))
+			success.Content[format.MediaType()] = MediaType{Schema: &Schema{
+				Type:        "string",
+				Description: fmt.Sprintf("Every matching row as %s, paging aside.", strings.ToUpper(string(format))),
+			}}
+		}
+
+		op.Parameters = append(op.Parameters, Parameter{
+			Name:   "format",
+			In:     "query",
+			Schema: &Schema{Type: "string", Enum: enum, Description: "Format of the response; it overrides the Accept header."},
+		})
+	}
+	op.Responses[strconv.Itoa(route.Status)] = success
+
+	codes := make(map[int][]string)
+	for _, e := range route.Errors {
+		if !slices.Contains(codes[e.Status], e.Code) {
+			codes[e.Status] = append(codes[e.Status], e.Code)
+		}
+	}
+	for status, statusCodes := range codes {
+		slices.Sort(statusCodes)
+
+		enum := make([]any, 0, len(statusCodes))
+		for _, code := range statusCodes {
+			enum = append(enum, code)
+		}
+
+		op.Responses[strconv.Itoa(status)] = Response{
+			Description: fmt.Sprintf("%s: %s", http.StatusText(status), strings.Join(statusCodes, ", ")),
+			Content: map[string]MediaType{"application/json": {Schema: &Schema{
+				AllOf: []*Schema{
+					{Ref: "#/components/schemas/" + errorSchema},
+					{Properties: map[string]*Schema{"error": {Enum: enum}}},
+				},
+			}}},
+		}
+	}
+
+	if route.Authenticated {
+		op.Security = []map[string][]string{{bearerAuth: {}}}
+	}
+
+	return op
+}
+
+// parameters returns the path, header and query parameters bound into t. Like echo's
+// binder, it looks into embedded structs and untagged struct fields.
+func (g *generator) parameters(t reflect.Type) []Parameter {
+	var params []Parameter
+
+	for _, field := range fields(t) {
+		if name, ok := tagName(field, "param"); ok {
+			params = append(params, Parameter{Name: name, In: "path", Required: true, Schema: g.schema(field.Type)})
+			continue
+		}
+
+		if name, ok := tagName(field, "header"); ok {
+			params = append(params, Parameter{Name: name, In: "header", Schema: g.schema(field.Type)})
+		}
+
+		if name, ok := tagName(field, "query"); ok {
+			params = append(params, Parameter{
+				Name:     name,
+				In:       "query",
+				Required: isRequired(field),
+				Schema:   g.schema(field.Type),
+			})
+			continue
+		}
+
+		if field.Type.Kind() == reflect.Struct && !isBindUnmarshaler(field.Type) && field.Tag.Get("json") == "" {
+			params = append(params, g.parameters(field.Type)...)
+		}
+	}
+
+	return params
+}
+
+// fields returns the exported fields of t, and of its embedded structs in place
+// of them.
+func fields(t reflect.Type) []reflect.StructField {
+	t = indirect(t)
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var all []reflect.StructField
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if field.Anonymous && indirect(field.Type).Kind() == reflect.Struct && field.Tag.Get("json") == "" {
+			all = append(all, fields(field.Type)...)
+			continue
+		}
+		if field.IsExported() {
+			all = append(all, field)
+		}
+	}
+
+	return all
+}
+
+func hasJSONFields(t reflect.Type) bool {
+	for _, field := range fields(t) {
+		if _, ok := jsonName(field); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasBody(method string) bool {
+	return method == http.MethodPost || method == http.MethodPut || method == http.MethodPatch
+}
+
+func tagName(field reflect.StructField, key string) (string, bool) {
+	name, _, _ := strings.Cut(field.Tag.Get(key), ",")
+	return name, name != "" && name != "-"
+}
+
+// openAPIPath turns the echo path parameters of path into OpenAPI ones.
+func openAPIPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if name, ok := strings.CutPrefix(segment, ":"); ok {
+			segments[i] = "{" + name + "}"
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
+
+// operationID derives an ID such as get_users_id from the method and path of a route.
+func operationID(method, path string) string {
+	id := strings.ToLower(method)
+	for _, segment := range strings.Split(path, "/") {
+		segment = strings.TrimPrefix(segment, ":")
+		if segment != "" {
+			id += "_" + segment
+		}
+	}
+
+	return id
+}
@@ -0,0 +1,152 @@
+package openapi_test
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kianooshaz/skeleton/foundation/export"
+	"github.com/kianooshaz/skeleton/foundation/pagination"
+	"github.com/kianooshaz/skeleton/foundation/types"
+	"github.com/kianooshaz/skeleton/internal/app/web/rest/openapi"
+)
+
+type widget struct {
+	Name      string                 `json:"name" validate:"required,min=3"`
+	Color     string                 `json:"color,omitempty" validate:"oneof=red blue"`
+	Weight    types.Nullable[int]    `json:"weight"`
+	Note      types.Optional[string] `json:"note,omitzero"`
+	CreatedAt time.Time              `json:"created_at"`
+	internal  string
+}
+
+type watchWidgetsRequest struct {
+	LastEventID string `header:"Last-Event-ID" query:"last_event_id"`
+}
+
+type updateWidgetRequest struct {
+	ID     string `param:"id" json:"-"`
+	DryRun bool   `query:"dry_run"`
+	widget
+}
+
+func TestBuild(t *testing.T) {
+	document := openapi.Build(openapi.Info{Title: "Widgets", Version: "1"}, []openapi.Route{
+		{
+			Method:   http.MethodPut,
+			Path:     "/widgets/:id",
+			Summary:  "Update a widget",
+			Request:  reflect.TypeFor[updateWidgetRequest](),
+			Response: reflect.TypeFor[pagination.Response[widget]](),
+			Status:   http.StatusOK,
+			Errors: []openapi.Error{
+				{Status: http.StatusBadRequest, Code: "100002"},
+				{Status: http.StatusBadRequest, Code: "100001"},
+				{Status: http.StatusBadRequest, Code: "100002"},
+			},
+			Authenticated: true,
+		},
+		{
+			Method: http.MethodDelete,
+			Path:   "/widgets/:id",
+			Status: http.StatusNoContent,
+		},
+		{
+			Method:        http.MethodGet,
+			Path:          "/widgets",
+			Response:      reflect.TypeFor[pagination.Response[widget]](),
+			Status:        http.StatusOK,
+			ExportFormats: export.Formats(),
+		},
+		{
+			Method:   http.MethodGet,
+			Path:     "/widgets/events",
+			Request:  reflect.TypeFor[watchWidgetsRequest](),
+			Response: reflect.TypeFor[widget](),
+			Status:   http.StatusOK,
+			Events:   true,
+		},
+	})
+
+	assert.Equal(t, openapi.Version, document.OpenAPI)
+	require.Contains(t, document.Paths, "/widgets/{id}")
+	assert.Contains(t, document.Paths["/widgets/{id}"], "delete")
+
+	operation := document.Paths["/widgets/{id}"]["put"]
+	require.NotNil(t, operation)
+	assert.Equal(t, "put_widgets_id", operation.OperationID)
+	assert.Equal(t, []map[string][]string{{"bearerAuth": {}}}, operation.Security)
+
+	t.Run("parameters", func(t *testing.T) {
+		require.Len(t, operation.Parameters, 2)
+		assert.Equal(t, openapi.Parameter{Name: "id", In: "path", Required: true, Schema: &openapi.Schema{Type: "string"}}, operation.Parameters[0])
+		assert.Equal(t, openapi.Parameter{Name: "dry_run", In: "query", Schema: &openapi.Schema{Type: "boolean"}}, operation.Parameters[1])
+	})
+
+	t.Run("request body", func(t *testing.T) {
+		require.NotNil(t, operation.RequestBody)
+		ref := operation.RequestBody.Content["application/json"].Schema.Ref
+		assert.Equal(t, "#/components/schemas/openapi_test.updateWidgetRequest", ref)
+
+		schema := document.Components.Schemas["openapi_test.updateWidgetRequest"]
+		require.NotNil(t, schema)
+		assert.ElementsMatch(t, []string{"name", "color", "weight", "note", "created_at"}, keys(schema.Properties), "path and query fields are left out")
+		assert.Equal(t, []string{"name"}, schema.Required)
+
+		minLength := 3
+		assert.Equal(t, &openapi.Schema{Type: "string", MinLength: &minLength}, schema.Properties["name"])
+		assert.Equal(t, []any{"red", "blue"}, schema.Properties["color"].Enum)
+		assert.Equal(t, []string{"integer", "null"}, schema.Properties["weight"].Type)
+		assert.Equal(t, []string{"string", "null"}, schema.Properties["note"].Type)
+		assert.Equal(t, "date-time", schema.Properties["created_at"].Format)
+	})
+
+	t.Run("generic response", func(t *testing.T) {
+		ref := operation.Responses["200"].Content["application/json"].Schema.Ref
+		assert.Equal(t, "#/components/schemas/pagination.Response_openapi_test.widget", ref)
+		assert.Contains(t, document.Components.Schemas, "openapi_test.widget")
+	})
+
+	t.Run("error codes are grouped by status", func(t *testing.T) {
+		response, ok := operation.Responses["400"]
+		require.True(t, ok)
+		assert.Equal(t, "Bad Request: 100001, 100002", response.Description)
+	})
+
+	t.Run("export formats", func(t *testing.T) {
+		list := document.Paths["/widgets"]["get"]
+		require.NotNil(t, list)
+		require.Len(t, list.Parameters, 1)
+		assert.Equal(t, "format", list.Parameters[0].Name)
+		assert.Equal(t, []any{"json", "csv", "ndjson"}, list.Parameters[0].Schema.Enum)
+		assert.ElementsMatch(t, []string{"application/json", "text/csv", "application/x-ndjson"}, keys(list.Responses["200"].Content))
+	})
+
+	t.Run("events", func(t *testing.T) {
+		watch := document.Paths["/widgets/events"]["get"]
+		require.NotNil(t, watch)
+		assert.ElementsMatch(t, []openapi.Parameter{
+			{Name: "Last-Event-ID", In: "header", Schema: &openapi.Schema{Type: "string"}},
+			{Name: "last_event_id", In: "query", Schema: &openapi.Schema{Type: "string"}},
+		}, watch.Parameters)
+		assert.Equal(t, []string{"text/event-stream"}, keys(watch.Responses["200"].Content))
+	})
+
+	t.Run("no content", func(t *testing.T) {
+		response := document.Paths["/widgets/{id}"]["delete"].Responses["204"]
+		assert.Nil(t, response.Content)
+	})
+}
+
+func keys[V any](m map[string]V) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+
+	return out
+}
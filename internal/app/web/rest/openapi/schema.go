@@ -0,0 +1,268 @@
+package openapi
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kianooshaz/skeleton/foundation/id"
+	"github.com/kianooshaz/skeleton/foundation/stat"
+	"github.com/kianooshaz/skeleton/foundation/types"
+	"github.com/labstack/echo/v4"
+)
+
+// Schema is a JSON Schema, as used by OpenAPI 3.1.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 any                `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Enum                 []any              `json:"enum,omitempty"`
+	Examples             []any              `json:"examples,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+}
+
+var (
+	timeType        = reflect.TypeFor[time.Time]()
+	uuidType        = reflect.TypeFor[uuid.UUID]()
+	rawMessageType  = reflect.TypeFor[json.RawMessage]()
+	statusType      = reflect.TypeFor[stat.Status]()
+	textMarshaler   = reflect.TypeFor[encoding.TextMarshaler]()
+	textUnmarshaler = reflect.TypeFor[encoding.TextUnmarshaler]()
+	bindUnmarshaler = reflect.TypeFor[echo.BindUnmarshaler]()
+
+	idPackage    = reflect.TypeFor[id.Kind]().PkgPath()
+	typesPackage = reflect.TypeFor[types.Nullable[int]]().PkgPath()
+)
+
+// generator builds schemas and keeps those of named structs as components.
+type generator struct {
+	schemas map[string]*Schema
+	names   map[reflect.Type]string
+}
+
+func newGenerator() *generator {
+	return &generator{
+		schemas: make(map[string]*Schema),
+		names:   make(map[reflect.Type]string),
+	}
+}
+
+// schema returns the schema of the JSON encoding of t.
+func (g *generator) schema(t reflect.Type) *Schema {
+	t = indirect(t)
+
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t == uuidType:
+		return &Schema{Type: "string", Format: "uuid"}
+	case t == rawMessageType:
+		return &Schema{}
+	case t == statusType:
+		enum := make([]any, 0)
+		for _, name := range stat.FlagNames() {
+			enum = append(enum, name)
+		}
+		return &Schema{Type: "array", Items: &Schema{Type: "string", Enum: enum}}
+	case t.PkgPath() == idPackage:
+		return idSchema(t)
+	case t.PkgPath() == typesPackage && strings.HasPrefix(t.Name(), "Nullable["):
+		return nullable(g.schema(t.Field(0).Type))
+	case t.PkgPath() == typesPackage && strings.HasPrefix(t.Name(), "Optional["):
+		return g.schema(t.Field(0).Type)
+	case t.Implements(textMarshaler) || isBindUnmarshaler(t):
+		return &Schema{Type: "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Int8, reflect.Int16, reflect.Int32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer", Minimum: new(float64)}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: g.schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schema(t.Elem())}
+	case reflect.Struct:
+		return g.structSchema(t)
+	default:
+		return &Schema{}
+	}
+}
+
+// structSchema returns a reference to the component of a named struct, or the
+// schema itself for anonymous structs.
+func (g *generator) structSchema(t reflect.Type) *Schema {
+	if t.Name() == "" {
+		return g.object(t)
+	}
+
+	name, ok := g.names[t]
+	if !ok {
+		name = componentName(t)
+		g.names[t] = name
+		// Registered before its properties are built, so recursive types end.
+		g.schemas[name] = &Schema{}
+		*g.schemas[name] = *g.object(t)
+	}
+
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+func (g *generator) object(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+
+	for _, field := range fields(t) {
+		name, ok := jsonName(field)
+		if !ok {
+			continue
+		}
+
+		property := g.schema(field.Type)
+		applyValidation(property, field.Tag.Get("validate"))
+		s.Properties[name] = property
+
+		if isRequired(field) {
+			s.Required = append(s.Required, name)
+		}
+	}
+
+	return s
+}
+
+// jsonName returns the name of field in JSON, and false when it is left out.
+// Fields bound from the path or the query string only are left out too.
+func jsonName(field reflect.StructField) (string, bool) {
+	tag, ok := field.Tag.Lookup("json")
+	if tag == "-" {
+		return "", false
+	}
+	if !ok && (field.Tag.Get("param") != "" || field.Tag.Get("query") != "") {
+		return "", false
+	}
+
+	name, _, _ := strings.Cut(tag, ",")
+	if name == "" {
+		name = field.Name
+	}
+
+	return name, true
+}
+
+func isRequired(field reflect.StructField) bool {
+	for _, rule := range strings.Split(field.Tag.Get("validate"), ",") {
+		if rule == "required" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// applyValidation documents the validate rules of a field that JSON Schema can express.
+func applyValidation(s *Schema, tag string) {
+	for _, rule := range strings.Split(tag, ",") {
+		name, arg, _ := strings.Cut(rule, "=")
+		switch name {
+		case "email":
+			s.Format = "email"
+		case "uuid":
+			s.Format = "uuid"
+		case "oneof":
+			for _, value := range strings.Fields(arg) {
+				s.Enum = append(s.Enum, value)
+			}
+		case "min", "max", "gte", "lte":
+			limit, err := strconv.ParseFloat(arg, 64)
+			if err != nil {
+				continue
+			}
+			lower := name == "min" || name == "gte"
+			if s.Type == "string" {
+				length := int(limit)
+				if lower {
+					s.MinLength = &length
+				} else {
+					s.MaxLength = &length
+				}
+				continue
+			}
+			if lower {
+				s.Minimum = &limit
+			} else {
+				s.Maximum = &limit
+			}
+		}
+	}
+}
+
+// idSchema documents an id.ID, giving the prefix of its kind by example.
+func idSchema(t reflect.Type) *Schema {
+	s := &Schema{Type: "string"}
+
+	if example, err := reflect.Zero(t).Interface().(encoding.TextMarshaler).MarshalText(); err == nil {
+		s.Examples = []any{string(example)}
+	}
+
+	return s
+}
+
+// nullable allows null besides the values of s.
+func nullable(s *Schema) *Schema {
+	if typ, ok := s.Type.(string); ok && s.Ref == "" {
+		s.Type = []string{typ, "null"}
+		return s
+	}
+
+	return &Schema{AnyOf: []*Schema{s, {Type: "null"}}}
+}
+
+func isBindUnmarshaler(t reflect.Type) bool {
+	return reflect.PointerTo(t).Implements(bindUnmarshaler) || reflect.PointerTo(t).Implements(textUnmarshaler)
+}
+
+func indirect(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	return t
+}
+
+// packagePath matches the import path qualifying a type in the name of a
+// generic instantiation, such as github.com/org/repo/ in repo.Type.
+var packagePath = regexp.MustCompile(`[\w.\-]+(/[\w.\-]+)*/`)
+
+// componentName names the component of t after its package and type, such as
+// userproto.User or pagination.Response_userproto.User.
+func componentName(t reflect.Type) string {
+	name := packagePath.ReplaceAllString(t.String(), "")
+
+	return strings.NewReplacer("[", "_", "]", "", ",", "_", " ", "", "*", "").Replace(name)
+}
@@ -2,15 +2,28 @@ package rest
 
 import (
 	"context"
+	"errors"
+	"expvar"
+	"fmt"
 	"log/slog"
+	"net"
+	"strings"
 	"time"
 
-	"github.com/kianooshaz/skeleton/foundation/session"
+	"github.com/kianooshaz/skeleton/foundation/idempotency"
+	"github.com/kianooshaz/skeleton/foundation/lifecycle"
+	"github.com/kianooshaz/skeleton/foundation/pagination"
+	"github.com/kianooshaz/skeleton/foundation/ratelimit"
 	"github.com/kianooshaz/skeleton/internal/app/web/protocol"
+	"github.com/kianooshaz/skeleton/internal/app/web/rest/middleware"
+	"github.com/kianooshaz/skeleton/internal/app/web/rest/openapi"
 	usernameproto "github.com/kianooshaz/skeleton/services/account/username/proto"
+	lockoutproto "github.com/kianooshaz/skeleton/services/authentication/lockout/proto"
 	passwordproto "github.com/kianooshaz/skeleton/services/authentication/password/proto"
+	tokenproto "github.com/kianooshaz/skeleton/services/authentication/token/proto"
 	orgproto "github.com/kianooshaz/skeleton/services/organization/organization/proto"
 	auditproto "github.com/kianooshaz/skeleton/services/risk/audit/proto"
+	birthdayproto "github.com/kianooshaz/skeleton/services/user/birthday/proto"
 	userproto "github.com/kianooshaz/skeleton/services/user/user/proto"
 	"github.com/labstack/echo/v4"
 	echomw "github.com/labstack/echo/v4/middleware"
@@ -31,29 +44,111 @@ type Config struct {
 		AllowCredentials bool     `yaml:"allow_credentials"`
 		ExposedHeaders   []string `yaml:"exposed_headers"`
 		MaxAge           int      `yaml:"max_age"`
+	} `yaml:"cors"`
+	RequestID   RequestIDConfig              `yaml:"request_id"`
+	Auth        AuthConfig                   `yaml:"auth"`
+	Guest       middleware.GuestConfig       `yaml:"guest"`
+	RateLimit   RateLimitConfig              `yaml:"rate_limit"`
+	Idempotency middleware.IdempotencyConfig `yaml:"idempotency"`
+	Pagination  PaginationConfig             `yaml:"pagination"`
+	Docs        DocsConfig                   `yaml:"docs"`
+	Versions    VersionsConfig               `yaml:"versions"`
+	Stream      StreamConfig                 `yaml:"stream"`
+}
+
+// RequestIDConfig holds where request IDs may come from.
+type RequestIDConfig struct {
+	// TrustedHeaders are the headers, in order of preference, whose request ID
+	// is kept. Only list headers set by proxies or services in front of the
+	// server; requests without them get a new ID.
+	TrustedHeaders []string `yaml:"trusted_headers"`
+}
+
+// AuthConfig holds how requests are attributed to a principal. Requests
+// authenticate with a bearer token of the token service, or an API key.
+type AuthConfig struct {
+	// APIKeyHeader is the header API keys are read from; it defaults to X-API-Key.
+	APIKeyHeader string `yaml:"api_key_header"`
+	// APIKeys are the keys accepted, each with the principal it authenticates as.
+	APIKeys []middleware.APIKeyConfig `yaml:"api_keys"`
+	// TrustedGateway takes the principal from the X-User-ID, X-Account-ID and
+	// X-Organization-ID headers. Only enable it when every request reaches the
+	// server through a gateway that authenticates it and sets those headers.
+	TrustedGateway bool `yaml:"trusted_gateway"`
+}
+
+// RateLimitConfig holds the rate limit policies of the server. Routes opt into
+// policies by name when they are registered.
+type RateLimitConfig struct {
+	Enable bool `yaml:"enable"`
+	// Backend is where the windows are kept: redis (the default), memory or hybrid.
+	Backend ratelimit.Backend `yaml:"backend"`
+	// Default names the policy applied to every request, on top of the policies of its route.
+	Default  string                      `yaml:"default"`
+	Policies map[string]ratelimit.Policy `yaml:"policies"`
+	// OrganizationTiers maps organization IDs to the tier whose overrides apply to them.
+	OrganizationTiers map[string]string `yaml:"organization_tiers"`
+}
+
+// Validate reports whether every policy can be enforced and the default policy exists.
+func (cfg RateLimitConfig) Validate() error {
+	for name, policy := range cfg.Policies {
+		if err := policy.Validate(); err != nil {
+			return fmt.Errorf("rate limit policy %s: %w", name, err)
+		}
 	}
-	RateLimit struct {
-		Enable   bool          `yaml:"enable"`
-		Rate     float64       `yaml:"rate"`
-		Burst    int           `yaml:"burst"`
-		Duration time.Duration `yaml:"duration"`
+
+	if _, ok := cfg.Policies[cfg.Default]; cfg.Default != "" && !ok {
+		return fmt.Errorf("default rate limit policy %s is not defined", cfg.Default)
 	}
+
+	return nil
+}
+
+// PaginationConfig holds the page size policies of the listed resources.
+type PaginationConfig struct {
+	// Default applies to resources without a policy of their own, and fills the
+	// unset fields of those that have one.
+	Default   pagination.Policy            `yaml:"default"`
+	Resources map[string]pagination.Policy `yaml:"resources"`
 }
 
 type server struct {
-	core    *echo.Echo
-	address string
-	logger  *slog.Logger
+	core       *echo.Echo
+	address    string
+	logger     *slog.Logger
+	pagination PaginationConfig
+	rateLimit  RateLimitConfig
+	limiter    ratelimit.Limiter
+	tiers      ratelimit.Tiers
+	stream     StreamConfig
+	// guest gives a guest principal to the requests of anonymous visitors. It
+	// is only attached to the routes that act for one, so other requests do
+	// not create guests.
+	guest []echo.MiddlewareFunc
+	// health reports the state of the components of the application, if any.
+	health lifecycle.Reporter
+	// closing is closed when the server shuts down, to end the streams it
+	// serves, which would otherwise hold the shutdown up.
+	closing chan struct{}
+	// routes are the routes registered so far, as documented.
+	routes []openapi.Route
 }
 
 func New(
 	cfg Config,
 	logger *slog.Logger,
+	limiter ratelimit.Limiter,
+	idempotencyStore idempotency.Store,
+	health lifecycle.Reporter,
 	userService userproto.UserService,
 	organizationService orgproto.OrganizationService,
 	passwordService passwordproto.PasswordService,
 	usernameService usernameproto.UsernameService,
 	auditService auditproto.AuditService,
+	birthdayService birthdayproto.BirthdayService,
+	tokenService tokenproto.TokenService,
+	lockoutService lockoutproto.LockoutService,
 ) (protocol.WebService, error) {
 	e := echo.New()
 
@@ -65,13 +160,13 @@ func New(
 	e.Server.IdleTimeout = cfg.IdleTimeout
 	e.Server.ErrorLog = slog.NewLogLogger(logger.Handler(), slog.LevelError)
 	e.HTTPErrorHandler = ErrorResponse
+	e.Validator = newRequestValidator()
 
 	// Middlewares
 	e.Use(echomw.Recover())
-	e.Use(echomw.RequestIDWithConfig(echomw.RequestIDConfig{
-		RequestIDHandler: session.SetRequestIDEcho(),
-	}))
+	e.Use(middleware.RequestID(cfg.RequestID.TrustedHeaders...))
 	e.Use(echomw.Secure())
+	e.Use(middleware.ClientIP())
 
 	if cfg.CORS.Enable {
 		e.Use(echomw.CORSWithConfig(echomw.CORSConfig{
@@ -84,31 +179,141 @@ func New(
 		}))
 	}
 
+	if cfg.BodyLimitSize != "" {
+		e.Use(echomw.BodyLimit(cfg.BodyLimitSize))
+	}
+
+	authenticators := []middleware.Authenticator{middleware.BearerToken(tokenService.Verify)}
+	if len(cfg.Auth.APIKeys) > 0 {
+		verifyAPIKey, err := middleware.StaticAPIKeys(cfg.Auth.APIKeys)
+		if err != nil {
+			return nil, err
+		}
+		if cfg.Auth.APIKeyHeader == "" {
+			cfg.Auth.APIKeyHeader = "X-API-Key"
+		}
+		authenticators = append(authenticators, middleware.APIKey(cfg.Auth.APIKeyHeader, verifyAPIKey))
+	}
+	if cfg.Auth.TrustedGateway {
+		logger.Warn("Trusting the principal headers set by the gateway")
+		authenticators = append(authenticators, middleware.TrustedGateway())
+	}
+	e.Use(middleware.Authenticate(logger, authenticators...))
+
+	var guest []echo.MiddlewareFunc
+	if cfg.Guest.Enable {
+		if cfg.Guest.Secret == "" {
+			return nil, errors.New("guest cookies need a secret")
+		}
+		guest = append(guest, middleware.Guest(userService, cfg.Guest, logger))
+	}
+
 	if cfg.RateLimit.Enable {
-		// TODO implement rate limiter with redis and echo
+		if err := cfg.RateLimit.Validate(); err != nil {
+			return nil, err
+		}
 	}
 
-	if cfg.BodyLimitSize != "" {
-		e.Use(echomw.BodyLimit(cfg.BodyLimitSize))
+	if err := cfg.Versions.Validate(); err != nil {
+		return nil, err
 	}
 
 	server := &server{
-		core:    e,
-		address: cfg.Address,
-		logger:  logger,
+		core:       e,
+		address:    cfg.Address,
+		logger:     logger,
+		pagination: cfg.Pagination,
+		rateLimit:  cfg.RateLimit,
+		limiter:    limiter,
+		tiers:      ratelimit.StaticTiers(cfg.RateLimit.OrganizationTiers),
+		stream:     cfg.Stream,
+		guest:      guest,
+		health:     health,
+		closing:    make(chan struct{}),
+	}
+	e.Server.RegisterOnShutdown(func() { close(server.closing) })
+
+	if cfg.RateLimit.Default != "" {
+		e.Use(server.rateLimited(cfg.RateLimit.Default)...)
+	}
+
+	if cfg.Idempotency.Enable {
+		if idempotencyStore == nil {
+			return nil, errors.New("idempotency keys need a store")
+		}
+		e.Use(middleware.Idempotency(idempotencyStore, cfg.Idempotency, logger))
 	}
 
 	server.registerRoutes(
+		cfg.Versions,
 		userService,
 		organizationService,
 		passwordService,
 		usernameService,
 		auditService,
+		birthdayService,
+		tokenService,
+		lockoutService,
 	)
 
+	if cfg.Docs.Enable {
+		server.registerDocs(cfg.Docs)
+	}
+
+	if cfg.Debug {
+		e.GET("/debug/vars", echo.WrapHandler(expvar.Handler()))
+	}
+
+	// Unversioned paths are those of the routes registered outside versions;
+	// the others go to a version of the API.
+	unversioned := make(map[string]bool)
+	for _, route := range e.Routes() {
+		if first, _, _ := strings.Cut(strings.TrimPrefix(route.Path, "/"), "/"); !versionSegment.MatchString(first) {
+			unversioned[route.Path] = true
+		}
+	}
+	e.Pre(middleware.Version(versions, cfg.Versions.defaultVersion(), func(path string) bool {
+		return unversioned[path]
+	}))
+
 	return server, nil
 }
 
+// paginationPolicy returns the pagination policy configured for resource.
+func (s *server) paginationPolicy(resource string) pagination.Policy {
+	fallback := s.pagination.Default.Or(pagination.DefaultPolicy)
+
+	return s.pagination.Resources[resource].Or(fallback)
+}
+
+// rateLimited returns the middleware enforcing the named rate limit policy, to
+// be attached to routes. It returns none when rate limiting is disabled or the
+// policy is not configured.
+func (s *server) rateLimited(policy string) []echo.MiddlewareFunc {
+	if !s.rateLimit.Enable {
+		return nil
+	}
+
+	p, ok := s.rateLimit.Policies[policy]
+	if !ok {
+		s.logger.Warn("Rate limit policy is not configured", slog.String("policy", policy))
+		return nil
+	}
+
+	return []echo.MiddlewareFunc{middleware.RateLimit(s.limiter, policy, p, s.tiers, s.logger)}
+}
+
+// Listen binds the address of the server, which Start then serves on.
+func (s *server) Listen() error {
+	listener, err := net.Listen("tcp", s.address)
+	if err != nil {
+		return err
+	}
+	s.core.Listener = listener
+
+	return nil
+}
+
 func (s *server) Start() error {
 	return s.core.Start(s.address)
 }
@@ -1,22 +1,224 @@
 package rest
 
 import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"slices"
+
+	"github.com/kianooshaz/skeleton/foundation/derror"
+	"github.com/kianooshaz/skeleton/foundation/session"
 	usernameproto "github.com/kianooshaz/skeleton/services/account/username/proto"
+	lockoutproto "github.com/kianooshaz/skeleton/services/authentication/lockout/proto"
 	passwordproto "github.com/kianooshaz/skeleton/services/authentication/password/proto"
+	tokenproto "github.com/kianooshaz/skeleton/services/authentication/token/proto"
 	orgproto "github.com/kianooshaz/skeleton/services/organization/organization/proto"
 	auditproto "github.com/kianooshaz/skeleton/services/risk/audit/proto"
+	birthdayproto "github.com/kianooshaz/skeleton/services/user/birthday/proto"
 	userproto "github.com/kianooshaz/skeleton/services/user/user/proto"
 )
 
 func (s *server) registerRoutes(
+	cfg VersionsConfig,
+	userService userproto.UserService,
+	organizationService orgproto.OrganizationService,
+	passwordService passwordproto.PasswordService,
+	usernameService usernameproto.UsernameService,
+	auditService auditproto.AuditService,
+	birthdayService birthdayproto.BirthdayService,
+	tokenService tokenproto.TokenService,
+	lockoutService lockoutproto.LockoutService,
+) {
+	s.route(http.MethodGet, "/health", endpoint{
+		handler:  s.healthCheck,
+		response: reflect.TypeFor[HealthResponse](),
+		status:   http.StatusOK,
+	}, doc{summary: "Report whether the server is up"})
+	s.route(http.MethodGet, "/health/ready", endpoint{
+		handler:  s.readinessCheck,
+		response: reflect.TypeFor[HealthResponse](),
+		status:   http.StatusOK,
+	}, doc{summary: "Report whether every component of the application is running"})
+
+	for _, version := range versions {
+		s.api(version, cfg).registerRoutes(
+			userService,
+			organizationService,
+			passwordService,
+			usernameService,
+			auditService,
+			birthdayService,
+			tokenService,
+			lockoutService,
+		)
+	}
+}
+
+// registerRoutes registers the routes of the version. Routes whose shape
+// changed in a version check it with since.
+func (a api) registerRoutes(
 	userService userproto.UserService,
 	organizationService orgproto.OrganizationService,
 	passwordService passwordproto.PasswordService,
 	usernameService usernameproto.UsernameService,
 	auditService auditproto.AuditService,
+	birthdayService birthdayproto.BirthdayService,
+	tokenService tokenproto.TokenService,
+	lockoutService lockoutproto.LockoutService,
 ) {
-	s.core.GET("/health", HealthCheck)
+	s := a.server
+	reads := s.rateLimited("reads")
+	writes := s.rateLimited("writes")
+
+	a.route(http.MethodPost, "/users", registerCreateHandlerNoRequest(signUp(userService)),
+		doc{summary: "Create a user, or register the guest making the request"}, slices.Concat(writes, s.guest)...)
+	a.route(http.MethodGet, "/users", registerListHandler(s.paginationPolicy("users"), userService.List),
+		doc{summary: "List users"}, reads...)
+	a.route(http.MethodGet, "/users/:id", registerHandler(userService.Get),
+		doc{summary: "Get a user", errors: []error{derror.ErrUserNotFound}}, reads...)
+	a.route(http.MethodGet, "/users/:user_id/birthday", registerHandler(birthdayService.GetByUserID),
+		doc{summary: "Get the birthday of a user"}, reads...)
+
+	a.route(http.MethodPost, "/organizations", registerCreateHandlerNoRequest(organizationService.Create),
+		doc{summary: "Create an organization", authenticated: true}, writes...)
+	a.route(http.MethodGet, "/organizations", registerListHandler(s.paginationPolicy("organizations"), organizationService.List),
+		doc{summary: "List organizations"}, reads...)
+	a.route(http.MethodGet, "/organizations/:id", registerHandler(organizationService.Get),
+		doc{summary: "Get an organization", errors: []error{derror.ErrOrganizationNotFound}}, reads...)
+
+	listAssigned := registerListHandler(s.paginationPolicy("usernames"), usernameService.ListAssigned)
+	if a.since("v2") {
+		listAssigned = registerListHandler(s.paginationPolicy("usernames"), asListV2(usernameService.ListAssigned))
+	}
+	a.route(http.MethodGet, "/accounts/:account_id/usernames", listAssigned,
+		doc{summary: "List the usernames assigned to an account"}, reads...)
+	a.route(http.MethodPut, "/accounts/:account_id/password", registerHandlerNoResponse(passwordService.Update),
+		doc{
+			summary: "Change the password of an account",
+			errors: []error{
+				derror.ErrPasswordInvalid,
+				derror.ErrPasswordIsWeak,
+				derror.ErrPasswordIsCommon,
+				derror.ErrPasswordUsedBefore,
+				derror.ErrPasswordNotFound,
+				derror.ErrAccountLocked,
+				derror.ErrTooManyAttempts,
+				derror.ErrForbidden,
+			},
+			authenticated: true,
+		}, writes...)
+	a.route(http.MethodPost, "/accounts/:account_id/unlock", registerHandlerNoResponse(lockoutService.Unlock),
+		doc{
+			summary:       "Lift the lock of an account, as an admin",
+			errors:        []error{derror.ErrForbidden},
+			authenticated: true,
+		}, writes...)
+
+	a.route(http.MethodPost, "/usernames", registerCreateHandler(usernameService.Assign),
+		doc{
+			summary: "Assign a username to an account",
+			errors: []error{
+				derror.ErrUsernameInvalid,
+				derror.ErrUsernameLocked,
+				derror.ErrUsernameCannotBeAssigned,
+				derror.ErrUsernameMaxPerUser,
+				derror.ErrUsernameMaxPerOrganization,
+				derror.ErrForbidden,
+			},
+			authenticated: true,
+		}, writes...)
+	listUsernames := registerExportableListHandler(s.paginationPolicy("usernames"), usernameService.List, usernameService.Export)
+	if a.since("v2") {
+		listUsernames = registerExportableListHandler(
+			s.paginationPolicy("usernames"), asListV2(usernameService.List), usernameService.Export,
+		)
+	}
+	a.route(http.MethodGet, "/usernames", listUsernames, doc{summary: "List usernames"}, reads...)
+	a.route(http.MethodGet, "/usernames/:id", registerHandler(usernameService.Get),
+		doc{summary: "Get a username", errors: []error{derror.ErrUsernameNotFound}}, reads...)
+	a.route(http.MethodDelete, "/usernames/:id", registerHandlerNoResponse(usernameService.Unassign),
+		doc{
+			summary:       "Unassign a username",
+			errors:        []error{derror.ErrUsernameNotFound, derror.ErrForbidden},
+			authenticated: true,
+		}, writes...)
+	a.route(http.MethodPut, "/usernames/:id/primary", registerHandlerNoResponse(usernameService.BePrimary),
+		doc{
+			summary:       "Make a username the primary one of its account",
+			errors:        []error{derror.ErrUsernameNotFound, derror.ErrForbidden},
+			authenticated: true,
+		}, writes...)
+
+	a.route(http.MethodPost, "/tokens", registerCreateHandler(tokenService.Issue),
+		doc{
+			summary: "Issue a bearer token for an account, proving its password",
+			errors:  []error{derror.ErrPasswordInvalid, derror.ErrAccountLocked, derror.ErrTooManyAttempts},
+		}, writes...)
+
+	a.route(http.MethodGet, "/passwords/guidelines", registerHandlerNoRequest(func(context.Context) (passwordproto.GuidelinesResponse, error) {
+		return passwordService.Guidelines()
+	}), doc{summary: "Get the rules new passwords must follow"}, reads...)
+
+	a.route(http.MethodGet, "/audit/records", registerExportableListHandler(
+		s.paginationPolicy("audit_records"), auditService.List, auditService.Export,
+	),
+		doc{summary: "List audit records", authenticated: true}, reads...)
+	a.route(http.MethodGet, "/audit/records/:id", registerHandler(auditService.Get),
+		doc{summary: "Get an audit record", authenticated: true}, reads...)
+	a.route(http.MethodGet, "/audit/stream", registerEventStream(auditService.Stream, recordEventID, s.stream, s.closing),
+		doc{summary: "Stream audit records as they are written", authenticated: true}, reads...)
+
+	a.route(http.MethodPost, "/birthdays", registerCreateHandler(birthdayService.Create),
+		doc{
+			summary:       "Record the birthday of a user",
+			errors:        []error{derror.ErrUserAlreadyExists, derror.ErrForbidden},
+			authenticated: true,
+		}, writes...)
+	listBirthdays := registerListHandler(s.paginationPolicy("birthdays"), birthdayService.List)
+	if a.since("v2") {
+		listBirthdays = registerListHandler(s.paginationPolicy("birthdays"), asListV2(birthdayService.List))
+	}
+	a.route(http.MethodGet, "/birthdays", listBirthdays, doc{summary: "List birthdays"}, reads...)
+	a.route(http.MethodGet, "/birthdays/:id", registerHandler(birthdayService.Get),
+		doc{summary: "Get a birthday"}, reads...)
+	a.route(http.MethodPatch, "/birthdays/:id", registerHandler(birthdayService.Update),
+		doc{summary: "Change the birthday of a user", errors: []error{derror.ErrForbidden}, authenticated: true}, writes...)
+	a.route(http.MethodPut, "/birthdays/:id", registerHandler(birthdayService.Update),
+		doc{
+			summary:       "Change the birthday of a user, like its PATCH",
+			errors:        []error{derror.ErrForbidden},
+			authenticated: true,
+			deprecated:    true,
+		}, writes...)
+	a.route(http.MethodDelete, "/birthdays/:id", registerHandlerNoResponse(birthdayService.Delete),
+		doc{summary: "Delete a birthday", errors: []error{derror.ErrForbidden}, authenticated: true}, writes...)
+}
+
+// signUp registers a guest in place, keeping its ID and everything attached to
+// it, and creates a new user for any other visitor. A guest that signed up or
+// was purged since its cookie was issued gets a new user too.
+func signUp(userService userproto.UserService) func(ctx context.Context) (userproto.CreateResponse, error) {
+	return func(ctx context.Context) (userproto.CreateResponse, error) {
+		principal, ok := session.GetPrincipal(ctx)
+		if !ok || principal.AuthMethod != session.AuthMethodGuest {
+			return userService.Create(ctx)
+		}
+
+		res, err := userService.Upgrade(ctx, userproto.UpgradeRequest{ID: userproto.UserID(principal.UserID)})
+		if errors.Is(err, derror.ErrUserNotFound) || errors.Is(err, derror.ErrUserNotGuest) {
+			return userService.Create(ctx)
+		}
+		if err != nil {
+			return userproto.CreateResponse{}, err
+		}
+
+		return userproto.CreateResponse{Data: res.Data}, nil
+	}
+}
 
-	s.core.GET("/user", registerHandler(userService.Get))
-	// s.core.GET("/user/list", registerHandler(userService.Service.List))
+// recordEventID identifies the event of an audit record, for clients to resume
+// a stream after it.
+func recordEventID(record auditproto.Record) string {
+	return record.ID.String()
 }
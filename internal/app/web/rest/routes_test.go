@@ -0,0 +1,505 @@
+package rest_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kianooshaz/skeleton/foundation/derror"
+	"github.com/kianooshaz/skeleton/foundation/session"
+	"github.com/kianooshaz/skeleton/internal/app/web/rest"
+	"github.com/kianooshaz/skeleton/internal/app/web/rest/middleware"
+	accproto "github.com/kianooshaz/skeleton/services/account/accounts/proto"
+	usernameproto "github.com/kianooshaz/skeleton/services/account/username/proto"
+	lockoutproto "github.com/kianooshaz/skeleton/services/authentication/lockout/proto"
+	passwordproto "github.com/kianooshaz/skeleton/services/authentication/password/proto"
+	tokenproto "github.com/kianooshaz/skeleton/services/authentication/token/proto"
+	orgproto "github.com/kianooshaz/skeleton/services/organization/organization/proto"
+	auditproto "github.com/kianooshaz/skeleton/services/risk/audit/proto"
+	birthdayproto "github.com/kianooshaz/skeleton/services/user/birthday/proto"
+	userproto "github.com/kianooshaz/skeleton/services/user/user/proto"
+)
+
+// The fakes keep the last request they were called with.
+
+type fakeUsers struct {
+	userproto.UserService
+	get     userproto.GetRequest
+	guests  int
+	upgrade userproto.UpgradeRequest
+}
+
+func (f *fakeUsers) Create(context.Context) (userproto.CreateResponse, error) {
+	return userproto.CreateResponse{}, nil
+}
+
+func (f *fakeUsers) CreateGuest(context.Context) (userproto.CreateResponse, error) {
+	f.guests++
+	return userproto.CreateResponse{Data: userproto.User{ID: userproto.UserID(uuid.New()), LastSeenAt: time.Now()}}, nil
+}
+
+func (f *fakeUsers) Upgrade(_ context.Context, req userproto.UpgradeRequest) (userproto.UpgradeResponse, error) {
+	f.upgrade = req
+	return userproto.UpgradeResponse{Data: userproto.User{ID: req.ID}}, nil
+}
+
+func (f *fakeUsers) Get(_ context.Context, req userproto.GetRequest) (userproto.GetResponse, error) {
+	f.get = req
+	return userproto.GetResponse{Data: userproto.User{ID: req.ID}}, nil
+}
+
+type fakeUsernames struct {
+	usernameproto.UsernameService
+	unassign  usernameproto.UnassignRequest
+	bePrimary usernameproto.BePrimaryRequest
+}
+
+// Assign refuses the usernames "locked" and "taken", as the service does when
+// they are locked or assigned to another account.
+func (f *fakeUsernames) Assign(_ context.Context, req usernameproto.AssignRequest) (usernameproto.Username, error) {
+	switch req.Username {
+	case "locked":
+		return usernameproto.Username{}, derror.ErrUsernameLocked
+	case "taken":
+		return usernameproto.Username{}, derror.ErrUsernameCannotBeAssigned
+	}
+
+	return usernameproto.Username{}, nil
+}
+
+func (f *fakeUsernames) Unassign(_ context.Context, req usernameproto.UnassignRequest) error {
+	f.unassign = req
+	return nil
+}
+
+func (f *fakeUsernames) BePrimary(_ context.Context, req usernameproto.BePrimaryRequest) error {
+	f.bePrimary = req
+	return nil
+}
+
+type fakeOrganizations struct {
+	orgproto.OrganizationService
+}
+
+func (f *fakeOrganizations) Get(context.Context, orgproto.GetRequest) (orgproto.GetResponse, error) {
+	return orgproto.GetResponse{}, derror.ErrOrganizationNotFound
+}
+
+type fakePasswords struct {
+	passwordproto.PasswordService
+	update passwordproto.UpdateRequest
+}
+
+func (f *fakePasswords) Update(_ context.Context, req passwordproto.UpdateRequest) error {
+	f.update = req
+	return nil
+}
+
+type fakeBirthdays struct {
+	birthdayproto.BirthdayService
+	list   birthdayproto.ListRequest
+	update birthdayproto.UpdateRequest
+}
+
+func (f *fakeBirthdays) Update(_ context.Context, req birthdayproto.UpdateRequest) (birthdayproto.UpdateResponse, error) {
+	f.update = req
+	return birthdayproto.UpdateResponse{}, nil
+}
+
+func (f *fakeBirthdays) Create(_ context.Context, req birthdayproto.CreateRequest) (birthdayproto.CreateResponse, error) {
+	return birthdayproto.CreateResponse{Data: birthdayproto.Birthday{UserID: req.UserID}}, nil
+}
+
+func (f *fakeBirthdays) List(_ context.Context, req birthdayproto.ListRequest) (birthdayproto.ListResponse, error) {
+	f.list = req
+	return birthdayproto.ListResponse{}, nil
+}
+
+type fakeLockouts struct {
+	lockoutproto.LockoutService
+	unlock lockoutproto.UnlockRequest
+}
+
+func (f *fakeLockouts) Unlock(_ context.Context, req lockoutproto.UnlockRequest) error {
+	f.unlock = req
+	return nil
+}
+
+// fakeTokens accepts the token "valid", of the account in principal.
+type fakeTokens struct {
+	tokenproto.TokenService
+	principal session.Principal
+	issue     tokenproto.IssueRequest
+}
+
+func (f *fakeTokens) Issue(_ context.Context, req tokenproto.IssueRequest) (tokenproto.IssueResponse, error) {
+	f.issue = req
+	return tokenproto.IssueResponse{Data: tokenproto.Token{Token: "valid"}}, nil
+}
+
+func (f *fakeTokens) Verify(_ context.Context, token string) (session.Principal, error) {
+	if token != "valid" {
+		return session.Principal{}, derror.ErrUnauthenticated
+	}
+
+	return f.principal, nil
+}
+
+func TestRoutes(t *testing.T) {
+	users := &fakeUsers{}
+	usernames := &fakeUsernames{}
+	passwords := &fakePasswords{}
+	birthdays := &fakeBirthdays{}
+	tokens := &fakeTokens{}
+	lockouts := &fakeLockouts{}
+
+	ws, err := rest.New(
+		rest.Config{},
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+		nil,
+		nil,
+		nil,
+		users,
+		&fakeOrganizations{},
+		passwords,
+		usernames,
+		struct{ auditproto.AuditService }{},
+		birthdays,
+		tokens,
+		lockouts,
+	)
+	require.NoError(t, err)
+	handler := rest.Handler(ws)
+
+	userID := uuid.New()
+	usernameID := uuid.New()
+	birthdayID := uuid.New()
+	accountID := uuid.New()
+	tokens.principal = session.Principal{AccountID: accountID}
+
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		body       string
+		token      string
+		wantStatus int
+		wantBody   string
+		assert     func(t *testing.T)
+	}{
+		{
+			name:       "create user",
+			method:     http.MethodPost,
+			target:     "/users",
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:       "get user by prefixed id",
+			method:     http.MethodGet,
+			target:     "/users/usr_" + userID.String(),
+			wantStatus: http.StatusOK,
+			assert: func(t *testing.T) {
+				assert.Equal(t, userproto.UserID(userID), users.get.ID)
+			},
+		},
+		{
+			name:       "get user by malformed id",
+			method:     http.MethodGet,
+			target:     "/users/42",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "unassign username",
+			method:     http.MethodDelete,
+			target:     "/usernames/" + usernameID.String(),
+			token:      "valid",
+			wantStatus: http.StatusNoContent,
+			assert: func(t *testing.T) {
+				assert.Equal(t, usernameID, usernames.unassign.ID)
+			},
+		},
+		{
+			name:       "make username primary",
+			method:     http.MethodPut,
+			target:     "/usernames/" + usernameID.String() + "/primary",
+			token:      "valid",
+			wantStatus: http.StatusNoContent,
+			assert: func(t *testing.T) {
+				assert.Equal(t, usernameID, usernames.bePrimary.ID)
+			},
+		},
+		{
+			name:       "update password",
+			method:     http.MethodPut,
+			target:     "/accounts/" + accountID.String() + "/password",
+			token:      "valid",
+			body:       `{"current_password":"current","new_password":"secret","account_id":"` + uuid.NewString() + `"}`,
+			wantStatus: http.StatusNoContent,
+			assert: func(t *testing.T) {
+				assert.Equal(t, accproto.AccountID(accountID), passwords.update.AccountID, "the path names the account")
+				assert.Equal(t, "current", passwords.update.CurrentPassword)
+				assert.Equal(t, "secret", passwords.update.NewPassword)
+			},
+		},
+		{
+			name:       "create birthday",
+			method:     http.MethodPost,
+			target:     "/birthdays",
+			token:      "valid",
+			body:       `{"user_id":"usr_` + userID.String() + `","date_of_birth":"2000-01-02T00:00:00Z"}`,
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:       "update birthday leaving absent fields untouched",
+			method:     http.MethodPatch,
+			target:     "/birthdays/" + birthdayID.String(),
+			token:      "valid",
+			body:       `{}`,
+			wantStatus: http.StatusOK,
+			assert: func(t *testing.T) {
+				assert.False(t, birthdays.update.DateOfBirth.IsPresent())
+				assert.False(t, birthdays.update.PlaceOfBirth.IsPresent())
+			},
+		},
+		{
+			name:       "update birthday clearing the place of birth",
+			method:     http.MethodPatch,
+			target:     "/birthdays/" + birthdayID.String(),
+			token:      "valid",
+			body:       `{"place_of_birth":null}`,
+			wantStatus: http.StatusOK,
+			assert: func(t *testing.T) {
+				assert.True(t, birthdays.update.PlaceOfBirth.IsNull())
+				assert.False(t, birthdays.update.DateOfBirth.IsPresent())
+			},
+		},
+		{
+			name:       "update birthday setting the place of birth",
+			method:     http.MethodPatch,
+			target:     "/birthdays/" + birthdayID.String(),
+			token:      "valid",
+			body:       `{"place_of_birth":"Tehran","date_of_birth":"2000-01-02T00:00:00Z"}`,
+			wantStatus: http.StatusOK,
+			assert: func(t *testing.T) {
+				place, ok := birthdays.update.PlaceOfBirth.Get()
+				require.True(t, ok)
+				assert.Equal(t, "Tehran", place)
+				dateOfBirth, ok := birthdays.update.DateOfBirth.Get()
+				require.True(t, ok)
+				assert.Equal(t, 2000, dateOfBirth.Year())
+			},
+		},
+		{
+			name:       "update birthday clearing the date of birth",
+			method:     http.MethodPatch,
+			target:     "/birthdays/" + birthdayID.String(),
+			token:      "valid",
+			body:       `{"date_of_birth":null}`,
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"error":"100017"}`,
+		},
+		{
+			name:       "update birthday to the future",
+			method:     http.MethodPatch,
+			target:     "/birthdays/" + birthdayID.String(),
+			token:      "valid",
+			body:       `{"date_of_birth":"2999-01-02T00:00:00Z"}`,
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"error":"100017","fields":[{"field":"date_of_birth","rule":"past"}]}`,
+		},
+		{
+			name:       "create birthday in the future",
+			method:     http.MethodPost,
+			target:     "/birthdays",
+			token:      "valid",
+			body:       `{"user_id":"usr_` + userID.String() + `","date_of_birth":"2999-01-02T00:00:00Z"}`,
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"error":"100017","fields":[{"field":"date_of_birth","rule":"past"}]}`,
+		},
+		{
+			name:       "create birthday without user",
+			method:     http.MethodPost,
+			target:     "/birthdays",
+			token:      "valid",
+			body:       `{"date_of_birth":"2000-01-02T00:00:00Z"}`,
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"error":"100017","fields":[{"field":"user_id","rule":"id"}]}`,
+		},
+		{
+			name:       "update password without a new one",
+			method:     http.MethodPut,
+			target:     "/accounts/" + accountID.String() + "/password",
+			token:      "valid",
+			body:       `{"current_password":"current"}`,
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"error":"100017","fields":[{"field":"new_password","rule":"required"}]}`,
+		},
+		{
+			name:       "assign username without account",
+			method:     http.MethodPost,
+			target:     "/usernames",
+			token:      "valid",
+			body:       `{"username":"kianoosh"}`,
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"error":"100500"}`,
+		},
+		{
+			name:       "assign locked username",
+			method:     http.MethodPost,
+			target:     "/usernames",
+			token:      "valid",
+			body:       `{"account_id":"acc_` + accountID.String() + `","username":"locked"}`,
+			wantStatus: http.StatusLocked,
+			wantBody:   `{"error":"100306"}`,
+		},
+		{
+			name:       "assign taken username",
+			method:     http.MethodPost,
+			target:     "/usernames",
+			token:      "valid",
+			body:       `{"account_id":"acc_` + accountID.String() + `","username":"taken"}`,
+			wantStatus: http.StatusConflict,
+			wantBody:   `{"error":"100305"}`,
+		},
+		{
+			name:       "get missing organization",
+			method:     http.MethodGet,
+			target:     "/organizations/" + uuid.NewString(),
+			wantStatus: http.StatusNotFound,
+			wantBody:   `{"error":"100401"}`,
+		},
+		{
+			name:       "issue token",
+			method:     http.MethodPost,
+			target:     "/tokens",
+			body:       `{"account_id":"acc_` + accountID.String() + `","password":"secret"}`,
+			wantStatus: http.StatusCreated,
+			assert: func(t *testing.T) {
+				assert.Equal(t, accproto.AccountID(accountID), tokens.issue.AccountID)
+			},
+		},
+		{
+			name:       "unlock account",
+			method:     http.MethodPost,
+			target:     "/accounts/" + accountID.String() + "/unlock",
+			token:      "valid",
+			wantStatus: http.StatusNoContent,
+			assert: func(t *testing.T) {
+				assert.Equal(t, accproto.AccountID(accountID), lockouts.unlock.AccountID)
+			},
+		},
+		{
+			name:       "update password without credentials",
+			method:     http.MethodPut,
+			target:     "/accounts/" + accountID.String() + "/password",
+			body:       `{"current_password":"current","new_password":"secret"}`,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "unlock account without credentials",
+			method:     http.MethodPost,
+			target:     "/accounts/" + accountID.String() + "/unlock",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "request with an invalid token",
+			method:     http.MethodGet,
+			target:     "/users/usr_" + userID.String(),
+			token:      "forged",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "list birthdays with filters",
+			method:     http.MethodGet,
+			target:     "/birthdays?min_age=18&birth_month=4",
+			wantStatus: http.StatusOK,
+			assert: func(t *testing.T) {
+				require.NotNil(t, birthdays.list.MinAge)
+				require.NotNil(t, birthdays.list.BirthMonth)
+				assert.Equal(t, 18, *birthdays.list.MinAge)
+				assert.Equal(t, 4, *birthdays.list.BirthMonth)
+				assert.Nil(t, birthdays.list.MaxAge)
+			},
+		},
+		{
+			name:       "list birthdays of a month out of range",
+			method:     http.MethodGet,
+			target:     "/birthdays?birth_month=13",
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"error":"100017","fields":[{"field":"birth_month","rule":"max","param":"12"}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			if tt.body != "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			rec := httptest.NewRecorder()
+
+			// Execute.
+			handler.ServeHTTP(rec, req)
+
+			// Assert.
+			require.Equal(t, tt.wantStatus, rec.Code, rec.Body.String())
+			if tt.wantBody != "" {
+				assert.JSONEq(t, tt.wantBody, rec.Body.String())
+			}
+			if tt.assert != nil {
+				tt.assert(t)
+			}
+		})
+	}
+}
+
+func TestSignUpUpgradesGuest(t *testing.T) {
+	users := &fakeUsers{}
+
+	ws, err := rest.New(
+		rest.Config{Guest: middleware.GuestConfig{Enable: true, Secret: "secret"}},
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+		nil,
+		nil,
+		nil,
+		users,
+		struct{ orgproto.OrganizationService }{},
+		&fakePasswords{},
+		&fakeUsernames{},
+		struct{ auditproto.AuditService }{},
+		&fakeBirthdays{},
+		&fakeTokens{},
+		&fakeLockouts{},
+	)
+	require.NoError(t, err)
+	handler := rest.Handler(ws)
+
+	health := httptest.NewRecorder()
+	handler.ServeHTTP(health, httptest.NewRequest(http.MethodGet, "/health", nil))
+	require.Equal(t, http.StatusOK, health.Code)
+	assert.Empty(t, health.Result().Cookies(), "only the routes acting for a guest create one")
+	assert.Zero(t, users.guests)
+
+	rec := httptest.NewRecorder()
+
+	// Execute.
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/users", nil))
+
+	// Assert.
+	require.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, 1, users.guests)
+	assert.NotEqual(t, userproto.UserID{}, users.upgrade.ID, "the guest is registered in place")
+	assert.Contains(t, rec.Body.String(), users.upgrade.ID.String())
+}
@@ -0,0 +1,114 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// StreamConfig holds how the server streams events.
+type StreamConfig struct {
+	// Heartbeat is how often an idle stream sends a comment, so proxies and
+	// clients keep it open. It defaults to 15s.
+	Heartbeat time.Duration `yaml:"heartbeat"`
+}
+
+func (cfg StreamConfig) heartbeat() time.Duration {
+	if cfg.Heartbeat <= 0 {
+		return 15 * time.Second
+	}
+
+	return cfg.Heartbeat
+}
+
+// registerEventStream serves what stream sends as Server-Sent Events, each
+// carrying a value as JSON along with the ID returned by id, so EventSource
+// clients resume after the last event they received. Besides the query, the
+// request is bound from the headers, such as Last-Event-ID, which take
+// precedence.
+//
+// The response ends when stream closes its channel, the client goes away or
+// closing is closed, as it is when the server shuts down.
+func registerEventStream[T any, R any](
+	stream func(ctx context.Context, req T) (<-chan R, error),
+	id func(R) string,
+	cfg StreamConfig,
+	closing <-chan struct{},
+) endpoint {
+	fn := func(c echo.Context) error {
+		var req T
+		if err := c.Bind(&req); err != nil {
+			return err
+		}
+		if err := (&echo.DefaultBinder{}).BindHeaders(c, &req); err != nil {
+			return err
+		}
+		if err := c.Validate(&req); err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithCancel(c.Request().Context())
+		defer cancel()
+
+		events, err := stream(ctx, req)
+		if err != nil {
+			return err
+		}
+
+		res := c.Response()
+		// Streams outlive the write timeout of the server; servers without
+		// deadlines do not support clearing them, which is as good.
+		_ = http.NewResponseController(res.Writer).SetWriteDeadline(time.Time{})
+		res.Header().Set(echo.HeaderContentType, "text/event-stream")
+		res.Header().Set(echo.HeaderCacheControl, "no-cache")
+		// Keeps nginx from buffering the events.
+		res.Header().Set("X-Accel-Buffering", "no")
+		res.WriteHeader(http.StatusOK)
+		res.Flush()
+
+		heartbeat := time.NewTicker(cfg.heartbeat())
+		defer heartbeat.Stop()
+
+		for {
+			select {
+			case event, ok := <-events:
+				if !ok {
+					return nil
+				}
+
+				data, err := json.Marshal(event)
+				if err != nil {
+					return err
+				}
+				if _, err := fmt.Fprintf(res, "id: %s\ndata: %s\n\n", id(event), data); err != nil {
+					return err
+				}
+			case <-heartbeat.C:
+				if _, err := io.WriteString(res, ": heartbeat\n\n"); err != nil {
+					return err
+				}
+			case <-closing:
+				return nil
+			case <-ctx.Done():
+				return nil
+			}
+
+			res.Flush()
+		}
+	}
+
+	return endpoint{
+		handler:  fn,
+		request:  reflect.TypeFor[T](),
+		response: reflect.TypeFor[R](),
+		status:   http.StatusOK,
+		errors:   bindErrors,
+		events:   true,
+	}
+}
@@ -0,0 +1,164 @@
+package rest_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kianooshaz/skeleton/foundation/id"
+	"github.com/kianooshaz/skeleton/internal/app/web/rest"
+	usernameproto "github.com/kianooshaz/skeleton/services/account/username/proto"
+	lockoutproto "github.com/kianooshaz/skeleton/services/authentication/lockout/proto"
+	passwordproto "github.com/kianooshaz/skeleton/services/authentication/password/proto"
+	orgproto "github.com/kianooshaz/skeleton/services/organization/organization/proto"
+	auditproto "github.com/kianooshaz/skeleton/services/risk/audit/proto"
+	userproto "github.com/kianooshaz/skeleton/services/user/user/proto"
+)
+
+// fakeAuditStream streams its records, then ends the stream unless open is set.
+type fakeAuditStream struct {
+	auditproto.AuditService
+	records []auditproto.Record
+	open    bool
+	req     auditproto.StreamRequest
+}
+
+func (f *fakeAuditStream) Stream(ctx context.Context, req auditproto.StreamRequest) (<-chan auditproto.Record, error) {
+	f.req = req
+
+	records := make(chan auditproto.Record, len(f.records))
+	for _, record := range f.records {
+		records <- record
+	}
+	if !f.open {
+		close(records)
+	}
+
+	return records, nil
+}
+
+func TestEventStream(t *testing.T) {
+	record := auditproto.Record{
+		ID:           id.MustNew[auditproto.RecordKind](),
+		Action:       auditproto.Insert,
+		CreatedAt:    time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC),
+		ResourceType: "user",
+	}
+	data, err := json.Marshal(record)
+	require.NoError(t, err)
+	lastEventID := id.MustNew[auditproto.RecordKind]()
+	queryEventID := id.MustNew[auditproto.RecordKind]()
+
+	tests := []struct {
+		name       string
+		target     string
+		header     http.Header
+		records    []auditproto.Record
+		open       bool
+		wantStatus int
+		wantBody   string
+		assert     func(t *testing.T, req auditproto.StreamRequest)
+	}{
+		{
+			name:       "records as events",
+			target:     "/v2/audit/stream?action=insert&resource_type=user",
+			records:    []auditproto.Record{record},
+			wantStatus: http.StatusOK,
+			wantBody:   "id: " + record.ID.String() + "\ndata: " + string(data) + "\n\n",
+			assert: func(t *testing.T, req auditproto.StreamRequest) {
+				assert.Equal(t, []auditproto.Action{auditproto.Insert}, req.Actions)
+				assert.Equal(t, []string{"user"}, req.ResourceTypes)
+			},
+		},
+		{
+			name:       "heartbeats while idle",
+			target:     "/audit/stream",
+			open:       true,
+			wantStatus: http.StatusOK,
+			wantBody:   ": heartbeat\n\n",
+		},
+		{
+			name:       "last event id from the query",
+			target:     "/audit/stream?last_event_id=" + queryEventID.String(),
+			wantStatus: http.StatusOK,
+			assert: func(t *testing.T, req auditproto.StreamRequest) {
+				assert.Equal(t, queryEventID, req.LastEventID)
+			},
+		},
+		{
+			name:       "last event id header wins over the query",
+			target:     "/audit/stream?last_event_id=" + queryEventID.String(),
+			header:     http.Header{"Last-Event-Id": {lastEventID.String()}},
+			wantStatus: http.StatusOK,
+			assert: func(t *testing.T, req auditproto.StreamRequest) {
+				assert.Equal(t, lastEventID, req.LastEventID)
+			},
+		},
+		{
+			name:       "invalid last event id",
+			target:     "/audit/stream",
+			header:     http.Header{"Last-Event-Id": {"42"}},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			audit := &fakeAuditStream{records: tt.records, open: tt.open}
+			ws, err := rest.New(
+				rest.Config{Stream: rest.StreamConfig{Heartbeat: 10 * time.Millisecond}},
+				slog.New(slog.NewTextHandler(io.Discard, nil)),
+				nil,
+				nil,
+				nil,
+				struct{ userproto.UserService }{},
+				struct{ orgproto.OrganizationService }{},
+				struct{ passwordproto.PasswordService }{},
+				struct{ usernameproto.UsernameService }{},
+				audit,
+				&fakeBirthdays{},
+				&fakeTokens{},
+				struct{ lockoutproto.LockoutService }{},
+			)
+			require.NoError(t, err)
+
+			// Open streams end when the client goes away.
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+			req := httptest.NewRequestWithContext(ctx, http.MethodGet, tt.target, nil)
+			req.Header.Set("Authorization", "Bearer valid")
+			for key, values := range tt.header {
+				req.Header[key] = values
+			}
+			rec := httptest.NewRecorder()
+
+			// Execute.
+			rest.Handler(ws).ServeHTTP(rec, req)
+
+			// Assert.
+			require.Equal(t, tt.wantStatus, rec.Code, rec.Body.String())
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			assert.Equal(t, "text/event-stream", rec.Header().Get("Content-Type"))
+			assert.Equal(t, "no-cache", rec.Header().Get("Cache-Control"))
+			if tt.open {
+				assert.True(t, strings.HasPrefix(rec.Body.String(), tt.wantBody), rec.Body.String())
+			} else {
+				assert.Equal(t, tt.wantBody, rec.Body.String())
+			}
+			if tt.assert != nil {
+				tt.assert(t, audit.req)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/kianooshaz/skeleton/foundation/derror"
+	"github.com/kianooshaz/skeleton/foundation/types"
+)
+
+// FieldError is a rule a field of a request broke.
+type FieldError struct {
+	// Field is the name of the field as the client sent it, such as user_id.
+	Field string `json:"field"`
+	Rule  string `json:"rule"`
+	Param string `json:"param,omitempty"`
+}
+
+// ValidationError is the failure of a request to meet the validate tags of its
+// fields. It wraps derror.ErrInvalidRequest, and its fields are answered along
+// with the error code.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	rules := make([]string, 0, len(e.Fields))
+	for _, field := range e.Fields {
+		rules = append(rules, field.Field+": "+field.Rule)
+	}
+
+	return fmt.Sprintf("invalid request: %s", strings.Join(rules, ", "))
+}
+
+func (e *ValidationError) Unwrap() error {
+	return derror.ErrInvalidRequest
+}
+
+// charsets are the character sets the charset rule accepts by name.
+var charsets = map[string]string{
+	"digits":      "0123456789",
+	"lower_alnum": "abcdefghijklmnopqrstuvwxyz0123456789",
+	"alnum":       "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789",
+}
+
+// requestValidator checks the validate tags of requests. Besides the rules of
+// go-playground/validator it knows:
+//   - id: a UUID-typed identifier, or a string holding one, that is not the nil UUID.
+//   - charset=<name>: a string made only of the characters of a set in charsets.
+//   - past: a time before now.
+//
+// Rules on Optional fields check the value they set, if any.
+type requestValidator struct {
+	validate *validator.Validate
+}
+
+// requests checks the requests of other transports, see ValidateRequest.
+var requests = newRequestValidator()
+
+// ValidateRequest checks req the way the server checks the requests it binds,
+// so other transports calling the same services apply the same rules. It
+// returns a *ValidationError for broken validate tags.
+func ValidateRequest(req any) error {
+	return requests.Validate(req)
+}
+
+func newRequestValidator() *requestValidator {
+	v := validator.New(validator.WithRequiredStructEnabled())
+	v.RegisterTagNameFunc(fieldName)
+	v.RegisterCustomTypeFunc(optionalValue[time.Time], types.Optional[time.Time]{})
+	v.RegisterCustomTypeFunc(optionalValue[string], types.Optional[string]{})
+
+	for tag, fn := range map[string]validator.Func{
+		"id":      validateID,
+		"charset": validateCharset,
+		"past":    validatePast,
+	} {
+		if err := v.RegisterValidation(tag, fn); err != nil {
+			panic(fmt.Sprintf("registering the %s validation: %v", tag, err))
+		}
+	}
+
+	return &requestValidator{validate: v}
+}
+
+// Validate checks the validate tags of req, then its Validate method, if any.
+// It implements echo.Validator.
+func (v *requestValidator) Validate(req any) error {
+	if err := v.validate.Struct(req); err != nil {
+		var invalid validator.ValidationErrors
+		if !errors.As(err, &invalid) {
+			return err
+		}
+
+		fields := make([]FieldError, 0, len(invalid))
+		for _, fe := range invalid {
+			fields = append(fields, FieldError{Field: fieldPath(fe), Rule: fe.Tag(), Param: fe.Param()})
+		}
+
+		return &ValidationError{Fields: fields}
+	}
+
+	if validatable, ok := req.(interface{ Validate() error }); ok {
+		return validatable.Validate()
+	}
+
+	return nil
+}
+
+// fieldName names a field as the client sends it: by its json, param or query tag.
+func fieldName(field reflect.StructField) string {
+	for _, key := range []string{"json", "param", "query"} {
+		name, _, _ := strings.Cut(field.Tag.Get(key), ",")
+		if name != "" && name != "-" {
+			return name
+		}
+	}
+
+	return field.Name
+}
+
+// fieldPath is the path of the field of fe from the request, such as user_id
+// or address.city.
+func fieldPath(fe validator.FieldError) string {
+	_, path, _ := strings.Cut(fe.Namespace(), ".")
+	if path == "" {
+		return fe.Field()
+	}
+
+	return path
+}
+
+// optionalValue lets the rules of an Optional field check the value it sets.
+// Absent and null fields hold none, so rules after omitempty skip them.
+func optionalValue[T any](field reflect.Value) any {
+	if value, ok := field.Interface().(types.Optional[T]).Get(); ok {
+		return value
+	}
+
+	return nil
+}
+
+func validateID(fl validator.FieldLevel) bool {
+	field := fl.Field()
+
+	switch {
+	case field.Kind() == reflect.String:
+		id, err := uuid.Parse(field.String())
+		return err == nil && id != uuid.Nil
+	case field.Kind() == reflect.Array && field.Len() == len(uuid.UUID{}) && field.Type().Elem().Kind() == reflect.Uint8:
+		return !field.IsZero()
+	default:
+		return false
+	}
+}
+
+func validateCharset(fl validator.FieldLevel) bool {
+	charset, ok := charsets[fl.Param()]
+	if !ok || fl.Field().Kind() != reflect.String {
+		return false
+	}
+
+	for _, char := range fl.Field().String() {
+		if !strings.ContainsRune(charset, char) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func validatePast(fl validator.FieldLevel) bool {
+	t, ok := fl.Field().Interface().(time.Time)
+	return ok && t.Before(time.Now())
+}
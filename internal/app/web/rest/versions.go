@@ -0,0 +1,128 @@
+package rest
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+
+	"github.com/kianooshaz/skeleton/foundation/pagination"
+	"github.com/kianooshaz/skeleton/internal/app/web/rest/middleware"
+	"github.com/labstack/echo/v4"
+)
+
+// versions are the versions of the API the server serves, oldest first.
+var versions = []string{"v1", "v2"}
+
+// versionSegment matches the first path segment of versioned routes.
+var versionSegment = regexp.MustCompile(`^v\d+$`)
+
+// VersionsConfig holds how requests are routed to versions of the API.
+type VersionsConfig struct {
+	// Default is the version of requests to unversioned paths whose Accept
+	// header names none. It defaults to v1, which unversioned paths served
+	// before versions were introduced.
+	Default string `yaml:"default"`
+	// Deprecated holds the deprecation of versions by name, such as v1.
+	Deprecated map[string]middleware.Deprecation `yaml:"deprecated"`
+}
+
+// Validate reports whether the versions named exist.
+func (cfg VersionsConfig) Validate() error {
+	if cfg.Default != "" && !slices.Contains(versions, cfg.Default) {
+		return fmt.Errorf("default version %s is not served", cfg.Default)
+	}
+
+	for version := range cfg.Deprecated {
+		if !slices.Contains(versions, version) {
+			return fmt.Errorf("deprecated version %s is not served", version)
+		}
+	}
+
+	return nil
+}
+
+func (cfg VersionsConfig) defaultVersion() string {
+	if cfg.Default == "" {
+		return versions[0]
+	}
+
+	return cfg.Default
+}
+
+// api registers the routes of a version of the API under /<version>.
+type api struct {
+	server  *server
+	version string
+	// number orders versions, for routes whose shape changed in a version.
+	number      int
+	deprecated  bool
+	middlewares []echo.MiddlewareFunc
+}
+
+func (s *server) api(version string, cfg VersionsConfig) api {
+	a := api{
+		server:  s,
+		version: version,
+		number:  slices.Index(versions, version) + 1,
+	}
+
+	if deprecation, ok := cfg.Deprecated[version]; ok {
+		a.deprecated = true
+		a.middlewares = []echo.MiddlewareFunc{middleware.Deprecated(version, deprecation)}
+	}
+
+	return a
+}
+
+func (a api) route(method, path string, e endpoint, d doc, middlewares ...echo.MiddlewareFunc) {
+	d.deprecated = d.deprecated || a.deprecated
+	a.server.route(method, "/"+a.version+path, e, d, slices.Concat(a.middlewares, middlewares)...)
+}
+
+// since reports whether the version is version or a later one.
+func (a api) since(version string) bool {
+	return a.number >= slices.Index(versions, version)+1
+}
+
+// listV2 is the shape of listings since v2: the rows under data, and where the
+// page sits under pagination rather than beside them.
+type listV2[T any] struct {
+	Data       []T        `json:"data"`
+	Pagination pageInfoV2 `json:"pagination"`
+}
+
+type pageInfoV2 struct {
+	pagination.Page
+	pagination.Navigation
+}
+
+func (l listV2[T]) CurrentPage() pagination.Page {
+	return l.Pagination.Page
+}
+
+func (l listV2[T]) CurrentNavigation() pagination.Navigation {
+	return l.Pagination.Navigation
+}
+
+// asListV2 adapts a list method of a service to answer in the shape of v2.
+// R is the response type of the service, defined from pagination.Response[T].
+func asListV2[Q any, T any, R ~struct {
+	pagination.Page
+	pagination.Navigation
+	Data []T `json:"data" bson:"data"`
+}](list func(ctx context.Context, req Q) (R, error)) func(ctx context.Context, req Q) (listV2[T], error) {
+	return func(ctx context.Context, req Q) (listV2[T], error) {
+		res, err := list(ctx, req)
+		if err != nil {
+			return listV2[T]{}, err
+		}
+
+		listing := pagination.Response[T](res)
+
+		return listV2[T]{
+			Data:       listing.Data,
+			Pagination: pageInfoV2{Page: listing.Page, Navigation: listing.Navigation},
+		}, nil
+	}
+}
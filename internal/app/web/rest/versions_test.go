@@ -0,0 +1,138 @@
+package rest_test
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kianooshaz/skeleton/internal/app/web/rest"
+	"github.com/kianooshaz/skeleton/internal/app/web/rest/middleware"
+	"github.com/kianooshaz/skeleton/internal/app/web/rest/openapi"
+	usernameproto "github.com/kianooshaz/skeleton/services/account/username/proto"
+	lockoutproto "github.com/kianooshaz/skeleton/services/authentication/lockout/proto"
+	passwordproto "github.com/kianooshaz/skeleton/services/authentication/password/proto"
+	tokenproto "github.com/kianooshaz/skeleton/services/authentication/token/proto"
+	orgproto "github.com/kianooshaz/skeleton/services/organization/organization/proto"
+	auditproto "github.com/kianooshaz/skeleton/services/risk/audit/proto"
+	userproto "github.com/kianooshaz/skeleton/services/user/user/proto"
+)
+
+func TestVersions(t *testing.T) {
+	cfg := rest.Config{
+		Docs: rest.DocsConfig{Enable: true},
+		Versions: rest.VersionsConfig{
+			Default: "v1",
+			Deprecated: map[string]middleware.Deprecation{
+				"v1": {Since: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+			},
+		},
+	}
+	ws, err := rest.New(
+		cfg,
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+		nil,
+		nil,
+		nil,
+		struct{ userproto.UserService }{},
+		struct{ orgproto.OrganizationService }{},
+		struct{ passwordproto.PasswordService }{},
+		struct{ usernameproto.UsernameService }{},
+		struct{ auditproto.AuditService }{},
+		&fakeBirthdays{},
+		struct{ tokenproto.TokenService }{},
+		struct{ lockoutproto.LockoutService }{},
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		target         string
+		accept         string
+		wantStatus     int
+		wantKeys       []string
+		wantDeprecated bool
+	}{
+		{
+			name:           "unversioned path serves the default version",
+			target:         "/birthdays",
+			wantStatus:     http.StatusOK,
+			wantKeys:       []string{"data", "page_number", "page_rows", "has_more"},
+			wantDeprecated: true,
+		},
+		{
+			name:       "v2 nests the pagination",
+			target:     "/v2/birthdays",
+			wantStatus: http.StatusOK,
+			wantKeys:   []string{"data", "pagination"},
+		},
+		{
+			name:       "accept selects v2",
+			target:     "/birthdays",
+			accept:     "application/json; version=2",
+			wantStatus: http.StatusOK,
+			wantKeys:   []string{"data", "pagination"},
+		},
+		{
+			name:       "unsupported version",
+			target:     "/birthdays",
+			accept:     "application/json; version=3",
+			wantStatus: http.StatusNotAcceptable,
+			wantKeys:   []string{"error"},
+		},
+		{
+			name:       "health is unversioned",
+			target:     "/health",
+			accept:     "application/json; version=2",
+			wantStatus: http.StatusOK,
+			wantKeys:   []string{"status"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			rec := httptest.NewRecorder()
+
+			// Execute.
+			rest.Handler(ws).ServeHTTP(rec, req)
+
+			// Assert.
+			require.Equal(t, tt.wantStatus, rec.Code, rec.Body.String())
+
+			var body map[string]any
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.ElementsMatch(t, tt.wantKeys, keys(body))
+			assert.Equal(t, tt.wantDeprecated, rec.Header().Get(middleware.HeaderDeprecation) != "")
+		})
+	}
+
+	t.Run("deprecated operations are documented", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		rest.Handler(ws).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+
+		var document openapi.Document
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &document))
+		assert.True(t, document.Paths["/v1/birthdays"]["get"].Deprecated)
+		assert.False(t, document.Paths["/v2/birthdays"]["get"].Deprecated)
+		assert.Equal(t, []string{"birthdays"}, document.Paths["/v2/birthdays"]["get"].Tags)
+	})
+}
+
+func keys(m map[string]any) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+
+	return out
+}
@@ -4,12 +4,17 @@ import (
 	"time"
 
 	"github.com/kianooshaz/skeleton/foundation/database/postgres"
+	"github.com/kianooshaz/skeleton/foundation/database/redis"
 	"github.com/kianooshaz/skeleton/foundation/log"
+	"github.com/kianooshaz/skeleton/internal/app/web/grpc"
 	"github.com/kianooshaz/skeleton/internal/app/web/rest"
 	usernameservice "github.com/kianooshaz/skeleton/services/account/username/service"
+	lockoutservice "github.com/kianooshaz/skeleton/services/authentication/lockout/service"
 	passwordservice "github.com/kianooshaz/skeleton/services/authentication/password/service"
+	tokenservice "github.com/kianooshaz/skeleton/services/authentication/token/service"
 	auditservice "github.com/kianooshaz/skeleton/services/risk/audit/service"
 	birthdayservice "github.com/kianooshaz/skeleton/services/user/birthday/service"
+	userservice "github.com/kianooshaz/skeleton/services/user/user/service"
 )
 
 // AppConfig represents the root application configuration.
@@ -17,9 +22,14 @@ type AppConfig struct {
 	ShutdownTimeout time.Duration          `yaml:"shutdown_timeout"`
 	Logger          log.LoggerConfig       `yaml:"logger"`
 	RestServer      rest.Config            `yaml:"rest_server"`
+	GRPCServer      grpc.Config            `yaml:"grpc_server"`
 	Postgres        postgres.Config        `yaml:"postgres"`
+	Redis           redis.Config           `yaml:"redis"`
 	Password        passwordservice.Config `yaml:"password"`
+	Lockout         lockoutservice.Config  `yaml:"lockout"`
+	Token           tokenservice.Config    `yaml:"token"`
 	Username        usernameservice.Config `yaml:"username"`
 	Audit           auditservice.Config    `yaml:"audit"`
 	Birthday        birthdayservice.Config `yaml:"birthday"`
+	User            userservice.Config     `yaml:"user"`
 }
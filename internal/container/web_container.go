@@ -3,8 +3,15 @@ package container
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"log/slog"
+	"maps"
+	"net/http"
+	"slices"
 
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/kianooshaz/skeleton/foundation/lifecycle"
 	"github.com/kianooshaz/skeleton/internal/app/web/protocol"
 	usernameproto "github.com/kianooshaz/skeleton/services/account/username/proto"
 	passwordproto "github.com/kianooshaz/skeleton/services/authentication/password/proto"
@@ -18,29 +25,36 @@ import (
 type WebContainer struct {
 	config              *AppConfig
 	logger              *slog.Logger
+	lifecycle           *lifecycle.Registry
 	db                  *sql.DB
-	webService          protocol.WebService
+	redis               *goredis.Client
+	webServices         map[string]protocol.WebService
 	userService         userproto.UserService
 	organizationService orgproto.OrganizationService
 	passwordService     passwordproto.PasswordService
 	usernameService     usernameproto.UsernameService
 	auditService        auditproto.AuditService
 	birthdayService     birthdayproto.BirthdayService
+	// cancel ends the application when a component fails while running.
+	cancel context.CancelFunc
 }
 
-// Start initializes and starts all services.
+// Start starts all components in dependency order. It returns the error of the
+// first component failing to start, once the ones started before it are
+// stopped again.
 func (c *WebContainer) Start(cancel context.CancelFunc) error {
-	go func() {
-		if err := c.webService.Start(); err != nil {
-			c.logger.Error("Failed to start web service", "error", err)
-			cancel()
-		}
-	}()
+	c.cancel = cancel
 
-	return nil
+	if err := c.registerComponents(); err != nil {
+		return err
+	}
+
+	return c.lifecycle.Start(context.Background())
 }
 
-// Stop gracefully shuts down all services.
+// Stop gracefully shuts down all components, in the reverse order they were
+// started, within the shutdown timeout. It returns the errors of the
+// components that failed to stop or failed while running.
 func (c *WebContainer) Stop() error {
 	c.logger.Info("Starting graceful shutdown of web container")
 
@@ -48,30 +62,114 @@ func (c *WebContainer) Stop() error {
 	ctx, cancel := context.WithTimeout(context.Background(), c.config.ShutdownTimeout)
 	defer cancel()
 
-	// Stop services in reverse order of dependency
-	if c.webService != nil {
-		c.logger.Info("Shutting down web service")
-		if err := c.webService.Shutdown(ctx); err != nil {
-			c.webService.Close()
-			c.logger.Error("Failed to shutdown web service", "error", err)
+	return c.lifecycle.Stop(ctx)
+}
+
+func (c *WebContainer) Logger() *slog.Logger {
+	return c.logger
+}
+
+// registerComponents registers the components of the application along with
+// what they depend on.
+func (c *WebContainer) registerComponents() error {
+	var stores []string
+
+	if c.db != nil {
+		err := c.lifecycle.Register("postgres", lifecycle.Hooks{
+			OnStart: c.db.PingContext,
+			OnStop:  func(context.Context) error { return c.db.Close() },
+		})
+		if err != nil {
+			return err
+		}
+		stores = append(stores, "postgres")
+	}
+
+	// Redis is not pinged: the hybrid rate limit backend works while it is down.
+	if c.redis != nil {
+		err := c.lifecycle.Register("redis", lifecycle.Hooks{
+			OnStop: func(context.Context) error { return c.redis.Close() },
+		})
+		if err != nil {
+			return err
+		}
+		stores = append(stores, "redis")
+	}
+
+	var services []string
+
+	if c.userService != nil {
+		err := c.lifecycle.Register("user", lifecycle.Hooks{
+			OnStop: func(ctx context.Context) error {
+				c.userService.Shutdown(ctx)
+				return ctx.Err()
+			},
+		}, stores...)
+		if err != nil {
+			return err
 		}
+		services = append(services, "user")
 	}
 
 	if c.auditService != nil {
-		c.logger.Info("Shutting down audit service")
-		c.auditService.Shutdown(ctx)
+		err := c.lifecycle.Register("audit", lifecycle.Hooks{
+			OnStop: func(ctx context.Context) error {
+				c.auditService.Shutdown(ctx)
+				return ctx.Err()
+			},
+		}, stores...)
+		if err != nil {
+			return err
+		}
+		services = append(services, "audit")
 	}
 
-	if c.db != nil {
-		c.logger.Info("Closing database connection")
-		if err := c.db.Close(); err != nil {
-			c.logger.Error("Failed to close database connection", "error", err)
+	for _, name := range slices.Sorted(maps.Keys(c.webServices)) {
+		component := &webComponent{
+			service: c.webServices[name],
+			failed:  func(err error) { c.failed(name, err) },
+		}
+		if err := c.lifecycle.Register(name, component, slices.Concat(stores, services)...); err != nil {
+			return err
 		}
 	}
 
 	return nil
 }
 
-func (c *WebContainer) Logger() *slog.Logger {
-	return c.logger
+// failed ends the application after the named component failed while running.
+func (c *WebContainer) failed(name string, err error) {
+	c.lifecycle.Fail(name, err)
+	c.logger.Error("Component failed while running", "component", name, "error", err)
+	c.cancel()
+}
+
+// webComponent runs a web service. It is started once the service listens,
+// and reports the service failing afterwards to failed.
+type webComponent struct {
+	service protocol.WebService
+	failed  func(err error)
+}
+
+func (w *webComponent) Start(context.Context) error {
+	if err := w.service.Listen(); err != nil {
+		return err
+	}
+
+	go func() {
+		if err := w.service.Start(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			w.failed(err)
+		}
+	}()
+
+	return nil
+}
+
+func (w *webComponent) Stop(ctx context.Context) error {
+	if err := w.service.Shutdown(ctx); err != nil {
+		w.service.Close()
+		return err
+	}
+
+	return nil
 }
@@ -9,16 +9,28 @@ import (
 
 	"github.com/google/wire"
 	"github.com/knadh/koanf/v2"
+	goredis "github.com/redis/go-redis/v9"
 
 	"github.com/kianooshaz/skeleton/foundation/config"
 	"github.com/kianooshaz/skeleton/foundation/database/postgres"
+	"github.com/kianooshaz/skeleton/foundation/database/redis"
+	"github.com/kianooshaz/skeleton/foundation/idempotency"
+	"github.com/kianooshaz/skeleton/foundation/lifecycle"
 	"github.com/kianooshaz/skeleton/foundation/log"
+	"github.com/kianooshaz/skeleton/foundation/pubsub"
+	"github.com/kianooshaz/skeleton/foundation/ratelimit"
+	"github.com/kianooshaz/skeleton/internal/app/web/grpc"
 	"github.com/kianooshaz/skeleton/internal/app/web/protocol"
 	"github.com/kianooshaz/skeleton/internal/app/web/rest"
+	statusservice "github.com/kianooshaz/skeleton/services/account/status/service"
 	usernameproto "github.com/kianooshaz/skeleton/services/account/username/proto"
 	usernameservice "github.com/kianooshaz/skeleton/services/account/username/service"
+	lockoutproto "github.com/kianooshaz/skeleton/services/authentication/lockout/proto"
+	lockoutservice "github.com/kianooshaz/skeleton/services/authentication/lockout/service"
 	passwordproto "github.com/kianooshaz/skeleton/services/authentication/password/proto"
 	passwordservice "github.com/kianooshaz/skeleton/services/authentication/password/service"
+	tokenproto "github.com/kianooshaz/skeleton/services/authentication/token/proto"
+	tokenservice "github.com/kianooshaz/skeleton/services/authentication/token/service"
 	orgproto "github.com/kianooshaz/skeleton/services/organization/organization/proto"
 	orgservice "github.com/kianooshaz/skeleton/services/organization/organization/service"
 	auditproto "github.com/kianooshaz/skeleton/services/risk/audit/proto"
@@ -39,19 +51,155 @@ func ProvideAppConfig(k *koanf.Koanf) (*AppConfig, error) {
 }
 
 func ProvidePasswordConfig(cfg *AppConfig) passwordservice.Config { return cfg.Password }
+func ProvideLockoutConfig(cfg *AppConfig) lockoutservice.Config   { return cfg.Lockout }
+func ProvideTokenConfig(cfg *AppConfig) tokenservice.Config       { return cfg.Token }
 func ProvideUsernameConfig(cfg *AppConfig) usernameservice.Config { return cfg.Username }
 func ProvideAuditConfig(cfg *AppConfig) auditservice.Config       { return cfg.Audit }
 func ProvideBirthdayConfig(cfg *AppConfig) birthdayservice.Config { return cfg.Birthday }
+func ProvideUserConfig(cfg *AppConfig) userservice.Config         { return cfg.User }
 func ProvideRestConfig(cfg *AppConfig) rest.Config                { return cfg.RestServer }
+func ProvideGRPCConfig(cfg *AppConfig) grpc.Config                { return cfg.GRPCServer }
 func ProvideLoggerConfig(cfg *AppConfig) log.LoggerConfig         { return cfg.Logger }
 func ProvidePostgresConfig(cfg *AppConfig) postgres.Config        { return cfg.Postgres }
 
+// ProvideRedisClient connects to Redis. Only the rate limiter, the idempotency
+// store and the audit stream need it, so unless one of their backends uses
+// Redis no connection is made and the client is nil. The hybrid rate limit
+// backend copes with Redis being down, so it may start without it when nothing
+// else needs it.
+func ProvideRedisClient(cfg *AppConfig) (*goredis.Client, error) {
+	rateLimit := cfg.RestServer.RateLimit
+	idempotency := cfg.RestServer.Idempotency
+	limiterNeedsRedis := rateLimitEnabled(cfg) && rateLimit.Backend.NeedsRedis()
+	othersNeedRedis := (idempotency.Enable && idempotency.Backend.NeedsRedis()) ||
+		cfg.Audit.Stream.Backend.NeedsRedis()
+	if !limiterNeedsRedis && !othersNeedRedis {
+		return nil, nil
+	}
+
+	if !othersNeedRedis && rateLimit.Backend == ratelimit.BackendHybrid {
+		return redis.Open(cfg.Redis), nil
+	}
+
+	return redis.NewClient(cfg.Redis)
+}
+
+// ProvideAuditBroker provides the broker written audit records reach the
+// streams of every instance through.
+func ProvideAuditBroker(cfg auditservice.Config, client *goredis.Client) (pubsub.Broker, error) {
+	var redisClient goredis.UniversalClient
+	if client != nil {
+		redisClient = client
+	}
+
+	return pubsub.New(cfg.Stream.Backend, redisClient)
+}
+
+// ProvideRateLimiter provides the limiter shared by the REST and gRPC servers,
+// on the backend of the REST server, or nil when neither limits requests.
+func ProvideRateLimiter(cfg *AppConfig, client *goredis.Client, logger *slog.Logger) (ratelimit.Limiter, error) {
+	if !rateLimitEnabled(cfg) {
+		return nil, nil
+	}
+
+	var redisClient goredis.Cmdable
+	if client != nil {
+		redisClient = client
+	}
+
+	return ratelimit.New(cfg.RestServer.RateLimit.Backend, redisClient, logger)
+}
+
+// ProvideIdempotencyStore provides the store of the responses of requests made
+// under an idempotency key, or nil when the REST server does not honour them.
+func ProvideIdempotencyStore(cfg *AppConfig, client *goredis.Client, db *sql.DB) (idempotency.Store, error) {
+	if !cfg.RestServer.Idempotency.Enable {
+		return nil, nil
+	}
+
+	var redisClient goredis.Cmdable
+	if client != nil {
+		redisClient = client
+	}
+
+	return idempotency.New(cfg.RestServer.Idempotency.Backend, redisClient, db)
+}
+
+// rateLimitEnabled reports whether any server limits requests.
+func rateLimitEnabled(cfg *AppConfig) bool {
+	return cfg.RestServer.RateLimit.Enable || (cfg.GRPCServer.Enable && cfg.GRPCServer.RateLimit.Enable)
+}
+
+// ProvideWebServices provides the servers of the application by name: the REST
+// server, and the gRPC server when it is enabled.
+func ProvideWebServices(
+	restCfg rest.Config,
+	grpcCfg grpc.Config,
+	logger *slog.Logger,
+	limiter ratelimit.Limiter,
+	idempotencyStore idempotency.Store,
+	health lifecycle.Reporter,
+	userService userproto.UserService,
+	orgService orgproto.OrganizationService,
+	passwordService passwordproto.PasswordService,
+	usernameService usernameproto.UsernameService,
+	auditService auditproto.AuditService,
+	birthdayService birthdayproto.BirthdayService,
+	tokenService tokenproto.TokenService,
+	lockoutService lockoutproto.LockoutService,
+) (map[string]protocol.WebService, error) {
+	restService, err := rest.New(
+		restCfg,
+		logger,
+		limiter,
+		idempotencyStore,
+		health,
+		userService,
+		orgService,
+		passwordService,
+		usernameService,
+		auditService,
+		birthdayService,
+		tokenService,
+		lockoutService,
+	)
+	if err != nil {
+		return nil, err
+	}
+	webServices := map[string]protocol.WebService{"rest": restService}
+
+	if grpcCfg.Enable {
+		grpcService, err := grpc.New(
+			grpcCfg,
+			logger,
+			limiter,
+			health,
+			userService,
+			orgService,
+			passwordService,
+			usernameService,
+			auditService,
+			birthdayService,
+			tokenService,
+			lockoutService,
+		)
+		if err != nil {
+			return nil, err
+		}
+		webServices["grpc"] = grpcService
+	}
+
+	return webServices, nil
+}
+
 // ProvideWebContainer provides the complete web container.
 func ProvideWebContainer(
 	cfg *AppConfig,
 	logger *slog.Logger,
+	registry *lifecycle.Registry,
 	db *sql.DB,
-	webService protocol.WebService,
+	redisClient *goredis.Client,
+	webServices map[string]protocol.WebService,
 	userService userproto.UserService,
 	orgService orgproto.OrganizationService,
 	passwordService passwordproto.PasswordService,
@@ -62,8 +210,10 @@ func ProvideWebContainer(
 	return &WebContainer{
 		config:              cfg,
 		logger:              logger,
+		lifecycle:           registry,
 		db:                  db,
-		webService:          webService,
+		redis:               redisClient,
+		webServices:         webServices,
 		userService:         userService,
 		organizationService: orgService,
 		passwordService:     passwordService,
@@ -78,10 +228,14 @@ var ConfigSet = wire.NewSet(
 	config.LoadConfigWithDefaults,
 	ProvideAppConfig,
 	ProvidePasswordConfig,
+	ProvideLockoutConfig,
+	ProvideTokenConfig,
 	ProvideUsernameConfig,
 	ProvideAuditConfig,
 	ProvideBirthdayConfig,
+	ProvideUserConfig,
 	ProvideRestConfig,
+	ProvideGRPCConfig,
 	ProvideLoggerConfig,
 	ProvidePostgresConfig,
 )
@@ -90,21 +244,34 @@ var LoggerSet = wire.NewSet(
 	log.NewLogger,
 )
 
+var LifecycleSet = wire.NewSet(
+	lifecycle.NewRegistry,
+	wire.Bind(new(lifecycle.Reporter), new(*lifecycle.Registry)),
+)
+
 var DatabaseSet = wire.NewSet(
 	postgres.NewConnection,
+	ProvideRedisClient,
 )
 
 var WebContainerSet = wire.NewSet(
 	ConfigSet,
 	LoggerSet,
+	LifecycleSet,
 	DatabaseSet,
+	statusservice.New,
 	userservice.New,
 	orgservice.New,
+	lockoutservice.New,
 	passwordservice.New,
+	tokenservice.New,
 	usernameservice.New,
+	ProvideAuditBroker,
 	auditservice.New,
 	birthdayservice.New,
-	rest.New,
+	ProvideRateLimiter,
+	ProvideIdempotencyStore,
+	ProvideWebServices,
 	ProvideWebContainer,
 )
 
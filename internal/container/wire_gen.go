@@ -11,13 +11,24 @@ import (
 	"github.com/google/wire"
 	"github.com/kianooshaz/skeleton/foundation/config"
 	"github.com/kianooshaz/skeleton/foundation/database/postgres"
+	redis2 "github.com/kianooshaz/skeleton/foundation/database/redis"
+	"github.com/kianooshaz/skeleton/foundation/idempotency"
+	"github.com/kianooshaz/skeleton/foundation/lifecycle"
 	"github.com/kianooshaz/skeleton/foundation/log"
+	"github.com/kianooshaz/skeleton/foundation/pubsub"
+	"github.com/kianooshaz/skeleton/foundation/ratelimit"
+	"github.com/kianooshaz/skeleton/internal/app/web/grpc"
 	"github.com/kianooshaz/skeleton/internal/app/web/protocol"
 	"github.com/kianooshaz/skeleton/internal/app/web/rest"
+	"github.com/kianooshaz/skeleton/services/account/status/service"
 	"github.com/kianooshaz/skeleton/services/account/username/proto"
 	"github.com/kianooshaz/skeleton/services/account/username/service"
+	"github.com/kianooshaz/skeleton/services/authentication/lockout/proto"
+	"github.com/kianooshaz/skeleton/services/authentication/lockout/service"
 	"github.com/kianooshaz/skeleton/services/authentication/password/proto"
 	"github.com/kianooshaz/skeleton/services/authentication/password/service"
+	"github.com/kianooshaz/skeleton/services/authentication/token/proto"
+	"github.com/kianooshaz/skeleton/services/authentication/token/service"
 	"github.com/kianooshaz/skeleton/services/organization/organization/proto"
 	"github.com/kianooshaz/skeleton/services/organization/organization/service"
 	"github.com/kianooshaz/skeleton/services/risk/audit/proto"
@@ -27,6 +38,7 @@ import (
 	"github.com/kianooshaz/skeleton/services/user/user/proto"
 	"github.com/kianooshaz/skeleton/services/user/user/service"
 	"github.com/knadh/koanf/v2"
+	"github.com/redis/go-redis/v9"
 	"log/slog"
 )
 
@@ -44,27 +56,57 @@ func NewWebContainer() (Container, error) {
 	}
 	loggerConfig := ProvideLoggerConfig(appConfig)
 	logger := log.NewLogger(loggerConfig)
+	registry := lifecycle.NewRegistry(logger)
 	postgresConfig := ProvidePostgresConfig(appConfig)
 	db, err := postgres.NewConnection(postgresConfig)
 	if err != nil {
 		return nil, err
 	}
+	client, err := ProvideRedisClient(appConfig)
+	if err != nil {
+		return nil, err
+	}
 	restConfig := ProvideRestConfig(appConfig)
-	userService := userservice.New(db, logger)
-	organizationService := orgservice.New(db, logger)
-	passwordserviceConfig := ProvidePasswordConfig(appConfig)
-	passwordService := passwordservice.New(passwordserviceConfig, db, logger)
-	usernameserviceConfig := ProvideUsernameConfig(appConfig)
-	usernameService := usernameservice.New(usernameserviceConfig, db, logger)
+	grpcConfig := ProvideGRPCConfig(appConfig)
+	limiter, err := ProvideRateLimiter(appConfig, client, logger)
+	if err != nil {
+		return nil, err
+	}
+	store, err := ProvideIdempotencyStore(appConfig, client, db)
+	if err != nil {
+		return nil, err
+	}
+	userserviceConfig := ProvideUserConfig(appConfig)
 	auditserviceConfig := ProvideAuditConfig(appConfig)
-	auditService := auditservice.New(auditserviceConfig, db, logger)
-	webService, err := rest.New(restConfig, logger, userService, organizationService, passwordService, usernameService, auditService)
+	broker, err := ProvideAuditBroker(auditserviceConfig, client)
+	if err != nil {
+		return nil, err
+	}
+	auditService, err := auditservice.New(auditserviceConfig, db, broker, logger)
 	if err != nil {
 		return nil, err
 	}
+	statusService := statusservice.New(db, auditService, logger)
+	userService := userservice.New(userserviceConfig, db, statusService, logger)
+	organizationService := orgservice.New(db, logger)
+	passwordserviceConfig := ProvidePasswordConfig(appConfig)
+	lockoutserviceConfig := ProvideLockoutConfig(appConfig)
+	lockoutService := lockoutservice.New(lockoutserviceConfig, db, statusService, logger)
+	passwordService := passwordservice.New(passwordserviceConfig, db, lockoutService, logger)
+	usernameserviceConfig := ProvideUsernameConfig(appConfig)
+	usernameService := usernameservice.New(usernameserviceConfig, db, statusService, logger)
 	birthdayserviceConfig := ProvideBirthdayConfig(appConfig)
 	birthdayService := birthdayservice.New(birthdayserviceConfig, db, logger)
-	container := ProvideWebContainer(appConfig, logger, db, webService, userService, organizationService, passwordService, usernameService, auditService, birthdayService)
+	tokenserviceConfig := ProvideTokenConfig(appConfig)
+	tokenService, err := tokenservice.New(tokenserviceConfig, passwordService, logger)
+	if err != nil {
+		return nil, err
+	}
+	v, err := ProvideWebServices(restConfig, grpcConfig, logger, limiter, store, registry, userService, organizationService, passwordService, usernameService, auditService, birthdayService, tokenService, lockoutService)
+	if err != nil {
+		return nil, err
+	}
+	container := ProvideWebContainer(appConfig, logger, registry, db, client, v, userService, organizationService, passwordService, usernameService, auditService, birthdayService)
 	return container, nil
 }
 
@@ -81,24 +123,164 @@ func ProvideAppConfig(k *koanf.Koanf) (*AppConfig, error) {
 
 func ProvidePasswordConfig(cfg *AppConfig) passwordservice.Config { return cfg.Password }
 
+func ProvideLockoutConfig(cfg *AppConfig) lockoutservice.Config { return cfg.Lockout }
+
+func ProvideTokenConfig(cfg *AppConfig) tokenservice.Config { return cfg.Token }
+
 func ProvideUsernameConfig(cfg *AppConfig) usernameservice.Config { return cfg.Username }
 
 func ProvideAuditConfig(cfg *AppConfig) auditservice.Config { return cfg.Audit }
 
 func ProvideBirthdayConfig(cfg *AppConfig) birthdayservice.Config { return cfg.Birthday }
 
+func ProvideUserConfig(cfg *AppConfig) userservice.Config { return cfg.User }
+
 func ProvideRestConfig(cfg *AppConfig) rest.Config { return cfg.RestServer }
 
+func ProvideGRPCConfig(cfg *AppConfig) grpc.Config { return cfg.GRPCServer }
+
 func ProvideLoggerConfig(cfg *AppConfig) log.LoggerConfig { return cfg.Logger }
 
 func ProvidePostgresConfig(cfg *AppConfig) postgres.Config { return cfg.Postgres }
 
+// ProvideRedisClient connects to Redis. Only the rate limiter, the idempotency
+// store and the audit stream need it, so unless one of their backends uses
+// Redis no connection is made and the client is nil. The hybrid rate limit
+// backend copes with Redis being down, so it may start without it when nothing
+// else needs it.
+func ProvideRedisClient(cfg *AppConfig) (*redis.Client, error) {
+	rateLimit := cfg.RestServer.RateLimit
+	idempotency := cfg.RestServer.Idempotency
+	limiterNeedsRedis := rateLimitEnabled(cfg) && rateLimit.Backend.NeedsRedis()
+	othersNeedRedis := (idempotency.Enable && idempotency.Backend.NeedsRedis()) ||
+		cfg.Audit.Stream.Backend.NeedsRedis()
+	if !limiterNeedsRedis && !othersNeedRedis {
+		return nil, nil
+	}
+
+	if !othersNeedRedis && rateLimit.Backend == ratelimit.BackendHybrid {
+		return redis2.Open(cfg.Redis), nil
+	}
+
+	return redis2.NewClient(cfg.Redis)
+}
+
+// ProvideAuditBroker provides the broker written audit records reach the
+// streams of every instance through.
+func ProvideAuditBroker(cfg auditservice.Config, client *redis.Client) (pubsub.Broker, error) {
+	var redisClient redis.UniversalClient
+	if client != nil {
+		redisClient = client
+	}
+
+	return pubsub.New(cfg.Stream.Backend, redisClient)
+}
+
+// ProvideRateLimiter provides the limiter shared by the REST and gRPC servers,
+// on the backend of the REST server, or nil when neither limits requests.
+func ProvideRateLimiter(cfg *AppConfig, client *redis.Client, logger *slog.Logger) (ratelimit.Limiter, error) {
+	if !rateLimitEnabled(cfg) {
+		return nil, nil
+	}
+
+	var redisClient redis.Cmdable
+	if client != nil {
+		redisClient = client
+	}
+
+	return ratelimit.New(cfg.RestServer.RateLimit.Backend, redisClient, logger)
+}
+
+// ProvideIdempotencyStore provides the store of the responses of requests made
+// under an idempotency key, or nil when the REST server does not honour them.
+func ProvideIdempotencyStore(cfg *AppConfig, client *redis.Client, db *sql.DB) (idempotency.Store, error) {
+	if !cfg.RestServer.Idempotency.Enable {
+		return nil, nil
+	}
+
+	var redisClient redis.Cmdable
+	if client != nil {
+		redisClient = client
+	}
+
+	return idempotency.New(cfg.RestServer.Idempotency.Backend, redisClient, db)
+}
+
+// rateLimitEnabled reports whether any server limits requests.
+func rateLimitEnabled(cfg *AppConfig) bool {
+	return cfg.RestServer.RateLimit.Enable || (cfg.GRPCServer.Enable && cfg.GRPCServer.RateLimit.Enable)
+}
+
+// ProvideWebServices provides the servers of the application by name: the REST
+// server, and the gRPC server when it is enabled.
+func ProvideWebServices(
+	restCfg rest.Config,
+	grpcCfg grpc.Config,
+	logger *slog.Logger,
+	limiter ratelimit.Limiter,
+	idempotencyStore idempotency.Store,
+	health lifecycle.Reporter,
+	userService userproto.UserService,
+	orgService orgproto.OrganizationService,
+	passwordService passwordproto.PasswordService,
+	usernameService usernameproto.UsernameService,
+	auditService auditproto.AuditService,
+	birthdayService birthdayproto.BirthdayService,
+	tokenService tokenproto.TokenService,
+	lockoutService lockoutproto.LockoutService,
+) (map[string]protocol.WebService, error) {
+	restService, err := rest.New(
+		restCfg,
+		logger,
+		limiter,
+		idempotencyStore,
+		health,
+		userService,
+		orgService,
+		passwordService,
+		usernameService,
+		auditService,
+		birthdayService,
+		tokenService,
+		lockoutService,
+	)
+	if err != nil {
+		return nil, err
+	}
+	webServices := map[string]protocol.WebService{"rest": restService}
+
+	if grpcCfg.Enable {
+		grpcService, err := grpc.New(
+			grpcCfg,
+			logger,
+			limiter,
+			health,
+			userService,
+			orgService,
+			passwordService,
+			usernameService,
+			auditService,
+			birthdayService,
+			tokenService,
+			lockoutService,
+		)
+		if err != nil {
+			return nil, err
+		}
+		webServices["grpc"] = grpcService
+	}
+
+	return webServices, nil
+}
+
 // ProvideWebContainer provides the complete web container.
 func ProvideWebContainer(
 	cfg *AppConfig,
 	logger *slog.Logger,
+	registry *lifecycle.Registry,
 	db *sql.DB,
-	webService protocol.WebService,
+	redisClient *redis.Client,
+	webServices map[string]protocol.WebService,
 	userService userproto.UserService,
 	orgService orgproto.OrganizationService,
 	passwordService passwordproto.PasswordService,
@@ -109,8 +291,10 @@ func ProvideWebContainer(
 	return &WebContainer{
 		config:              cfg,
 		logger:              logger,
+		lifecycle:           registry,
 		db:                  db,
-		webService:          webService,
+		redis:               redisClient,
+		webServices:         webServices,
 		userService:         userService,
 		organizationService: orgService,
 		passwordService:     passwordService,
@@ -123,20 +307,30 @@ func ProvideWebContainer(
 // Wire sets define the dependency injection graph.
 var ConfigSet = wire.NewSet(config.LoadConfigWithDefaults, ProvideAppConfig,
 	ProvidePasswordConfig,
+	ProvideLockoutConfig,
+	ProvideTokenConfig,
 	ProvideUsernameConfig,
 	ProvideAuditConfig,
 	ProvideBirthdayConfig,
+	ProvideUserConfig,
 	ProvideRestConfig,
+	ProvideGRPCConfig,
 	ProvideLoggerConfig,
 	ProvidePostgresConfig,
 )
 
 var LoggerSet = wire.NewSet(log.NewLogger)
 
-var DatabaseSet = wire.NewSet(postgres.NewConnection)
+var LifecycleSet = wire.NewSet(lifecycle.NewRegistry, wire.Bind(new(lifecycle.Reporter), new(*lifecycle.Registry)))
+
+var DatabaseSet = wire.NewSet(postgres.NewConnection, ProvideRedisClient)
 
 var WebContainerSet = wire.NewSet(
 	ConfigSet,
 	LoggerSet,
-	DatabaseSet, userservice.New, orgservice.New, passwordservice.New, usernameservice.New, auditservice.New, birthdayservice.New, rest.New, ProvideWebContainer,
+	LifecycleSet,
+	DatabaseSet, statusservice.New, userservice.New, orgservice.New, lockoutservice.New, passwordservice.New, tokenservice.New, usernameservice.New, ProvideAuditBroker, auditservice.New, birthdayservice.New, ProvideRateLimiter,
+	ProvideIdempotencyStore,
+	ProvideWebServices,
+	ProvideWebContainer,
 )
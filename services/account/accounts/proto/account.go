@@ -3,16 +3,18 @@ package accproto
 import (
 	"time"
 
-	"github.com/google/uuid"
+	"github.com/kianooshaz/skeleton/foundation/id"
 	orgproto "github.com/kianooshaz/skeleton/services/organization/organization/proto"
 	userproto "github.com/kianooshaz/skeleton/services/user/user/proto"
 )
 
-type AccountID uuid.UUID
+// AccountKind is the kind of account IDs.
+type AccountKind struct{}
 
-func (a AccountID) String() string {
-	return uuid.UUID(a).String()
-}
+func (AccountKind) Prefix() string { return "acc" }
+
+// AccountID identifies an account.
+type AccountID = id.ID[AccountKind]
 
 type Account struct {
 	ID             AccountID               `json:"id"`
@@ -0,0 +1,60 @@
+package persistence
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/google/uuid"
+	dbproto "github.com/kianooshaz/skeleton/foundation/database/proto"
+	statusproto "github.com/kianooshaz/skeleton/services/account/status/proto"
+)
+
+// HistoryMemoryStorage keeps the status history in memory. It behaves like
+// HistoryStorage and is meant for tests.
+type HistoryMemoryStorage struct {
+	mu      sync.RWMutex
+	entries []statusproto.Entry
+}
+
+// NewHistoryMemoryStorage creates an empty HistoryMemoryStorage.
+func NewHistoryMemoryStorage() *HistoryMemoryStorage {
+	return &HistoryMemoryStorage{}
+}
+
+func (ms *HistoryMemoryStorage) Create(_ context.Context, entry statusproto.Entry) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	if slices.ContainsFunc(ms.entries, func(e statusproto.Entry) bool { return e.ID == entry.ID }) {
+		return dbproto.ErrDuplicateKey
+	}
+
+	ms.entries = append(ms.entries, entry)
+
+	return nil
+}
+
+func (ms *HistoryMemoryStorage) ListByEntity(_ context.Context, entity, entityID string) ([]statusproto.Entry, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	var entries []statusproto.Entry
+	for _, entry := range ms.entries {
+		if entry.Entity == entity && entry.EntityID == entityID {
+			entries = append(entries, entry)
+		}
+	}
+
+	slices.SortFunc(entries, func(a, b statusproto.Entry) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+
+		aID, bID := uuid.UUID(a.ID), uuid.UUID(b.ID)
+		return bytes.Compare(aID[:], bID[:])
+	})
+
+	return entries, nil
+}
@@ -0,0 +1,91 @@
+// Package persistencetest holds the contract every status history storage must
+// meet, so the in-memory storage used by tests stays faithful to the PostgreSQL one.
+package persistencetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kianooshaz/skeleton/foundation/stat"
+	statusproto "github.com/kianooshaz/skeleton/services/account/status/proto"
+)
+
+// Storage is the status history storage under contract.
+type Storage interface {
+	Create(ctx context.Context, entry statusproto.Entry) error
+	ListByEntity(ctx context.Context, entity, entityID string) ([]statusproto.Entry, error)
+}
+
+// Setup returns an empty storage and the context to call it with.
+type Setup func(t *testing.T) (Storage, context.Context)
+
+// Run runs the contract tests against the storages returned by setup.
+func Run(t *testing.T, setup Setup) {
+	t.Run("create and list", func(t *testing.T) {
+		storage, ctx := setup(t)
+
+		entityID := uuid.NewString()
+		base := time.Now().UTC().Truncate(time.Microsecond)
+		lock := newEntry(t, "username", entityID, "lock", stat.Unset, stat.Locked, base)
+		unlock := newEntry(t, "username", entityID, "unlock", stat.Locked, stat.Unset, base.Add(time.Minute))
+		other := newEntry(t, "username", uuid.NewString(), "lock", stat.Unset, stat.Locked, base)
+		otherEntity := newEntry(t, "account", entityID, "lock", stat.Unset, stat.Locked, base)
+
+		for _, entry := range []statusproto.Entry{unlock, lock, other, otherEntity} {
+			require.NoError(t, storage.Create(ctx, entry))
+		}
+		require.Error(t, storage.Create(ctx, lock), "duplicate ID")
+
+		entries, err := storage.ListByEntity(ctx, "username", entityID)
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		assertEntry(t, lock, entries[0])
+		assertEntry(t, unlock, entries[1])
+	})
+
+	t.Run("list without history", func(t *testing.T) {
+		storage, ctx := setup(t)
+
+		entries, err := storage.ListByEntity(ctx, "username", uuid.NewString())
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+}
+
+func newEntry(
+	t *testing.T, entity, entityID, transition string, from, to stat.Status, at time.Time,
+) statusproto.Entry {
+	t.Helper()
+
+	id, err := uuid.NewV7()
+	require.NoError(t, err)
+
+	return statusproto.Entry{
+		ID:         statusproto.EntryID(id),
+		Entity:     entity,
+		EntityID:   entityID,
+		Transition: transition,
+		From:       from,
+		To:         to,
+		Actor:      statusproto.SystemActor,
+		Reason:     "test",
+		CreatedAt:  at,
+	}
+}
+
+func assertEntry(t *testing.T, want, got statusproto.Entry) {
+	t.Helper()
+
+	assert.Equal(t, want.ID, got.ID)
+	assert.Equal(t, want.Transition, got.Transition)
+	assert.Equal(t, want.From, got.From)
+	assert.Equal(t, want.To, got.To)
+	assert.Equal(t, want.Actor, got.Actor)
+	assert.Equal(t, want.Reason, got.Reason)
+	assert.WithinDuration(t, want.CreatedAt, got.CreatedAt, time.Millisecond)
+}
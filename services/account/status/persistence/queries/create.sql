@@ -0,0 +1,12 @@
+INSERT INTO status_history (
+        id,
+        entity,
+        entity_id,
+        transition,
+        from_status,
+        to_status,
+        actor,
+        reason,
+        created_at
+    )
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
\ No newline at end of file
@@ -0,0 +1,14 @@
+SELECT id,
+    entity,
+    entity_id,
+    transition,
+    from_status,
+    to_status,
+    actor,
+    reason,
+    created_at
+FROM status_history
+WHERE entity = $1
+    AND entity_id = $2
+ORDER BY created_at,
+    id
\ No newline at end of file
@@ -0,0 +1,51 @@
+package persistence
+
+import (
+	"context"
+	_ "embed"
+
+	dbproto "github.com/kianooshaz/skeleton/foundation/database/proto"
+	"github.com/kianooshaz/skeleton/foundation/session"
+	statusproto "github.com/kianooshaz/skeleton/services/account/status/proto"
+)
+
+type HistoryStorage struct {
+	Conn dbproto.QueryExecutor
+}
+
+//go:embed queries/create.sql
+var createQuery string
+
+//go:embed queries/list_by_entity.sql
+var listByEntityQuery string
+
+func (hs *HistoryStorage) Create(ctx context.Context, entry statusproto.Entry) error {
+	conn := session.GetDBConnection(ctx, hs.Conn)
+
+	_, err := conn.ExecContext(ctx, createQuery, entry.ID, entry.Entity, entry.EntityID, entry.Transition,
+		entry.From, entry.To, entry.Actor, entry.Reason, entry.CreatedAt)
+	return err
+}
+
+func (hs *HistoryStorage) ListByEntity(ctx context.Context, entity, entityID string) ([]statusproto.Entry, error) {
+	conn := session.GetDBConnection(ctx, hs.Conn)
+
+	rows, err := conn.QueryContext(ctx, listByEntityQuery, entity, entityID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []statusproto.Entry
+	for rows.Next() {
+		var entry statusproto.Entry
+		err := rows.Scan(&entry.ID, &entry.Entity, &entry.EntityID, &entry.Transition,
+			&entry.From, &entry.To, &entry.Actor, &entry.Reason, &entry.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
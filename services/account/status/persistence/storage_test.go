@@ -0,0 +1,24 @@
+package persistence_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kianooshaz/skeleton/foundation/database/postgres/postgrestest"
+	"github.com/kianooshaz/skeleton/services/account/status/persistence"
+	"github.com/kianooshaz/skeleton/services/account/status/persistence/persistencetest"
+)
+
+func TestHistoryMemoryStorage(t *testing.T) {
+	persistencetest.Run(t, func(t *testing.T) (persistencetest.Storage, context.Context) {
+		return persistence.NewHistoryMemoryStorage(), t.Context()
+	})
+}
+
+func TestHistoryStorage(t *testing.T) {
+	db := postgrestest.Open(t, "../schema.sql")
+
+	persistencetest.Run(t, func(t *testing.T) (persistencetest.Storage, context.Context) {
+		return &persistence.HistoryStorage{Conn: db}, postgrestest.Context(t, db, "status_history")
+	})
+}
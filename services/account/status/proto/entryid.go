@@ -0,0 +1,11 @@
+package statusproto
+
+import "github.com/kianooshaz/skeleton/foundation/id"
+
+// EntryKind is the kind of status history IDs.
+type EntryKind struct{}
+
+func (EntryKind) Prefix() string { return "" }
+
+// EntryID identifies a status history entry.
+type EntryID = id.ID[EntryKind]
@@ -0,0 +1,53 @@
+package statusproto
+
+import (
+	"context"
+	"time"
+
+	"github.com/kianooshaz/skeleton/foundation/stat"
+)
+
+// SystemActor is the actor of transitions made without an authenticated principal,
+// such as locks clearing after their cooldown.
+const SystemActor = "system"
+
+// Entry is a status transition of an entity in the status history.
+type Entry struct {
+	ID         EntryID     `json:"id"`
+	Entity     string      `json:"entity"`
+	EntityID   string      `json:"entity_id"`
+	Transition string      `json:"transition"`
+	From       stat.Status `json:"from"`
+	To         stat.Status `json:"to"`
+	// Actor is the ID of the user that made the transition, or SystemActor.
+	Actor     string    `json:"actor"`
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type StatusService interface {
+	// Record adds a transition to the status history, in the transaction of ctx
+	// if there is one, and audits it once that transaction commits. The actor is
+	// the principal of ctx.
+	Record(ctx context.Context, req RecordRequest) error
+	// History returns the transitions of an entity, oldest first.
+	History(ctx context.Context, req HistoryRequest) (HistoryResponse, error)
+}
+
+type RecordRequest struct {
+	Entity     string      `json:"entity"`
+	EntityID   string      `json:"entity_id"`
+	Transition string      `json:"transition"`
+	From       stat.Status `json:"from"`
+	To         stat.Status `json:"to"`
+	Reason     string      `json:"reason"`
+}
+
+type HistoryRequest struct {
+	Entity   string `json:"entity"`
+	EntityID string `json:"entity_id"`
+}
+
+type HistoryResponse struct {
+	Data []Entry `json:"data"`
+}
@@ -0,0 +1,17 @@
+-- Status Service Database Schema
+-- This file contains the SQL schema for the status service tables.
+-- Run this manually in your PostgreSQL database to create the required tables.
+-- Create status_history table, shared by every entity with a status machine
+CREATE TABLE IF NOT EXISTS status_history (
+    id UUID PRIMARY KEY,
+    entity TEXT NOT NULL,
+    entity_id TEXT NOT NULL,
+    transition TEXT NOT NULL,
+    from_status BIGINT NOT NULL,
+    to_status BIGINT NOT NULL,
+    actor TEXT NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+-- Create indexes for better performance
+CREATE INDEX IF NOT EXISTS idx_status_history_entity ON status_history (entity, entity_id, created_at);
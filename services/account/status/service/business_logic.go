@@ -0,0 +1,93 @@
+package statusservice
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+
+	"github.com/kianooshaz/skeleton/foundation/derror"
+	"github.com/kianooshaz/skeleton/foundation/id"
+	"github.com/kianooshaz/skeleton/foundation/session"
+	statusproto "github.com/kianooshaz/skeleton/services/account/status/proto"
+	auditproto "github.com/kianooshaz/skeleton/services/risk/audit/proto"
+)
+
+func (s *Service) Record(ctx context.Context, req statusproto.RecordRequest) error {
+	entryID, err := id.New[statusproto.EntryKind]()
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error encountered while generating status history id", slog.String("error", err.Error()))
+
+		return derror.ErrInternalSystem
+	}
+
+	actor := statusproto.SystemActor
+	if userID, ok := session.GetUserID(ctx); ok {
+		actor = userID.String()
+	}
+
+	entry := statusproto.Entry{
+		ID:         entryID,
+		Entity:     req.Entity,
+		EntityID:   req.EntityID,
+		Transition: req.Transition,
+		From:       req.From,
+		To:         req.To,
+		Actor:      actor,
+		Reason:     req.Reason,
+		CreatedAt:  s.now(),
+	}
+
+	if err := s.persister.Create(ctx, entry); err != nil {
+		s.logger.ErrorContext(
+			ctx,
+			"Error encountered while creating status history entry in storage",
+			slog.String("error", err.Error()),
+			slog.Any("entry", entry),
+		)
+
+		return derror.ErrInternalSystem
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		s.logger.ErrorContext(
+			ctx,
+			"Error encountered while marshalling audit record of status transition",
+			slog.String("error", err.Error()),
+		)
+
+		return nil
+	}
+
+	record := auditproto.Record{
+		Action:       auditproto.Action(entry.Transition),
+		CreatedAt:    entry.CreatedAt,
+		Data:         data,
+		OriginIP:     session.GetClientIP(ctx),
+		ResourceType: entry.Entity,
+	}
+
+	// The transition is recorded within the transaction of the caller; it is
+	// only audited once that commits.
+	session.AfterCommit(ctx, func(ctx context.Context) {
+		s.audit.Record(ctx, record)
+	})
+
+	return nil
+}
+
+func (s *Service) History(ctx context.Context, req statusproto.HistoryRequest) (statusproto.HistoryResponse, error) {
+	entries, err := s.persister.ListByEntity(ctx, req.Entity, req.EntityID)
+	if err != nil {
+		s.logger.ErrorContext(
+			ctx,
+			"Error encountered while listing status history from storage",
+			slog.String("error", err.Error()),
+			slog.Any("req", req),
+		)
+
+		return statusproto.HistoryResponse{}, derror.ErrInternalSystem
+	}
+
+	return statusproto.HistoryResponse{Data: entries}, nil
+}
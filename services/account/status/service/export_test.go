@@ -0,0 +1,13 @@
+package statusservice
+
+import (
+	"log/slog"
+	"time"
+
+	auditproto "github.com/kianooshaz/skeleton/services/risk/audit/proto"
+)
+
+// NewWithStorage creates a service on the given storage, reading the time from now.
+func NewWithStorage(storage persister, audit auditproto.AuditService, logger *slog.Logger, now func() time.Time) *Service {
+	return newService(storage, audit, logger, now)
+}
@@ -0,0 +1,49 @@
+// Package statusservice keeps the history of the status transitions of every
+// entity with a status machine.
+package statusservice
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	"github.com/kianooshaz/skeleton/services/account/status/persistence"
+	statusproto "github.com/kianooshaz/skeleton/services/account/status/proto"
+	auditproto "github.com/kianooshaz/skeleton/services/risk/audit/proto"
+)
+
+type (
+	persister interface {
+		Create(ctx context.Context, entry statusproto.Entry) error
+		ListByEntity(ctx context.Context, entity, entityID string) ([]statusproto.Entry, error)
+	}
+
+	Service struct {
+		persister persister
+		audit     auditproto.AuditService
+		logger    *slog.Logger
+		now       func() time.Time
+	}
+)
+
+// New creates a new status service instance.
+func New(db *sql.DB, audit auditproto.AuditService, logger *slog.Logger) statusproto.StatusService {
+	serviceLogger := logger.With(
+		slog.Group("package_info",
+			slog.String("module", "account"),
+			slog.String("service", "status"),
+		),
+	)
+
+	return newService(&persistence.HistoryStorage{Conn: db}, audit, serviceLogger, time.Now)
+}
+
+func newService(persister persister, audit auditproto.AuditService, logger *slog.Logger, now func() time.Time) *Service {
+	return &Service{
+		persister: persister,
+		audit:     audit,
+		logger:    logger,
+		now:       now,
+	}
+}
@@ -0,0 +1,146 @@
+package statusservice_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kianooshaz/skeleton/foundation/session"
+	"github.com/kianooshaz/skeleton/foundation/stat"
+	"github.com/kianooshaz/skeleton/services/account/status/persistence"
+	statusproto "github.com/kianooshaz/skeleton/services/account/status/proto"
+	statusservice "github.com/kianooshaz/skeleton/services/account/status/service"
+	auditproto "github.com/kianooshaz/skeleton/services/risk/audit/proto"
+)
+
+// auditRecorder keeps the audit records it is given.
+type auditRecorder struct {
+	auditproto.AuditService
+
+	records []auditproto.Record
+}
+
+func (a *auditRecorder) Record(_ context.Context, record auditproto.Record) {
+	a.records = append(a.records, record)
+}
+
+func TestService_Record(t *testing.T) {
+	userID := uuid.New()
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		wantActor string
+	}{
+		{
+			name:      "by a user",
+			ctx:       session.SetPrincipal(context.Background(), session.Principal{UserID: userID}),
+			wantActor: userID.String(),
+		},
+		{
+			name:      "by the system",
+			ctx:       context.Background(),
+			wantActor: statusproto.SystemActor,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			audit := &auditRecorder{}
+			service := statusservice.NewWithStorage(
+				persistence.NewHistoryMemoryStorage(),
+				audit,
+				slog.New(slog.NewTextHandler(io.Discard, nil)),
+				func() time.Time { return now },
+			)
+			entityID := uuid.NewString()
+
+			// Execute.
+			err := service.Record(tt.ctx, statusproto.RecordRequest{
+				Entity:     "username",
+				EntityID:   entityID,
+				Transition: "lock",
+				From:       stat.Primary,
+				To:         stat.Primary | stat.Locked,
+				Reason:     "abuse report",
+			})
+
+			// Assert.
+			require.NoError(t, err)
+
+			history, err := service.History(context.Background(), statusproto.HistoryRequest{
+				Entity:   "username",
+				EntityID: entityID,
+			})
+			require.NoError(t, err)
+			require.Len(t, history.Data, 1)
+			entry := history.Data[0]
+			assert.Equal(t, "lock", entry.Transition)
+			assert.Equal(t, stat.Primary, entry.From)
+			assert.Equal(t, stat.Primary|stat.Locked, entry.To)
+			assert.Equal(t, tt.wantActor, entry.Actor)
+			assert.Equal(t, "abuse report", entry.Reason)
+			assert.Equal(t, now, entry.CreatedAt)
+
+			require.Len(t, audit.records, 1)
+			assert.Equal(t, auditproto.Action("lock"), audit.records[0].Action)
+			assert.Equal(t, "username", audit.records[0].ResourceType)
+			var audited statusproto.Entry
+			require.NoError(t, json.Unmarshal(audit.records[0].Data, &audited))
+			assert.Equal(t, entry.ID, audited.ID)
+		})
+	}
+}
+
+func TestService_RecordInTransaction(t *testing.T) {
+	tests := []struct {
+		name        string
+		commit      bool
+		wantRecords int
+	}{
+		{name: "audited on commit", commit: true, wantRecords: 1},
+		{name: "not audited on rollback", commit: false, wantRecords: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			audit := &auditRecorder{}
+			service := statusservice.NewWithStorage(
+				persistence.NewHistoryMemoryStorage(),
+				audit,
+				slog.New(slog.NewTextHandler(io.Discard, nil)),
+				time.Now,
+			)
+
+			tx, ctx, err := session.NopTransactor{}.Begin(context.Background())
+			require.NoError(t, err)
+
+			// Execute.
+			err = service.Record(ctx, statusproto.RecordRequest{
+				Entity:     "username",
+				EntityID:   uuid.NewString(),
+				Transition: "lock",
+				From:       stat.Primary,
+				To:         stat.Primary | stat.Locked,
+			})
+			require.NoError(t, err)
+			assert.Empty(t, audit.records, "audited before the transaction ends")
+
+			if tt.commit {
+				require.NoError(t, tx.Commit())
+			}
+			require.NoError(t, tx.Rollback())
+
+			// Assert.
+			assert.Len(t, audit.records, tt.wantRecords)
+		})
+	}
+}
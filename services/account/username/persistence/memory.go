@@ -0,0 +1,213 @@
+package persistence
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	dbproto "github.com/kianooshaz/skeleton/foundation/database/proto"
+	"github.com/kianooshaz/skeleton/foundation/order"
+	"github.com/kianooshaz/skeleton/foundation/pagination"
+	accprotocol "github.com/kianooshaz/skeleton/services/account/accounts/proto"
+	usernameproto "github.com/kianooshaz/skeleton/services/account/username/proto"
+)
+
+// UsernameMemoryStorage keeps usernames in memory. It behaves like
+// UsernameStorage, soft deletes included, and is meant for tests.
+type UsernameMemoryStorage struct {
+	mu        sync.RWMutex
+	usernames map[uuid.UUID]usernameproto.Username
+	deleted   map[uuid.UUID]bool
+}
+
+// NewUsernameMemoryStorage creates an empty UsernameMemoryStorage.
+func NewUsernameMemoryStorage() *UsernameMemoryStorage {
+	return &UsernameMemoryStorage{
+		usernames: make(map[uuid.UUID]usernameproto.Username),
+		deleted:   make(map[uuid.UUID]bool),
+	}
+}
+
+func (ms *UsernameMemoryStorage) Create(_ context.Context, username usernameproto.Username) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	if _, ok := ms.usernames[username.ID]; ok {
+		return dbproto.ErrDuplicateKey
+	}
+
+	now := time.Now()
+	username.CreatedAt = now
+	username.UpdatedAt = now
+	ms.usernames[username.ID] = username
+
+	return nil
+}
+
+func (ms *UsernameMemoryStorage) Delete(_ context.Context, id uuid.UUID) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	if _, ok := ms.usernames[id]; ok {
+		ms.deleted[id] = true
+	}
+
+	return nil
+}
+
+func (ms *UsernameMemoryStorage) Get(_ context.Context, id uuid.UUID) (usernameproto.Username, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	username, ok := ms.usernames[id]
+	if !ok || ms.deleted[id] {
+		return usernameproto.Username{}, dbproto.ErrRowNotFound
+	}
+
+	return username, nil
+}
+
+func (ms *UsernameMemoryStorage) ListWithSearch(
+	_ context.Context, req usernameproto.ListRequest,
+) ([]usernameproto.Username, pagination.Cursors, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	return ms.list(ms.search(req), req.Page, req.Sort)
+}
+
+func (ms *UsernameMemoryStorage) ExportWithSearch(
+	_ context.Context, req usernameproto.ListRequest, yield func(usernameproto.Username) error,
+) error {
+	columns, err := orderWhitelist.Resolve(req.Sort)
+	if err != nil {
+		return err
+	}
+
+	ms.mu.RLock()
+	usernames := ms.search(req)
+	ms.mu.RUnlock()
+
+	for _, username := range memoryKeyset.Sort(usernames, columns...) {
+		if err := yield(username); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (ms *UsernameMemoryStorage) CountWithSearch(_ context.Context, req usernameproto.ListRequest) (int64, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	return int64(len(ms.search(req))), nil
+}
+
+func (ms *UsernameMemoryStorage) ListByUserAndOrganization(
+	_ context.Context, req usernameproto.ListAssignedRequest,
+) ([]usernameproto.Username, pagination.Cursors, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	return ms.list(ms.byAccount(req.AccountID), req.Page, req.Sort)
+}
+
+func (ms *UsernameMemoryStorage) UpdateStatus(_ context.Context, username usernameproto.Username) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	existing, ok := ms.usernames[username.ID]
+	if !ok || ms.deleted[username.ID] {
+		return nil
+	}
+
+	existing.Status = username.Status
+	existing.UpdatedAt = time.Now()
+	ms.usernames[username.ID] = existing
+
+	return nil
+}
+
+func (ms *UsernameMemoryStorage) Exist(_ context.Context, username string) (bool, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	for id, existing := range ms.usernames {
+		if existing.Username == username && !ms.deleted[id] {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (ms *UsernameMemoryStorage) CountByAccount(_ context.Context, accountID accprotocol.AccountID) (int64, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	return int64(len(ms.byAccount(accountID))), nil
+}
+
+// list pages the usernames of an account like UsernameStorage does. The
+// caller holds the lock.
+func (ms *UsernameMemoryStorage) list(
+	usernames []usernameproto.Username, page pagination.Page, sort order.Spec,
+) ([]usernameproto.Username, pagination.Cursors, error) {
+	columns, err := orderWhitelist.Resolve(sort)
+	if err != nil {
+		return nil, pagination.Cursors{}, err
+	}
+
+	keyset := memoryKeyset
+	keyset.Key = cursorKey(columns)
+	keyset.Lookup = func(id string) (usernameproto.Username, bool) {
+		parsed, err := uuid.Parse(id)
+		if err != nil {
+			return usernameproto.Username{}, false
+		}
+
+		username, ok := ms.usernames[parsed]
+		return username, ok
+	}
+
+	return keyset.Page(usernames, page, columns...)
+}
+
+func (ms *UsernameMemoryStorage) byAccount(accountID accprotocol.AccountID) []usernameproto.Username {
+	usernames := make([]usernameproto.Username, 0)
+	for id, username := range ms.usernames {
+		if username.AccountID == accountID && !ms.deleted[id] {
+			usernames = append(usernames, username)
+		}
+	}
+
+	return usernames
+}
+
+func (ms *UsernameMemoryStorage) search(req usernameproto.ListRequest) []usernameproto.Username {
+	usernames := ms.byAccount(req.AccountID.Get())
+
+	return slices.DeleteFunc(usernames, func(username usernameproto.Username) bool {
+		return !req.Status.Match(username.Status)
+	})
+}
+
+var memoryKeyset = pagination.MemoryKeyset[usernameproto.Username]{
+	Compare: compareUsernames,
+	ID:      cursorID,
+}
+
+func compareUsernames(a, b usernameproto.Username, column string) int {
+	switch column {
+	case "created_at":
+		return a.CreatedAt.Compare(b.CreatedAt)
+	case "account_id":
+		return strings.Compare(a.AccountID.String(), b.AccountID.String())
+	default:
+		return 0
+	}
+}
@@ -1,27 +1,36 @@
 package persistence
 
-import "github.com/kianooshaz/skeleton/foundation/order"
+import (
+	"github.com/kianooshaz/skeleton/foundation/order"
+	"github.com/kianooshaz/skeleton/foundation/pagination"
+	usernameproto "github.com/kianooshaz/skeleton/services/account/username/proto"
+)
 
-var oderStringer order.StringerFunc = func(orderBy order.OrderBy) string {
-	var field string
-	switch orderBy.Field {
-	case "created_at":
-		field = "created_at"
-	case "account_id":
-		field = "account_id"
-	default:
-		field = "created_at"
-	}
+var orderWhitelist = order.Whitelist{
+	Columns: map[string]string{
+		"created_at": "created_at",
+		"account_id": "account_id",
+	},
+	Default: order.Spec{{Field: "created_at", Direction: order.ASC}},
+}
 
-	var direction string
-	switch orderBy.Direction {
-	case order.ASC:
-		direction = "ASC"
-	case order.DESC:
-		direction = "DESC"
-	default:
-		direction = "ASC"
+// cursorKey returns a function extracting the ORDER BY key of a username for the given columns.
+func cursorKey(columns []order.Column) func(usernameproto.Username) []string {
+	return func(username usernameproto.Username) []string {
+		keys := make([]string, 0, len(columns))
+		for _, column := range columns {
+			switch column.Name {
+			case "created_at":
+				keys = append(keys, pagination.TimeKey(username.CreatedAt))
+			case "account_id":
+				keys = append(keys, username.AccountID.String())
+			}
+		}
+
+		return keys
 	}
+}
 
-	return field + " " + direction
+func cursorID(username usernameproto.Username) string {
+	return username.ID.String()
 }
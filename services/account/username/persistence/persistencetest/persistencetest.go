@@ -0,0 +1,307 @@
+// Package persistencetest holds the contract every username storage must meet,
+// so the in-memory storage used by tests stays faithful to the PostgreSQL one.
+package persistencetest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbproto "github.com/kianooshaz/skeleton/foundation/database/proto"
+	"github.com/kianooshaz/skeleton/foundation/derror"
+	"github.com/kianooshaz/skeleton/foundation/order"
+	"github.com/kianooshaz/skeleton/foundation/pagination"
+	"github.com/kianooshaz/skeleton/foundation/stat"
+	"github.com/kianooshaz/skeleton/foundation/types"
+	accprotocol "github.com/kianooshaz/skeleton/services/account/accounts/proto"
+	usernameproto "github.com/kianooshaz/skeleton/services/account/username/proto"
+	usernameservice "github.com/kianooshaz/skeleton/services/account/username/service"
+)
+
+// Storage is the username storage under contract.
+type Storage = usernameservice.Storer
+
+// Setup returns an empty storage and the context to call it with.
+type Setup func(t *testing.T) (Storage, context.Context)
+
+// Run runs the contract tests against the storages returned by setup.
+func Run(t *testing.T, setup Setup) {
+	t.Run("create and get", func(t *testing.T) {
+		storage, ctx := setup(t)
+
+		username := newUsername(accprotocol.AccountID(uuid.New()), "skeleton")
+		require.NoError(t, storage.Create(ctx, username))
+
+		got, err := storage.Get(ctx, username.ID)
+		require.NoError(t, err)
+		assert.Equal(t, username.ID, got.ID)
+		assert.Equal(t, username.Username, got.Username)
+		assert.Equal(t, username.AccountID, got.AccountID)
+		assert.Equal(t, username.Status, got.Status)
+		assert.False(t, got.CreatedAt.IsZero())
+
+		exists, err := storage.Exist(ctx, "skeleton")
+		require.NoError(t, err)
+		assert.True(t, exists)
+
+		exists, err = storage.Exist(ctx, "unknown")
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("get missing", func(t *testing.T) {
+		storage, ctx := setup(t)
+
+		_, err := storage.Get(ctx, uuid.New())
+		require.ErrorIs(t, err, dbproto.ErrRowNotFound)
+	})
+
+	t.Run("delete is soft", func(t *testing.T) {
+		storage, ctx := setup(t)
+
+		username := newUsername(accprotocol.AccountID(uuid.New()), "skeleton")
+		require.NoError(t, storage.Create(ctx, username))
+		require.NoError(t, storage.Delete(ctx, username.ID))
+
+		_, err := storage.Get(ctx, username.ID)
+		require.ErrorIs(t, err, dbproto.ErrRowNotFound)
+
+		exists, err := storage.Exist(ctx, "skeleton")
+		require.NoError(t, err)
+		assert.False(t, exists, "a deleted username can be taken again")
+
+		count, err := storage.CountByAccount(ctx, username.AccountID)
+		require.NoError(t, err)
+		assert.Zero(t, count)
+	})
+
+	t.Run("update status", func(t *testing.T) {
+		storage, ctx := setup(t)
+
+		username := newUsername(accprotocol.AccountID(uuid.New()), "skeleton")
+		require.NoError(t, storage.Create(ctx, username))
+
+		username.Status = stat.Primary | stat.Locked
+		require.NoError(t, storage.UpdateStatus(ctx, username))
+
+		got, err := storage.Get(ctx, username.ID)
+		require.NoError(t, err)
+		assert.Equal(t, stat.Primary|stat.Locked, got.Status)
+	})
+
+	t.Run("list and count by account", func(t *testing.T) {
+		storage, ctx := setup(t)
+
+		accountID := accprotocol.AccountID(uuid.New())
+		for _, name := range []string{"first", "second", "third"} {
+			require.NoError(t, storage.Create(ctx, newUsername(accountID, name)))
+		}
+		require.NoError(t, storage.Create(ctx, newUsername(accprotocol.AccountID(uuid.New()), "other")))
+
+		count, err := storage.CountByAccount(ctx, accountID)
+		require.NoError(t, err)
+		assert.EqualValues(t, 3, count)
+
+		assigned, _, err := storage.ListByUserAndOrganization(ctx, usernameproto.ListAssignedRequest{
+			AccountID: accountID,
+			Page:      pagination.Page{PageRows: 2},
+		})
+		require.NoError(t, err)
+		assert.Len(t, assigned, 2)
+
+		assigned, _, err = storage.ListByUserAndOrganization(ctx, usernameproto.ListAssignedRequest{
+			AccountID: accountID,
+			Page:      pagination.Page{PageRows: 2, PageNumber: 2},
+		})
+		require.NoError(t, err)
+		assert.Len(t, assigned, 1)
+
+		search := usernameproto.ListRequest{
+			AccountID: types.NewNullable(accountID),
+			Page:      pagination.Page{PageRows: 10},
+		}
+
+		searched, _, err := storage.ListWithSearch(ctx, search)
+		require.NoError(t, err)
+		assert.Len(t, searched, 3)
+
+		count, err = storage.CountWithSearch(ctx, search)
+		require.NoError(t, err)
+		assert.EqualValues(t, 3, count)
+	})
+
+	t.Run("search filters by status flags", func(t *testing.T) {
+		storage, ctx := setup(t)
+
+		accountID := accprotocol.AccountID(uuid.New())
+		statuses := map[string]stat.Status{
+			"primary":        stat.Primary,
+			"locked-primary": stat.Primary | stat.Locked,
+			"blocked":        stat.Blocked,
+			"reserved":       stat.Reserved,
+		}
+		for name, status := range statuses {
+			username := newUsername(accountID, name)
+			username.Status = status
+			require.NoError(t, storage.Create(ctx, username))
+		}
+
+		tests := []struct {
+			name   string
+			filter stat.Filter
+			want   []string
+		}{
+			{
+				name: "no filter",
+				want: []string{"primary", "locked-primary", "blocked", "reserved"},
+			},
+			{
+				name:   "all",
+				filter: stat.Filter{All: stat.Primary | stat.Locked},
+				want:   []string{"locked-primary"},
+			},
+			{
+				name:   "any",
+				filter: stat.Filter{Any: stat.Locked | stat.Blocked},
+				want:   []string{"locked-primary", "blocked"},
+			},
+			{
+				name:   "none",
+				filter: stat.Filter{None: stat.Locked | stat.Blocked},
+				want:   []string{"primary", "reserved"},
+			},
+			{
+				name:   "combined",
+				filter: stat.Filter{All: stat.Primary, None: stat.Locked},
+				want:   []string{"primary"},
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				search := usernameproto.ListRequest{
+					AccountID: types.NewNullable(accountID),
+					Status:    tt.filter,
+					Page:      pagination.Page{PageRows: 10},
+				}
+
+				searched, _, err := storage.ListWithSearch(ctx, search)
+				require.NoError(t, err)
+				names := make([]string, 0, len(searched))
+				for _, username := range searched {
+					names = append(names, username.Username)
+				}
+				assert.ElementsMatch(t, tt.want, names)
+
+				count, err := storage.CountWithSearch(ctx, search)
+				require.NoError(t, err)
+				assert.EqualValues(t, len(tt.want), count)
+			})
+		}
+	})
+
+	t.Run("export with search", func(t *testing.T) {
+		storage, ctx := setup(t)
+
+		accountID := accprotocol.AccountID(uuid.New())
+		for _, name := range []string{"first", "second", "third"} {
+			username := newUsername(accountID, name)
+			if name == "second" {
+				username.Status = stat.Blocked
+			}
+			require.NoError(t, storage.Create(ctx, username))
+		}
+		require.NoError(t, storage.Create(ctx, newUsername(accprotocol.AccountID(uuid.New()), "other")))
+
+		search := usernameproto.ListRequest{
+			AccountID: types.NewNullable(accountID),
+			Status:    stat.Filter{None: stat.Blocked},
+			Page:      pagination.Page{PageRows: 1},
+		}
+
+		var names []string
+		err := storage.ExportWithSearch(ctx, search, func(username usernameproto.Username) error {
+			names = append(names, username.Username)
+			return nil
+		})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"first", "third"}, names, "paging does not apply to exports")
+
+		stop := errors.New("stop")
+		calls := 0
+		err = storage.ExportWithSearch(ctx, search, func(usernameproto.Username) error {
+			calls++
+			return stop
+		})
+		require.ErrorIs(t, err, stop)
+		assert.Equal(t, 1, calls)
+
+		search.Sort = order.Spec{{Field: "status"}}
+		err = storage.ExportWithSearch(ctx, search, func(usernameproto.Username) error { return nil })
+		require.ErrorIs(t, err, derror.ErrUnknownOrder)
+	})
+
+	t.Run("list pages by cursor", func(t *testing.T) {
+		storage, ctx := setup(t)
+
+		accountID := accprotocol.AccountID(uuid.New())
+		for _, name := range []string{"first", "second", "third"} {
+			require.NoError(t, storage.Create(ctx, newUsername(accountID, name)))
+		}
+
+		search := usernameproto.ListRequest{
+			AccountID: types.NewNullable(accountID),
+			Page:      pagination.Page{PageRows: 2},
+		}
+		first, cursors, err := storage.ListWithSearch(ctx, search)
+		require.NoError(t, err)
+		require.Len(t, first, 2)
+		require.NotEmpty(t, cursors.Next)
+		assert.Empty(t, cursors.Prev)
+
+		search.Page = pagination.Page{PageRows: 2, Cursor: cursors.Next}
+		second, cursors, err := storage.ListWithSearch(ctx, search)
+		require.NoError(t, err)
+		require.Len(t, second, 1)
+		assert.NotContains(t, first, second[0])
+		assert.Empty(t, cursors.Next)
+		require.NotEmpty(t, cursors.Prev)
+
+		assigned, _, err := storage.ListByUserAndOrganization(ctx, usernameproto.ListAssignedRequest{
+			AccountID: accountID,
+			Page:      pagination.Page{PageRows: 2, Cursor: cursors.Prev},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, first, assigned, "the previous cursor leads back to the first page")
+
+		search.Sort = order.Spec{{Field: "created_at", Direction: order.DESC}}
+		_, _, err = storage.ListWithSearch(ctx, search)
+		require.ErrorIs(t, err, derror.ErrInvalidCursor, "cursor of another ordering")
+
+		search.Page.Cursor = "not-a-cursor"
+		_, _, err = storage.ListWithSearch(ctx, search)
+		require.ErrorIs(t, err, derror.ErrInvalidCursor)
+	})
+
+	t.Run("list rejects unknown order", func(t *testing.T) {
+		storage, ctx := setup(t)
+
+		_, _, err := storage.ListByUserAndOrganization(ctx, usernameproto.ListAssignedRequest{
+			Sort: order.Spec{{Field: "status"}},
+		})
+		require.ErrorIs(t, err, derror.ErrUnknownOrder)
+	})
+}
+
+func newUsername(accountID accprotocol.AccountID, name string) usernameproto.Username {
+	return usernameproto.Username{
+		ID:        uuid.New(),
+		Username:  name,
+		AccountID: accountID,
+		Status:    stat.Reserved,
+	}
+}
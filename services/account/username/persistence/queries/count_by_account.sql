@@ -1,4 +1,4 @@
-SELECT COUNT(`id`)
-FROM `usernames`
-WHERE `account_id` = $1
-    AND `deleted_at` IS NULL
\ No newline at end of file
+SELECT COUNT(id)
+FROM usernames
+WHERE account_id = $1
+    AND deleted_at IS NULL
\ No newline at end of file
@@ -1,4 +1,4 @@
-UPDATE `usernames`
-SET `deleted_at` = NOW()
-WHERE `id` = $1
-    AND `deleted_at` IS NULL
\ No newline at end of file
+UPDATE usernames
+SET deleted_at = NOW()
+WHERE id = $1
+    AND deleted_at IS NULL
\ No newline at end of file
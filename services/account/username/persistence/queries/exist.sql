@@ -1,6 +1,6 @@
 SELECT EXISTS(
                 SELECT 1
-                FROM `usernames`
-                WHERE `username` = $1
-                        AND `deleted_at` IS NULL
+                FROM usernames
+                WHERE username = $1
+                        AND deleted_at IS NULL
         )
\ No newline at end of file
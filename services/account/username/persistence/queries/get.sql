@@ -1,9 +1,9 @@
-SELECT `id`,
-    `username`,
-    `account_id`,
-    `status`,
-    `created_at`,
-    `updated_at`
-FROM `usernames`
-WHERE `id` = $1
-    AND `deleted_at` IS NULL
\ No newline at end of file
+SELECT id,
+    username,
+    account_id,
+    status,
+    created_at,
+    updated_at
+FROM usernames
+WHERE id = $1
+    AND deleted_at IS NULL
\ No newline at end of file
@@ -1,5 +1,5 @@
-UPDATE `usernames`
-SET `status` = $2,
-    `updated_at` = NOW()
-WHERE `id` = $1
-    AND `deleted_at` IS NULL
\ No newline at end of file
+UPDATE usernames
+SET status = $2,
+    updated_at = NOW()
+WHERE id = $1
+    AND deleted_at IS NULL
\ No newline at end of file
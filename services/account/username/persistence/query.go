@@ -8,8 +8,10 @@ import (
 
 	"github.com/google/uuid"
 	dbproto "github.com/kianooshaz/skeleton/foundation/database/proto"
+	"github.com/kianooshaz/skeleton/foundation/order"
 	"github.com/kianooshaz/skeleton/foundation/pagination"
 	"github.com/kianooshaz/skeleton/foundation/session"
+	"github.com/kianooshaz/skeleton/foundation/stat"
 	accprotocol "github.com/kianooshaz/skeleton/services/account/accounts/proto"
 	usernameproto "github.com/kianooshaz/skeleton/services/account/username/proto"
 )
@@ -18,8 +20,6 @@ type UsernameStorage struct {
 	Conn dbproto.QueryExecutor
 }
 
-const defaultPageSize = 20
-
 //go:embed queries/create.sql
 var createQuery string
 
@@ -76,19 +76,34 @@ func (us *UsernameStorage) Get(ctx context.Context, id uuid.UUID) (usernameproto
 
 func (us *UsernameStorage) ListWithSearch(
 	ctx context.Context, req usernameproto.ListRequest,
-) ([]usernameproto.Username, error) {
+) ([]usernameproto.Username, pagination.Cursors, error) {
+	condition, args := statusCondition(req.Status)
+
+	return us.list(ctx, condition, append([]any{req.AccountID.Get()}, args...), req.Page, req.Sort)
+}
+
+// ExportWithSearch calls yield with every username matching req, paging aside,
+// reading them from the database as yield consumes them. It stops at the first
+// error of yield.
+func (us *UsernameStorage) ExportWithSearch(
+	ctx context.Context, req usernameproto.ListRequest, yield func(usernameproto.Username) error,
+) error {
 	conn := session.GetDBConnection(ctx, us.Conn)
 
-	query := listByAccountQuery + req.OrderBy.String(oderStringer) +
-		req.Page.String(pagination.SQLStringer(defaultPageSize))
+	columns, err := orderWhitelist.Resolve(req.Sort)
+	if err != nil {
+		return err
+	}
+
+	condition, args := statusCondition(req.Status)
+	query := listByAccountQuery + condition + order.SQL(columns)
 
-	rows, err := conn.QueryContext(ctx, query, req.AccountID)
+	rows, err := conn.QueryContext(ctx, query, append([]any{req.AccountID.Get()}, args...)...)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer rows.Close()
 
-	var usernames []usernameproto.Username
 	for rows.Next() {
 		var username usernameproto.Username
 		if err := rows.Scan(
@@ -99,19 +114,24 @@ func (us *UsernameStorage) ListWithSearch(
 			&username.CreatedAt,
 			&username.UpdatedAt,
 		); err != nil {
-			return nil, err
+			return err
+		}
+
+		if err := yield(username); err != nil {
+			return err
 		}
-		usernames = append(usernames, username)
 	}
 
-	return usernames, nil
+	return rows.Err()
 }
 
 func (us *UsernameStorage) CountWithSearch(ctx context.Context, req usernameproto.ListRequest) (int64, error) {
 	conn := session.GetDBConnection(ctx, us.Conn)
 
+	condition, args := statusCondition(req.Status)
+
 	var count int64
-	err := conn.QueryRowContext(ctx, countWithSearchQuery, req.AccountID).Scan(&count)
+	err := conn.QueryRowContext(ctx, countWithSearchQuery+condition, append([]any{req.AccountID.Get()}, args...)...).Scan(&count)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return 0, dbproto.ErrRowNotFound
@@ -122,29 +142,76 @@ func (us *UsernameStorage) CountWithSearch(ctx context.Context, req usernameprot
 	return count, nil
 }
 
-func (us *UsernameStorage) ListByUserAndOrganization(ctx context.Context, req usernameproto.ListAssignedRequest) ([]usernameproto.Username, error) {
+// statusCondition narrows a search query, whose only placeholder is the
+// account, to the statuses passing filter.
+func statusCondition(filter stat.Filter) (string, []any) {
+	where, args := filter.Where("status", 2)
+	if where == "" {
+		return "", nil
+	}
+
+	return " AND " + where, args
+}
+
+func (us *UsernameStorage) ListByUserAndOrganization(
+	ctx context.Context, req usernameproto.ListAssignedRequest,
+) ([]usernameproto.Username, pagination.Cursors, error) {
+	return us.list(ctx, "", []any{req.AccountID}, req.Page, req.Sort)
+}
+
+// list pages the usernames of an account narrowed by condition, whose
+// placeholders follow the account in args.
+func (us *UsernameStorage) list(
+	ctx context.Context, condition string, args []any, page pagination.Page, sort order.Spec,
+) ([]usernameproto.Username, pagination.Cursors, error) {
 	conn := session.GetDBConnection(ctx, us.Conn)
 
-	query := listByAccountQuery + req.OrderBy.String(oderStringer) + req.Page.String(pagination.SQLStringer(defaultPageSize))
+	columns, err := orderWhitelist.Resolve(sort)
+	if err != nil {
+		return nil, pagination.Cursors{}, err
+	}
+
+	keyset, err := pagination.NewSQLKeyset(page, "id", columns...)
+	if err != nil {
+		return nil, pagination.Cursors{}, err
+	}
 
-	rows, err := conn.QueryContext(ctx, query, req.AccountID)
+	query := listByAccountQuery + condition
+	where, keysetArgs := keyset.Where(len(args) + 1)
+	if where != "" {
+		query += " AND " + where
+	}
+	query += keyset.OrderBy() + keyset.Limit()
+
+	rows, err := conn.QueryContext(ctx, query, append(args, keysetArgs...)...)
 	if err != nil {
-		return nil, err
+		return nil, pagination.Cursors{}, err
 	}
 	defer rows.Close()
 
-	usernames := make([]usernameproto.Username, 0, req.Page.PageRows)
+	usernames := make([]usernameproto.Username, 0, keyset.Rows()+1)
 	for rows.Next() {
 		var username usernameproto.Username
-		err := rows.Scan(&username.ID, &username.Username, &username.AccountID, &username.Status, &username.CreatedAt, &username.UpdatedAt)
-		if err != nil {
-			return nil, err
+		if err := rows.Scan(
+			&username.ID,
+			&username.Username,
+			&username.AccountID,
+			&username.Status,
+			&username.CreatedAt,
+			&username.UpdatedAt,
+		); err != nil {
+			return nil, pagination.Cursors{}, err
 		}
-
 		usernames = append(usernames, username)
 	}
 
-	return usernames, nil
+	if err := rows.Err(); err != nil {
+		return nil, pagination.Cursors{}, err
+	}
+
+	usernames, cursors := pagination.Window(keyset, usernames, cursorKey(columns), cursorID)
+
+	return usernames, cursors, nil
 }
 
 func (us *UsernameStorage) UpdateStatus(ctx context.Context, username usernameproto.Username) error {
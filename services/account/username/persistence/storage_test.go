@@ -0,0 +1,24 @@
+package persistence_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kianooshaz/skeleton/foundation/database/postgres/postgrestest"
+	"github.com/kianooshaz/skeleton/services/account/username/persistence"
+	"github.com/kianooshaz/skeleton/services/account/username/persistence/persistencetest"
+)
+
+func TestUsernameMemoryStorage(t *testing.T) {
+	persistencetest.Run(t, func(t *testing.T) (persistencetest.Storage, context.Context) {
+		return persistence.NewUsernameMemoryStorage(), t.Context()
+	})
+}
+
+func TestUsernameStorage(t *testing.T) {
+	db := postgrestest.Open(t, "../schema.sql")
+
+	persistencetest.Run(t, func(t *testing.T) (persistencetest.Storage, context.Context) {
+		return &persistence.UsernameStorage{Conn: db}, postgrestest.Context(t, db, "usernames")
+	})
+}
@@ -37,27 +37,45 @@ type UsernameService interface {
 
 	ListAssigned(ctx context.Context, req ListAssignedRequest) (ListAssignedResponse, error)
 
+	// Unassign releases the username with the specified ID from its account.
+	Unassign(ctx context.Context, req UnassignRequest) error
+
 	// Get returns the username with the specified ID.
-	Get(ctx context.Context, id uuid.UUID) (Username, error)
+	Get(ctx context.Context, req GetRequest) (Username, error)
 
 	// Search returns usernames with the specified search criteria.
 	List(ctx context.Context, req ListRequest) (ListResponse, error)
 
+	// Export calls yield with every username matching req, paging aside, as
+	// they are read. It stops at the first error of yield and returns it.
+	Export(ctx context.Context, req ListRequest, yield func(ListUsername) error) error
+
 	// BePrimary sets the username with the specified ID as the primary username.
-	BePrimary(context.Context, uuid.UUID) error
+	BePrimary(ctx context.Context, req BePrimaryRequest) error
 }
 
 type ListAssignedResponse pagination.Response[ListUsername]
 
 type ListRequest struct {
-	Username  types.Nullable[Username]              `query:"username"`
+	Username  types.Nullable[string]                `query:"username"`
 	AccountID types.Nullable[accprotocol.AccountID] `query:"account_id"`
-	Status    types.Nullable[stat.Status]           `query:"status"`
+	// Status narrows the listing by flags, bound from the status_all,
+	// status_any and status_none parameters.
+	Status stat.Filter
 	pagination.Page
-	order.OrderBy
+	Sort order.Spec `query:"sort"`
 }
 
 type ListResponse pagination.Response[ListUsername]
 
 type GetRequest struct {
+	ID uuid.UUID `param:"id" json:"-"`
+}
+
+type UnassignRequest struct {
+	ID uuid.UUID `param:"id" json:"-"`
+}
+
+type BePrimaryRequest struct {
+	ID uuid.UUID `param:"id" json:"-"`
 }
@@ -12,24 +12,23 @@ type AssignRequest struct {
 	Username  string                `json:"username" bson:"username"`
 }
 
-func (req *AssignRequest) Validate(minLen, MaxLen int) error {
-	if len(req.AccountID.String()) == 0 {
+// Validate reports whether the request names an account and a username. The
+// length and characters of the username depend on the service configuration
+// and are checked by Assign.
+func (req AssignRequest) Validate() error {
+	if req.AccountID.IsZero() {
 		return derror.ErrAccountIDRequired
 	}
 
-	if len(req.Username) == 0 {
+	if req.Username == "" {
 		return derror.ErrUsernameRequired
 	}
 
-	if len(req.Username) < minLen || len(req.Username) > MaxLen {
-		return derror.ErrUsernameInvalid
-	}
-
 	return nil
 }
 
 type ListAssignedRequest struct {
-	AccountID accprotocol.AccountID `query:"account_id"`
+	AccountID accprotocol.AccountID `param:"account_id" query:"account_id"`
 	pagination.Page
-	order.OrderBy
+	Sort order.Spec `query:"sort"`
 }
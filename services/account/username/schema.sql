@@ -0,0 +1,17 @@
+-- Username Service Database Schema
+-- This file contains the SQL schema for the username service tables.
+-- Run this manually in your PostgreSQL database to create the required tables.
+-- Create usernames table
+CREATE TABLE IF NOT EXISTS usernames (
+    id UUID PRIMARY KEY,
+    username TEXT NOT NULL,
+    account_id UUID NOT NULL,
+    status BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP NULL
+);
+-- Create indexes for better performance
+CREATE UNIQUE INDEX IF NOT EXISTS idx_usernames_username ON usernames (username)
+WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_usernames_account_id ON usernames (account_id);
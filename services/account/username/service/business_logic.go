@@ -5,7 +5,6 @@ import (
 	"errors"
 	"log/slog"
 
-	"github.com/google/uuid"
 	dbproto "github.com/kianooshaz/skeleton/foundation/database/proto"
 	"github.com/kianooshaz/skeleton/foundation/derror"
 	"github.com/kianooshaz/skeleton/foundation/pagination"
@@ -13,8 +12,8 @@ import (
 	aunp "github.com/kianooshaz/skeleton/services/account/username/proto"
 )
 
-func (s *Service) Get(ctx context.Context, id uuid.UUID) (aunp.Username, error) {
-	username, err := s.storage.Get(ctx, id)
+func (s *Service) Get(ctx context.Context, req aunp.GetRequest) (aunp.Username, error) {
+	username, err := s.storage.Get(ctx, req.ID)
 	if err != nil {
 		if errors.Is(err, dbproto.ErrRowNotFound) {
 			return aunp.Username{}, derror.ErrUsernameNotFound
@@ -28,8 +27,13 @@ func (s *Service) Get(ctx context.Context, id uuid.UUID) (aunp.Username, error)
 }
 
 func (s *Service) List(ctx context.Context, req aunp.ListRequest) (aunp.ListResponse, error) {
-	usernames, err := s.storage.ListWithSearch(ctx, req)
+	usernames, cursors, err := s.storage.ListWithSearch(ctx, req)
 	if err != nil {
+		if errors.Is(err, derror.ErrInvalidCursor) || errors.Is(err, derror.ErrUnknownOrder) ||
+			errors.Is(err, derror.ErrUnknownOrderDirection) {
+			return aunp.ListResponse{}, err
+		}
+
 		s.logger.ErrorContext(
 			ctx,
 			"Error encountered while searching usernames",
@@ -40,30 +44,63 @@ func (s *Service) List(ctx context.Context, req aunp.ListRequest) (aunp.ListResp
 		return aunp.ListResponse{}, derror.ErrInternalSystem
 	}
 
-	count, err := s.storage.CountWithSearch(ctx, req)
+	var count int64
+	if req.NeedsTotal() {
+		count, err = s.storage.CountWithSearch(ctx, req)
+		if err != nil {
+			s.logger.ErrorContext(
+				ctx,
+				"Error encountered while counting usernames",
+				slog.String("error", err.Error()),
+				slog.Any("request", req),
+			)
+
+			return aunp.ListResponse{}, derror.ErrInternalSystem
+		}
+	}
+
+	result := make([]aunp.ListUsername, 0, len(usernames))
+	for _, username := range usernames {
+		result = append(result, listUsername(username))
+	}
+
+	return aunp.ListResponse(pagination.NewCursorResponse(req.Page, int(count), result, cursors)), nil
+}
+
+func (s *Service) Export(ctx context.Context, req aunp.ListRequest, yield func(aunp.ListUsername) error) error {
+	// yieldErr tells the errors of yield, which are returned as they are,
+	// from those of the storage.
+	var yieldErr error
+	err := s.storage.ExportWithSearch(ctx, req, func(username aunp.Username) error {
+		yieldErr = yield(listUsername(username))
+		return yieldErr
+	})
 	if err != nil {
+		if yieldErr != nil || errors.Is(err, derror.ErrUnknownOrder) || errors.Is(err, derror.ErrUnknownOrderDirection) {
+			return err
+		}
+
 		s.logger.ErrorContext(
 			ctx,
-			"Error encountered while counting usernames",
+			"Error encountered while exporting usernames",
 			slog.String("error", err.Error()),
 			slog.Any("request", req),
 		)
 
-		return aunp.ListResponse{}, derror.ErrInternalSystem
+		return derror.ErrInternalSystem
 	}
 
-	result := make([]aunp.ListUsername, 0, len(usernames))
-	for _, username := range usernames {
-		result = append(result, aunp.ListUsername{
-			ID:        username.ID,
-			Username:  username.Username,
-			AccountID: username.AccountID,
-			Primary:   username.Status.Has(stat.Primary),
-			Locked:    username.Status.Has(stat.Locked),
-			Blocked:   username.Status.Has(stat.Blocked),
-			Reserved:  username.Status.Has(stat.Reserved),
-		})
-	}
+	return nil
+}
 
-	return aunp.ListResponse(pagination.NewResponse(req.Page, int(count), result)), nil
+func listUsername(username aunp.Username) aunp.ListUsername {
+	return aunp.ListUsername{
+		ID:        username.ID,
+		Username:  username.Username,
+		AccountID: username.AccountID,
+		Primary:   username.Status.Has(stat.Primary),
+		Locked:    username.Status.Has(stat.Locked),
+		Blocked:   username.Status.Has(stat.Blocked),
+		Reserved:  username.Status.Has(stat.Reserved),
+	}
 }
@@ -0,0 +1,21 @@
+package usernameservice
+
+import (
+	"log/slog"
+
+	"github.com/kianooshaz/skeleton/foundation/session"
+	statusproto "github.com/kianooshaz/skeleton/services/account/status/proto"
+)
+
+// NewWithStorage creates a service on the given storage, transactor and status history.
+func NewWithStorage(
+	cfg Config, storage Storer, transactor session.Transactor, status statusproto.StatusService, logger *slog.Logger,
+) *Service {
+	return &Service{
+		config:     cfg,
+		logger:     *logger,
+		storage:    storage,
+		transactor: transactor,
+		status:     status,
+	}
+}
@@ -6,7 +6,10 @@ import (
 	"log/slog"
 
 	"github.com/google/uuid"
+	"github.com/kianooshaz/skeleton/foundation/pagination"
+	"github.com/kianooshaz/skeleton/foundation/session"
 	accprotocol "github.com/kianooshaz/skeleton/services/account/accounts/proto"
+	statusproto "github.com/kianooshaz/skeleton/services/account/status/proto"
 	"github.com/kianooshaz/skeleton/services/account/username/persistence"
 	usernameproto "github.com/kianooshaz/skeleton/services/account/username/proto"
 )
@@ -23,25 +26,33 @@ type (
 		Create(ctx context.Context, username usernameproto.Username) error
 		Delete(ctx context.Context, id uuid.UUID) error
 		Get(ctx context.Context, id uuid.UUID) (usernameproto.Username, error)
-		ListWithSearch(ctx context.Context, req usernameproto.ListRequest) ([]usernameproto.Username, error)
+		ListWithSearch(
+			ctx context.Context, req usernameproto.ListRequest,
+		) ([]usernameproto.Username, pagination.Cursors, error)
+		ExportWithSearch(
+			ctx context.Context, req usernameproto.ListRequest, yield func(usernameproto.Username) error,
+		) error
 		CountWithSearch(ctx context.Context, req usernameproto.ListRequest) (int64, error)
 
-		ListByUserAndOrganization(ctx context.Context, req usernameproto.ListAssignedRequest) ([]usernameproto.Username, error)
+		ListByUserAndOrganization(
+			ctx context.Context, req usernameproto.ListAssignedRequest,
+		) ([]usernameproto.Username, pagination.Cursors, error)
 		UpdateStatus(ctx context.Context, username usernameproto.Username) error
 		Exist(ctx context.Context, username string) (bool, error)
 		CountByAccount(ctx context.Context, accountID accprotocol.AccountID) (int64, error)
 	}
 
 	Service struct {
-		config      Config
-		logger      slog.Logger
-		storage     Storer
-		storageConn *sql.DB
+		config     Config
+		logger     slog.Logger
+		storage    Storer
+		transactor session.Transactor
+		status     statusproto.StatusService
 	}
 )
 
 // New creates a new username service instance.
-func New(cfg Config, db *sql.DB, logger *slog.Logger) usernameproto.UsernameService {
+func New(cfg Config, db *sql.DB, status statusproto.StatusService, logger *slog.Logger) usernameproto.UsernameService {
 	serviceLogger := *logger.With(
 		slog.Group("package_info",
 			slog.String("module", "username"),
@@ -55,6 +66,7 @@ func New(cfg Config, db *sql.DB, logger *slog.Logger) usernameproto.UsernameServ
 		storage: &persistence.UsernameStorage{
 			Conn: db,
 		},
-		storageConn: db,
+		transactor: session.SQLTransactor{DB: db},
+		status:     status,
 	}
 }
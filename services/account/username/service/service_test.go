@@ -0,0 +1,293 @@
+package usernameservice_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kianooshaz/skeleton/foundation/derror"
+	"github.com/kianooshaz/skeleton/foundation/order"
+	"github.com/kianooshaz/skeleton/foundation/pagination"
+	"github.com/kianooshaz/skeleton/foundation/session"
+	"github.com/kianooshaz/skeleton/foundation/stat"
+	"github.com/kianooshaz/skeleton/foundation/types"
+	accprotocol "github.com/kianooshaz/skeleton/services/account/accounts/proto"
+	statusproto "github.com/kianooshaz/skeleton/services/account/status/proto"
+	"github.com/kianooshaz/skeleton/services/account/username/persistence"
+	usernameproto "github.com/kianooshaz/skeleton/services/account/username/proto"
+	usernameservice "github.com/kianooshaz/skeleton/services/account/username/service"
+)
+
+// statusRecorder keeps the status transitions recorded through it.
+type statusRecorder struct {
+	statusproto.StatusService
+
+	requests []statusproto.RecordRequest
+}
+
+func (r *statusRecorder) Record(_ context.Context, req statusproto.RecordRequest) error {
+	r.requests = append(r.requests, req)
+	return nil
+}
+
+// admin may act for every account.
+var admin = session.Principal{Roles: []string{session.RoleAdmin}}
+
+func newService(t *testing.T) (*usernameservice.Service, *persistence.UsernameMemoryStorage, *statusRecorder) {
+	t.Helper()
+
+	storage := persistence.NewUsernameMemoryStorage()
+	status := &statusRecorder{}
+	service := usernameservice.NewWithStorage(usernameservice.Config{
+		MaxUserUsernamePerOrganization: 2,
+		MinLength:                      3,
+		MaxLength:                      10,
+		AllowCharacters:                "abcdefghijklmnopqrstuvwxyz0123456789",
+	}, storage, session.NopTransactor{}, status, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	return service, storage, status
+}
+
+func TestService_Assign(t *testing.T) {
+	ctx := session.SetPrincipal(context.Background(), admin)
+	accountID := accprotocol.AccountID(uuid.New())
+
+	service, _, _ := newService(t)
+
+	first, err := service.Assign(ctx, usernameproto.AssignRequest{AccountID: accountID, Username: "first"})
+	require.NoError(t, err)
+	assert.True(t, first.Status.Has(stat.Primary), "the first username of an account is primary")
+
+	second, err := service.Assign(ctx, usernameproto.AssignRequest{AccountID: accountID, Username: "second"})
+	require.NoError(t, err)
+	assert.False(t, second.Status.Has(stat.Primary))
+
+	tests := []struct {
+		name      string
+		accountID accprotocol.AccountID
+		username  string
+		wantErr   error
+	}{
+		{name: "too short", accountID: accountID, username: "ab", wantErr: derror.ErrUsernameInvalid},
+		{name: "too long", accountID: accountID, username: "abcdefghijk", wantErr: derror.ErrUsernameInvalid},
+		{name: "disallowed character", accountID: accountID, username: "ab_cd", wantErr: derror.ErrUsernameInvalid},
+		{name: "taken", accountID: accprotocol.AccountID(uuid.New()), username: "first",
+			wantErr: derror.ErrUsernameCannotBeAssigned},
+		{name: "account at maximum", accountID: accountID, username: "third",
+			wantErr: derror.ErrUsernameMaxPerOrganization},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.Assign(ctx, usernameproto.AssignRequest{AccountID: tt.accountID, Username: tt.username})
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+
+	// Principals may only assign usernames to their own account.
+	other := session.SetPrincipal(context.Background(), session.Principal{AccountID: uuid.New()})
+	_, err = service.Assign(other, usernameproto.AssignRequest{AccountID: accountID, Username: "other"})
+	require.ErrorIs(t, err, derror.ErrForbidden)
+}
+
+func TestService_BePrimary(t *testing.T) {
+	ctx := session.SetPrincipal(context.Background(), admin)
+	accountID := accprotocol.AccountID(uuid.New())
+
+	service, storage, status := newService(t)
+
+	first, err := service.Assign(ctx, usernameproto.AssignRequest{AccountID: accountID, Username: "first"})
+	require.NoError(t, err)
+
+	second, err := service.Assign(ctx, usernameproto.AssignRequest{AccountID: accountID, Username: "second"})
+	require.NoError(t, err)
+
+	require.NoError(t, service.BePrimary(ctx, usernameproto.BePrimaryRequest{ID: second.ID}))
+
+	assert.ElementsMatch(t, []statusproto.RecordRequest{
+		{
+			Entity:     "username",
+			EntityID:   first.ID.String(),
+			Transition: "unset_primary",
+			From:       stat.Primary,
+			To:         stat.Unset,
+			Reason:     "replaced",
+		},
+		{
+			Entity:     "username",
+			EntityID:   second.ID.String(),
+			Transition: "set_primary",
+			From:       stat.Unset,
+			To:         stat.Primary,
+		},
+	}, status.requests)
+
+	got, err := storage.Get(ctx, first.ID)
+	require.NoError(t, err)
+	assert.False(t, got.Status.Has(stat.Primary), "the previous primary is demoted")
+
+	got, err = storage.Get(ctx, second.ID)
+	require.NoError(t, err)
+	assert.True(t, got.Status.Has(stat.Primary))
+
+	// Promoting the primary again changes nothing.
+	require.NoError(t, service.BePrimary(ctx, usernameproto.BePrimaryRequest{ID: second.ID}))
+
+	err = service.BePrimary(ctx, usernameproto.BePrimaryRequest{ID: uuid.New()})
+	require.ErrorIs(t, err, derror.ErrUsernameNotFound)
+}
+
+// failingTransactor fails to begin any transaction.
+type failingTransactor struct{}
+
+func (failingTransactor) Begin(ctx context.Context) (session.Tx, context.Context, error) {
+	return nil, ctx, errors.New("connection refused")
+}
+
+func TestService_BePrimaryBeginFails(t *testing.T) {
+	ctx := session.SetPrincipal(context.Background(), admin)
+	accountID := accprotocol.AccountID(uuid.New())
+
+	service, storage, status := newService(t)
+	failing := usernameservice.NewWithStorage(usernameservice.Config{}, storage, failingTransactor{}, status,
+		slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	_, err := service.Assign(ctx, usernameproto.AssignRequest{AccountID: accountID, Username: "first"})
+	require.NoError(t, err)
+	second, err := service.Assign(ctx, usernameproto.AssignRequest{AccountID: accountID, Username: "second"})
+	require.NoError(t, err)
+
+	// Execute.
+	err = failing.BePrimary(ctx, usernameproto.BePrimaryRequest{ID: second.ID})
+
+	// Assert.
+	require.ErrorIs(t, err, derror.ErrInternalSystem)
+	assert.Empty(t, status.requests)
+	got, err := storage.Get(ctx, second.ID)
+	require.NoError(t, err)
+	assert.False(t, got.Status.Has(stat.Primary), "nothing is written without a transaction")
+}
+
+func TestService_Unassign(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      stat.Status
+		wantErr     error
+		wantDeleted bool
+	}{
+		{
+			name:        "assigned",
+			status:      stat.Primary,
+			wantDeleted: true,
+		},
+		{
+			name:    "locked",
+			status:  stat.Primary | stat.Locked,
+			wantErr: derror.ErrUsernameLocked,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := session.SetPrincipal(context.Background(), admin)
+			service, storage, status := newService(t)
+
+			username, err := service.Assign(ctx, usernameproto.AssignRequest{
+				AccountID: accprotocol.AccountID(uuid.New()),
+				Username:  "first",
+			})
+			require.NoError(t, err)
+			username.Status = tt.status
+			require.NoError(t, storage.UpdateStatus(ctx, username))
+
+			// Execute.
+			err = service.Unassign(ctx, usernameproto.UnassignRequest{ID: username.ID})
+
+			// Assert.
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				assert.Empty(t, status.requests)
+				_, err = storage.Get(ctx, username.ID)
+				require.NoError(t, err, "kept")
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, status.requests, 1)
+			assert.Equal(t, "unassign", status.requests[0].Transition)
+			_, err = storage.Get(ctx, username.ID)
+			require.Error(t, err, "deleted")
+		})
+	}
+}
+
+func TestService_Export(t *testing.T) {
+	stop := errors.New("stop")
+
+	tests := []struct {
+		name     string
+		sort     order.Spec
+		yieldErr error
+		want     []string
+		wantErr  error
+	}{
+		{
+			name: "every matching username",
+			want: []string{"first", "second"},
+		},
+		{
+			name:     "yield error",
+			yieldErr: stop,
+			want:     []string{"first"},
+			wantErr:  stop,
+		},
+		{
+			name:    "unknown order",
+			sort:    order.Spec{{Field: "status"}},
+			wantErr: derror.ErrUnknownOrder,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := session.SetPrincipal(context.Background(), admin)
+			service, _, _ := newService(t)
+
+			accountID := accprotocol.AccountID(uuid.New())
+			for _, name := range []string{"first", "second"} {
+				_, err := service.Assign(ctx, usernameproto.AssignRequest{AccountID: accountID, Username: name})
+				require.NoError(t, err)
+			}
+
+			var exported []usernameproto.ListUsername
+
+			// Execute.
+			err := service.Export(ctx, usernameproto.ListRequest{
+				AccountID: types.NewNullable(accountID),
+				Page:      pagination.Page{PageRows: 1},
+				Sort:      tt.sort,
+			}, func(username usernameproto.ListUsername) error {
+				exported = append(exported, username)
+				return tt.yieldErr
+			})
+
+			// Assert.
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			names := make([]string, 0, len(exported))
+			for _, username := range exported {
+				names = append(names, username.Username)
+				assert.Equal(t, accountID, username.AccountID)
+			}
+			assert.ElementsMatch(t, tt.want, names)
+		})
+	}
+}
@@ -1,8 +1,35 @@
 package usernameservice
 
-import "github.com/kianooshaz/skeleton/foundation/stat"
+import (
+	"github.com/kianooshaz/skeleton/foundation/derror"
+	"github.com/kianooshaz/skeleton/foundation/stat"
+)
 
 var Primary = stat.Primary
 var Locked = stat.Locked
 var Blocked = stat.Blocked
 var Reserved = stat.Reserved
+
+// Transitions of the status of usernames.
+const (
+	transitionSetPrimary   = "set_primary"
+	transitionUnsetPrimary = "unset_primary"
+	transitionLock         = "lock"
+	transitionUnlock       = "unlock"
+	transitionBlock        = "block"
+	transitionUnblock      = "unblock"
+	transitionUnassign     = "unassign"
+)
+
+// machine declares the statuses of usernames.
+var machine = stat.NewMachine("username", Primary|Locked|Blocked|Reserved,
+	stat.Transition{Name: transitionSetPrimary, Add: Primary, Forbids: Primary | Blocked},
+	stat.Transition{Name: transitionUnsetPrimary, Remove: Primary, Requires: Primary},
+	stat.Transition{Name: transitionLock, Add: Locked, Forbids: Locked},
+	stat.Transition{Name: transitionUnlock, Remove: Locked, Requires: Locked},
+	// A blocked username cannot stay primary.
+	stat.Transition{Name: transitionBlock, Add: Blocked, Remove: Primary, Forbids: Blocked},
+	stat.Transition{Name: transitionUnblock, Remove: Blocked, Requires: Blocked},
+	// Unassigning deletes the username, leaving its status as it was.
+	stat.Transition{Name: transitionUnassign, Forbids: Locked, Err: derror.ErrUsernameLocked},
+)
@@ -7,17 +7,21 @@ import (
 	"strings"
 
 	"github.com/google/uuid"
-	"github.com/jackc/pgx/v5"
 	dbproto "github.com/kianooshaz/skeleton/foundation/database/proto"
 	"github.com/kianooshaz/skeleton/foundation/derror"
 	"github.com/kianooshaz/skeleton/foundation/pagination"
 	"github.com/kianooshaz/skeleton/foundation/session"
 	"github.com/kianooshaz/skeleton/foundation/stat"
 	accprotocol "github.com/kianooshaz/skeleton/services/account/accounts/proto"
+	statusproto "github.com/kianooshaz/skeleton/services/account/status/proto"
 	usernameproto "github.com/kianooshaz/skeleton/services/account/username/proto"
 )
 
 func (s *Service) Assign(ctx context.Context, req usernameproto.AssignRequest) (usernameproto.Username, error) {
+	if err := session.AuthorizeAccount(ctx, uuid.UUID(req.AccountID)); err != nil {
+		return usernameproto.Username{}, err
+	}
+
 	if len(req.Username) < int(s.config.MinLength) || len(req.Username) > int(s.config.MaxLength) {
 		return usernameproto.Username{}, derror.ErrUsernameInvalid
 	}
@@ -112,7 +116,7 @@ func (s *Service) checkAccountMax(ctx context.Context, accountID accprotocol.Acc
 
 		return false, derror.ErrInternalSystem
 	}
-	if countByAccount > int64(s.config.MaxUserUsernamePerOrganization) {
+	if countByAccount >= int64(s.config.MaxUserUsernamePerOrganization) {
 		return false, derror.ErrUsernameMaxPerOrganization
 	}
 
@@ -121,30 +125,48 @@ func (s *Service) checkAccountMax(ctx context.Context, accountID accprotocol.Acc
 	return shouldBePrimary, nil
 }
 
-func (s *Service) Unassigned(ctx context.Context, id uuid.UUID) error {
+func (s *Service) Unassign(ctx context.Context, req usernameproto.UnassignRequest) error {
+	id := req.ID
 	username, err := s.storage.Get(ctx, id)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
+		if errors.Is(err, dbproto.ErrRowNotFound) {
 			return derror.ErrUsernameNotFound
 		}
-		s.logger.Error(
+		s.logger.ErrorContext(
+			ctx,
 			"Error encountered while getting username from database",
-			"id", id,
+			slog.String("id", id.String()),
 			slog.String("error", err.Error()),
 		)
 
 		return derror.ErrInternalSystem
 	}
 
-	if username.Status.Has(stat.Locked) {
+	if err := session.AuthorizeAccount(ctx, uuid.UUID(username.AccountID)); err != nil {
+		return err
+	}
+
+	if _, err := machine.Next(username.Status, transitionUnassign); err != nil {
 		s.logger.ErrorContext(
 			ctx,
 			"username is locked and cannot be unassigned",
 			slog.String("username", id.String()),
 		)
 
-		return derror.ErrUsernameLocked
+		return err
+	}
+
+	tx, ctx, err := s.transactor.Begin(ctx)
+	if err != nil {
+		s.logger.ErrorContext(
+			ctx,
+			"Error encountered while beginning transaction",
+			slog.String("error", err.Error()),
+		)
+
+		return derror.ErrInternalSystem
 	}
+	defer s.rollback(ctx, tx)
 
 	err = s.storage.Delete(ctx, id)
 	if err != nil {
@@ -158,13 +180,22 @@ func (s *Service) Unassigned(ctx context.Context, id uuid.UUID) error {
 		return derror.ErrInternalSystem
 	}
 
-	return nil
+	if err := s.recordTransition(ctx, username, transitionUnassign, username.Status, ""); err != nil {
+		return err
+	}
+
+	return s.commit(ctx, tx)
 }
 
 func (s *Service) ListAssigned(ctx context.Context, req usernameproto.ListAssignedRequest) (
 	usernameproto.ListAssignedResponse, error) {
-	usernames, err := s.storage.ListByUserAndOrganization(ctx, req)
+	usernames, cursors, err := s.storage.ListByUserAndOrganization(ctx, req)
 	if err != nil {
+		if errors.Is(err, derror.ErrInvalidCursor) || errors.Is(err, derror.ErrUnknownOrder) ||
+			errors.Is(err, derror.ErrUnknownOrderDirection) {
+			return usernameproto.ListAssignedResponse{}, err
+		}
+
 		s.logger.ErrorContext(
 			ctx,
 			"Error encountered while listing assigned usernames",
@@ -175,15 +206,18 @@ func (s *Service) ListAssigned(ctx context.Context, req usernameproto.ListAssign
 		return usernameproto.ListAssignedResponse{}, derror.ErrInternalSystem
 	}
 
-	count, err := s.storage.CountByAccount(ctx, req.AccountID)
-	if err != nil {
-		s.logger.ErrorContext(
-			ctx,
-			"Error encountered while counting assigned usernames",
-			slog.String("accountID", req.AccountID.String()),
-			slog.String("error", err.Error()),
-		)
-		return usernameproto.ListAssignedResponse{}, derror.ErrInternalSystem
+	var count int64
+	if req.NeedsTotal() {
+		count, err = s.storage.CountByAccount(ctx, req.AccountID)
+		if err != nil {
+			s.logger.ErrorContext(
+				ctx,
+				"Error encountered while counting assigned usernames",
+				slog.String("accountID", req.AccountID.String()),
+				slog.String("error", err.Error()),
+			)
+			return usernameproto.ListAssignedResponse{}, derror.ErrInternalSystem
+		}
 	}
 
 	result := make([]usernameproto.ListUsername, 0, len(usernames))
@@ -193,21 +227,14 @@ func (s *Service) ListAssigned(ctx context.Context, req usernameproto.ListAssign
 			continue
 		}
 
-		result = append(result, usernameproto.ListUsername{
-			ID:        username.ID,
-			Username:  username.Username,
-			AccountID: username.AccountID,
-			Primary:   username.Status.Has(stat.Primary),
-			Locked:    username.Status.Has(stat.Locked),
-			Blocked:   username.Status.Has(stat.Blocked),
-			Reserved:  username.Status.Has(stat.Reserved),
-		})
+		result = append(result, listUsername(username))
 	}
 
-	return usernameproto.ListAssignedResponse(pagination.NewResponse(req.Page, int(count), result)), nil
+	return usernameproto.ListAssignedResponse(pagination.NewCursorResponse(req.Page, int(count), result, cursors)), nil
 }
 
-func (s *Service) BePrimary(ctx context.Context, id uuid.UUID) error {
+func (s *Service) BePrimary(ctx context.Context, req usernameproto.BePrimaryRequest) error {
+	id := req.ID
 	shouldBePrimary, err := s.storage.Get(ctx, id)
 	if err != nil {
 		if errors.Is(err, dbproto.ErrRowNotFound) {
@@ -223,10 +250,14 @@ func (s *Service) BePrimary(ctx context.Context, id uuid.UUID) error {
 		return derror.ErrInternalSystem
 	}
 
-	usernames, err := s.storage.ListByUserAndOrganization(ctx, usernameproto.ListAssignedRequest{
+	if err := session.AuthorizeAccount(ctx, uuid.UUID(shouldBePrimary.AccountID)); err != nil {
+		return err
+	}
+
+	usernames, _, err := s.storage.ListByUserAndOrganization(ctx, usernameproto.ListAssignedRequest{
 		AccountID: shouldBePrimary.AccountID,
 		Page: pagination.Page{
-			PageNumber: s.config.MaxUserUsernamePerOrganization, // We only need to fetch the usernames to update their status
+			PageRows: s.config.MaxUserUsernamePerOrganization, // We only need to fetch the usernames to update their status
 		},
 	})
 	if err != nil {
@@ -240,21 +271,18 @@ func (s *Service) BePrimary(ctx context.Context, id uuid.UUID) error {
 		return derror.ErrInternalSystem
 	}
 
-	tx, ctx, err := session.BeginTransaction(ctx, s.storageConn)
+	tx, ctx, err := s.transactor.Begin(ctx)
 	if err != nil {
-		s.logger.Error("error")
+		s.logger.ErrorContext(
+			ctx,
+			"Error encountered while beginning transaction",
+			slog.String("error", err.Error()),
+		)
+
+		return derror.ErrInternalSystem
 	}
 
-	defer func() {
-		// TODO pgx.ErrTxClosed should change not depend database
-		if err := tx.Rollback(); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
-			s.logger.ErrorContext(
-				ctx,
-				"Error encountered while rolling back transaction",
-				slog.String("error", err.Error()),
-			)
-		}
-	}()
+	defer s.rollback(ctx, tx)
 
 	for _, username := range usernames {
 		switch {
@@ -263,36 +291,60 @@ func (s *Service) BePrimary(ctx context.Context, id uuid.UUID) error {
 				return nil
 			}
 
-			username.Status.Add(Primary)
-
-			if err := s.storage.UpdateStatus(ctx, username); err != nil {
-				s.logger.ErrorContext(
-					ctx,
-					"Error encountered while adding primary status to username",
-					slog.String("error", err.Error()),
-					slog.Any("username", username),
-				)
-
-				return derror.ErrInternalSystem
+			if err := s.transition(ctx, username, transitionSetPrimary, ""); err != nil {
+				return err
 			}
 
 		case username.Status.Has(Primary):
+			if err := s.transition(ctx, username, transitionUnsetPrimary, "replaced"); err != nil {
+				return err
+			}
+		}
+	}
 
-			username.Status.Remove(Primary)
+	return s.commit(ctx, tx)
+}
 
-			if err := s.storage.UpdateStatus(ctx, username); err != nil {
-				s.logger.ErrorContext(
-					ctx,
-					"Error encountered while adding deleting status to username",
-					slog.String("error", err.Error()),
-					slog.Any("username", username),
-				)
+// transition moves username through the named transition of its status
+// machine, and records it in the status history. It must run in a transaction.
+func (s *Service) transition(ctx context.Context, username usernameproto.Username, name, reason string) error {
+	from := username.Status
 
-				return derror.ErrInternalSystem
-			}
-		}
+	next, err := machine.Next(from, name)
+	if err != nil {
+		return err
+	}
+
+	username.Status = next
+	if err := s.storage.UpdateStatus(ctx, username); err != nil {
+		s.logger.ErrorContext(
+			ctx,
+			"Error encountered while updating status of username",
+			slog.String("error", err.Error()),
+			slog.String("transition", name),
+			slog.Any("username", username),
+		)
+
+		return derror.ErrInternalSystem
 	}
 
+	return s.recordTransition(ctx, username, name, from, reason)
+}
+
+func (s *Service) recordTransition(
+	ctx context.Context, username usernameproto.Username, name string, from stat.Status, reason string,
+) error {
+	return s.status.Record(ctx, statusproto.RecordRequest{
+		Entity:     machine.Entity(),
+		EntityID:   username.ID.String(),
+		Transition: name,
+		From:       from,
+		To:         username.Status,
+		Reason:     reason,
+	})
+}
+
+func (s *Service) commit(ctx context.Context, tx session.Tx) error {
 	if err := tx.Commit(); err != nil {
 		s.logger.ErrorContext(
 			ctx,
@@ -305,3 +357,13 @@ func (s *Service) BePrimary(ctx context.Context, id uuid.UUID) error {
 
 	return nil
 }
+
+func (s *Service) rollback(ctx context.Context, tx session.Tx) {
+	if err := tx.Rollback(); err != nil {
+		s.logger.ErrorContext(
+			ctx,
+			"Error encountered while rolling back transaction",
+			slog.String("error", err.Error()),
+		)
+	}
+}
@@ -0,0 +1,98 @@
+package persistence
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	dbproto "github.com/kianooshaz/skeleton/foundation/database/proto"
+	"github.com/kianooshaz/skeleton/foundation/stat"
+	lockoutproto "github.com/kianooshaz/skeleton/services/authentication/lockout/proto"
+)
+
+// AttemptMemoryStorage keeps failed attempts in memory. It behaves like
+// AttemptStorage and is meant for tests.
+type AttemptMemoryStorage struct {
+	mu       sync.Mutex
+	attempts map[string]lockoutproto.Attempts
+}
+
+// NewAttemptMemoryStorage creates an empty AttemptMemoryStorage.
+func NewAttemptMemoryStorage() *AttemptMemoryStorage {
+	return &AttemptMemoryStorage{
+		attempts: make(map[string]lockoutproto.Attempts),
+	}
+}
+
+func (ms *AttemptMemoryStorage) Get(_ context.Context, key string) (lockoutproto.Attempts, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	attempts, ok := ms.attempts[key]
+	if !ok {
+		return lockoutproto.Attempts{}, dbproto.ErrRowNotFound
+	}
+
+	return attempts, nil
+}
+
+func (ms *AttemptMemoryStorage) Fail(_ context.Context, key string, at, since time.Time) (lockoutproto.Attempts, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	attempts, ok := ms.attempts[key]
+	if !ok || !attempts.LastFailedAt.After(since) {
+		attempts.Key = key
+		attempts.FailedAttempts = 0
+	}
+
+	attempts.FailedAttempts++
+	attempts.LastFailedAt = at
+	ms.attempts[key] = attempts
+
+	return attempts, nil
+}
+
+func (ms *AttemptMemoryStorage) Lock(_ context.Context, key string, until time.Time) (bool, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	attempts, ok := ms.attempts[key]
+	if !ok || attempts.Status.Has(stat.Locked) {
+		return false, nil
+	}
+
+	attempts.Status.Add(stat.Locked)
+	attempts.LockedUntil = until
+	ms.attempts[key] = attempts
+
+	return true, nil
+}
+
+func (ms *AttemptMemoryStorage) Unlock(_ context.Context, key string) (bool, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	attempts, ok := ms.attempts[key]
+	if !ok || !attempts.Status.Has(stat.Locked) {
+		return false, nil
+	}
+
+	attempts.Status.Remove(stat.Locked)
+	attempts.FailedAttempts = 0
+	attempts.LockedUntil = time.Time{}
+	ms.attempts[key] = attempts
+
+	return true, nil
+}
+
+func (ms *AttemptMemoryStorage) Delete(_ context.Context, key string) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	if attempts, ok := ms.attempts[key]; ok && !attempts.Status.Has(stat.Locked) {
+		delete(ms.attempts, key)
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+// Package persistencetest holds the contract every attempt storage must meet,
+// so the in-memory storage used by tests stays faithful to the PostgreSQL one.
+package persistencetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbproto "github.com/kianooshaz/skeleton/foundation/database/proto"
+	"github.com/kianooshaz/skeleton/foundation/stat"
+	lockoutservice "github.com/kianooshaz/skeleton/services/authentication/lockout/service"
+)
+
+// Storage is the attempt storage under contract.
+type Storage = lockoutservice.Storer
+
+// Setup returns an empty storage and the context to call it with.
+type Setup func(t *testing.T) (Storage, context.Context)
+
+// Run runs the contract tests against the storages returned by setup.
+func Run(t *testing.T, setup Setup) {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	t.Run("get missing", func(t *testing.T) {
+		storage, ctx := setup(t)
+
+		_, err := storage.Get(ctx, newKey())
+		require.ErrorIs(t, err, dbproto.ErrRowNotFound)
+	})
+
+	t.Run("fail counts attempts", func(t *testing.T) {
+		storage, ctx := setup(t)
+		key := newKey()
+
+		for i := 1; i <= 3; i++ {
+			at := now.Add(time.Duration(i) * time.Second)
+			attempts, err := storage.Fail(ctx, key, at, now.Add(-time.Hour))
+			require.NoError(t, err)
+			assert.Equal(t, key, attempts.Key)
+			assert.Equal(t, i, attempts.FailedAttempts)
+			assert.True(t, at.Equal(attempts.LastFailedAt))
+		}
+
+		got, err := storage.Get(ctx, key)
+		require.NoError(t, err)
+		assert.Equal(t, 3, got.FailedAttempts)
+		assert.False(t, got.Status.Has(stat.Locked))
+		assert.True(t, got.LockedUntil.IsZero())
+	})
+
+	t.Run("fail forgets attempts before since", func(t *testing.T) {
+		storage, ctx := setup(t)
+		key := newKey()
+
+		_, err := storage.Fail(ctx, key, now, now.Add(-time.Hour))
+		require.NoError(t, err)
+
+		attempts, err := storage.Fail(ctx, key, now.Add(2*time.Hour), now.Add(time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, 1, attempts.FailedAttempts)
+	})
+
+	t.Run("lock and unlock", func(t *testing.T) {
+		storage, ctx := setup(t)
+		key := newKey()
+		until := now.Add(time.Hour)
+
+		_, err := storage.Fail(ctx, key, now, now.Add(-time.Hour))
+		require.NoError(t, err)
+
+		locked, err := storage.Lock(ctx, key, until)
+		require.NoError(t, err)
+		assert.True(t, locked)
+
+		locked, err = storage.Lock(ctx, key, until.Add(time.Hour))
+		require.NoError(t, err)
+		assert.False(t, locked, "already locked")
+
+		got, err := storage.Get(ctx, key)
+		require.NoError(t, err)
+		assert.True(t, got.Status.Has(stat.Locked))
+		assert.True(t, until.Equal(got.LockedUntil))
+
+		// A locked key keeps its attempts.
+		require.NoError(t, storage.Delete(ctx, key))
+		_, err = storage.Get(ctx, key)
+		require.NoError(t, err)
+
+		unlocked, err := storage.Unlock(ctx, key)
+		require.NoError(t, err)
+		assert.True(t, unlocked)
+
+		unlocked, err = storage.Unlock(ctx, key)
+		require.NoError(t, err)
+		assert.False(t, unlocked, "already unlocked")
+
+		got, err = storage.Get(ctx, key)
+		require.NoError(t, err)
+		assert.False(t, got.Status.Has(stat.Locked))
+		assert.Zero(t, got.FailedAttempts)
+		assert.True(t, got.LockedUntil.IsZero())
+	})
+
+	t.Run("lock missing", func(t *testing.T) {
+		storage, ctx := setup(t)
+
+		locked, err := storage.Lock(ctx, newKey(), now)
+		require.NoError(t, err)
+		assert.False(t, locked)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		storage, ctx := setup(t)
+		key := newKey()
+
+		_, err := storage.Fail(ctx, key, now, now.Add(-time.Hour))
+		require.NoError(t, err)
+
+		require.NoError(t, storage.Delete(ctx, key))
+
+		_, err = storage.Get(ctx, key)
+		require.ErrorIs(t, err, dbproto.ErrRowNotFound)
+	})
+}
+
+func newKey() string {
+	return "account:" + uuid.NewString()
+}
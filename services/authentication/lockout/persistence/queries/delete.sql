@@ -0,0 +1,3 @@
+DELETE FROM credential_attempts
+WHERE key = $1
+    AND status & $2::BIGINT = 0
\ No newline at end of file
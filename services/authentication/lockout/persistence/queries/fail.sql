@@ -0,0 +1,13 @@
+INSERT INTO credential_attempts (key, failed_attempts, last_failed_at)
+VALUES ($1, 1, $2) ON CONFLICT (key) DO
+UPDATE
+SET failed_attempts = CASE
+        WHEN credential_attempts.last_failed_at <= $3 THEN 1
+        ELSE credential_attempts.failed_attempts + 1
+    END,
+    last_failed_at = $2
+RETURNING key,
+    failed_attempts,
+    last_failed_at,
+    status,
+    locked_until
\ No newline at end of file
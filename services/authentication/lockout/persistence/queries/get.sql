@@ -0,0 +1,7 @@
+SELECT key,
+    failed_attempts,
+    last_failed_at,
+    status,
+    locked_until
+FROM credential_attempts
+WHERE key = $1
\ No newline at end of file
@@ -0,0 +1,5 @@
+UPDATE credential_attempts
+SET status = status | $2::BIGINT,
+    locked_until = $3
+WHERE key = $1
+    AND status & $2::BIGINT = 0
\ No newline at end of file
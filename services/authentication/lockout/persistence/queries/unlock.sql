@@ -0,0 +1,6 @@
+UPDATE credential_attempts
+SET status = status & ~$2::BIGINT,
+    failed_attempts = 0,
+    locked_until = NULL
+WHERE key = $1
+    AND status & $2::BIGINT <> 0
\ No newline at end of file
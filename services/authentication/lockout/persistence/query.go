@@ -0,0 +1,111 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"errors"
+	"time"
+
+	dbproto "github.com/kianooshaz/skeleton/foundation/database/proto"
+	"github.com/kianooshaz/skeleton/foundation/session"
+	"github.com/kianooshaz/skeleton/foundation/stat"
+	lockoutproto "github.com/kianooshaz/skeleton/services/authentication/lockout/proto"
+)
+
+type AttemptStorage struct {
+	Conn dbproto.QueryExecutor
+}
+
+//go:embed queries/get.sql
+var getQuery string
+
+//go:embed queries/fail.sql
+var failQuery string
+
+//go:embed queries/lock.sql
+var lockQuery string
+
+//go:embed queries/unlock.sql
+var unlockQuery string
+
+//go:embed queries/delete.sql
+var deleteQuery string
+
+func (as *AttemptStorage) Get(ctx context.Context, key string) (lockoutproto.Attempts, error) {
+	conn := session.GetDBConnection(ctx, as.Conn)
+
+	attempts, err := scanAttempts(conn.QueryRowContext(ctx, getQuery, key))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return lockoutproto.Attempts{}, dbproto.ErrRowNotFound
+		}
+		return lockoutproto.Attempts{}, err
+	}
+
+	return attempts, nil
+}
+
+// Fail counts a failed attempt for key made at the given time. Attempts made at
+// or before since are forgotten first.
+func (as *AttemptStorage) Fail(ctx context.Context, key string, at, since time.Time) (lockoutproto.Attempts, error) {
+	conn := session.GetDBConnection(ctx, as.Conn)
+
+	return scanAttempts(conn.QueryRowContext(ctx, failQuery, key, at, since))
+}
+
+// Lock locks key until the given time. It reports false when key was already locked.
+func (as *AttemptStorage) Lock(ctx context.Context, key string, until time.Time) (bool, error) {
+	conn := session.GetDBConnection(ctx, as.Conn)
+
+	result, err := conn.ExecContext(ctx, lockQuery, key, stat.Locked, until)
+	if err != nil {
+		return false, err
+	}
+
+	return affected(result)
+}
+
+// Unlock lifts the lock of key and forgets its failed attempts. It reports false
+// when key was not locked.
+func (as *AttemptStorage) Unlock(ctx context.Context, key string) (bool, error) {
+	conn := session.GetDBConnection(ctx, as.Conn)
+
+	result, err := conn.ExecContext(ctx, unlockQuery, key, stat.Locked)
+	if err != nil {
+		return false, err
+	}
+
+	return affected(result)
+}
+
+// Delete forgets the failed attempts of key, unless it is locked.
+func (as *AttemptStorage) Delete(ctx context.Context, key string) error {
+	conn := session.GetDBConnection(ctx, as.Conn)
+
+	_, err := conn.ExecContext(ctx, deleteQuery, key, stat.Locked)
+	return err
+}
+
+func scanAttempts(row *sql.Row) (lockoutproto.Attempts, error) {
+	var attempts lockoutproto.Attempts
+	var lockedUntil sql.NullTime
+
+	err := row.Scan(&attempts.Key, &attempts.FailedAttempts, &attempts.LastFailedAt, &attempts.Status, &lockedUntil)
+	if err != nil {
+		return lockoutproto.Attempts{}, err
+	}
+
+	attempts.LockedUntil = lockedUntil.Time
+
+	return attempts, nil
+}
+
+func affected(result sql.Result) (bool, error) {
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rows > 0, nil
+}
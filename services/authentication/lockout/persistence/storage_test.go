@@ -0,0 +1,24 @@
+package persistence_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kianooshaz/skeleton/foundation/database/postgres/postgrestest"
+	"github.com/kianooshaz/skeleton/services/authentication/lockout/persistence"
+	"github.com/kianooshaz/skeleton/services/authentication/lockout/persistence/persistencetest"
+)
+
+func TestAttemptMemoryStorage(t *testing.T) {
+	persistencetest.Run(t, func(t *testing.T) (persistencetest.Storage, context.Context) {
+		return persistence.NewAttemptMemoryStorage(), t.Context()
+	})
+}
+
+func TestAttemptStorage(t *testing.T) {
+	db := postgrestest.Open(t, "../schema.sql")
+
+	persistencetest.Run(t, func(t *testing.T) (persistencetest.Storage, context.Context) {
+		return &persistence.AttemptStorage{Conn: db}, postgrestest.Context(t, db, "credential_attempts")
+	})
+}
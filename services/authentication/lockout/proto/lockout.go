@@ -0,0 +1,72 @@
+package lockoutproto
+
+import (
+	"context"
+	"time"
+
+	"github.com/kianooshaz/skeleton/foundation/stat"
+	accproto "github.com/kianooshaz/skeleton/services/account/accounts/proto"
+)
+
+// Attempts holds the failed credential attempts made for an account or from an IP address.
+type Attempts struct {
+	// Key is "account:" followed by the account ID, or "ip:" followed by the IP address.
+	Key            string      `json:"key"`
+	FailedAttempts int         `json:"failed_attempts"`
+	LastFailedAt   time.Time   `json:"last_failed_at"`
+	Status         stat.Status `json:"status"`
+	// LockedUntil is when the lock clears by itself; zero unless Status has stat.Locked.
+	LockedUntil time.Time `json:"locked_until"`
+}
+
+// LockoutService protects credentials against brute force. Each failed attempt
+// delays the next one, exponentially, and an account is locked for a cooldown
+// once its failures reach a threshold.
+type LockoutService interface {
+	// Check returns derror.ErrAccountLocked or derror.ErrTooManyAttempts when a
+	// credential of the account may not be tried from the client of ctx yet.
+	Check(ctx context.Context, req CheckRequest) error
+	// Fail records a failed attempt of the account from the client of ctx,
+	// locking the account once the threshold is reached.
+	Fail(ctx context.Context, req FailRequest) error
+	// Succeed forgets the failed attempts of the account.
+	Succeed(ctx context.Context, req SucceedRequest) error
+	// Unlock lifts the lock of the account. Only admins may, others fail with
+	// derror.ErrForbidden.
+	Unlock(ctx context.Context, req UnlockRequest) error
+	Get(ctx context.Context, req GetRequest) (GetResponse, error)
+}
+
+type CheckRequest struct {
+	AccountID accproto.AccountID `json:"account_id"`
+}
+
+type FailRequest struct {
+	AccountID accproto.AccountID `json:"account_id"`
+}
+
+type SucceedRequest struct {
+	AccountID accproto.AccountID `json:"account_id"`
+}
+
+type UnlockRequest struct {
+	AccountID accproto.AccountID `param:"account_id" json:"-" validate:"id"`
+}
+
+type GetRequest struct {
+	AccountID accproto.AccountID `query:"account_id"`
+}
+
+type GetResponse struct {
+	Data Attempts `json:"data"`
+}
+
+// AccountKey returns the key the attempts of the account are kept under.
+func AccountKey(accountID accproto.AccountID) string {
+	return "account:" + accountID.String()
+}
+
+// IPKey returns the key the attempts from the IP address are kept under.
+func IPKey(ip string) string {
+	return "ip:" + ip
+}
@@ -0,0 +1,13 @@
+-- Lockout Service Database Schema
+-- This file contains the SQL schema for the lockout service tables.
+-- Run this manually in your PostgreSQL database to create the required tables.
+-- Create credential_attempts table; keys are "account:<id>" or "ip:<address>"
+CREATE TABLE IF NOT EXISTS credential_attempts (
+    key TEXT PRIMARY KEY,
+    failed_attempts INTEGER NOT NULL DEFAULT 0,
+    last_failed_at TIMESTAMPTZ NOT NULL,
+    status BIGINT NOT NULL DEFAULT 0,
+    locked_until TIMESTAMPTZ NULL
+);
+-- Create indexes for better performance
+CREATE INDEX IF NOT EXISTS idx_credential_attempts_last_failed_at ON credential_attempts (last_failed_at);
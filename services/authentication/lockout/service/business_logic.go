@@ -0,0 +1,239 @@
+package lockoutservice
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	dbproto "github.com/kianooshaz/skeleton/foundation/database/proto"
+	"github.com/kianooshaz/skeleton/foundation/derror"
+	"github.com/kianooshaz/skeleton/foundation/session"
+	"github.com/kianooshaz/skeleton/foundation/stat"
+	accproto "github.com/kianooshaz/skeleton/services/account/accounts/proto"
+	statusproto "github.com/kianooshaz/skeleton/services/account/status/proto"
+	lockoutproto "github.com/kianooshaz/skeleton/services/authentication/lockout/proto"
+)
+
+// accountMachine declares the statuses of accounts kept by the lockout service.
+var accountMachine = stat.NewMachine("account", stat.Locked,
+	stat.Transition{Name: "lock", Add: stat.Locked, Forbids: stat.Locked},
+	stat.Transition{Name: "unlock", Remove: stat.Locked, Requires: stat.Locked},
+)
+
+// Reasons recorded in the status history of locks and unlocks.
+const (
+	reasonThreshold = "threshold"
+	reasonCooldown  = "cooldown"
+	reasonAdmin     = "admin"
+)
+
+func (s *Service) Check(ctx context.Context, req lockoutproto.CheckRequest) error {
+	now := s.now()
+
+	account, err := s.get(ctx, lockoutproto.AccountKey(req.AccountID))
+	if err != nil {
+		return err
+	}
+
+	if account.Status.Has(stat.Locked) {
+		if now.Before(account.LockedUntil) {
+			return derror.ErrAccountLocked
+		}
+
+		if err := s.unlock(ctx, req.AccountID, reasonCooldown); err != nil {
+			return err
+		}
+
+		// The failures that led to the lock are forgotten with it.
+		account = lockoutproto.Attempts{}
+	}
+
+	if now.Before(account.LastFailedAt.Add(s.delay(account.FailedAttempts))) {
+		return derror.ErrTooManyAttempts
+	}
+
+	if ip := session.GetClientIP(ctx); ip != "" {
+		client, err := s.get(ctx, lockoutproto.IPKey(ip))
+		if err != nil {
+			return err
+		}
+
+		if now.Before(client.LastFailedAt.Add(s.delay(client.FailedAttempts))) {
+			return derror.ErrTooManyAttempts
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) Fail(ctx context.Context, req lockoutproto.FailRequest) error {
+	now := s.now()
+	since := now.Add(-s.config.FailureWindow)
+
+	if ip := session.GetClientIP(ctx); ip != "" {
+		if _, err := s.storage.Fail(ctx, lockoutproto.IPKey(ip), now, since); err != nil {
+			s.logger.ErrorContext(
+				ctx,
+				"Error encountered while recording failed attempt of ip",
+				slog.String("error", err.Error()),
+				slog.String("ip", ip),
+			)
+			return derror.ErrInternalSystem
+		}
+	}
+
+	key := lockoutproto.AccountKey(req.AccountID)
+
+	account, err := s.storage.Fail(ctx, key, now, since)
+	if err != nil {
+		s.logger.ErrorContext(
+			ctx,
+			"Error encountered while recording failed attempt of account",
+			slog.String("error", err.Error()),
+			slog.Any("accountID", req.AccountID),
+		)
+		return derror.ErrInternalSystem
+	}
+
+	if account.FailedAttempts < s.config.LockThreshold {
+		return nil
+	}
+
+	next, err := accountMachine.Next(account.Status, "lock")
+	if err != nil {
+		// Already locked.
+		return nil
+	}
+
+	until := now.Add(s.config.LockDuration)
+
+	locked, err := s.storage.Lock(ctx, key, until)
+	if err != nil {
+		s.logger.ErrorContext(
+			ctx,
+			"Error encountered while locking account",
+			slog.String("error", err.Error()),
+			slog.Any("accountID", req.AccountID),
+		)
+		return derror.ErrInternalSystem
+	}
+
+	if !locked {
+		return nil
+	}
+
+	return s.record(ctx, req.AccountID, "lock", account.Status, next, reasonThreshold)
+}
+
+func (s *Service) Succeed(ctx context.Context, req lockoutproto.SucceedRequest) error {
+	if err := s.storage.Delete(ctx, lockoutproto.AccountKey(req.AccountID)); err != nil {
+		s.logger.ErrorContext(
+			ctx,
+			"Error encountered while forgetting failed attempts of account",
+			slog.String("error", err.Error()),
+			slog.Any("accountID", req.AccountID),
+		)
+		return derror.ErrInternalSystem
+	}
+
+	return nil
+}
+
+func (s *Service) Unlock(ctx context.Context, req lockoutproto.UnlockRequest) error {
+	if err := session.AuthorizeRole(ctx, session.RoleAdmin); err != nil {
+		return err
+	}
+
+	return s.unlock(ctx, req.AccountID, reasonAdmin)
+}
+
+func (s *Service) Get(ctx context.Context, req lockoutproto.GetRequest) (lockoutproto.GetResponse, error) {
+	attempts, err := s.get(ctx, lockoutproto.AccountKey(req.AccountID))
+	if err != nil {
+		return lockoutproto.GetResponse{}, err
+	}
+
+	return lockoutproto.GetResponse{Data: attempts}, nil
+}
+
+// get returns the attempts kept under key, which are empty when there are none.
+func (s *Service) get(ctx context.Context, key string) (lockoutproto.Attempts, error) {
+	attempts, err := s.storage.Get(ctx, key)
+	if err != nil {
+		if errors.Is(err, dbproto.ErrRowNotFound) {
+			return lockoutproto.Attempts{Key: key}, nil
+		}
+
+		s.logger.ErrorContext(
+			ctx,
+			"Error encountered while getting failed attempts",
+			slog.String("error", err.Error()),
+			slog.String("key", key),
+		)
+		return lockoutproto.Attempts{}, derror.ErrInternalSystem
+	}
+
+	return attempts, nil
+}
+
+func (s *Service) unlock(ctx context.Context, accountID accproto.AccountID, reason string) error {
+	account, err := s.get(ctx, lockoutproto.AccountKey(accountID))
+	if err != nil {
+		return err
+	}
+
+	next, err := accountMachine.Next(account.Status, "unlock")
+	if err != nil {
+		// Not locked.
+		return nil
+	}
+
+	unlocked, err := s.storage.Unlock(ctx, lockoutproto.AccountKey(accountID))
+	if err != nil {
+		s.logger.ErrorContext(
+			ctx,
+			"Error encountered while unlocking account",
+			slog.String("error", err.Error()),
+			slog.Any("accountID", accountID),
+		)
+		return derror.ErrInternalSystem
+	}
+
+	if !unlocked {
+		return nil
+	}
+
+	return s.record(ctx, accountID, "unlock", account.Status, next, reason)
+}
+
+// delay returns how long to wait after the last of the given number of failures.
+func (s *Service) delay(failures int) time.Duration {
+	if failures <= s.config.FreeAttempts {
+		return 0
+	}
+
+	delay := s.config.BaseDelay
+	for range failures - s.config.FreeAttempts - 1 {
+		delay *= 2
+		if delay >= s.config.MaxDelay {
+			return s.config.MaxDelay
+		}
+	}
+
+	return min(delay, s.config.MaxDelay)
+}
+
+// record adds a transition of the account to the status history.
+func (s *Service) record(
+	ctx context.Context, accountID accproto.AccountID, transition string, from, to stat.Status, reason string,
+) error {
+	return s.status.Record(ctx, statusproto.RecordRequest{
+		Entity:     accountMachine.Entity(),
+		EntityID:   accountID.String(),
+		Transition: transition,
+		From:       from,
+		To:         to,
+		Reason:     reason,
+	})
+}
@@ -0,0 +1,18 @@
+package lockoutservice
+
+import (
+	"log/slog"
+	"time"
+
+	statusproto "github.com/kianooshaz/skeleton/services/account/status/proto"
+)
+
+// NewWithStorage creates a service on the given storage, telling the time with now.
+func NewWithStorage(
+	cfg Config, storage Storer, status statusproto.StatusService, logger *slog.Logger, now func() time.Time,
+) *Service {
+	s := newService(cfg, storage, status, logger)
+	s.now = now
+
+	return s
+}
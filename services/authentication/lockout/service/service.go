@@ -0,0 +1,87 @@
+package lockoutservice
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	statusproto "github.com/kianooshaz/skeleton/services/account/status/proto"
+	"github.com/kianooshaz/skeleton/services/authentication/lockout/persistence"
+	lockoutproto "github.com/kianooshaz/skeleton/services/authentication/lockout/proto"
+)
+
+type (
+	Config struct {
+		// FreeAttempts is the number of failures allowed before attempts are delayed.
+		FreeAttempts int `yaml:"free_attempts"`
+		// BaseDelay is the delay after the first failure past FreeAttempts. It
+		// doubles with every further failure, up to MaxDelay.
+		BaseDelay time.Duration `yaml:"base_delay"`
+		MaxDelay  time.Duration `yaml:"max_delay"`
+		// LockThreshold is the number of failures that locks an account.
+		LockThreshold int `yaml:"lock_threshold"`
+		// LockDuration is the cooldown after which a lock clears by itself.
+		LockDuration time.Duration `yaml:"lock_duration"`
+		// FailureWindow is how long a failure is remembered without further ones.
+		FailureWindow time.Duration `yaml:"failure_window"`
+	}
+
+	Storer interface {
+		Get(ctx context.Context, key string) (lockoutproto.Attempts, error)
+		Fail(ctx context.Context, key string, at, since time.Time) (lockoutproto.Attempts, error)
+		Lock(ctx context.Context, key string, until time.Time) (bool, error)
+		Unlock(ctx context.Context, key string) (bool, error)
+		Delete(ctx context.Context, key string) error
+	}
+
+	Service struct {
+		config  Config
+		storage Storer
+		status  statusproto.StatusService
+		logger  *slog.Logger
+		now     func() time.Time
+	}
+)
+
+// New creates a new lockout service instance.
+func New(cfg Config, db *sql.DB, status statusproto.StatusService, logger *slog.Logger) lockoutproto.LockoutService {
+	serviceLogger := logger.With(
+		slog.Group("package_info",
+			slog.String("module", "authentication"),
+			slog.String("service", "lockout"),
+		),
+	)
+
+	return newService(cfg, &persistence.AttemptStorage{Conn: db}, status, serviceLogger)
+}
+
+func newService(cfg Config, storage Storer, status statusproto.StatusService, logger *slog.Logger) *Service {
+	// Set default values if not configured
+	if cfg.FreeAttempts == 0 {
+		cfg.FreeAttempts = 3
+	}
+	if cfg.BaseDelay == 0 {
+		cfg.BaseDelay = time.Second
+	}
+	if cfg.MaxDelay == 0 {
+		cfg.MaxDelay = 15 * time.Minute
+	}
+	if cfg.LockThreshold == 0 {
+		cfg.LockThreshold = 10
+	}
+	if cfg.LockDuration == 0 {
+		cfg.LockDuration = 30 * time.Minute
+	}
+	if cfg.FailureWindow == 0 {
+		cfg.FailureWindow = 24 * time.Hour
+	}
+
+	return &Service{
+		config:  cfg,
+		storage: storage,
+		status:  status,
+		logger:  logger,
+		now:     time.Now,
+	}
+}
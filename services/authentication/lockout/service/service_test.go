@@ -0,0 +1,193 @@
+package lockoutservice_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kianooshaz/skeleton/foundation/derror"
+	"github.com/kianooshaz/skeleton/foundation/session"
+	"github.com/kianooshaz/skeleton/foundation/stat"
+	accproto "github.com/kianooshaz/skeleton/services/account/accounts/proto"
+	statusproto "github.com/kianooshaz/skeleton/services/account/status/proto"
+	"github.com/kianooshaz/skeleton/services/authentication/lockout/persistence"
+	lockoutproto "github.com/kianooshaz/skeleton/services/authentication/lockout/proto"
+	lockoutservice "github.com/kianooshaz/skeleton/services/authentication/lockout/service"
+)
+
+// statusRecorder keeps the status transitions recorded through it.
+type statusRecorder struct {
+	statusproto.StatusService
+
+	mu       sync.Mutex
+	requests []statusproto.RecordRequest
+}
+
+func (r *statusRecorder) Record(_ context.Context, req statusproto.RecordRequest) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.requests = append(r.requests, req)
+
+	return nil
+}
+
+// reasons returns the recorded transitions with their reasons.
+func (r *statusRecorder) reasons() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	reasons := make([]string, 0, len(r.requests))
+	for _, req := range r.requests {
+		reasons = append(reasons, req.Transition+":"+req.Reason)
+	}
+
+	return reasons
+}
+
+type clock struct{ now time.Time }
+
+func (c *clock) Now() time.Time { return c.now }
+
+var config = lockoutservice.Config{
+	FreeAttempts:  2,
+	BaseDelay:     time.Second,
+	MaxDelay:      4 * time.Second,
+	LockThreshold: 6,
+	LockDuration:  time.Hour,
+	FailureWindow: 24 * time.Hour,
+}
+
+func newService(t *testing.T) (*lockoutservice.Service, *statusRecorder, *clock) {
+	t.Helper()
+
+	status := &statusRecorder{}
+	clock := &clock{now: time.Now()}
+	service := lockoutservice.NewWithStorage(config, persistence.NewAttemptMemoryStorage(), status,
+		slog.New(slog.NewTextHandler(io.Discard, nil)), clock.Now)
+
+	return service, status, clock
+}
+
+func TestService_Backoff(t *testing.T) {
+	service, _, clock := newService(t)
+	ctx := session.SetClientIP(context.Background(), "192.0.2.1")
+	accountID := accproto.AccountID(uuid.New())
+
+	// Execute & Assert: each failure past the free ones doubles the delay, up to the maximum.
+	wantDelays := []time.Duration{0, 0, time.Second, 2 * time.Second, 4 * time.Second}
+	for i, want := range wantDelays {
+		require.NoError(t, service.Fail(ctx, lockoutproto.FailRequest{AccountID: accountID}), "failure %d", i+1)
+
+		if want > 0 {
+			clock.now = clock.now.Add(want - time.Millisecond)
+			require.ErrorIs(t, service.Check(ctx, lockoutproto.CheckRequest{AccountID: accountID}),
+				derror.ErrTooManyAttempts, "failure %d", i+1)
+			clock.now = clock.now.Add(time.Millisecond)
+		}
+
+		require.NoError(t, service.Check(ctx, lockoutproto.CheckRequest{AccountID: accountID}), "failure %d", i+1)
+	}
+}
+
+func TestService_BackoffPerIP(t *testing.T) {
+	service, _, _ := newService(t)
+	ctx := session.SetClientIP(context.Background(), "192.0.2.1")
+
+	// Failures spread over accounts still delay the client.
+	for range config.FreeAttempts + 1 {
+		require.NoError(t, service.Fail(ctx, lockoutproto.FailRequest{AccountID: accproto.AccountID(uuid.New())}))
+	}
+
+	fresh := lockoutproto.CheckRequest{AccountID: accproto.AccountID(uuid.New())}
+	require.ErrorIs(t, service.Check(ctx, fresh), derror.ErrTooManyAttempts)
+
+	// Other clients are not affected.
+	other := session.SetClientIP(context.Background(), "192.0.2.2")
+	require.NoError(t, service.Check(other, fresh))
+}
+
+func TestService_Lock(t *testing.T) {
+	tests := []struct {
+		name        string
+		unlock      func(t *testing.T, service *lockoutservice.Service, clock *clock, accountID accproto.AccountID)
+		wantReasons []string
+	}{
+		{
+			name: "clears after cooldown",
+			unlock: func(t *testing.T, _ *lockoutservice.Service, clock *clock, _ accproto.AccountID) {
+				clock.now = clock.now.Add(config.LockDuration)
+			},
+			wantReasons: []string{"lock:threshold", "unlock:cooldown"},
+		},
+		{
+			name: "unlocked by admin",
+			unlock: func(t *testing.T, service *lockoutservice.Service, _ *clock, accountID accproto.AccountID) {
+				unlock := lockoutproto.UnlockRequest{AccountID: accountID}
+				owner := session.SetPrincipal(context.Background(), session.Principal{AccountID: uuid.UUID(accountID)})
+				require.ErrorIs(t, service.Unlock(owner, unlock), derror.ErrForbidden, "only admins unlock")
+
+				admin := session.SetPrincipal(context.Background(), session.Principal{Roles: []string{session.RoleAdmin}})
+				require.NoError(t, service.Unlock(admin, unlock))
+			},
+			wantReasons: []string{"lock:threshold", "unlock:admin"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, status, clock := newService(t)
+			ctx := context.Background()
+			accountID := accproto.AccountID(uuid.New())
+
+			for range config.LockThreshold {
+				require.NoError(t, service.Fail(ctx, lockoutproto.FailRequest{AccountID: accountID}))
+			}
+
+			// Waiting out the delay does not help while locked.
+			clock.now = clock.now.Add(config.MaxDelay)
+			require.ErrorIs(t, service.Check(ctx, lockoutproto.CheckRequest{AccountID: accountID}), derror.ErrAccountLocked)
+
+			res, err := service.Get(ctx, lockoutproto.GetRequest{AccountID: accountID})
+			require.NoError(t, err)
+			assert.True(t, res.Data.Status.Has(stat.Locked))
+
+			// Execute.
+			tt.unlock(t, service, clock, accountID)
+
+			// Assert.
+			require.NoError(t, service.Check(ctx, lockoutproto.CheckRequest{AccountID: accountID}))
+			assert.Equal(t, tt.wantReasons, status.reasons())
+
+			// The failures were forgotten with the lock.
+			require.NoError(t, service.Fail(ctx, lockoutproto.FailRequest{AccountID: accountID}))
+			res, err = service.Get(ctx, lockoutproto.GetRequest{AccountID: accountID})
+			require.NoError(t, err)
+			assert.Equal(t, 1, res.Data.FailedAttempts)
+		})
+	}
+}
+
+func TestService_Succeed(t *testing.T) {
+	service, _, _ := newService(t)
+	ctx := context.Background()
+	accountID := accproto.AccountID(uuid.New())
+
+	for range config.FreeAttempts + 1 {
+		require.NoError(t, service.Fail(ctx, lockoutproto.FailRequest{AccountID: accountID}))
+	}
+	require.ErrorIs(t, service.Check(ctx, lockoutproto.CheckRequest{AccountID: accountID}), derror.ErrTooManyAttempts)
+
+	// Execute.
+	require.NoError(t, service.Succeed(ctx, lockoutproto.SucceedRequest{AccountID: accountID}))
+
+	// Assert.
+	require.NoError(t, service.Check(ctx, lockoutproto.CheckRequest{AccountID: accountID}))
+}
@@ -0,0 +1,158 @@
+package persistence
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	dbproto "github.com/kianooshaz/skeleton/foundation/database/proto"
+	"github.com/kianooshaz/skeleton/foundation/pagination"
+	accprotocol "github.com/kianooshaz/skeleton/services/account/accounts/proto"
+	passwordproto "github.com/kianooshaz/skeleton/services/authentication/password/proto"
+)
+
+// PasswordMemoryStorage keeps passwords in memory. It behaves like
+// PasswordStorage, soft deletes included, and is meant for tests.
+type PasswordMemoryStorage struct {
+	mu        sync.RWMutex
+	passwords map[uuid.UUID]passwordproto.Password
+	deleted   map[uuid.UUID]bool
+}
+
+// NewPasswordMemoryStorage creates an empty PasswordMemoryStorage.
+func NewPasswordMemoryStorage() *PasswordMemoryStorage {
+	return &PasswordMemoryStorage{
+		passwords: make(map[uuid.UUID]passwordproto.Password),
+		deleted:   make(map[uuid.UUID]bool),
+	}
+}
+
+func (ms *PasswordMemoryStorage) Create(_ context.Context, password passwordproto.Password) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	if _, ok := ms.passwords[password.ID]; ok {
+		return dbproto.ErrDuplicateKey
+	}
+
+	now := time.Now()
+	password.CreatedAt = now
+	password.UpdatedAt = now
+	ms.passwords[password.ID] = password
+
+	return nil
+}
+
+func (ms *PasswordMemoryStorage) Delete(_ context.Context, id uuid.UUID) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	if _, ok := ms.passwords[id]; ok {
+		ms.deleted[id] = true
+	}
+
+	return nil
+}
+
+func (ms *PasswordMemoryStorage) Get(_ context.Context, id uuid.UUID) (passwordproto.Password, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	password, ok := ms.passwords[id]
+	if !ok || ms.deleted[id] {
+		return passwordproto.Password{}, dbproto.ErrRowNotFound
+	}
+
+	return password, nil
+}
+
+func (ms *PasswordMemoryStorage) GetByAccountID(
+	_ context.Context, accountID accprotocol.AccountID,
+) (passwordproto.Password, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	passwords := ms.byAccount(accountID, false)
+	if len(passwords) == 0 {
+		return passwordproto.Password{}, dbproto.ErrRowNotFound
+	}
+
+	return passwords[0], nil
+}
+
+func (ms *PasswordMemoryStorage) ListWithSearch(
+	_ context.Context, req passwordproto.ListRequest,
+) ([]passwordproto.Password, pagination.Cursors, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	columns, err := orderWhitelist.Resolve(req.Sort)
+	if err != nil {
+		return nil, pagination.Cursors{}, err
+	}
+
+	keyset := pagination.MemoryKeyset[passwordproto.Password]{
+		Compare: comparePasswords,
+		Key:     cursorKey(columns),
+		ID:      cursorID,
+		Lookup: func(id string) (passwordproto.Password, bool) {
+			parsed, err := uuid.Parse(id)
+			if err != nil {
+				return passwordproto.Password{}, false
+			}
+
+			password, ok := ms.passwords[parsed]
+			return password, ok
+		},
+	}
+
+	return keyset.Page(ms.byAccount(req.AccountID, false), req.Page, columns...)
+}
+
+func (ms *PasswordMemoryStorage) CountWithSearch(_ context.Context, req passwordproto.ListRequest) (int64, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	return int64(len(ms.byAccount(req.AccountID, false))), nil
+}
+
+func (ms *PasswordMemoryStorage) History(
+	_ context.Context, accountID accprotocol.AccountID, limit int32,
+) ([]passwordproto.Password, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	passwords := ms.byAccount(accountID, true)
+
+	return passwords[:min(int(max(limit, 0)), len(passwords))], nil
+}
+
+// byAccount returns the passwords of an account, newest first.
+func (ms *PasswordMemoryStorage) byAccount(accountID accprotocol.AccountID, withDeleted bool) []passwordproto.Password {
+	passwords := make([]passwordproto.Password, 0)
+	for id, password := range ms.passwords {
+		if password.AccountID == accountID && (withDeleted || !ms.deleted[id]) {
+			passwords = append(passwords, password)
+		}
+	}
+
+	slices.SortFunc(passwords, func(a, b passwordproto.Password) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+
+	return passwords
+}
+
+func comparePasswords(a, b passwordproto.Password, column string) int {
+	switch column {
+	case "created_at":
+		return a.CreatedAt.Compare(b.CreatedAt)
+	case "account_id":
+		return strings.Compare(a.AccountID.String(), b.AccountID.String())
+	default:
+		return 0
+	}
+}
@@ -1,27 +1,36 @@
 package persistence
 
-import "github.com/kianooshaz/skeleton/foundation/order"
+import (
+	"github.com/kianooshaz/skeleton/foundation/order"
+	"github.com/kianooshaz/skeleton/foundation/pagination"
+	passwordproto "github.com/kianooshaz/skeleton/services/authentication/password/proto"
+)
 
-var oderStringer order.StringerFunc = func(orderBy order.OrderBy) string {
-	var field string
-	switch orderBy.Field {
-	case "created_at":
-		field = "created_at"
-	case "account_id":
-		field = "account_id"
-	default:
-		field = "created_at"
-	}
+var orderWhitelist = order.Whitelist{
+	Columns: map[string]string{
+		"created_at": "created_at",
+		"account_id": "account_id",
+	},
+	Default: order.Spec{{Field: "created_at", Direction: order.ASC}},
+}
 
-	var direction string
-	switch orderBy.Direction {
-	case order.ASC:
-		direction = "ASC"
-	case order.DESC:
-		direction = "DESC"
-	default:
-		direction = "ASC"
+// cursorKey returns a function extracting the ORDER BY key of a password for the given columns.
+func cursorKey(columns []order.Column) func(passwordproto.Password) []string {
+	return func(password passwordproto.Password) []string {
+		keys := make([]string, 0, len(columns))
+		for _, column := range columns {
+			switch column.Name {
+			case "created_at":
+				keys = append(keys, pagination.TimeKey(password.CreatedAt))
+			case "account_id":
+				keys = append(keys, password.AccountID.String())
+			}
+		}
+
+		return keys
 	}
+}
 
-	return field + " " + direction
+func cursorID(password passwordproto.Password) string {
+	return password.ID.String()
 }
@@ -0,0 +1,137 @@
+// Package persistencetest holds the contract every password storage must meet,
+// so the in-memory storage used by tests stays faithful to the PostgreSQL one.
+package persistencetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbproto "github.com/kianooshaz/skeleton/foundation/database/proto"
+	"github.com/kianooshaz/skeleton/foundation/derror"
+	"github.com/kianooshaz/skeleton/foundation/order"
+	"github.com/kianooshaz/skeleton/foundation/pagination"
+	accprotocol "github.com/kianooshaz/skeleton/services/account/accounts/proto"
+	passwordproto "github.com/kianooshaz/skeleton/services/authentication/password/proto"
+	passwordservice "github.com/kianooshaz/skeleton/services/authentication/password/service"
+)
+
+// Storage is the password storage under contract.
+type Storage = passwordservice.Storer
+
+// Setup returns an empty storage and the context to call it with.
+type Setup func(t *testing.T) (Storage, context.Context)
+
+// Run runs the contract tests against the storages returned by setup.
+func Run(t *testing.T, setup Setup) {
+	t.Run("create and get", func(t *testing.T) {
+		storage, ctx := setup(t)
+
+		password := newPassword(accprotocol.AccountID(uuid.New()), "hash")
+		require.NoError(t, storage.Create(ctx, password))
+
+		got, err := storage.Get(ctx, password.ID)
+		require.NoError(t, err)
+		assert.Equal(t, password.ID, got.ID)
+		assert.Equal(t, password.AccountID, got.AccountID)
+		assert.Equal(t, password.PasswordHash, got.PasswordHash)
+		assert.False(t, got.CreatedAt.IsZero())
+
+		got, err = storage.GetByAccountID(ctx, password.AccountID)
+		require.NoError(t, err)
+		assert.Equal(t, password.ID, got.ID)
+	})
+
+	t.Run("get missing", func(t *testing.T) {
+		storage, ctx := setup(t)
+
+		_, err := storage.Get(ctx, uuid.New())
+		require.ErrorIs(t, err, dbproto.ErrRowNotFound)
+
+		_, err = storage.GetByAccountID(ctx, accprotocol.AccountID(uuid.New()))
+		require.ErrorIs(t, err, dbproto.ErrRowNotFound)
+	})
+
+	t.Run("replaced passwords stay in history", func(t *testing.T) {
+		storage, ctx := setup(t)
+
+		accountID := accprotocol.AccountID(uuid.New())
+
+		old := newPassword(accountID, "old")
+		require.NoError(t, storage.Create(ctx, old))
+		require.NoError(t, storage.Delete(ctx, old.ID))
+
+		// Creation timestamps come from the clock; keep them apart.
+		time.Sleep(10 * time.Millisecond)
+
+		current := newPassword(accountID, "current")
+		require.NoError(t, storage.Create(ctx, current))
+
+		_, err := storage.Get(ctx, old.ID)
+		require.ErrorIs(t, err, dbproto.ErrRowNotFound)
+
+		got, err := storage.GetByAccountID(ctx, accountID)
+		require.NoError(t, err)
+		assert.Equal(t, current.ID, got.ID)
+
+		history, err := storage.History(ctx, accountID, 5)
+		require.NoError(t, err)
+		require.Len(t, history, 2)
+		assert.Equal(t, current.ID, history[0].ID, "newest first")
+		assert.Equal(t, old.ID, history[1].ID)
+
+		history, err = storage.History(ctx, accountID, 1)
+		require.NoError(t, err)
+		assert.Len(t, history, 1)
+
+		request := passwordproto.ListRequest{AccountID: accountID, Page: pagination.Page{PageRows: 10}}
+
+		listed, _, err := storage.ListWithSearch(ctx, request)
+		require.NoError(t, err)
+		require.Len(t, listed, 1)
+		assert.Equal(t, current.ID, listed[0].ID)
+
+		count, err := storage.CountWithSearch(ctx, request)
+		require.NoError(t, err)
+		assert.EqualValues(t, 1, count)
+	})
+
+	t.Run("list pages by cursor", func(t *testing.T) {
+		storage, ctx := setup(t)
+
+		accountID := accprotocol.AccountID(uuid.New())
+		for _, hash := range []string{"first", "second", "third"} {
+			require.NoError(t, storage.Create(ctx, newPassword(accountID, hash)))
+		}
+
+		request := passwordproto.ListRequest{AccountID: accountID, Page: pagination.Page{PageRows: 2}}
+		first, cursors, err := storage.ListWithSearch(ctx, request)
+		require.NoError(t, err)
+		require.Len(t, first, 2)
+		require.NotEmpty(t, cursors.Next)
+
+		request.Page.Cursor = cursors.Next
+		second, cursors, err := storage.ListWithSearch(ctx, request)
+		require.NoError(t, err)
+		require.Len(t, second, 1)
+		assert.NotContains(t, first, second[0])
+		assert.Empty(t, cursors.Next)
+		assert.NotEmpty(t, cursors.Prev)
+
+		request.Sort = order.Spec{{Field: "created_at", Direction: order.DESC}}
+		_, _, err = storage.ListWithSearch(ctx, request)
+		require.ErrorIs(t, err, derror.ErrInvalidCursor, "cursor of another ordering")
+	})
+}
+
+func newPassword(accountID accprotocol.AccountID, hash string) passwordproto.Password {
+	return passwordproto.Password{
+		ID:           uuid.New(),
+		AccountID:    accountID,
+		PasswordHash: hash,
+	}
+}
@@ -1,9 +1,9 @@
-INSERT INTO `passwords` (
-        `id`,
-        `account_id`,
-        `password_hash`,
-        `created_at`,
-        `updated_at`,
-        `deleted_at`
+INSERT INTO passwords (
+        id,
+        account_id,
+        password_hash,
+        created_at,
+        updated_at,
+        deleted_at
     )
 VALUES ($1, $2, $3, NOW(), NOW(), NULL)
\ No newline at end of file
@@ -1,8 +1,8 @@
-SELECT `id`,
-    `account_id`,
-    `password_hash`,
-    `created_at`,
-    `updated_at`
-FROM `passwords`
-WHERE `id` = $1
-    AND `deleted_at` IS NULL
\ No newline at end of file
+SELECT id,
+    account_id,
+    password_hash,
+    created_at,
+    updated_at
+FROM passwords
+WHERE id = $1
+    AND deleted_at IS NULL
\ No newline at end of file
@@ -18,8 +18,6 @@ type PasswordStorage struct {
 	Conn dbproto.QueryExecutor
 }
 
-const defaultPageSize = 20
-
 //go:embed queries/create.sql
 var createQuery string
 
@@ -91,15 +89,29 @@ func (ps *PasswordStorage) GetByAccountID(
 
 func (ps *PasswordStorage) ListWithSearch(
 	ctx context.Context, req passwordproto.ListRequest,
-) ([]passwordproto.Password, error) {
+) ([]passwordproto.Password, pagination.Cursors, error) {
 	conn := session.GetDBConnection(ctx, ps.Conn)
 
-	query := listByAccountQuery + req.OrderBy.String(oderStringer) +
-		req.Page.String(pagination.SQLStringer(defaultPageSize))
+	columns, err := orderWhitelist.Resolve(req.Sort)
+	if err != nil {
+		return nil, pagination.Cursors{}, err
+	}
 
-	rows, err := conn.QueryContext(ctx, query, req.AccountID)
+	keyset, err := pagination.NewSQLKeyset(req.Page, "id", columns...)
 	if err != nil {
-		return nil, err
+		return nil, pagination.Cursors{}, err
+	}
+
+	query := listByAccountQuery
+	where, args := keyset.Where(2)
+	if where != "" {
+		query += " AND " + where
+	}
+	query += keyset.OrderBy() + keyset.Limit()
+
+	rows, err := conn.QueryContext(ctx, query, append([]any{req.AccountID}, args...)...)
+	if err != nil {
+		return nil, pagination.Cursors{}, err
 	}
 	defer rows.Close()
 
@@ -113,12 +125,18 @@ func (ps *PasswordStorage) ListWithSearch(
 			&password.CreatedAt,
 			&password.UpdatedAt,
 		); err != nil {
-			return nil, err
+			return nil, pagination.Cursors{}, err
 		}
 		passwords = append(passwords, password)
 	}
 
-	return passwords, nil
+	if err := rows.Err(); err != nil {
+		return nil, pagination.Cursors{}, err
+	}
+
+	passwords, cursors := pagination.Window(keyset, passwords, cursorKey(columns), cursorID)
+
+	return passwords, cursors, nil
 }
 
 func (ps *PasswordStorage) CountWithSearch(ctx context.Context, req passwordproto.ListRequest) (int64, error) {
@@ -0,0 +1,24 @@
+package persistence_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kianooshaz/skeleton/foundation/database/postgres/postgrestest"
+	"github.com/kianooshaz/skeleton/services/authentication/password/persistence"
+	"github.com/kianooshaz/skeleton/services/authentication/password/persistence/persistencetest"
+)
+
+func TestPasswordMemoryStorage(t *testing.T) {
+	persistencetest.Run(t, func(t *testing.T) (persistencetest.Storage, context.Context) {
+		return persistence.NewPasswordMemoryStorage(), t.Context()
+	})
+}
+
+func TestPasswordStorage(t *testing.T) {
+	db := postgrestest.Open(t, "../schema.sql")
+
+	persistencetest.Run(t, func(t *testing.T) (persistencetest.Storage, context.Context) {
+		return &persistence.PasswordStorage{Conn: db}, postgrestest.Context(t, db, "passwords")
+	})
+}
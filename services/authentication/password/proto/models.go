@@ -20,22 +20,29 @@ type Password struct {
 
 type PasswordService interface {
 	Update(ctx context.Context, req UpdateRequest) error
-	Verify(ctx context.Context, password string) error
+	Verify(ctx context.Context, req VerifyRequest) error
 	Guidelines() (GuidelinesResponse, error)
 	Get(ctx context.Context, id uuid.UUID) (Password, error)
 	List(ctx context.Context, req ListRequest) (ListResponse, error)
 }
 
 type UpdateRequest struct {
-	OTP         string             `json:"otp"`
-	NewPassword string             `json:"new_password"`
-	AccountID   accproto.AccountID `json:"account_id"`
+	// CurrentPassword proves the change is wanted. Accounts without a
+	// password yet leave it empty.
+	CurrentPassword string             `json:"current_password"`
+	NewPassword     string             `json:"new_password" validate:"required"`
+	AccountID       accproto.AccountID `param:"account_id" json:"-" validate:"id"`
+}
+
+type VerifyRequest struct {
+	AccountID accproto.AccountID `json:"account_id"`
+	Password  string             `json:"password"`
 }
 
 type ListRequest struct {
 	AccountID accproto.AccountID `query:"account_id"`
 	pagination.Page
-	order.OrderBy
+	Sort order.Spec `query:"sort"`
 }
 
 type ListResponse pagination.Response[Password]
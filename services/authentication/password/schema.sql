@@ -0,0 +1,14 @@
+-- Password Service Database Schema
+-- This file contains the SQL schema for the password service tables.
+-- Run this manually in your PostgreSQL database to create the required tables.
+-- Create passwords table; replaced passwords are soft-deleted and kept as history
+CREATE TABLE IF NOT EXISTS passwords (
+    id UUID PRIMARY KEY,
+    account_id UUID NOT NULL,
+    password_hash TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP NULL
+);
+-- Create indexes for better performance
+CREATE INDEX IF NOT EXISTS idx_passwords_account_id ON passwords (account_id, created_at);
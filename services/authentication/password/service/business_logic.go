@@ -11,15 +11,44 @@ import (
 	dbproto "github.com/kianooshaz/skeleton/foundation/database/proto"
 	"github.com/kianooshaz/skeleton/foundation/derror"
 	"github.com/kianooshaz/skeleton/foundation/pagination"
+	"github.com/kianooshaz/skeleton/foundation/session"
 	accproto "github.com/kianooshaz/skeleton/services/account/accounts/proto"
+	lockoutproto "github.com/kianooshaz/skeleton/services/authentication/lockout/proto"
 	passwordproto "github.com/kianooshaz/skeleton/services/authentication/password/proto"
 	"golang.org/x/crypto/bcrypt"
 )
 
 type hash []byte
 
-func (s *Service) Verify(ctx context.Context, password string) error {
-	return nil
+// Verify checks password against the current password of the account. Failed
+// attempts are tracked, and further attempts are delayed or refused after too
+// many of them.
+func (s *Service) Verify(ctx context.Context, req passwordproto.VerifyRequest) error {
+	if err := s.lockout.Check(ctx, lockoutproto.CheckRequest{AccountID: req.AccountID}); err != nil {
+		return err
+	}
+
+	current, err := s.storage.GetByAccountID(ctx, req.AccountID)
+	if err != nil && !errors.Is(err, dbproto.ErrRowNotFound) {
+		s.logger.ErrorContext(
+			ctx,
+			"Error encountered while fetching password of account",
+			slog.String("error", err.Error()),
+			slog.Any("accountID", req.AccountID),
+		)
+		return derror.ErrInternalSystem
+	}
+
+	// An account without a password fails like a wrong password, so it cannot be told apart.
+	if err != nil || !s.verifyPassword(hash(current.PasswordHash), req.Password) {
+		if err := s.lockout.Fail(ctx, lockoutproto.FailRequest{AccountID: req.AccountID}); err != nil {
+			return err
+		}
+
+		return derror.ErrPasswordInvalid
+	}
+
+	return s.lockout.Succeed(ctx, lockoutproto.SucceedRequest{AccountID: req.AccountID})
 }
 
 func (s *Service) Get(ctx context.Context, id uuid.UUID) (passwordproto.Password, error) {
@@ -37,8 +66,13 @@ func (s *Service) Get(ctx context.Context, id uuid.UUID) (passwordproto.Password
 }
 
 func (s *Service) List(ctx context.Context, req passwordproto.ListRequest) (passwordproto.ListResponse, error) {
-	passwords, err := s.storage.ListWithSearch(ctx, req)
+	passwords, cursors, err := s.storage.ListWithSearch(ctx, req)
 	if err != nil {
+		if errors.Is(err, derror.ErrInvalidCursor) || errors.Is(err, derror.ErrUnknownOrder) ||
+			errors.Is(err, derror.ErrUnknownOrderDirection) {
+			return passwordproto.ListResponse{}, err
+		}
+
 		s.logger.Error(
 			"Error encountered while searching passwords",
 			slog.String("error", err.Error()),
@@ -48,23 +82,59 @@ func (s *Service) List(ctx context.Context, req passwordproto.ListRequest) (pass
 		return passwordproto.ListResponse{}, derror.ErrInternalSystem
 	}
 
-	count, err := s.storage.CountWithSearch(ctx, req)
-	if err != nil {
-		s.logger.Error(
-			"Error encountered while counting passwords",
-			slog.String("error", err.Error()),
-			slog.Any("request", req),
-		)
+	var count int64
+	if req.NeedsTotal() {
+		count, err = s.storage.CountWithSearch(ctx, req)
+		if err != nil {
+			s.logger.Error(
+				"Error encountered while counting passwords",
+				slog.String("error", err.Error()),
+				slog.Any("request", req),
+			)
 
-		return passwordproto.ListResponse{}, derror.ErrInternalSystem
+			return passwordproto.ListResponse{}, derror.ErrInternalSystem
+		}
 	}
 
-	return passwordproto.ListResponse(pagination.NewResponse(req.Page, int(count), passwords)), nil
+	return passwordproto.ListResponse(pagination.NewCursorResponse(req.Page, int(count), passwords, cursors)), nil
 }
 
-// Update updates the password for a given account.
+// Update replaces the password of the account. The current password must be
+// proven, except by accounts without one, and failed proofs are tracked as
+// Verify tracks them.
 func (s *Service) Update(ctx context.Context, req passwordproto.UpdateRequest) error {
-	// TODO check otp
+	if err := session.AuthorizeAccount(ctx, uuid.UUID(req.AccountID)); err != nil {
+		return err
+	}
+
+	if err := s.lockout.Check(ctx, lockoutproto.CheckRequest{AccountID: req.AccountID}); err != nil {
+		return err
+	}
+
+	current, err := s.storage.GetByAccountID(ctx, req.AccountID)
+	if err != nil && !errors.Is(err, dbproto.ErrRowNotFound) {
+		s.logger.ErrorContext(
+			ctx,
+			"Error encountered while fetching password of account",
+			slog.String("error", err.Error()),
+			slog.Any("accountID", req.AccountID),
+		)
+		return derror.ErrInternalSystem
+	}
+	hasPassword := err == nil
+
+	if hasPassword {
+		if !s.verifyPassword(hash(current.PasswordHash), req.CurrentPassword) {
+			if err := s.lockout.Fail(ctx, lockoutproto.FailRequest{AccountID: req.AccountID}); err != nil {
+				return err
+			}
+			return derror.ErrPasswordInvalid
+		}
+
+		if err := s.lockout.Succeed(ctx, lockoutproto.SucceedRequest{AccountID: req.AccountID}); err != nil {
+			return err
+		}
+	}
 
 	if !s.evaluatePasswordStrength(req.NewPassword) {
 		return derror.ErrPasswordIsWeak
@@ -76,7 +146,7 @@ func (s *Service) Update(ctx context.Context, req passwordproto.UpdateRequest) e
 		return err
 	}
 
-	used, err := s.usedBefore(ctx, req.AccountID, string(passwordHash))
+	used, err := s.usedBefore(ctx, req.AccountID, req.NewPassword)
 	if err != nil {
 		return err
 	}
@@ -85,21 +155,9 @@ func (s *Service) Update(ctx context.Context, req passwordproto.UpdateRequest) e
 		return derror.ErrPasswordUsedBefore
 	}
 
-	// Get existing password to delete it
-	existingPassword, err := s.storage.GetByAccountID(ctx, req.AccountID)
-	if err != nil && !errors.Is(err, dbproto.ErrRowNotFound) {
-		s.logger.ErrorContext(
-			ctx,
-			"failed to get existing password",
-			slog.String("error", err.Error()),
-			slog.Any("accountID", req.AccountID),
-		)
-		return derror.ErrInternalSystem
-	}
-
-	// Delete existing password if it exists
-	if err == nil {
-		if err := s.storage.Delete(ctx, existingPassword.ID); err != nil {
+	// Delete the current password if there is one
+	if hasPassword {
+		if err := s.storage.Delete(ctx, current.ID); err != nil {
 			s.logger.ErrorContext(
 				ctx,
 				"failed to delete old password",
@@ -145,7 +203,8 @@ func (s *Service) verifyPassword(storedPassword hash, password string) bool {
 	return err == nil
 }
 
-func (s *Service) usedBefore(ctx context.Context, accountID accproto.AccountID, hashedPassword string) (bool, error) {
+// usedBefore reports whether password matches one of the recent passwords of the account.
+func (s *Service) usedBefore(ctx context.Context, accountID accproto.AccountID, password string) (bool, error) {
 	passwords, err := s.storage.History(ctx, accountID, s.config.CheckPasswordHistoryLimit)
 	if err != nil {
 		s.logger.ErrorContext(
@@ -157,8 +216,8 @@ func (s *Service) usedBefore(ctx context.Context, accountID accproto.AccountID,
 		return false, derror.ErrInternalSystem
 	}
 
-	for _, password := range passwords {
-		if err := bcrypt.CompareHashAndPassword([]byte(password.PasswordHash), []byte(hashedPassword)); err == nil {
+	for _, previous := range passwords {
+		if s.verifyPassword(hash(previous.PasswordHash), password) {
 			return true, nil
 		}
 	}
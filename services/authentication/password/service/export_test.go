@@ -0,0 +1,17 @@
+package passwordservice
+
+import (
+	"log/slog"
+
+	lockoutproto "github.com/kianooshaz/skeleton/services/authentication/lockout/proto"
+)
+
+// NewWithStorage creates a service on the given storage.
+func NewWithStorage(cfg Config, storage Storer, lockout lockoutproto.LockoutService, logger *slog.Logger) *Service {
+	return &Service{
+		config:  cfg,
+		logger:  *logger,
+		storage: storage,
+		lockout: lockout,
+	}
+}
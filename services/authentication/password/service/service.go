@@ -6,7 +6,9 @@ import (
 	"log/slog"
 
 	"github.com/google/uuid"
+	"github.com/kianooshaz/skeleton/foundation/pagination"
 	accprotocol "github.com/kianooshaz/skeleton/services/account/accounts/proto"
+	lockoutproto "github.com/kianooshaz/skeleton/services/authentication/lockout/proto"
 	"github.com/kianooshaz/skeleton/services/authentication/password/persistence"
 	passwordproto "github.com/kianooshaz/skeleton/services/authentication/password/proto"
 )
@@ -26,7 +28,9 @@ type (
 		Delete(ctx context.Context, id uuid.UUID) error
 		Get(ctx context.Context, id uuid.UUID) (passwordproto.Password, error)
 		GetByAccountID(ctx context.Context, accountID accprotocol.AccountID) (passwordproto.Password, error)
-		ListWithSearch(ctx context.Context, req passwordproto.ListRequest) ([]passwordproto.Password, error)
+		ListWithSearch(
+			ctx context.Context, req passwordproto.ListRequest,
+		) ([]passwordproto.Password, pagination.Cursors, error)
 		CountWithSearch(ctx context.Context, req passwordproto.ListRequest) (int64, error)
 		History(ctx context.Context, accountID accprotocol.AccountID, limit int32) ([]passwordproto.Password, error)
 	}
@@ -37,11 +41,14 @@ type (
 		logger          slog.Logger
 		storage         Storer
 		storageConn     *sql.DB
+		lockout         lockoutproto.LockoutService
 	}
 )
 
 // New creates a new password service instance.
-func New(cfg Config, db *sql.DB, logger *slog.Logger) passwordproto.PasswordService {
+func New(
+	cfg Config, db *sql.DB, lockout lockoutproto.LockoutService, logger *slog.Logger,
+) passwordproto.PasswordService {
 	serviceLogger := *logger.With(
 		slog.Group("package_info",
 			slog.String("module", "password"),
@@ -62,5 +69,6 @@ func New(cfg Config, db *sql.DB, logger *slog.Logger) passwordproto.PasswordServ
 			Conn: db,
 		},
 		storageConn: db,
+		lockout:     lockout,
 	}
 }
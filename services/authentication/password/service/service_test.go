@@ -0,0 +1,212 @@
+package passwordservice_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/kianooshaz/skeleton/foundation/derror"
+	"github.com/kianooshaz/skeleton/foundation/session"
+	accproto "github.com/kianooshaz/skeleton/services/account/accounts/proto"
+	lockoutproto "github.com/kianooshaz/skeleton/services/authentication/lockout/proto"
+	"github.com/kianooshaz/skeleton/services/authentication/password/persistence"
+	passwordproto "github.com/kianooshaz/skeleton/services/authentication/password/proto"
+	passwordservice "github.com/kianooshaz/skeleton/services/authentication/password/service"
+)
+
+func TestService_Update(t *testing.T) {
+	accountID := accproto.AccountID(uuid.New())
+	ctx := session.SetPrincipal(context.Background(), session.Principal{AccountID: uuid.UUID(accountID)})
+
+	storage := persistence.NewPasswordMemoryStorage()
+	service := passwordservice.NewWithStorage(passwordservice.Config{
+		MinLength:                 8,
+		Cost:                      bcrypt.MinCost,
+		CheckPasswordHistoryLimit: 3,
+	}, storage, &fakeLockout{}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	update := func(current, password string) error {
+		return service.Update(ctx, passwordproto.UpdateRequest{
+			AccountID:       accountID,
+			CurrentPassword: current,
+			NewPassword:     password,
+		})
+	}
+
+	require.ErrorIs(t, update("", "short!"), derror.ErrPasswordIsWeak)
+	require.ErrorIs(t, update("", "nospecialchars"), derror.ErrPasswordIsWeak)
+
+	// The first password needs no current one.
+	require.NoError(t, update("", "first-secret"))
+
+	first, err := storage.GetByAccountID(ctx, accountID)
+	require.NoError(t, err)
+	require.NoError(t, bcrypt.CompareHashAndPassword([]byte(first.PasswordHash), []byte("first-secret")))
+
+	require.NoError(t, update("first-secret", "second-secret"))
+
+	second, err := storage.GetByAccountID(ctx, accountID)
+	require.NoError(t, err)
+	assert.NotEqual(t, first.ID, second.ID, "the old password is replaced")
+
+	require.ErrorIs(t, update("second-secret", "first-secret"), derror.ErrPasswordUsedBefore)
+	require.ErrorIs(t, update("second-secret", "second-secret"), derror.ErrPasswordUsedBefore)
+
+	// Only the account itself may change its password.
+	other := session.SetPrincipal(context.Background(), session.Principal{AccountID: uuid.New()})
+	err = service.Update(other, passwordproto.UpdateRequest{AccountID: accountID, NewPassword: "third-secret"})
+	require.ErrorIs(t, err, derror.ErrForbidden)
+	err = service.Update(context.Background(), passwordproto.UpdateRequest{AccountID: accountID, NewPassword: "third-secret"})
+	require.ErrorIs(t, err, derror.ErrUnauthenticated)
+}
+
+func TestService_UpdateProvesCurrent(t *testing.T) {
+	accountID := accproto.AccountID(uuid.New())
+
+	tests := []struct {
+		name          string
+		current       string
+		checkErr      error
+		wantErr       error
+		wantFailed    int
+		wantSucceeded int
+	}{
+		{name: "current password", current: "first-secret", wantSucceeded: 1},
+		{name: "wrong current password", current: "wrong-secret", wantErr: derror.ErrPasswordInvalid, wantFailed: 1},
+		{name: "no current password", wantErr: derror.ErrPasswordInvalid, wantFailed: 1},
+		{name: "locked out", current: "first-secret", checkErr: derror.ErrAccountLocked, wantErr: derror.ErrAccountLocked},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := session.SetPrincipal(context.Background(), session.Principal{AccountID: uuid.UUID(accountID)})
+			lockout := &fakeLockout{}
+			storage := persistence.NewPasswordMemoryStorage()
+			service := passwordservice.NewWithStorage(passwordservice.Config{
+				MinLength: 8,
+				Cost:      bcrypt.MinCost,
+			}, storage, lockout, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+			require.NoError(t, service.Update(ctx, passwordproto.UpdateRequest{AccountID: accountID, NewPassword: "first-secret"}))
+			lockout.checkErr = tt.checkErr
+
+			// Execute.
+			err := service.Update(ctx, passwordproto.UpdateRequest{
+				AccountID:       accountID,
+				CurrentPassword: tt.current,
+				NewPassword:     "second-secret",
+			})
+
+			// Assert.
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+
+				stored, err := storage.GetByAccountID(ctx, accountID)
+				require.NoError(t, err)
+				require.NoError(t, bcrypt.CompareHashAndPassword([]byte(stored.PasswordHash), []byte("first-secret")),
+					"the password is kept")
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantFailed, lockout.failed)
+			assert.Equal(t, tt.wantSucceeded, lockout.succeeded)
+		})
+	}
+}
+
+// fakeLockout refuses attempts with checkErr and counts the outcomes reported to it.
+type fakeLockout struct {
+	lockoutproto.LockoutService
+
+	checkErr  error
+	failed    int
+	succeeded int
+}
+
+func (f *fakeLockout) Check(context.Context, lockoutproto.CheckRequest) error {
+	return f.checkErr
+}
+
+func (f *fakeLockout) Fail(context.Context, lockoutproto.FailRequest) error {
+	f.failed++
+	return nil
+}
+
+func (f *fakeLockout) Succeed(context.Context, lockoutproto.SucceedRequest) error {
+	f.succeeded++
+	return nil
+}
+
+func TestService_Verify(t *testing.T) {
+	accountID := accproto.AccountID(uuid.New())
+
+	tests := []struct {
+		name          string
+		accountID     accproto.AccountID
+		password      string
+		checkErr      error
+		wantErr       error
+		wantFailed    int
+		wantSucceeded int
+	}{
+		{
+			name:          "correct password",
+			accountID:     accountID,
+			password:      "first-secret",
+			wantSucceeded: 1,
+		},
+		{
+			name:       "wrong password",
+			accountID:  accountID,
+			password:   "second-secret",
+			wantErr:    derror.ErrPasswordInvalid,
+			wantFailed: 1,
+		},
+		{
+			name:       "account without password",
+			accountID:  accproto.AccountID(uuid.New()),
+			password:   "first-secret",
+			wantErr:    derror.ErrPasswordInvalid,
+			wantFailed: 1,
+		},
+		{
+			name:      "locked out",
+			accountID: accountID,
+			password:  "first-secret",
+			checkErr:  derror.ErrAccountLocked,
+			wantErr:   derror.ErrAccountLocked,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := session.SetPrincipal(context.Background(), session.Principal{AccountID: uuid.UUID(accountID)})
+			lockout := &fakeLockout{}
+			service := passwordservice.NewWithStorage(passwordservice.Config{
+				MinLength: 8,
+				Cost:      bcrypt.MinCost,
+			}, persistence.NewPasswordMemoryStorage(), lockout, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+			require.NoError(t, service.Update(ctx, passwordproto.UpdateRequest{AccountID: accountID, NewPassword: "first-secret"}))
+			lockout.checkErr = tt.checkErr
+
+			// Execute.
+			err := service.Verify(ctx, passwordproto.VerifyRequest{AccountID: tt.accountID, Password: tt.password})
+
+			// Assert.
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantFailed, lockout.failed)
+			assert.Equal(t, tt.wantSucceeded, lockout.succeeded)
+		})
+	}
+}
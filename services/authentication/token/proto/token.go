@@ -0,0 +1,35 @@
+package tokenproto
+
+import (
+	"context"
+	"time"
+
+	"github.com/kianooshaz/skeleton/foundation/session"
+	accproto "github.com/kianooshaz/skeleton/services/account/accounts/proto"
+)
+
+// Token is a bearer token proving the account it was issued for, until it expires.
+type Token struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// TokenService issues the bearer tokens requests authenticate with. Tokens are
+// signed rather than stored, so they stay valid until they expire.
+type TokenService interface {
+	// Issue verifies the password of the account, as PasswordService.Verify
+	// does, and returns a token for the account.
+	Issue(ctx context.Context, req IssueRequest) (IssueResponse, error)
+	// Verify returns the principal of a token returned by Issue. Tokens that
+	// expired or were tampered with fail with derror.ErrUnauthenticated.
+	Verify(ctx context.Context, token string) (session.Principal, error)
+}
+
+type IssueRequest struct {
+	AccountID accproto.AccountID `json:"account_id" validate:"id"`
+	Password  string             `json:"password" validate:"required"`
+}
+
+type IssueResponse struct {
+	Data Token `json:"data"`
+}
@@ -0,0 +1,81 @@
+package tokenservice
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kianooshaz/skeleton/foundation/derror"
+	"github.com/kianooshaz/skeleton/foundation/session"
+	passwordproto "github.com/kianooshaz/skeleton/services/authentication/password/proto"
+	tokenproto "github.com/kianooshaz/skeleton/services/authentication/token/proto"
+)
+
+// claims are what a token asserts, signed as base64 encoded JSON.
+type claims struct {
+	AccountID uuid.UUID `json:"acc"`
+	IssuedAt  int64     `json:"iat"`
+	ExpiresAt int64     `json:"exp"`
+}
+
+func (s *Service) Issue(ctx context.Context, req tokenproto.IssueRequest) (tokenproto.IssueResponse, error) {
+	err := s.passwords.Verify(ctx, passwordproto.VerifyRequest{AccountID: req.AccountID, Password: req.Password})
+	if err != nil {
+		return tokenproto.IssueResponse{}, err
+	}
+
+	now := s.now()
+	expiresAt := now.Add(s.config.TTL)
+
+	payload, err := json.Marshal(claims{
+		AccountID: uuid.UUID(req.AccountID),
+		IssuedAt:  now.Unix(),
+		ExpiresAt: expiresAt.Unix(),
+	})
+	if err != nil {
+		s.logger.ErrorContext(
+			ctx,
+			"Error encountered while encoding token claims",
+			slog.String("error", err.Error()),
+			slog.Any("accountID", req.AccountID),
+		)
+		return tokenproto.IssueResponse{}, derror.ErrInternalSystem
+	}
+
+	return tokenproto.IssueResponse{
+		Data: tokenproto.Token{
+			Token:     s.signer.Sign(base64.RawURLEncoding.EncodeToString(payload)),
+			ExpiresAt: time.Unix(expiresAt.Unix(), 0),
+		},
+	}, nil
+}
+
+func (s *Service) Verify(_ context.Context, token string) (session.Principal, error) {
+	value, ok := s.signer.Verify(token)
+	if !ok {
+		return session.Principal{}, derror.ErrUnauthenticated
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return session.Principal{}, derror.ErrUnauthenticated
+	}
+
+	var c claims
+	if err := json.Unmarshal(payload, &c); err != nil {
+		return session.Principal{}, derror.ErrUnauthenticated
+	}
+
+	if !s.now().Before(time.Unix(c.ExpiresAt, 0)) {
+		return session.Principal{}, derror.ErrUnauthenticated
+	}
+
+	return session.Principal{
+		AccountID:       c.AccountID,
+		AuthMethod:      session.AuthMethodToken,
+		AuthenticatedAt: time.Unix(c.IssuedAt, 0),
+	}, nil
+}
@@ -0,0 +1,21 @@
+package tokenservice
+
+import (
+	"log/slog"
+	"time"
+
+	passwordproto "github.com/kianooshaz/skeleton/services/authentication/password/proto"
+)
+
+// NewWithClock creates a service telling the time with now.
+func NewWithClock(
+	cfg Config, passwords passwordproto.PasswordService, logger *slog.Logger, now func() time.Time,
+) (*Service, error) {
+	s, err := newService(cfg, passwords, logger)
+	if err != nil {
+		return nil, err
+	}
+	s.now = now
+
+	return s, nil
+}
@@ -0,0 +1,59 @@
+package tokenservice
+
+import (
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/kianooshaz/skeleton/foundation/signed"
+	passwordproto "github.com/kianooshaz/skeleton/services/authentication/password/proto"
+	tokenproto "github.com/kianooshaz/skeleton/services/authentication/token/proto"
+)
+
+type (
+	Config struct {
+		// Secret signs the tokens. Changing it invalidates every token issued before.
+		Secret string `yaml:"secret"`
+		// TTL is how long a token is valid.
+		TTL time.Duration `yaml:"ttl"`
+	}
+
+	Service struct {
+		config    Config
+		signer    signed.Signer
+		passwords passwordproto.PasswordService
+		logger    *slog.Logger
+		now       func() time.Time
+	}
+)
+
+// New creates a new token service instance.
+func New(
+	cfg Config, passwords passwordproto.PasswordService, logger *slog.Logger,
+) (tokenproto.TokenService, error) {
+	serviceLogger := logger.With(
+		slog.Group("package_info",
+			slog.String("module", "authentication"),
+			slog.String("service", "token"),
+		),
+	)
+
+	return newService(cfg, passwords, serviceLogger)
+}
+
+func newService(cfg Config, passwords passwordproto.PasswordService, logger *slog.Logger) (*Service, error) {
+	if cfg.Secret == "" {
+		return nil, errors.New("tokens need a secret")
+	}
+	if cfg.TTL == 0 {
+		cfg.TTL = time.Hour
+	}
+
+	return &Service{
+		config:    cfg,
+		signer:    signed.NewSigner(cfg.Secret),
+		passwords: passwords,
+		logger:    logger,
+		now:       time.Now,
+	}, nil
+}
@@ -0,0 +1,117 @@
+package tokenservice_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kianooshaz/skeleton/foundation/derror"
+	"github.com/kianooshaz/skeleton/foundation/session"
+	accproto "github.com/kianooshaz/skeleton/services/account/accounts/proto"
+	passwordproto "github.com/kianooshaz/skeleton/services/authentication/password/proto"
+	tokenproto "github.com/kianooshaz/skeleton/services/authentication/token/proto"
+	tokenservice "github.com/kianooshaz/skeleton/services/authentication/token/service"
+)
+
+// fakePasswords accepts the password "secret" only.
+type fakePasswords struct {
+	passwordproto.PasswordService
+}
+
+func (fakePasswords) Verify(_ context.Context, req passwordproto.VerifyRequest) error {
+	if req.Password != "secret" {
+		return derror.ErrPasswordInvalid
+	}
+
+	return nil
+}
+
+type clock struct{ now time.Time }
+
+func (c *clock) Now() time.Time { return c.now }
+
+func newService(t *testing.T, secret string) (*tokenservice.Service, *clock) {
+	t.Helper()
+
+	clock := &clock{now: time.Now()}
+	service, err := tokenservice.NewWithClock(tokenservice.Config{Secret: secret, TTL: time.Hour}, fakePasswords{},
+		slog.New(slog.NewTextHandler(io.Discard, nil)), clock.Now)
+	require.NoError(t, err)
+
+	return service, clock
+}
+
+func TestNew_RequiresSecret(t *testing.T) {
+	_, err := tokenservice.New(tokenservice.Config{}, fakePasswords{}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	assert.Error(t, err)
+}
+
+func TestService_Issue(t *testing.T) {
+	ctx := context.Background()
+	accountID := accproto.AccountID(uuid.New())
+	service, clock := newService(t, "key")
+
+	_, err := service.Issue(ctx, tokenproto.IssueRequest{AccountID: accountID, Password: "wrong"})
+	require.ErrorIs(t, err, derror.ErrPasswordInvalid)
+
+	res, err := service.Issue(ctx, tokenproto.IssueRequest{AccountID: accountID, Password: "secret"})
+	require.NoError(t, err)
+	assert.Equal(t, clock.now.Add(time.Hour).Unix(), res.Data.ExpiresAt.Unix())
+
+	principal, err := service.Verify(ctx, res.Data.Token)
+	require.NoError(t, err)
+	assert.Equal(t, uuid.UUID(accountID), principal.AccountID)
+	assert.Equal(t, session.AuthMethodToken, principal.AuthMethod)
+}
+
+func TestService_Verify(t *testing.T) {
+	ctx := context.Background()
+	service, clock := newService(t, "key")
+	other, _ := newService(t, "other key")
+
+	issue := func(service *tokenservice.Service) string {
+		res, err := service.Issue(ctx, tokenproto.IssueRequest{AccountID: accproto.AccountID(uuid.New()), Password: "secret"})
+		require.NoError(t, err)
+		return res.Data.Token
+	}
+	token := issue(service)
+	value, signature, _ := strings.Cut(token, ".")
+
+	tests := []struct {
+		name    string
+		token   string
+		after   time.Duration
+		wantErr error
+	}{
+		{name: "valid token", token: token},
+		{name: "expired token", token: token, after: time.Hour, wantErr: derror.ErrUnauthenticated},
+		{name: "token of another secret", token: issue(other), wantErr: derror.ErrUnauthenticated},
+		{name: "tampered claims", token: value + "x." + signature, wantErr: derror.ErrUnauthenticated},
+		{name: "not a token", token: "token", wantErr: derror.ErrUnauthenticated},
+	}
+
+	now := clock.now
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock.now = now.Add(tt.after)
+
+			// Execute.
+			_, err := service.Verify(ctx, tt.token)
+
+			// Assert.
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
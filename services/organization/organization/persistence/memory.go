@@ -0,0 +1,98 @@
+package persistence
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/google/uuid"
+	dbproto "github.com/kianooshaz/skeleton/foundation/database/proto"
+	"github.com/kianooshaz/skeleton/foundation/derror"
+	"github.com/kianooshaz/skeleton/foundation/order"
+	"github.com/kianooshaz/skeleton/foundation/pagination"
+	orgproto "github.com/kianooshaz/skeleton/services/organization/organization/proto"
+)
+
+// OrganizationMemoryStorage keeps organizations in memory. It behaves like
+// OrganizationStorage and is meant for tests.
+type OrganizationMemoryStorage struct {
+	mu            sync.RWMutex
+	organizations map[orgproto.OrganizationID]orgproto.Organization
+}
+
+// NewOrganizationMemoryStorage creates an empty OrganizationMemoryStorage.
+func NewOrganizationMemoryStorage() *OrganizationMemoryStorage {
+	return &OrganizationMemoryStorage{
+		organizations: make(map[orgproto.OrganizationID]orgproto.Organization),
+	}
+}
+
+func (ms *OrganizationMemoryStorage) Create(_ context.Context, organization orgproto.Organization) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	if _, ok := ms.organizations[organization.ID]; ok {
+		return dbproto.ErrDuplicateKey
+	}
+
+	ms.organizations[organization.ID] = organization
+
+	return nil
+}
+
+func (ms *OrganizationMemoryStorage) Get(_ context.Context, id orgproto.OrganizationID) (orgproto.Organization, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	organization, ok := ms.organizations[id]
+	if !ok {
+		return orgproto.Organization{}, derror.ErrOrganizationNotFound
+	}
+
+	return organization, nil
+}
+
+func (ms *OrganizationMemoryStorage) List(_ context.Context, page pagination.Page,
+	sort order.Spec) ([]orgproto.Organization, pagination.Cursors, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	columns, err := orderWhitelist.Resolve(sort)
+	if err != nil {
+		return nil, pagination.Cursors{}, err
+	}
+
+	keyset := pagination.MemoryKeyset[orgproto.Organization]{
+		Compare: compareOrganizations,
+		Key:     cursorKey(columns),
+		ID:      cursorID,
+		Lookup: func(id string) (orgproto.Organization, bool) {
+			parsed, err := uuid.Parse(id)
+			if err != nil {
+				return orgproto.Organization{}, false
+			}
+
+			organization, ok := ms.organizations[orgproto.OrganizationID(parsed)]
+			return organization, ok
+		},
+	}
+
+	return keyset.Page(slices.Collect(maps.Values(ms.organizations)), page, columns...)
+}
+
+func (ms *OrganizationMemoryStorage) Count(_ context.Context) (int, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	return len(ms.organizations), nil
+}
+
+func compareOrganizations(a, b orgproto.Organization, column string) int {
+	switch column {
+	case "created_at":
+		return a.CreatedAt.Compare(b.CreatedAt)
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,126 @@
+// Package persistencetest holds the contract every organization storage must meet, so
+// the in-memory storage used by tests stays faithful to the PostgreSQL one.
+package persistencetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kianooshaz/skeleton/foundation/derror"
+	"github.com/kianooshaz/skeleton/foundation/order"
+	"github.com/kianooshaz/skeleton/foundation/pagination"
+	orgproto "github.com/kianooshaz/skeleton/services/organization/organization/proto"
+)
+
+// Storage is the organization storage under contract.
+type Storage interface {
+	Create(ctx context.Context, organization orgproto.Organization) error
+	Get(ctx context.Context, id orgproto.OrganizationID) (orgproto.Organization, error)
+	List(ctx context.Context, page pagination.Page, sort order.Spec) ([]orgproto.Organization, pagination.Cursors, error)
+	Count(ctx context.Context) (int, error)
+}
+
+// Setup returns an empty storage and the context to call it with.
+type Setup func(t *testing.T) (Storage, context.Context)
+
+// Run runs the contract tests against the storages returned by setup.
+func Run(t *testing.T, setup Setup) {
+	t.Run("create and get", func(t *testing.T) {
+		storage, ctx := setup(t)
+
+		organization := newOrganization(t, time.Now())
+		require.NoError(t, storage.Create(ctx, organization))
+		require.Error(t, storage.Create(ctx, organization), "duplicate ID")
+
+		got, err := storage.Get(ctx, organization.ID)
+		require.NoError(t, err)
+		assert.Equal(t, organization.ID, got.ID)
+		assert.WithinDuration(t, organization.CreatedAt, got.CreatedAt, time.Millisecond)
+	})
+
+	t.Run("get missing", func(t *testing.T) {
+		storage, ctx := setup(t)
+
+		_, err := storage.Get(ctx, orgproto.OrganizationID(uuid.New()))
+		require.ErrorIs(t, err, derror.ErrOrganizationNotFound)
+	})
+
+	t.Run("list and count", func(t *testing.T) {
+		storage, ctx := setup(t)
+
+		base := time.Now().Add(-time.Hour)
+		ids := make([]orgproto.OrganizationID, 0, 5)
+		for i := range 5 {
+			organization := newOrganization(t, base.Add(time.Duration(i)*time.Minute))
+			require.NoError(t, storage.Create(ctx, organization))
+			ids = append(ids, organization.ID)
+		}
+
+		count, err := storage.Count(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 5, count)
+
+		first, cursors, err := storage.List(ctx, pagination.Page{PageRows: 2}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, ids[:2], organizationIDs(first))
+		assert.Empty(t, cursors.Prev)
+
+		second, cursors, err := storage.List(ctx, pagination.Page{PageRows: 2, Cursor: cursors.Next}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, ids[2:4], organizationIDs(second))
+
+		last, cursors, err := storage.List(ctx, pagination.Page{PageRows: 2, Cursor: cursors.Next}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, ids[4:], organizationIDs(last))
+		assert.Empty(t, cursors.Next)
+
+		back, _, err := storage.List(ctx, pagination.Page{PageRows: 2, Cursor: cursors.Prev}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, ids[2:4], organizationIDs(back))
+
+		newest, _, err := storage.List(ctx, pagination.Page{PageRows: 2, PageNumber: 1},
+			order.Spec{{Field: "created_at", Direction: order.DESC}})
+		require.NoError(t, err)
+		assert.Equal(t, []orgproto.OrganizationID{ids[4], ids[3]}, organizationIDs(newest))
+
+		numbered, _, err := storage.List(ctx, pagination.Page{PageRows: 2, PageNumber: 3}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, ids[4:], organizationIDs(numbered))
+	})
+
+	t.Run("list rejects unknown order and cursor", func(t *testing.T) {
+		storage, ctx := setup(t)
+
+		_, _, err := storage.List(ctx, pagination.Page{}, order.Spec{{Field: "password"}})
+		require.ErrorIs(t, err, derror.ErrUnknownOrder)
+
+		_, _, err = storage.List(ctx, pagination.Page{Cursor: "garbage"}, nil)
+		require.ErrorIs(t, err, derror.ErrInvalidCursor)
+	})
+}
+
+func newOrganization(t *testing.T, createdAt time.Time) orgproto.Organization {
+	t.Helper()
+
+	id, err := uuid.NewV7()
+	require.NoError(t, err)
+
+	return orgproto.Organization{
+		ID:        orgproto.OrganizationID(id),
+		CreatedAt: createdAt.UTC().Truncate(time.Microsecond),
+	}
+}
+
+func organizationIDs(organizations []orgproto.Organization) []orgproto.OrganizationID {
+	ids := make([]orgproto.OrganizationID, 0, len(organizations))
+	for _, organization := range organizations {
+		ids = append(ids, organization.ID)
+	}
+
+	return ids
+}
@@ -1,2 +1,2 @@
 SELECT COUNT(*)
-FROM `organizations`
\ No newline at end of file
+FROM organizations
\ No newline at end of file
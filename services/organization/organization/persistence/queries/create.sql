@@ -1,2 +1,2 @@
-INSERT INTO `organizations` (`id`, `created_at`)
+INSERT INTO organizations (id, created_at)
 VALUES ($1, $2)
\ No newline at end of file
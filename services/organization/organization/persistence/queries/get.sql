@@ -1,4 +1,4 @@
-SELECT `id`,
-    `created_at`
-FROM `organizations`
-WHERE `id` = $1
\ No newline at end of file
+SELECT id,
+    created_at
+FROM organizations
+WHERE id = $1
\ No newline at end of file
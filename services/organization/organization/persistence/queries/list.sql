@@ -1,3 +1,3 @@
-SELECT `id`,
-    `created_at`
-FROM `organizations`
\ No newline at end of file
+SELECT id,
+    created_at
+FROM organizations
\ No newline at end of file
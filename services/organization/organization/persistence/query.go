@@ -18,8 +18,6 @@ type OrganizationStorage struct {
 	Conn dbproto.QueryExecutor
 }
 
-const defaultPageSize = 20
-
 //go:embed queries/create.sql
 var createQuery string
 
@@ -58,14 +56,29 @@ func (os *OrganizationStorage) Get(ctx context.Context, id orgproto.Organization
 }
 
 func (os *OrganizationStorage) List(ctx context.Context, page pagination.Page,
-	orderBy order.OrderBy) ([]orgproto.Organization, error) {
+	sort order.Spec) ([]orgproto.Organization, pagination.Cursors, error) {
 	conn := session.GetDBConnection(ctx, os.Conn)
 
-	query := listQuery + page.String(pagination.SQLStringer(defaultPageSize)) + orderBy.String(oderStringer)
+	columns, err := orderWhitelist.Resolve(sort)
+	if err != nil {
+		return nil, pagination.Cursors{}, err
+	}
 
-	rows, err := conn.QueryContext(ctx, query)
+	keyset, err := pagination.NewSQLKeyset(page, "id", columns...)
 	if err != nil {
-		return nil, err
+		return nil, pagination.Cursors{}, err
+	}
+
+	query := listQuery
+	where, args := keyset.Where(1)
+	if where != "" {
+		query += " WHERE " + where
+	}
+	query += keyset.OrderBy() + keyset.Limit()
+
+	rows, err := conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, pagination.Cursors{}, err
 	}
 	defer rows.Close()
 
@@ -74,16 +87,24 @@ func (os *OrganizationStorage) List(ctx context.Context, page pagination.Page,
 		var organization orgproto.Organization
 		err := rows.Scan(&organization.ID, &organization.CreatedAt)
 		if err != nil {
-			return nil, err
+			return nil, pagination.Cursors{}, err
 		}
 		organizations = append(organizations, organization)
 	}
 
-	return organizations, nil
+	if err := rows.Err(); err != nil {
+		return nil, pagination.Cursors{}, err
+	}
+
+	organizations, cursors := pagination.Window(keyset, organizations, cursorKey(columns), cursorID)
+
+	return organizations, cursors, nil
 }
 
 func (os *OrganizationStorage) Count(ctx context.Context) (int, error) {
-	row := os.Conn.QueryRowContext(ctx, countQuery)
+	conn := session.GetDBConnection(ctx, os.Conn)
+
+	row := conn.QueryRowContext(ctx, countQuery)
 	var count int
 	err := row.Scan(&count)
 	return count, err
@@ -0,0 +1,24 @@
+package persistence_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kianooshaz/skeleton/foundation/database/postgres/postgrestest"
+	"github.com/kianooshaz/skeleton/services/organization/organization/persistence"
+	"github.com/kianooshaz/skeleton/services/organization/organization/persistence/persistencetest"
+)
+
+func TestOrganizationMemoryStorage(t *testing.T) {
+	persistencetest.Run(t, func(t *testing.T) (persistencetest.Storage, context.Context) {
+		return persistence.NewOrganizationMemoryStorage(), t.Context()
+	})
+}
+
+func TestOrganizationStorage(t *testing.T) {
+	db := postgrestest.Open(t, "../schema.sql")
+
+	persistencetest.Run(t, func(t *testing.T) (persistencetest.Storage, context.Context) {
+		return &persistence.OrganizationStorage{Conn: db}, postgrestest.Context(t, db, "organizations")
+	})
+}
@@ -24,7 +24,7 @@ type CreateResponse struct {
 }
 
 type GetRequest struct {
-	ID OrganizationID `json:"query"`
+	ID OrganizationID `param:"id" json:"-"`
 }
 
 type GetResponse struct {
@@ -33,7 +33,7 @@ type GetResponse struct {
 
 type ListRequest struct {
 	pagination.Page
-	order.OrderBy
+	Sort order.Spec `query:"sort"`
 }
 
 type ListResponse pagination.Response[Organization]
@@ -1,9 +1,11 @@
 package orgproto
 
-import "github.com/google/uuid"
+import "github.com/kianooshaz/skeleton/foundation/id"
 
-type OrganizationID uuid.UUID
+// OrganizationKind is the kind of organization IDs.
+type OrganizationKind struct{}
 
-func (o OrganizationID) String() string {
-	return uuid.UUID(o).String()
-}
+func (OrganizationKind) Prefix() string { return "org" }
+
+// OrganizationID identifies an organization.
+type OrganizationID = id.ID[OrganizationKind]
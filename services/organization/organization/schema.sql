@@ -0,0 +1,10 @@
+-- Organization Service Database Schema
+-- This file contains the SQL schema for the organization service tables.
+-- Run this manually in your PostgreSQL database to create the required tables.
+-- Create organizations table
+CREATE TABLE IF NOT EXISTS organizations (
+    id UUID PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+-- Create indexes for better performance
+CREATE INDEX IF NOT EXISTS idx_organizations_created_at ON organizations (created_at);
@@ -6,14 +6,14 @@ import (
 	"log/slog"
 	"time"
 
-	"github.com/google/uuid"
 	"github.com/kianooshaz/skeleton/foundation/derror"
+	"github.com/kianooshaz/skeleton/foundation/id"
 	"github.com/kianooshaz/skeleton/foundation/pagination"
 	orgproto "github.com/kianooshaz/skeleton/services/organization/organization/proto"
 )
 
 func (s *Service) Create(ctx context.Context) (orgproto.CreateResponse, error) {
-	id, err := uuid.NewV7()
+	organizationID, err := id.New[orgproto.OrganizationKind]()
 	if err != nil {
 		s.logger.ErrorContext(
 			ctx,
@@ -25,7 +25,7 @@ func (s *Service) Create(ctx context.Context) (orgproto.CreateResponse, error) {
 	}
 
 	organization := orgproto.Organization{
-		ID:        orgproto.OrganizationID(id),
+		ID:        organizationID,
 		CreatedAt: time.Now(),
 	}
 
@@ -66,8 +66,13 @@ func (s *Service) Get(ctx context.Context, req orgproto.GetRequest) (orgproto.Ge
 }
 
 func (s *Service) List(ctx context.Context, req orgproto.ListRequest) (orgproto.ListResponse, error) {
-	organizations, err := s.persister.List(ctx, req.Page, req.OrderBy)
+	organizations, cursors, err := s.persister.List(ctx, req.Page, req.Sort)
 	if err != nil {
+		if errors.Is(err, derror.ErrInvalidCursor) || errors.Is(err, derror.ErrUnknownOrder) ||
+			errors.Is(err, derror.ErrUnknownOrderDirection) {
+			return orgproto.ListResponse{}, err
+		}
+
 		s.logger.ErrorContext(
 			ctx,
 			"Error encountered while listing organizations from storage",
@@ -78,17 +83,20 @@ func (s *Service) List(ctx context.Context, req orgproto.ListRequest) (orgproto.
 		return orgproto.ListResponse{}, derror.ErrInternalSystem
 	}
 
-	totalCount, err := s.persister.Count(ctx)
-	if err != nil {
-		s.logger.ErrorContext(
-			ctx,
-			"Error encountered while counting organizations from storage",
-			slog.String("error", err.Error()),
-			slog.Any("req", req),
-		)
-
-		return orgproto.ListResponse{}, derror.ErrInternalSystem
+	var totalCount int
+	if req.NeedsTotal() {
+		totalCount, err = s.persister.Count(ctx)
+		if err != nil {
+			s.logger.ErrorContext(
+				ctx,
+				"Error encountered while counting organizations from storage",
+				slog.String("error", err.Error()),
+				slog.Any("req", req),
+			)
+
+			return orgproto.ListResponse{}, derror.ErrInternalSystem
+		}
 	}
 
-	return orgproto.ListResponse(pagination.NewResponse(req.Page, totalCount, organizations)), nil
+	return orgproto.ListResponse(pagination.NewCursorResponse(req.Page, totalCount, organizations, cursors)), nil
 }
@@ -16,7 +16,9 @@ type (
 	persister interface {
 		Create(ctx context.Context, organization orgproto.Organization) error
 		Get(ctx context.Context, id orgproto.OrganizationID) (orgproto.Organization, error)
-		List(ctx context.Context, page pagination.Page, orderBy order.OrderBy) ([]orgproto.Organization, error)
+		List(
+			ctx context.Context, page pagination.Page, sort order.Spec,
+		) ([]orgproto.Organization, pagination.Cursors, error)
 		Count(ctx context.Context) (int, error)
 	}
 
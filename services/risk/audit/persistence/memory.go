@@ -0,0 +1,203 @@
+package persistence
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	dbproto "github.com/kianooshaz/skeleton/foundation/database/proto"
+	"github.com/kianooshaz/skeleton/foundation/derror"
+	"github.com/kianooshaz/skeleton/foundation/order"
+	"github.com/kianooshaz/skeleton/foundation/pagination"
+	auditproto "github.com/kianooshaz/skeleton/services/risk/audit/proto"
+)
+
+// AuditMemoryStorage keeps audit records in memory. It behaves like AuditStorage
+// and is meant for tests.
+type AuditMemoryStorage struct {
+	mu      sync.RWMutex
+	records map[auditproto.RecordID]auditproto.Record
+	// written holds when each record was written, as the written_at column does.
+	written map[auditproto.RecordID]time.Time
+}
+
+// NewAuditMemoryStorage creates an empty AuditMemoryStorage.
+func NewAuditMemoryStorage() *AuditMemoryStorage {
+	return &AuditMemoryStorage{
+		records: make(map[auditproto.RecordID]auditproto.Record),
+		written: make(map[auditproto.RecordID]time.Time),
+	}
+}
+
+func (ms *AuditMemoryStorage) Create(_ context.Context, record auditproto.Record) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	if _, ok := ms.records[record.ID]; ok {
+		return dbproto.ErrDuplicateKey
+	}
+
+	ms.records[record.ID] = record
+	ms.written[record.ID] = time.Now()
+
+	return nil
+}
+
+// CreateBatch stores all records, or none of them if any ID is already taken.
+// Like AuditStorage.CreateBatch, it holds at most MaxBatchSize records.
+func (ms *AuditMemoryStorage) CreateBatch(_ context.Context, records []auditproto.Record) error {
+	if len(records) > MaxBatchSize {
+		return fmt.Errorf("batch of %d records exceeds the maximum of %d", len(records), MaxBatchSize)
+	}
+
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	seen := make(map[auditproto.RecordID]struct{}, len(records))
+	for _, record := range records {
+		if _, ok := ms.records[record.ID]; ok {
+			return dbproto.ErrDuplicateKey
+		}
+		if _, ok := seen[record.ID]; ok {
+			return dbproto.ErrDuplicateKey
+		}
+		seen[record.ID] = struct{}{}
+	}
+
+	now := time.Now()
+	for _, record := range records {
+		ms.records[record.ID] = record
+		ms.written[record.ID] = now
+	}
+
+	return nil
+}
+
+func (ms *AuditMemoryStorage) Get(_ context.Context, id auditproto.RecordID) (auditproto.Record, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	record, ok := ms.records[id]
+	if !ok {
+		// Same error as AuditStorage.
+		return auditproto.Record{}, derror.ErrUserNotFound
+	}
+
+	return record, nil
+}
+
+func (ms *AuditMemoryStorage) List(
+	_ context.Context, page pagination.Page, sort order.Spec,
+) ([]auditproto.Record, pagination.Cursors, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	columns, err := orderWhitelist.Resolve(sort)
+	if err != nil {
+		return nil, pagination.Cursors{}, err
+	}
+
+	keyset := pagination.MemoryKeyset[auditproto.Record]{
+		Compare: compareRecords,
+		Key:     cursorKey(columns),
+		ID:      cursorID,
+		Lookup: func(id string) (auditproto.Record, bool) {
+			parsed, err := uuid.Parse(id)
+			if err != nil {
+				return auditproto.Record{}, false
+			}
+
+			record, ok := ms.records[auditproto.RecordID(parsed)]
+			return record, ok
+		},
+	}
+
+	return keyset.Page(slices.Collect(maps.Values(ms.records)), page, columns...)
+}
+
+func (ms *AuditMemoryStorage) Export(_ context.Context, sort order.Spec, yield func(auditproto.Record) error) error {
+	columns, err := orderWhitelist.Resolve(sort)
+	if err != nil {
+		return err
+	}
+
+	ms.mu.RLock()
+	records := slices.Collect(maps.Values(ms.records))
+	ms.mu.RUnlock()
+
+	keyset := pagination.MemoryKeyset[auditproto.Record]{Compare: compareRecords, ID: cursorID}
+	for _, record := range keyset.Sort(records, columns...) {
+		if err := yield(record); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (ms *AuditMemoryStorage) Since(
+	_ context.Context, after auditproto.RecordID, window time.Duration, yield func(auditproto.Record) error,
+) error {
+	type written struct {
+		record auditproto.Record
+		at     time.Time
+	}
+
+	ms.mu.RLock()
+	since := time.Time{}
+	if !after.IsZero() {
+		at, ok := ms.written[after]
+		if !ok {
+			ms.mu.RUnlock()
+			return nil
+		}
+		since = at.Add(-window)
+	}
+
+	records := make([]written, 0)
+	for id, record := range ms.records {
+		if at := ms.written[id]; id != after && !at.Before(since) {
+			records = append(records, written{record: record, at: at})
+		}
+	}
+	ms.mu.RUnlock()
+
+	slices.SortFunc(records, func(a, b written) int {
+		return cmp.Or(a.at.Compare(b.at), a.record.ID.Compare(b.record.ID))
+	})
+
+	for _, w := range records {
+		if err := yield(w.record); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (ms *AuditMemoryStorage) Count(_ context.Context) (int, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	return len(ms.records), nil
+}
+
+func compareRecords(a, b auditproto.Record, column string) int {
+	switch column {
+	case "created_at":
+		return a.CreatedAt.Compare(b.CreatedAt)
+	case "action":
+		return cmp.Compare(a.Action, b.Action)
+	case "user_id":
+		return cmp.Compare(a.UserID, b.UserID)
+	case "resource_type":
+		return cmp.Compare(a.ResourceType, b.ResourceType)
+	default:
+		return 0
+	}
+}
@@ -1,33 +1,44 @@
 package persistence
 
 import (
+	"strconv"
+
 	"github.com/kianooshaz/skeleton/foundation/order"
+	"github.com/kianooshaz/skeleton/foundation/pagination"
+	auditproto "github.com/kianooshaz/skeleton/services/risk/audit/proto"
 )
 
-var oderStringer order.StringerFunc = func(orderBy order.OrderBy) string {
-	var field string
-	switch orderBy.Field {
-	case "created_at":
-		field = "created_at"
-	case "action":
-		field = "action"
-	case "user_id":
-		field = "user_id"
-	case "resource_type":
-		field = "resource_type"
-	default:
-		field = "created_at"
-	}
+var orderWhitelist = order.Whitelist{
+	Columns: map[string]string{
+		"created_at":    "created_at",
+		"action":        "action",
+		"user_id":       "user_id",
+		"resource_type": "resource_type",
+	},
+	Default: order.Spec{{Field: "created_at", Direction: order.DESC}},
+}
 
-	var direction string
-	switch orderBy.Direction {
-	case order.ASC:
-		direction = "ASC"
-	case order.DESC:
-		direction = "DESC"
-	default:
-		direction = "DESC"
+// cursorKey returns a function extracting the ORDER BY key of a record for the given columns.
+func cursorKey(columns []order.Column) func(auditproto.Record) []string {
+	return func(record auditproto.Record) []string {
+		keys := make([]string, 0, len(columns))
+		for _, column := range columns {
+			switch column.Name {
+			case "created_at":
+				keys = append(keys, pagination.TimeKey(record.CreatedAt))
+			case "action":
+				keys = append(keys, string(record.Action))
+			case "user_id":
+				keys = append(keys, strconv.Itoa(record.UserID))
+			case "resource_type":
+				keys = append(keys, record.ResourceType)
+			}
+		}
+
+		return keys
 	}
+}
 
-	return " ORDER BY " + field + " " + direction
+func cursorID(record auditproto.Record) string {
+	return record.ID.String()
 }
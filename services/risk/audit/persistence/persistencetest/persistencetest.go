@@ -0,0 +1,269 @@
+// Package persistencetest holds the contract every audit storage must meet, so
+// the in-memory storage used by tests stays faithful to the PostgreSQL one.
+package persistencetest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kianooshaz/skeleton/foundation/derror"
+	"github.com/kianooshaz/skeleton/foundation/order"
+	"github.com/kianooshaz/skeleton/foundation/pagination"
+	orgproto "github.com/kianooshaz/skeleton/services/organization/organization/proto"
+	"github.com/kianooshaz/skeleton/services/risk/audit/persistence"
+	auditproto "github.com/kianooshaz/skeleton/services/risk/audit/proto"
+)
+
+// Storage is the audit storage under contract.
+type Storage interface {
+	Create(ctx context.Context, record auditproto.Record) error
+	CreateBatch(ctx context.Context, records []auditproto.Record) error
+	Get(ctx context.Context, id auditproto.RecordID) (auditproto.Record, error)
+	List(ctx context.Context, page pagination.Page, sort order.Spec) ([]auditproto.Record, pagination.Cursors, error)
+	Export(ctx context.Context, sort order.Spec, yield func(auditproto.Record) error) error
+	Since(ctx context.Context, after auditproto.RecordID, window time.Duration, yield func(auditproto.Record) error) error
+	Count(ctx context.Context) (int, error)
+}
+
+// Setup returns an empty storage and the context to call it with.
+type Setup func(t *testing.T) (Storage, context.Context)
+
+// Run runs the contract tests against the storages returned by setup.
+func Run(t *testing.T, setup Setup) {
+	t.Run("create and get", func(t *testing.T) {
+		storage, ctx := setup(t)
+
+		record := newRecord(t, auditproto.Insert, time.Now())
+		record.Data = json.RawMessage(`{"name":"skeleton"}`)
+		record.OrganizationID = orgproto.OrganizationID(uuid.New())
+		require.NoError(t, storage.Create(ctx, record))
+
+		got, err := storage.Get(ctx, record.ID)
+		require.NoError(t, err)
+		assert.Equal(t, record.ID, got.ID)
+		assert.Equal(t, record.RequestID, got.RequestID)
+		assert.Equal(t, record.Action, got.Action)
+		assert.Equal(t, record.ResourceType, got.ResourceType)
+		assert.Equal(t, record.UserID, got.UserID)
+		assert.Equal(t, record.OrganizationID, got.OrganizationID)
+		assert.JSONEq(t, string(record.Data), string(got.Data))
+		assert.WithinDuration(t, record.CreatedAt, got.CreatedAt, time.Millisecond)
+	})
+
+	t.Run("get missing", func(t *testing.T) {
+		storage, ctx := setup(t)
+
+		_, err := storage.Get(ctx, auditproto.RecordID(uuid.New()))
+		require.Error(t, err)
+	})
+
+	t.Run("create batch", func(t *testing.T) {
+		storage, ctx := setup(t)
+
+		require.NoError(t, storage.CreateBatch(ctx, nil))
+
+		records := []auditproto.Record{
+			newRecord(t, auditproto.Insert, time.Now()),
+			newRecord(t, auditproto.Update, time.Now()),
+			newRecord(t, auditproto.Delete, time.Now()),
+		}
+		records[1].Data = json.RawMessage(`{"name":"skeleton"}`)
+		require.NoError(t, storage.CreateBatch(ctx, records))
+
+		for _, record := range records {
+			got, err := storage.Get(ctx, record.ID)
+			require.NoError(t, err)
+			assert.Equal(t, record.Action, got.Action)
+		}
+
+		count, err := storage.Count(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, len(records), count)
+	})
+
+	t.Run("create batch size limit", func(t *testing.T) {
+		storage, ctx := setup(t)
+
+		records := make([]auditproto.Record, 0, persistence.MaxBatchSize+1)
+		for range persistence.MaxBatchSize + 1 {
+			records = append(records, newRecord(t, auditproto.Insert, time.Now()))
+		}
+		require.Error(t, storage.CreateBatch(ctx, records), "too many records")
+		require.NoError(t, storage.CreateBatch(ctx, records[:persistence.MaxBatchSize]))
+
+		count, err := storage.Count(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, persistence.MaxBatchSize, count)
+	})
+
+	t.Run("create batch is all or nothing", func(t *testing.T) {
+		storage, ctx := setup(t)
+
+		existing := newRecord(t, auditproto.Insert, time.Now())
+		require.NoError(t, storage.Create(ctx, existing))
+
+		fresh := newRecord(t, auditproto.Update, time.Now())
+		require.Error(t, storage.CreateBatch(ctx, []auditproto.Record{fresh, existing}))
+
+		_, err := storage.Get(ctx, fresh.ID)
+		require.Error(t, err)
+	})
+
+	t.Run("list and count", func(t *testing.T) {
+		storage, ctx := setup(t)
+
+		base := time.Now().Add(-time.Hour)
+		actions := []auditproto.Action{auditproto.Update, auditproto.Delete, auditproto.Insert}
+		ids := make([]auditproto.RecordID, 0, len(actions))
+		for i, action := range actions {
+			record := newRecord(t, action, base.Add(time.Duration(i)*time.Minute))
+			require.NoError(t, storage.Create(ctx, record))
+			ids = append(ids, record.ID)
+		}
+
+		count, err := storage.Count(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, len(actions), count)
+
+		// Newest first by default.
+		first, cursors, err := storage.List(ctx, pagination.Page{PageRows: 2}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []auditproto.RecordID{ids[2], ids[1]}, recordIDs(first))
+
+		rest, cursors, err := storage.List(ctx, pagination.Page{PageRows: 2, Cursor: cursors.Next}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []auditproto.RecordID{ids[0]}, recordIDs(rest))
+		assert.Empty(t, cursors.Next)
+
+		back, _, err := storage.List(ctx, pagination.Page{PageRows: 2, Cursor: cursors.Prev}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, recordIDs(first), recordIDs(back))
+
+		byAction, _, err := storage.List(ctx, pagination.Page{PageRows: 3},
+			order.Spec{{Field: "action", Direction: order.ASC}})
+		require.NoError(t, err)
+		assert.Equal(t, []auditproto.RecordID{ids[1], ids[2], ids[0]}, recordIDs(byAction))
+	})
+
+	t.Run("export", func(t *testing.T) {
+		storage, ctx := setup(t)
+
+		base := time.Now().Add(-time.Hour)
+		actions := []auditproto.Action{auditproto.Update, auditproto.Delete, auditproto.Insert}
+		ids := make([]auditproto.RecordID, 0, len(actions))
+		for i, action := range actions {
+			record := newRecord(t, action, base.Add(time.Duration(i)*time.Minute))
+			require.NoError(t, storage.Create(ctx, record))
+			ids = append(ids, record.ID)
+		}
+
+		var exported []auditproto.Record
+		collect := func(record auditproto.Record) error {
+			exported = append(exported, record)
+			return nil
+		}
+
+		require.NoError(t, storage.Export(ctx, nil, collect))
+		assert.Equal(t, []auditproto.RecordID{ids[2], ids[1], ids[0]}, recordIDs(exported), "newest first, like List")
+
+		exported = nil
+		require.NoError(t, storage.Export(ctx, order.Spec{{Field: "action", Direction: order.ASC}}, collect))
+		assert.Equal(t, []auditproto.RecordID{ids[1], ids[2], ids[0]}, recordIDs(exported))
+
+		stop := errors.New("stop")
+		calls := 0
+		err := storage.Export(ctx, nil, func(auditproto.Record) error {
+			calls++
+			return stop
+		})
+		require.ErrorIs(t, err, stop)
+		assert.Equal(t, 1, calls)
+
+		require.ErrorIs(t, storage.Export(ctx, order.Spec{{Field: "data"}}, collect), derror.ErrUnknownOrder)
+	})
+
+	t.Run("since", func(t *testing.T) {
+		storage, ctx := setup(t)
+
+		records := make([]auditproto.Record, 0, 3)
+		for range 3 {
+			record := newRecord(t, auditproto.Insert, time.Now())
+			records = append(records, record)
+		}
+		// Written out of ID order, as concurrent writers may. Writes are kept
+		// apart, as they are timed by the clock.
+		for _, i := range []int{2, 0, 1} {
+			require.NoError(t, storage.Create(ctx, records[i]))
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		var since []auditproto.Record
+		collect := func(record auditproto.Record) error {
+			since = append(since, record)
+			return nil
+		}
+
+		require.NoError(t, storage.Since(ctx, records[0].ID, 0, collect))
+		assert.Equal(t, recordIDs(records[1:2]), recordIDs(since), "written after, whatever its ID")
+
+		since = nil
+		require.NoError(t, storage.Since(ctx, records[0].ID, time.Minute, collect))
+		assert.Equal(t, recordIDs([]auditproto.Record{records[2], records[1]}), recordIDs(since),
+			"written within the window, in written order")
+
+		since = nil
+		require.NoError(t, storage.Since(ctx, records[1].ID, 0, collect))
+		assert.Empty(t, since)
+
+		since = nil
+		require.NoError(t, storage.Since(ctx, auditproto.RecordID{}, 0, collect))
+		assert.Equal(t, recordIDs([]auditproto.Record{records[2], records[0], records[1]}), recordIDs(since),
+			"the zero ID yields every record")
+
+		since = nil
+		unknown := newRecord(t, auditproto.Insert, time.Now())
+		require.NoError(t, storage.Since(ctx, unknown.ID, time.Minute, collect))
+		assert.Empty(t, since)
+	})
+
+	t.Run("list rejects unknown order", func(t *testing.T) {
+		storage, ctx := setup(t)
+
+		_, _, err := storage.List(ctx, pagination.Page{}, order.Spec{{Field: "data"}})
+		require.ErrorIs(t, err, derror.ErrUnknownOrder)
+	})
+}
+
+func newRecord(t *testing.T, action auditproto.Action, createdAt time.Time) auditproto.Record {
+	t.Helper()
+
+	id, err := uuid.NewV7()
+	require.NoError(t, err)
+
+	return auditproto.Record{
+		ID:           auditproto.RecordID(id),
+		RequestID:    uuid.NewString(),
+		Action:       action,
+		CreatedAt:    createdAt.UTC().Truncate(time.Microsecond),
+		OriginIP:     "127.0.0.1",
+		ResourceID:   1,
+		ResourceType: "user",
+		UserID:       1,
+	}
+}
+
+func recordIDs(records []auditproto.Record) []auditproto.RecordID {
+	ids := make([]auditproto.RecordID, 0, len(records))
+	for _, record := range records {
+		ids = append(ids, record.ID)
+	}
+
+	return ids
+}
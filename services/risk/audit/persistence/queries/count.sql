@@ -1,2 +1,2 @@
 SELECT COUNT(*)
-FROM `audit_records`
\ No newline at end of file
+FROM audit_records
\ No newline at end of file
@@ -1,12 +1,13 @@
-INSERT INTO `audit_records` (
-        `id`,
-        `request_id`,
-        `action`,
-        `created_at`,
-        `data`,
-        `origin_ip`,
-        `resource_id`,
-        `resource_type`,
-        `user_id`
+INSERT INTO audit_records (
+        id,
+        request_id,
+        action,
+        created_at,
+        data,
+        origin_ip,
+        resource_id,
+        resource_type,
+        user_id,
+        organization_id
     )
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
\ No newline at end of file
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
\ No newline at end of file
@@ -0,0 +1,13 @@
+INSERT INTO audit_records (
+        id,
+        request_id,
+        action,
+        created_at,
+        data,
+        origin_ip,
+        resource_id,
+        resource_type,
+        user_id,
+        organization_id
+    )
+VALUES
\ No newline at end of file
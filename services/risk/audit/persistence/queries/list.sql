@@ -1,10 +1,11 @@
-SELECT `id`,
-    `request_id`,
-    `action`,
-    `created_at`,
-    `data`,
-    `origin_ip`,
-    `resource_id`,
-    `resource_type`,
-    `user_id`
-FROM `audit_records`
\ No newline at end of file
+SELECT id,
+    request_id,
+    action,
+    created_at,
+    data,
+    origin_ip,
+    resource_id,
+    resource_type,
+    user_id,
+    organization_id
+FROM audit_records
\ No newline at end of file
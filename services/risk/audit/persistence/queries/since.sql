@@ -0,0 +1,22 @@
+SELECT id,
+    request_id,
+    action,
+    created_at,
+    data,
+    origin_ip,
+    resource_id,
+    resource_type,
+    user_id,
+    organization_id
+FROM audit_records
+WHERE (
+        $1::UUID = '00000000-0000-0000-0000-000000000000'
+        OR written_at >= (
+            SELECT written_at
+            FROM audit_records
+            WHERE id = $1
+        ) - make_interval(secs => $2)
+    )
+    AND id <> $1
+ORDER BY written_at,
+    id
\ No newline at end of file
@@ -5,6 +5,10 @@ import (
 	"database/sql"
 	_ "embed"
 	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	dbproto "github.com/kianooshaz/skeleton/foundation/database/proto"
 	"github.com/kianooshaz/skeleton/foundation/derror"
@@ -18,11 +22,19 @@ type AuditStorage struct {
 	Conn dbproto.QueryExecutor
 }
 
-const defaultPageSize = 20
-
 //go:embed queries/create.sql
 var createQuery string
 
+//go:embed queries/create_batch.sql
+var createBatchQuery string
+
+// recordColumns is the number of columns written per record by createQuery and createBatchQuery.
+const recordColumns = 10
+
+// MaxBatchSize is the most records CreateBatch writes at once, as PostgreSQL
+// accepts at most 65535 parameters per statement.
+const MaxBatchSize = 65535 / recordColumns
+
 //go:embed queries/get.sql
 var getQuery string
 
@@ -32,22 +44,76 @@ var listQuery string
 //go:embed queries/count.sql
 var countQuery string
 
+//go:embed queries/since.sql
+var sinceQuery string
+
 func (as *AuditStorage) Create(ctx context.Context, record auditproto.Record) error {
 	conn := session.GetDBConnection(ctx, as.Conn)
 
-	_, err := conn.ExecContext(ctx, createQuery, record.ID, record.RequestID, record.Action,
-		record.CreatedAt, record.Data, record.OriginIP, record.ResourceID, record.ResourceType, record.UserID)
+	_, err := conn.ExecContext(ctx, createQuery, recordArgs(record)...)
 	return err
 }
 
+// CreateBatch inserts all records with a single multi-row INSERT. Either every
+// record is written or none is. It holds at most MaxBatchSize records.
+func (as *AuditStorage) CreateBatch(ctx context.Context, records []auditproto.Record) error {
+	if len(records) == 0 {
+		return nil
+	}
+	if len(records) > MaxBatchSize {
+		return fmt.Errorf("batch of %d records exceeds the maximum of %d", len(records), MaxBatchSize)
+	}
+
+	conn := session.GetDBConnection(ctx, as.Conn)
+
+	var query strings.Builder
+	query.WriteString(createBatchQuery)
+
+	args := make([]any, 0, len(records)*recordColumns)
+	for i, record := range records {
+		if i > 0 {
+			query.WriteString(",")
+		}
+
+		query.WriteString(" (")
+		for column := range recordColumns {
+			if column > 0 {
+				query.WriteString(", ")
+			}
+			query.WriteString("$" + strconv.Itoa(i*recordColumns+column+1))
+		}
+		query.WriteString(")")
+
+		args = append(args, recordArgs(record)...)
+	}
+
+	_, err := conn.ExecContext(ctx, query.String(), args...)
+	return err
+}
+
+// recordArgs returns the values of record in the column order of the insert queries.
+func recordArgs(record auditproto.Record) []any {
+	// The data column is JSONB; raw bytes would be sent as bytea.
+	data := sql.NullString{String: string(record.Data), Valid: len(record.Data) > 0}
+
+	return []any{record.ID, record.RequestID, record.Action, record.CreatedAt, data,
+		record.OriginIP, record.ResourceID, record.ResourceType, record.UserID, record.OrganizationID}
+}
+
+// recordFields returns the fields of record in the column order of the select
+// queries, to scan a row into.
+func recordFields(record *auditproto.Record) []any {
+	return []any{&record.ID, &record.RequestID, &record.Action, &record.CreatedAt, &record.Data,
+		&record.OriginIP, &record.ResourceID, &record.ResourceType, &record.UserID, &record.OrganizationID}
+}
+
 func (as *AuditStorage) Get(ctx context.Context, id auditproto.RecordID) (auditproto.Record, error) {
 	conn := session.GetDBConnection(ctx, as.Conn)
 
 	row := conn.QueryRowContext(ctx, getQuery, id)
 
 	var record auditproto.Record
-	err := row.Scan(&record.ID, &record.RequestID, &record.Action, &record.CreatedAt,
-		&record.Data, &record.OriginIP, &record.ResourceID, &record.ResourceType, &record.UserID)
+	err := row.Scan(recordFields(&record)...)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return auditproto.Record{}, derror.ErrUserNotFound
@@ -60,34 +126,124 @@ func (as *AuditStorage) Get(ctx context.Context, id auditproto.RecordID) (auditp
 }
 
 func (as *AuditStorage) List(
-	ctx context.Context, page pagination.Page, orderBy order.OrderBy,
-) ([]auditproto.Record, error) {
+	ctx context.Context, page pagination.Page, sort order.Spec,
+) ([]auditproto.Record, pagination.Cursors, error) {
 	conn := session.GetDBConnection(ctx, as.Conn)
 
-	query := listQuery + page.String(pagination.SQLStringer(defaultPageSize)) + orderBy.String(oderStringer)
+	columns, err := orderWhitelist.Resolve(sort)
+	if err != nil {
+		return nil, pagination.Cursors{}, err
+	}
+
+	keyset, err := pagination.NewSQLKeyset(page, "id", columns...)
+	if err != nil {
+		return nil, pagination.Cursors{}, err
+	}
 
-	rows, err := conn.QueryContext(ctx, query)
+	query := listQuery
+	where, args := keyset.Where(1)
+	if where != "" {
+		query += " WHERE " + where
+	}
+	query += keyset.OrderBy() + keyset.Limit()
+
+	rows, err := conn.QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, err
+		return nil, pagination.Cursors{}, err
 	}
 	defer rows.Close()
 
 	var records []auditproto.Record
 	for rows.Next() {
 		var record auditproto.Record
-		err := rows.Scan(&record.ID, &record.RequestID, &record.Action, &record.CreatedAt,
-			&record.Data, &record.OriginIP, &record.ResourceID, &record.ResourceType, &record.UserID)
+		err := rows.Scan(recordFields(&record)...)
 		if err != nil {
-			return nil, err
+			return nil, pagination.Cursors{}, err
 		}
 		records = append(records, record)
 	}
 
-	return records, nil
+	if err := rows.Err(); err != nil {
+		return nil, pagination.Cursors{}, err
+	}
+
+	records, cursors := pagination.Window(keyset, records, cursorKey(columns), cursorID)
+
+	return records, cursors, nil
+}
+
+// Export calls yield with every record, in the order of List, reading them from
+// the database as yield consumes them. It stops at the first error of yield.
+func (as *AuditStorage) Export(ctx context.Context, sort order.Spec, yield func(auditproto.Record) error) error {
+	conn := session.GetDBConnection(ctx, as.Conn)
+
+	columns, err := orderWhitelist.Resolve(sort)
+	if err != nil {
+		return err
+	}
+
+	keyset, err := pagination.NewSQLKeyset(pagination.Page{}, "id", columns...)
+	if err != nil {
+		return err
+	}
+
+	rows, err := conn.QueryContext(ctx, listQuery+keyset.OrderBy())
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var record auditproto.Record
+		err := rows.Scan(recordFields(&record)...)
+		if err != nil {
+			return err
+		}
+
+		if err := yield(record); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// Since calls yield with the records written since the record of the given ID
+// was, less window, in the order they were written; the record itself is left
+// out. The zero ID yields every record and an unknown one none. It stops at
+// the first error of yield.
+//
+// Records are only visible once their batch commits, so a record may appear
+// after one written later was read; window covers that delay.
+func (as *AuditStorage) Since(
+	ctx context.Context, after auditproto.RecordID, window time.Duration, yield func(auditproto.Record) error,
+) error {
+	conn := session.GetDBConnection(ctx, as.Conn)
+
+	rows, err := conn.QueryContext(ctx, sinceQuery, after, window.Seconds())
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var record auditproto.Record
+		if err := rows.Scan(recordFields(&record)...); err != nil {
+			return err
+		}
+
+		if err := yield(record); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
 }
 
 func (as *AuditStorage) Count(ctx context.Context) (int, error) {
-	row := as.Conn.QueryRowContext(ctx, countQuery)
+	conn := session.GetDBConnection(ctx, as.Conn)
+
+	row := conn.QueryRowContext(ctx, countQuery)
 	var count int
 	err := row.Scan(&count)
 	return count, err
@@ -0,0 +1,24 @@
+package persistence_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kianooshaz/skeleton/foundation/database/postgres/postgrestest"
+	"github.com/kianooshaz/skeleton/services/risk/audit/persistence"
+	"github.com/kianooshaz/skeleton/services/risk/audit/persistence/persistencetest"
+)
+
+func TestAuditMemoryStorage(t *testing.T) {
+	persistencetest.Run(t, func(t *testing.T) (persistencetest.Storage, context.Context) {
+		return persistence.NewAuditMemoryStorage(), t.Context()
+	})
+}
+
+func TestAuditStorage(t *testing.T) {
+	db := postgrestest.Open(t, "../schema.sql")
+
+	persistencetest.Run(t, func(t *testing.T) (persistencetest.Storage, context.Context) {
+		return &persistence.AuditStorage{Conn: db}, postgrestest.Context(t, db, "audit_records")
+	})
+}
@@ -2,20 +2,37 @@ package auditproto
 
 import (
 	"context"
+	"slices"
 
 	"github.com/kianooshaz/skeleton/foundation/order"
 	"github.com/kianooshaz/skeleton/foundation/pagination"
+	orgproto "github.com/kianooshaz/skeleton/services/organization/organization/proto"
 )
 
 type AuditService interface {
-	Record(record Record)
+	// Record queues record to be written without waiting for it, dropping it if
+	// the queue is full. Its request ID defaults to the one of ctx.
+	Record(ctx context.Context, record Record)
 	Get(ctx context.Context, req GetRequest) (GetResponse, error)
 	List(ctx context.Context, req ListRequest) (ListResponse, error)
+	// Export calls yield with every record listed by req, paging aside, as
+	// they are read. It stops at the first error of yield and returns it.
+	Export(ctx context.Context, req ListRequest, yield func(Record) error) error
+	// Stream returns the records matching req as they are written, by any
+	// instance, starting with those written after req.LastEventID. The channel
+	// is closed when ctx is done, on shutdown, or when the subscriber falls too
+	// far behind; it may then stream again from the last record it received.
+	//
+	// Records are delivered at least once: a resumed stream replays those
+	// written within a window before req.LastEventID, in the order they were
+	// written, so a record written late by another instance is not skipped.
+	// Subscribers drop the records they already received by ID.
+	Stream(ctx context.Context, req StreamRequest) (<-chan Record, error)
 	Shutdown(ctx context.Context)
 }
 
 type GetRequest struct {
-	ID RecordID `json:"id"`
+	ID RecordID `param:"id" json:"-"`
 }
 
 type GetResponse struct {
@@ -24,9 +41,34 @@ type GetResponse struct {
 
 type ListRequest struct {
 	pagination.Page
-	order.OrderBy
+	Sort order.Spec `query:"sort"`
 }
 
 type ListResponse struct {
 	pagination.Response[Record]
 }
+
+// StreamRequest narrows a stream to the records matching every filter set. A
+// filter of several values matches any of them.
+type StreamRequest struct {
+	Actions        []Action                `query:"action"`
+	ResourceTypes  []string                `query:"resource_type"`
+	OrganizationID orgproto.OrganizationID `query:"organization_id"`
+	// LastEventID resumes a stream after the record of that ID, which must
+	// have been streamed. EventSource clients send the header on their own
+	// when they reconnect.
+	LastEventID RecordID `header:"Last-Event-ID" query:"last_event_id"`
+}
+
+// Matches reports whether record passes the filters of req.
+func (req StreamRequest) Matches(record Record) bool {
+	if len(req.Actions) > 0 && !slices.Contains(req.Actions, record.Action) {
+		return false
+	}
+
+	if len(req.ResourceTypes) > 0 && !slices.Contains(req.ResourceTypes, record.ResourceType) {
+		return false
+	}
+
+	return req.OrganizationID.IsZero() || req.OrganizationID == record.OrganizationID
+}
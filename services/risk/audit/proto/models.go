@@ -3,6 +3,8 @@ package auditproto
 import (
 	"encoding/json"
 	"time"
+
+	orgproto "github.com/kianooshaz/skeleton/services/organization/organization/proto"
 )
 
 type Record struct {
@@ -15,6 +17,8 @@ type Record struct {
 	ResourceID   int             `json:"resource_id"`
 	ResourceType string          `json:"resource_type"`
 	UserID       int             `json:"user_id"`
+	// OrganizationID is the organization the record was made in, if any.
+	OrganizationID orgproto.OrganizationID `json:"organization_id,omitzero"`
 }
 
 type Action string
@@ -25,4 +29,6 @@ const (
 	Delete Action = "delete"
 	List   Action = "list"
 	Get    Action = "get"
+	Lock   Action = "lock"
+	Unlock Action = "unlock"
 )
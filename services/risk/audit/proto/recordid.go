@@ -1,9 +1,11 @@
 package auditproto
 
-import "github.com/google/uuid"
+import "github.com/kianooshaz/skeleton/foundation/id"
 
-type RecordID uuid.UUID
+// RecordKind is the kind of audit record IDs.
+type RecordKind struct{}
 
-func (r RecordID) String() string {
-	return uuid.UUID(r).String()
-}
+func (RecordKind) Prefix() string { return "" }
+
+// RecordID identifies an audit record.
+type RecordID = id.ID[RecordKind]
@@ -0,0 +1,26 @@
+-- Audit Service Database Schema
+-- This file contains the SQL schema for the audit service tables.
+-- Run this manually in your PostgreSQL database to create the required tables.
+-- Create audit_records table
+CREATE TABLE IF NOT EXISTS audit_records (
+    id UUID PRIMARY KEY,
+    request_id TEXT NOT NULL DEFAULT '',
+    action TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    data JSONB NULL,
+    origin_ip TEXT NOT NULL DEFAULT '',
+    resource_id BIGINT NOT NULL DEFAULT 0,
+    resource_type TEXT NOT NULL DEFAULT '',
+    user_id BIGINT NOT NULL DEFAULT 0,
+    organization_id UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000000'
+);
+-- Add the organization of records to tables created before it was recorded
+ALTER TABLE audit_records
+ADD COLUMN IF NOT EXISTS organization_id UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000000';
+-- Add when records were written, which orders the replay of streams
+ALTER TABLE audit_records
+ADD COLUMN IF NOT EXISTS written_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+-- Create indexes for better performance
+CREATE INDEX IF NOT EXISTS idx_audit_records_created_at ON audit_records (created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_records_user_id ON audit_records (user_id);
+CREATE INDEX IF NOT EXISTS idx_audit_records_written_at ON audit_records (written_at, id);
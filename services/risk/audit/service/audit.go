@@ -2,22 +2,57 @@ package auditservice
 
 import (
 	"context"
+	"expvar"
 	"log/slog"
+	"time"
 
-	"github.com/google/uuid"
+	"github.com/kianooshaz/skeleton/foundation/id"
 	"github.com/kianooshaz/skeleton/foundation/pagination"
 	"github.com/kianooshaz/skeleton/foundation/session"
+	orgproto "github.com/kianooshaz/skeleton/services/organization/organization/proto"
 	auditproto "github.com/kianooshaz/skeleton/services/risk/audit/proto"
 )
 
-func (as *Service) Record(record auditproto.Record) {
+// pendingRecord is a record waiting to be written, with the context it was
+// recorded in.
+type pendingRecord struct {
+	// ctx is detached from the request, which usually ends before the record
+	// is written, and keeps its request ID and principal for the logs.
+	ctx    context.Context
+	record auditproto.Record
+}
+
+// DroppedRecords counts the records dropped because the buffer was full. It is
+// published by expvar as audit_dropped_records.
+var DroppedRecords = expvar.NewInt("audit_dropped_records")
+
+func (as *Service) Record(ctx context.Context, record auditproto.Record) {
 	// Generate ID if not provided
-	if record.ID == auditproto.RecordID(uuid.Nil) {
-		record.ID = auditproto.RecordID(uuid.New())
+	if record.ID.IsZero() {
+		record.ID = id.MustNew[auditproto.RecordKind]()
+	}
+
+	if record.RequestID == "" {
+		record.RequestID = session.GetRequestID(ctx)
 	}
 
-	as.workerWg.Add(1)
-	as.recordCh <- record
+	if organizationID, ok := session.GetOrganizationID(ctx); ok && record.OrganizationID.IsZero() {
+		record.OrganizationID = orgproto.OrganizationID(organizationID)
+	}
+
+	// Recording must not hold up the caller, so a record finding the buffer
+	// full is dropped rather than waited for.
+	select {
+	case as.recordCh <- pendingRecord{ctx: session.Detach(ctx), record: record}:
+	default:
+		DroppedRecords.Add(1)
+		as.logger.ErrorContext(
+			ctx,
+			"Audit buffer is full, dropping record",
+			slog.Int("buffer_size", as.config.BufferSize),
+			slog.Any("record", record),
+		)
+	}
 }
 
 func (as *Service) Get(ctx context.Context, req auditproto.GetRequest) (auditproto.GetResponse, error) {
@@ -30,48 +65,129 @@ func (as *Service) Get(ctx context.Context, req auditproto.GetRequest) (auditpro
 }
 
 func (as *Service) List(ctx context.Context, req auditproto.ListRequest) (auditproto.ListResponse, error) {
-	records, err := as.persister.List(ctx, req.Page, req.OrderBy)
+	records, cursors, err := as.persister.List(ctx, req.Page, req.Sort)
 	if err != nil {
 		return auditproto.ListResponse{}, err
 	}
 
-	count, err := as.persister.Count(ctx)
-	if err != nil {
-		return auditproto.ListResponse{}, err
+	var count int
+	if req.NeedsTotal() {
+		count, err = as.persister.Count(ctx)
+		if err != nil {
+			return auditproto.ListResponse{}, err
+		}
 	}
 
 	return auditproto.ListResponse{
-		Response: pagination.NewResponse(req.Page, count, records),
+		Response: pagination.NewCursorResponse(req.Page, count, records, cursors),
 	}, nil
 }
 
+func (as *Service) Export(ctx context.Context, req auditproto.ListRequest, yield func(auditproto.Record) error) error {
+	return as.persister.Export(ctx, req.Sort, yield)
+}
+
+// processRecords collects records into batches and writes a batch once it holds
+// BatchSize records or FlushInterval has passed since its first record. On
+// shutdown the records still buffered are written before returning.
 func (as *Service) processRecords() {
 	defer as.workerWg.Done()
 
+	batch := make([]pendingRecord, 0, as.config.BatchSize)
+
+	timer := time.NewTimer(as.config.FlushInterval)
+	timer.Stop()
+
+	flush := func() {
+		timer.Stop()
+		as.writeBatch(batch)
+		batch = batch[:0]
+	}
+
 	for {
 		select {
-		case record := <-as.recordCh:
-			if err := as.persister.Create(context.Background(), record); err != nil {
-				as.logger.ErrorContext(
-					session.SetRequestID(context.Background(), record.RequestID),
-					"failed to create audit record",
-					slog.String("error", err.Error()),
-					slog.Any("record", record),
-				)
+		case pending := <-as.recordCh:
+			if len(batch) == 0 {
+				timer.Reset(as.config.FlushInterval)
 			}
+
+			batch = append(batch, pending)
+			if len(batch) >= as.config.BatchSize {
+				flush()
+			}
+		case <-timer.C:
+			flush()
 		case <-as.shutdown:
+		drain:
+			for {
+				select {
+				case pending := <-as.recordCh:
+					batch = append(batch, pending)
+					if len(batch) >= as.config.BatchSize {
+						flush()
+					}
+				default:
+					break drain
+				}
+			}
+			flush()
+
 			as.logger.Info("worker shutting down")
 			return
 		}
 	}
 }
 
+// writeBatch writes the records in one statement. When that fails the records
+// are written one by one, so a single bad record does not lose the others.
+func (as *Service) writeBatch(pending []pendingRecord) {
+	if len(pending) == 0 {
+		return
+	}
+
+	records := make([]auditproto.Record, len(pending))
+	for i, p := range pending {
+		records[i] = p.record
+	}
+
+	err := as.persister.CreateBatch(context.Background(), records)
+	if err == nil {
+		as.publish(records)
+		return
+	}
+
+	as.logger.Warn(
+		"Error encountered while writing audit batch, retrying records one by one",
+		slog.String("error", err.Error()),
+		slog.Int("size", len(records)),
+	)
+
+	written := make([]auditproto.Record, 0, len(pending))
+	for _, p := range pending {
+		if err := as.persister.Create(context.Background(), p.record); err != nil {
+			as.logger.ErrorContext(
+				p.ctx,
+				"failed to create audit record",
+				slog.String("error", err.Error()),
+				slog.Any("record", p.record),
+			)
+			continue
+		}
+
+		written = append(written, p.record)
+	}
+
+	as.publish(written)
+}
+
 func (as *Service) Shutdown(ctx context.Context) {
 	close(as.shutdown)
 
 	done := make(chan struct{})
 	go func() {
 		as.workerWg.Wait()
+		// Streams end once the last records are written and published.
+		as.closeFeed()
 		close(done)
 	}()
 
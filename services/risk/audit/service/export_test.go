@@ -0,0 +1,30 @@
+package auditservice
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/kianooshaz/skeleton/foundation/pubsub"
+)
+
+// NewWithStorage creates a service on the given storage, streaming the records
+// it writes itself.
+func NewWithStorage(cfg Config, storage persister, logger *slog.Logger) *Service {
+	service, err := NewWithBroker(cfg, storage, pubsub.NewMemoryBroker(), logger)
+	if err != nil {
+		panic(err)
+	}
+
+	return service
+}
+
+// NewWithBroker creates a service on the given storage, streaming the records
+// published on broker.
+func NewWithBroker(cfg Config, storage persister, broker pubsub.Broker, logger *slog.Logger) (*Service, error) {
+	subscription, err := broker.Subscribe(context.Background(), feedChannel)
+	if err != nil {
+		return nil, err
+	}
+
+	return newService(cfg, storage, broker, subscription, logger), nil
+}
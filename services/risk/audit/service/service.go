@@ -3,25 +3,59 @@ package auditservice
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"log/slog"
 	"sync"
+	"time"
 
 	"github.com/kianooshaz/skeleton/foundation/order"
 	"github.com/kianooshaz/skeleton/foundation/pagination"
+	"github.com/kianooshaz/skeleton/foundation/pubsub"
 	"github.com/kianooshaz/skeleton/services/risk/audit/persistence"
 	auditproto "github.com/kianooshaz/skeleton/services/risk/audit/proto"
 )
 
 type (
 	Config struct {
+		// BufferSize is the number of records waiting to be written. Records
+		// recorded while it is full are dropped.
 		BufferSize  int `yaml:"buffer_size"`
 		WorkerCount int `yaml:"worker_count"`
+		// BatchSize is the number of records a worker collects before writing them
+		// at once, at most persistence.MaxBatchSize.
+		BatchSize int `yaml:"batch_size"`
+		// FlushInterval is the longest a collected record waits before it is written.
+		FlushInterval time.Duration `yaml:"flush_interval"`
+		// Stream holds how written records reach the streams of every instance.
+		Stream StreamConfig `yaml:"stream"`
+	}
+
+	StreamConfig struct {
+		// Backend is how written records reach streams: memory (the default),
+		// which only reaches the streams of the process, or redis, which
+		// reaches those of every instance.
+		Backend pubsub.Backend `yaml:"backend"`
+		// Buffer is the number of records a stream may fall behind by before
+		// it is ended.
+		Buffer int `yaml:"buffer"`
+		// ResumeWindow is how long before the last record a client received a
+		// resumed stream replays from. Records are published as their batch is
+		// written, so one may reach storage after a later one was streamed; it
+		// is replayed if written within the window.
+		ResumeWindow time.Duration `yaml:"resume_window"`
 	}
 
 	persister interface {
 		Create(ctx context.Context, record auditproto.Record) error
+		CreateBatch(ctx context.Context, records []auditproto.Record) error
 		Get(ctx context.Context, id auditproto.RecordID) (auditproto.Record, error)
-		List(ctx context.Context, page pagination.Page, orderBy order.OrderBy) ([]auditproto.Record, error)
+		List(
+			ctx context.Context, page pagination.Page, sort order.Spec,
+		) ([]auditproto.Record, pagination.Cursors, error)
+		Export(ctx context.Context, sort order.Spec, yield func(auditproto.Record) error) error
+		Since(
+			ctx context.Context, after auditproto.RecordID, window time.Duration, yield func(auditproto.Record) error,
+		) error
 		Count(ctx context.Context) (int, error)
 	}
 
@@ -29,15 +63,21 @@ type (
 		config    Config
 		persister persister
 		logger    *slog.Logger
-		recordCh  chan auditproto.Record
+		recordCh  chan pendingRecord
 		shutdown  chan struct{}
 		workerWg  *sync.WaitGroup
 		dbConn    *sql.DB
+
+		broker       pubsub.Broker
+		subscription pubsub.Subscription
+		feed         *feed
+		feedWg       sync.WaitGroup
 	}
 )
 
-// New creates a new audit service instance.
-func New(cfg Config, db *sql.DB, logger *slog.Logger) auditproto.AuditService {
+// New creates a new audit service instance. Written records are published on
+// broker, and the records published there by every instance are streamed.
+func New(cfg Config, db *sql.DB, broker pubsub.Broker, logger *slog.Logger) (auditproto.AuditService, error) {
 	serviceLogger := logger.With(
 		slog.Group("package_info",
 			slog.String("module", "audit"),
@@ -45,6 +85,20 @@ func New(cfg Config, db *sql.DB, logger *slog.Logger) auditproto.AuditService {
 		),
 	)
 
+	subscription, err := broker.Subscribe(context.Background(), feedChannel)
+	if err != nil {
+		return nil, fmt.Errorf("subscribing to audit records: %w", err)
+	}
+
+	svc := newService(cfg, &persistence.AuditStorage{Conn: db}, broker, subscription, serviceLogger)
+	svc.dbConn = db
+
+	return svc, nil
+}
+
+func newService(
+	cfg Config, persister persister, broker pubsub.Broker, subscription pubsub.Subscription, logger *slog.Logger,
+) *Service {
 	// Set default values if not configured
 	if cfg.BufferSize == 0 {
 		cfg.BufferSize = 1000
@@ -52,18 +106,45 @@ func New(cfg Config, db *sql.DB, logger *slog.Logger) auditproto.AuditService {
 	if cfg.WorkerCount == 0 {
 		cfg.WorkerCount = 3
 	}
+	if cfg.BatchSize == 0 {
+		cfg.BatchSize = 100
+	}
+	if cfg.BatchSize > persistence.MaxBatchSize {
+		logger.Warn(
+			"Audit batch size exceeds the maximum, using the maximum instead",
+			slog.Int("batch_size", cfg.BatchSize),
+			slog.Int("max_batch_size", persistence.MaxBatchSize),
+		)
+		cfg.BatchSize = persistence.MaxBatchSize
+	}
+	if cfg.FlushInterval == 0 {
+		cfg.FlushInterval = 200 * time.Millisecond
+	}
+	if cfg.Stream.Buffer == 0 {
+		cfg.Stream.Buffer = 100
+	}
+	if cfg.Stream.ResumeWindow == 0 {
+		cfg.Stream.ResumeWindow = time.Minute
+	}
 
 	svc := &Service{
 		config:    cfg,
-		persister: &persistence.AuditStorage{Conn: db},
-		logger:    serviceLogger,
-		recordCh:  make(chan auditproto.Record, cfg.BufferSize),
+		persister: persister,
+		logger:    logger,
+		recordCh:  make(chan pendingRecord, cfg.BufferSize),
 		shutdown:  make(chan struct{}),
 		workerWg:  &sync.WaitGroup{},
-		dbConn:    db,
+
+		broker:       broker,
+		subscription: subscription,
+		feed:         newFeed(),
 	}
 
+	svc.feedWg.Add(1)
+	go svc.receiveRecords()
+
 	// Start worker goroutines
+	svc.workerWg.Add(cfg.WorkerCount)
 	for range cfg.WorkerCount {
 		go svc.processRecords()
 	}
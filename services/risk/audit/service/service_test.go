@@ -0,0 +1,203 @@
+package auditservice_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kianooshaz/skeleton/foundation/session"
+	"github.com/kianooshaz/skeleton/services/risk/audit/persistence"
+	auditproto "github.com/kianooshaz/skeleton/services/risk/audit/proto"
+	auditservice "github.com/kianooshaz/skeleton/services/risk/audit/service"
+)
+
+func TestService_Record(t *testing.T) {
+	tests := []struct {
+		name   string
+		config auditservice.Config
+		// existing records are stored before recording, so batches holding them fail.
+		existing int
+		records  int
+	}{
+		{
+			name:    "full batches",
+			config:  auditservice.Config{WorkerCount: 2, BatchSize: 5, FlushInterval: time.Hour},
+			records: 20,
+		},
+		{
+			name:    "partial batch flushed on interval",
+			config:  auditservice.Config{WorkerCount: 1, BatchSize: 100, FlushInterval: time.Millisecond},
+			records: 3,
+		},
+		{
+			name:     "failed batch retried record by record",
+			config:   auditservice.Config{WorkerCount: 1, BatchSize: 10, FlushInterval: time.Hour},
+			existing: 1,
+			records:  4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			storage := persistence.NewAuditMemoryStorage()
+			service := auditservice.NewWithStorage(tt.config, storage, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+			records := make([]auditproto.Record, 0, tt.existing+tt.records)
+			for range tt.existing + tt.records {
+				records = append(records, newRecord())
+			}
+			for _, record := range records[:tt.existing] {
+				require.NoError(t, storage.Create(ctx, record))
+			}
+
+			// Execute.
+			for _, record := range records {
+				service.Record(ctx, record)
+			}
+
+			if tt.config.FlushInterval < time.Second {
+				// The partial batch is written without waiting for shutdown.
+				require.Eventually(t, func() bool {
+					count, err := storage.Count(ctx)
+					return err == nil && count == len(records)
+				}, time.Second, time.Millisecond)
+			}
+
+			shutdownCtx, cancel := context.WithTimeout(ctx, time.Second)
+			defer cancel()
+			service.Shutdown(shutdownCtx)
+
+			// Assert.
+			count, err := storage.Count(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, len(records), count)
+		})
+	}
+}
+
+func newRecord() auditproto.Record {
+	return auditproto.Record{
+		ID:           auditproto.RecordID(uuid.New()),
+		RequestID:    uuid.NewString(),
+		Action:       auditproto.Insert,
+		CreatedAt:    time.Now(),
+		ResourceType: "user",
+	}
+}
+
+func TestService_RecordRequestID(t *testing.T) {
+	tests := []struct {
+		name          string
+		ctx           context.Context
+		requestID     string
+		wantRequestID string
+	}{
+		{
+			name:          "taken from the context",
+			ctx:           session.SetRequestID(context.Background(), "from-context"),
+			wantRequestID: "from-context",
+		},
+		{
+			name:          "kept when set",
+			ctx:           session.SetRequestID(context.Background(), "from-context"),
+			requestID:     "from-record",
+			wantRequestID: "from-record",
+		},
+		{
+			name: "empty without a request",
+			ctx:  context.Background(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := persistence.NewAuditMemoryStorage()
+			service := auditservice.NewWithStorage(auditservice.Config{}, storage, slog.New(slog.NewTextHandler(io.Discard, nil)))
+			record := newRecord()
+			record.RequestID = tt.requestID
+
+			// Execute.
+			service.Record(tt.ctx, record)
+
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			service.Shutdown(shutdownCtx)
+
+			// Assert.
+			got, err := storage.Get(context.Background(), record.ID)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantRequestID, got.RequestID)
+		})
+	}
+}
+
+// blockingStorage holds up the writing of batches until release is closed.
+type blockingStorage struct {
+	*persistence.AuditMemoryStorage
+
+	writing chan struct{}
+	release chan struct{}
+}
+
+func (s *blockingStorage) CreateBatch(ctx context.Context, records []auditproto.Record) error {
+	s.writing <- struct{}{}
+	<-s.release
+
+	return s.AuditMemoryStorage.CreateBatch(ctx, records)
+}
+
+func TestService_RecordDropsWhenBufferFull(t *testing.T) {
+	ctx := context.Background()
+	storage := &blockingStorage{
+		AuditMemoryStorage: persistence.NewAuditMemoryStorage(),
+		writing:            make(chan struct{}, 2),
+		release:            make(chan struct{}),
+	}
+	service := auditservice.NewWithStorage(
+		auditservice.Config{BufferSize: 1, WorkerCount: 1, BatchSize: 1, FlushInterval: time.Hour},
+		storage,
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+	)
+
+	// The worker holds the first record while it is written, and the second
+	// fills the buffer.
+	written, buffered, dropped := newRecord(), newRecord(), newRecord()
+	service.Record(ctx, written)
+	<-storage.writing
+	service.Record(ctx, buffered)
+	before := auditservice.DroppedRecords.Value()
+
+	// Execute.
+	recorded := make(chan struct{})
+	go func() {
+		service.Record(ctx, dropped)
+		close(recorded)
+	}()
+
+	// Assert.
+	select {
+	case <-recorded:
+	case <-time.After(time.Second):
+		t.Fatal("Record waited for the full buffer")
+	}
+	assert.Equal(t, before+1, auditservice.DroppedRecords.Value())
+
+	close(storage.release)
+	shutdownCtx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	service.Shutdown(shutdownCtx)
+
+	for _, record := range []auditproto.Record{written, buffered} {
+		_, err := storage.Get(ctx, record.ID)
+		assert.NoError(t, err)
+	}
+	_, err := storage.Get(ctx, dropped.ID)
+	assert.Error(t, err, "the dropped record is not written")
+}
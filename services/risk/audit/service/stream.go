@@ -0,0 +1,240 @@
+package auditservice
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"sync"
+
+	auditproto "github.com/kianooshaz/skeleton/services/risk/audit/proto"
+)
+
+// feedChannel is the broker channel written records are published on, as a
+// JSON array per batch.
+const feedChannel = "audit:records"
+
+// feed hands the records published on feedChannel to the streams of the process.
+type feed struct {
+	mu      sync.Mutex
+	streams map[*stream]struct{}
+	closed  bool
+}
+
+// stream is a subscriber of the feed. Its records are closed when it is
+// removed from the feed.
+type stream struct {
+	req     auditproto.StreamRequest
+	records chan auditproto.Record
+}
+
+func newFeed() *feed {
+	return &feed{streams: make(map[*stream]struct{})}
+}
+
+// add subscribes a stream of the records matching req, holding up to buffer
+// of them. Once the feed is closed, the stream returned is already ended.
+func (f *feed) add(req auditproto.StreamRequest, buffer int) *stream {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	s := &stream{req: req, records: make(chan auditproto.Record, buffer)}
+	if f.closed {
+		close(s.records)
+		return s
+	}
+	f.streams[s] = struct{}{}
+
+	return s
+}
+
+// remove ends s, unless it already ended.
+func (f *feed) remove(s *stream) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.streams[s]; ok {
+		delete(f.streams, s)
+		close(s.records)
+	}
+}
+
+// deliver hands records to the streams they match. A stream whose buffer is
+// full is ended rather than waited for, so it resumes from storage.
+func (f *feed) deliver(records []auditproto.Record) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for s := range f.streams {
+		for _, record := range records {
+			if s.req.Matches(record) && !s.offer(record) {
+				delete(f.streams, s)
+				close(s.records)
+				break
+			}
+		}
+	}
+}
+
+// offer hands record to s unless its buffer is full.
+func (s *stream) offer(record auditproto.Record) bool {
+	select {
+	case s.records <- record:
+		return true
+	default:
+		return false
+	}
+}
+
+// close ends every stream and refuses new ones.
+func (f *feed) close() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.closed = true
+	for s := range f.streams {
+		delete(f.streams, s)
+		close(s.records)
+	}
+}
+
+func (as *Service) Stream(ctx context.Context, req auditproto.StreamRequest) (<-chan auditproto.Record, error) {
+	// Subscribe before replaying, so no record falls between the two.
+	live := as.feed.add(req, as.config.Stream.Buffer)
+
+	records := make(chan auditproto.Record)
+	go func() {
+		defer close(records)
+		defer as.feed.remove(live)
+
+		send := func(record auditproto.Record) bool {
+			select {
+			case records <- record:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		// replayed are the IDs of the last records replayed, which may be
+		// delivered live as well. A stream holds at most Buffer live records,
+		// so remembering as many replayed ones is enough.
+		replayed := newRecentIDs(as.config.Stream.Buffer)
+		if !req.LastEventID.IsZero() {
+			err := as.persister.Since(ctx, req.LastEventID, as.config.Stream.ResumeWindow, func(record auditproto.Record) error {
+				if !req.Matches(record) {
+					return nil
+				}
+				replayed.add(record.ID)
+				if !send(record) {
+					return ctx.Err()
+				}
+
+				return nil
+			})
+			if err != nil {
+				if ctx.Err() == nil {
+					as.logger.ErrorContext(
+						ctx,
+						"Error encountered while replaying audit records",
+						slog.String("error", err.Error()),
+						slog.String("last_event_id", req.LastEventID.String()),
+					)
+				}
+				return
+			}
+		}
+
+		for {
+			select {
+			case record, ok := <-live.records:
+				if !ok {
+					return
+				}
+				if replayed.has(record.ID) {
+					continue
+				}
+				if !send(record) {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return records, nil
+}
+
+// recentIDs remembers the last IDs added to it, up to its size.
+type recentIDs struct {
+	ids  []auditproto.RecordID
+	set  map[auditproto.RecordID]struct{}
+	next int
+}
+
+func newRecentIDs(size int) *recentIDs {
+	return &recentIDs{
+		ids: make([]auditproto.RecordID, 0, size),
+		set: make(map[auditproto.RecordID]struct{}, size),
+	}
+}
+
+func (r *recentIDs) add(id auditproto.RecordID) {
+	if len(r.ids) < cap(r.ids) {
+		r.ids = append(r.ids, id)
+	} else {
+		delete(r.set, r.ids[r.next])
+		r.ids[r.next] = id
+		r.next = (r.next + 1) % len(r.ids)
+	}
+	r.set[id] = struct{}{}
+}
+
+func (r *recentIDs) has(id auditproto.RecordID) bool {
+	_, ok := r.set[id]
+	return ok
+}
+
+// publish publishes records for the streams of every instance.
+func (as *Service) publish(records []auditproto.Record) {
+	if len(records) == 0 {
+		return
+	}
+
+	message, err := json.Marshal(records)
+	if err == nil {
+		err = as.broker.Publish(context.Background(), feedChannel, message)
+	}
+	if err != nil {
+		as.logger.Warn(
+			"Error encountered while publishing audit records",
+			slog.String("error", err.Error()),
+			slog.Int("size", len(records)),
+		)
+	}
+}
+
+// receiveRecords delivers the records published by every instance to the
+// streams of the process, until the subscription is closed.
+func (as *Service) receiveRecords() {
+	defer as.feedWg.Done()
+
+	for message := range as.subscription.Messages() {
+		var records []auditproto.Record
+		if err := json.Unmarshal(message, &records); err != nil {
+			as.logger.Warn("Error encountered while decoding published audit records", slog.String("error", err.Error()))
+			continue
+		}
+
+		as.feed.deliver(records)
+	}
+}
+
+// closeFeed ends the subscription and every stream.
+func (as *Service) closeFeed() {
+	if err := as.subscription.Close(); err != nil {
+		as.logger.Warn("Error encountered while closing audit subscription", slog.String("error", err.Error()))
+	}
+	as.feedWg.Wait()
+	as.feed.close()
+}
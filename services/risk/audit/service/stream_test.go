@@ -0,0 +1,274 @@
+package auditservice_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kianooshaz/skeleton/foundation/id"
+	"github.com/kianooshaz/skeleton/foundation/pubsub"
+	"github.com/kianooshaz/skeleton/foundation/session"
+	orgproto "github.com/kianooshaz/skeleton/services/organization/organization/proto"
+	"github.com/kianooshaz/skeleton/services/risk/audit/persistence"
+	auditproto "github.com/kianooshaz/skeleton/services/risk/audit/proto"
+	auditservice "github.com/kianooshaz/skeleton/services/risk/audit/service"
+)
+
+// streamConfig writes every record as soon as it is recorded.
+var streamConfig = auditservice.Config{WorkerCount: 1, BatchSize: 1, FlushInterval: time.Millisecond}
+
+func newStreamService(
+	t *testing.T, cfg auditservice.Config, storage *persistence.AuditMemoryStorage, broker pubsub.Broker,
+) *auditservice.Service {
+	t.Helper()
+
+	service, err := auditservice.NewWithBroker(cfg, storage, broker, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		service.Shutdown(ctx)
+	})
+
+	return service
+}
+
+func newStreamRecord(action auditproto.Action, resourceType string) auditproto.Record {
+	return auditproto.Record{
+		ID:           id.MustNew[auditproto.RecordKind](),
+		Action:       action,
+		CreatedAt:    time.Now(),
+		ResourceType: resourceType,
+	}
+}
+
+// receive returns the next record of records, failing when none comes in time.
+func receive(t *testing.T, records <-chan auditproto.Record) auditproto.Record {
+	t.Helper()
+
+	select {
+	case record, ok := <-records:
+		require.True(t, ok, "stream ended")
+		return record
+	case <-time.After(time.Second):
+		require.FailNow(t, "no record streamed")
+		return auditproto.Record{}
+	}
+}
+
+func TestService_Stream(t *testing.T) {
+	organizationID := uuid.New()
+
+	tests := []struct {
+		name string
+		req  auditproto.StreamRequest
+		// want are the indexes of the recorded records streamed.
+		want []int
+	}{
+		{
+			name: "every record",
+			want: []int{0, 1, 2},
+		},
+		{
+			name: "by action and resource type",
+			req:  auditproto.StreamRequest{Actions: []auditproto.Action{auditproto.Update}, ResourceTypes: []string{"user"}},
+			want: []int{1},
+		},
+		{
+			name: "by organization",
+			req:  auditproto.StreamRequest{OrganizationID: orgproto.OrganizationID(organizationID)},
+			want: []int{2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			// Two instances share the storage and the broker.
+			storage := persistence.NewAuditMemoryStorage()
+			broker := pubsub.NewMemoryBroker()
+			streaming := newStreamService(t, streamConfig, storage, broker)
+			recording := newStreamService(t, streamConfig, storage, broker)
+
+			records, err := streaming.Stream(ctx, tt.req)
+			require.NoError(t, err)
+
+			recorded := []auditproto.Record{
+				newStreamRecord(auditproto.Insert, "user"),
+				newStreamRecord(auditproto.Update, "user"),
+				newStreamRecord(auditproto.Update, "organization"),
+			}
+			inOrganization := session.SetPrincipal(ctx, session.Principal{OrganizationID: organizationID})
+
+			// Execute.
+			recording.Record(ctx, recorded[0])
+			recording.Record(ctx, recorded[1])
+			recording.Record(inOrganization, recorded[2])
+
+			// Assert.
+			for _, i := range tt.want {
+				assert.Equal(t, recorded[i].ID, receive(t, records).ID)
+			}
+			select {
+			case record := <-records:
+				assert.Failf(t, "unexpected record", "%+v", record)
+			case <-time.After(50 * time.Millisecond):
+			}
+		})
+	}
+}
+
+func TestService_StreamResume(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	storage := persistence.NewAuditMemoryStorage()
+	service := newStreamService(t, streamConfig, storage, pubsub.NewMemoryBroker())
+
+	written := []auditproto.Record{
+		newStreamRecord(auditproto.Insert, "user"),
+		newStreamRecord(auditproto.Update, "user"),
+		newStreamRecord(auditproto.Delete, "user"),
+	}
+	for _, record := range written {
+		require.NoError(t, storage.Create(ctx, record))
+	}
+
+	// Execute.
+	records, err := service.Stream(ctx, auditproto.StreamRequest{LastEventID: written[0].ID})
+	require.NoError(t, err)
+	live := newStreamRecord(auditproto.Lock, "user")
+	service.Record(ctx, live)
+
+	// Assert.
+	assert.Equal(t, written[1].ID, receive(t, records).ID, "replayed")
+	assert.Equal(t, written[2].ID, receive(t, records).ID, "replayed")
+	assert.Equal(t, live.ID, receive(t, records).ID, "then live")
+}
+
+func TestService_StreamResumeLateWrite(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	storage := persistence.NewAuditMemoryStorage()
+	service := newStreamService(t, streamConfig, storage, pubsub.NewMemoryBroker())
+
+	// late is made first but written after streamed, as by a slower instance.
+	late := newStreamRecord(auditproto.Insert, "user")
+	streamed := newStreamRecord(auditproto.Update, "user")
+	require.NoError(t, storage.Create(ctx, streamed))
+	require.NoError(t, storage.Create(ctx, late))
+
+	// Execute.
+	records, err := service.Stream(ctx, auditproto.StreamRequest{LastEventID: streamed.ID})
+	require.NoError(t, err)
+
+	// Assert.
+	assert.Equal(t, late.ID, receive(t, records).ID, "replayed despite its smaller ID")
+}
+
+func TestService_StreamEnds(t *testing.T) {
+	t.Run("falling behind", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		// The records are published at once, more than the stream holds.
+		cfg := auditservice.Config{WorkerCount: 1, BatchSize: 5, FlushInterval: time.Minute}
+		cfg.Stream.Buffer = 1
+		storage := persistence.NewAuditMemoryStorage()
+		service := newStreamService(t, cfg, storage, pubsub.NewMemoryBroker())
+
+		records, err := service.Stream(ctx, auditproto.StreamRequest{})
+		require.NoError(t, err)
+
+		// Execute.
+		var recorded []id.ID[auditproto.RecordKind]
+		for range 5 {
+			record := newStreamRecord(auditproto.Insert, "user")
+			recorded = append(recorded, record.ID)
+			service.Record(ctx, record)
+		}
+		require.Eventually(t, func() bool {
+			count, err := storage.Count(ctx)
+			return err == nil && count == len(recorded)
+		}, time.Second, time.Millisecond)
+
+		// Assert.
+		var streamed []id.ID[auditproto.RecordKind]
+		for ended := false; !ended; {
+			select {
+			case record, ok := <-records:
+				if !ok {
+					ended = true
+					break
+				}
+				streamed = append(streamed, record.ID)
+			case <-time.After(time.Second):
+				require.FailNow(t, "the stream did not end")
+			}
+		}
+		require.NotEmpty(t, streamed)
+		require.Less(t, len(streamed), len(recorded))
+
+		resumed, err := service.Stream(ctx, auditproto.StreamRequest{LastEventID: streamed[len(streamed)-1]})
+		require.NoError(t, err)
+		// Records written just before the last one streamed are replayed
+		// again; they are dropped by ID.
+		for len(streamed) < len(recorded) {
+			if record := receive(t, resumed); !slices.Contains(streamed, record.ID) {
+				streamed = append(streamed, record.ID)
+			}
+		}
+		assert.Equal(t, recorded, streamed, "resuming picks up where the stream ended")
+	})
+
+	t.Run("shutdown", func(t *testing.T) {
+		service, err := auditservice.NewWithBroker(
+			streamConfig,
+			persistence.NewAuditMemoryStorage(),
+			pubsub.NewMemoryBroker(),
+			slog.New(slog.NewTextHandler(io.Discard, nil)),
+		)
+		require.NoError(t, err)
+
+		records, err := service.Stream(context.Background(), auditproto.StreamRequest{})
+		require.NoError(t, err)
+
+		// Execute.
+		service.Shutdown(context.Background())
+
+		// Assert.
+		select {
+		case _, ok := <-records:
+			assert.False(t, ok)
+		case <-time.After(time.Second):
+			assert.Fail(t, "the stream did not end")
+		}
+	})
+}
+
+func TestService_RecordOrganization(t *testing.T) {
+	organizationID := uuid.New()
+	storage := persistence.NewAuditMemoryStorage()
+	service := newStreamService(t, streamConfig, storage, pubsub.NewMemoryBroker())
+	ctx := session.SetPrincipal(context.Background(), session.Principal{OrganizationID: organizationID})
+	record := newStreamRecord(auditproto.Insert, "user")
+
+	// Execute.
+	service.Record(ctx, record)
+
+	// Assert.
+	require.Eventually(t, func() bool {
+		got, err := storage.Get(context.Background(), record.ID)
+		return err == nil && got.OrganizationID == orgproto.OrganizationID(organizationID)
+	}, time.Second, time.Millisecond)
+}
@@ -0,0 +1,201 @@
+package persistence
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	dbproto "github.com/kianooshaz/skeleton/foundation/database/proto"
+	"github.com/kianooshaz/skeleton/foundation/id"
+	"github.com/kianooshaz/skeleton/foundation/order"
+	"github.com/kianooshaz/skeleton/foundation/pagination"
+	birthdayproto "github.com/kianooshaz/skeleton/services/user/birthday/proto"
+	userproto "github.com/kianooshaz/skeleton/services/user/user/proto"
+)
+
+// BirthdayMemoryStorage keeps birthday records in memory. It behaves like
+// BirthdayStorage and is meant for tests.
+type BirthdayMemoryStorage struct {
+	mu        sync.RWMutex
+	birthdays map[birthdayproto.BirthdayID]birthdayproto.Birthday
+}
+
+// NewBirthdayMemoryStorage creates an empty BirthdayMemoryStorage.
+func NewBirthdayMemoryStorage() *BirthdayMemoryStorage {
+	return &BirthdayMemoryStorage{
+		birthdays: make(map[birthdayproto.BirthdayID]birthdayproto.Birthday),
+	}
+}
+
+// Create creates a new birthday record. Like the database it sets the timestamps
+// and rejects a second record for the same user.
+func (s *BirthdayMemoryStorage) Create(_ context.Context, birthday birthdayproto.Birthday) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, existing := range s.birthdays {
+		if existing.ID == birthday.ID || existing.UserID == birthday.UserID {
+			return fmt.Errorf("creating birthday record: %w", dbproto.ErrDuplicateKey)
+		}
+	}
+
+	now := time.Now()
+	birthday.CreatedAt = now
+	birthday.UpdatedAt = now
+	s.birthdays[birthday.ID] = birthday
+
+	return nil
+}
+
+// Get retrieves a birthday record by ID.
+func (s *BirthdayMemoryStorage) Get(_ context.Context, id birthdayproto.BirthdayID) (birthdayproto.Birthday, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	birthday, ok := s.birthdays[id]
+	if !ok {
+		return birthdayproto.Birthday{}, fmt.Errorf("getting birthday record: %w", sql.ErrNoRows)
+	}
+
+	return birthday, nil
+}
+
+// GetByUserID retrieves a birthday record by user ID.
+func (s *BirthdayMemoryStorage) GetByUserID(_ context.Context, userID userproto.UserID) (birthdayproto.Birthday, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, birthday := range s.birthdays {
+		if birthday.UserID == userID {
+			return birthday, nil
+		}
+	}
+
+	return birthdayproto.Birthday{}, fmt.Errorf("getting birthday record by user ID: %w", sql.ErrNoRows)
+}
+
+// Update updates the date and place of birth and the age of an existing birthday record.
+func (s *BirthdayMemoryStorage) Update(_ context.Context, birthday birthdayproto.Birthday) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, ok := s.birthdays[birthday.ID]
+	if !ok {
+		return nil
+	}
+
+	existing.DateOfBirth = birthday.DateOfBirth
+	existing.PlaceOfBirth = birthday.PlaceOfBirth
+	existing.Age = birthday.Age
+	existing.UpdatedAt = time.Now()
+	s.birthdays[birthday.ID] = existing
+
+	return nil
+}
+
+// Delete removes a birthday record.
+func (s *BirthdayMemoryStorage) Delete(_ context.Context, id birthdayproto.BirthdayID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.birthdays, id)
+
+	return nil
+}
+
+// List retrieves a paginated list of birthday records with optional filters.
+func (s *BirthdayMemoryStorage) List(
+	_ context.Context, page pagination.Page, sort order.Spec, filters ListFilters,
+) ([]birthdayproto.Birthday, pagination.Cursors, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	columns, err := orderWhitelist.Resolve(sort)
+	if err != nil {
+		return nil, pagination.Cursors{}, fmt.Errorf("resolving birthday order: %w", err)
+	}
+
+	keyset := pagination.MemoryKeyset[birthdayproto.Birthday]{
+		Compare: compareBirthdays,
+		Key:     cursorKey(columns),
+		ID:      cursorID,
+		Lookup: func(key string) (birthdayproto.Birthday, bool) {
+			parsed, err := id.Parse[birthdayproto.BirthdayKind](key)
+			if err != nil {
+				return birthdayproto.Birthday{}, false
+			}
+
+			birthday, ok := s.birthdays[parsed]
+			return birthday, ok
+		},
+	}
+
+	birthdays, cursors, err := keyset.Page(s.filter(filters), page, columns...)
+	if err != nil {
+		return nil, pagination.Cursors{}, fmt.Errorf("paging birthday records: %w", err)
+	}
+
+	return birthdays, cursors, nil
+}
+
+// Count returns the total number of birthday records matching the filters.
+func (s *BirthdayMemoryStorage) Count(_ context.Context, filters ListFilters) (int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return len(s.filter(filters)), nil
+}
+
+// ExistsByUserID checks if a birthday record exists for the given user ID.
+func (s *BirthdayMemoryStorage) ExistsByUserID(_ context.Context, userID userproto.UserID) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, birthday := range s.birthdays {
+		if birthday.UserID == userID {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (s *BirthdayMemoryStorage) filter(filters ListFilters) []birthdayproto.Birthday {
+	birthdays := make([]birthdayproto.Birthday, 0, len(s.birthdays))
+	for _, birthday := range s.birthdays {
+		switch {
+		case filters.UserID != nil && birthday.UserID != *filters.UserID,
+			filters.MinAge != nil && birthday.Age < *filters.MinAge,
+			filters.MaxAge != nil && birthday.Age > *filters.MaxAge,
+			filters.BirthMonth != nil && int(birthday.DateOfBirth.Month()) != *filters.BirthMonth:
+			continue
+		}
+
+		birthdays = append(birthdays, birthday)
+	}
+
+	return birthdays
+}
+
+func compareBirthdays(a, b birthdayproto.Birthday, column string) int {
+	switch column {
+	case "id":
+		return strings.Compare(a.ID.String(), b.ID.String())
+	case "user_id":
+		return strings.Compare(a.UserID.String(), b.UserID.String())
+	case "date_of_birth":
+		return a.DateOfBirth.Compare(b.DateOfBirth)
+	case "age":
+		return cmp.Compare(a.Age, b.Age)
+	case "created_at":
+		return a.CreatedAt.Compare(b.CreatedAt)
+	case "updated_at":
+		return a.UpdatedAt.Compare(b.UpdatedAt)
+	default:
+		return 0
+	}
+}
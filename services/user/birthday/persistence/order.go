@@ -1,35 +1,51 @@
 package persistence
 
-import "github.com/kianooshaz/skeleton/foundation/order"
+import (
+	"strconv"
+	"time"
 
-var orderStringer order.StringerFunc = func(orderBy order.OrderBy) string {
-	var field string
-	switch orderBy.Field {
-	case "id":
-		field = "id"
-	case "user_id":
-		field = "user_id"
-	case "date_of_birth":
-		field = "date_of_birth"
-	case "age":
-		field = "age"
-	case "created_at":
-		field = "created_at"
-	case "updated_at":
-		field = "updated_at"
-	default:
-		field = "created_at"
-	}
+	"github.com/kianooshaz/skeleton/foundation/order"
+	"github.com/kianooshaz/skeleton/foundation/pagination"
+	birthdayproto "github.com/kianooshaz/skeleton/services/user/birthday/proto"
+)
+
+var orderWhitelist = order.Whitelist{
+	Columns: map[string]string{
+		"id":            "id",
+		"user_id":       "user_id",
+		"date_of_birth": "date_of_birth",
+		"age":           "age",
+		"created_at":    "created_at",
+		"updated_at":    "updated_at",
+	},
+	Default: order.Spec{{Field: "created_at", Direction: order.DESC}},
+}
 
-	var direction string
-	switch orderBy.Direction {
-	case order.ASC:
-		direction = "ASC"
-	case order.DESC:
-		direction = "DESC"
-	default:
-		direction = "DESC"
+// cursorKey returns a function extracting the ORDER BY key of a birthday for the given columns.
+func cursorKey(columns []order.Column) func(birthdayproto.Birthday) []string {
+	return func(birthday birthdayproto.Birthday) []string {
+		keys := make([]string, 0, len(columns))
+		for _, column := range columns {
+			switch column.Name {
+			case "id":
+				keys = append(keys, birthday.ID.String())
+			case "user_id":
+				keys = append(keys, birthday.UserID.String())
+			case "date_of_birth":
+				keys = append(keys, birthday.DateOfBirth.Format(time.DateOnly))
+			case "age":
+				keys = append(keys, strconv.Itoa(birthday.Age))
+			case "created_at":
+				keys = append(keys, pagination.TimeKey(birthday.CreatedAt))
+			case "updated_at":
+				keys = append(keys, pagination.TimeKey(birthday.UpdatedAt))
+			}
+		}
+
+		return keys
 	}
+}
 
-	return field + " " + direction
+func cursorID(birthday birthdayproto.Birthday) string {
+	return birthday.ID.String()
 }
@@ -0,0 +1,177 @@
+// Package persistencetest holds the contract every birthday storage must meet,
+// so the in-memory storage used by tests stays faithful to the PostgreSQL one.
+package persistencetest
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kianooshaz/skeleton/foundation/derror"
+	"github.com/kianooshaz/skeleton/foundation/id"
+	"github.com/kianooshaz/skeleton/foundation/order"
+	"github.com/kianooshaz/skeleton/foundation/pagination"
+	"github.com/kianooshaz/skeleton/foundation/types"
+	"github.com/kianooshaz/skeleton/services/user/birthday/persistence"
+	birthdayproto "github.com/kianooshaz/skeleton/services/user/birthday/proto"
+	userproto "github.com/kianooshaz/skeleton/services/user/user/proto"
+)
+
+// Storage is the birthday storage under contract.
+type Storage interface {
+	Create(ctx context.Context, birthday birthdayproto.Birthday) error
+	Get(ctx context.Context, id birthdayproto.BirthdayID) (birthdayproto.Birthday, error)
+	GetByUserID(ctx context.Context, userID userproto.UserID) (birthdayproto.Birthday, error)
+	Update(ctx context.Context, birthday birthdayproto.Birthday) error
+	Delete(ctx context.Context, id birthdayproto.BirthdayID) error
+	List(
+		ctx context.Context, page pagination.Page, sort order.Spec, filters persistence.ListFilters,
+	) ([]birthdayproto.Birthday, pagination.Cursors, error)
+	Count(ctx context.Context, filters persistence.ListFilters) (int, error)
+	ExistsByUserID(ctx context.Context, userID userproto.UserID) (bool, error)
+}
+
+// Setup returns an empty storage and the context to call it with.
+type Setup func(t *testing.T) (Storage, context.Context)
+
+// Run runs the contract tests against the storages returned by setup.
+func Run(t *testing.T, setup Setup) {
+	t.Run("create and get", func(t *testing.T) {
+		storage, ctx := setup(t)
+
+		birthday := newBirthday(time.Date(1990, time.May, 17, 0, 0, 0, 0, time.UTC), 35)
+		require.NoError(t, storage.Create(ctx, birthday))
+
+		duplicate := newBirthday(birthday.DateOfBirth, birthday.Age)
+		duplicate.UserID = birthday.UserID
+		require.Error(t, storage.Create(ctx, duplicate), "second birthday of a user")
+
+		got, err := storage.Get(ctx, birthday.ID)
+		require.NoError(t, err)
+		assertBirthday(t, birthday, got)
+		assert.False(t, got.CreatedAt.IsZero())
+
+		got, err = storage.GetByUserID(ctx, birthday.UserID)
+		require.NoError(t, err)
+		assertBirthday(t, birthday, got)
+
+		exists, err := storage.ExistsByUserID(ctx, birthday.UserID)
+		require.NoError(t, err)
+		assert.True(t, exists)
+
+		exists, err = storage.ExistsByUserID(ctx, userproto.UserID(uuid.New()))
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("get missing", func(t *testing.T) {
+		storage, ctx := setup(t)
+
+		_, err := storage.Get(ctx, id.MustNew[birthdayproto.BirthdayKind]())
+		require.ErrorIs(t, err, sql.ErrNoRows)
+
+		_, err = storage.GetByUserID(ctx, userproto.UserID(uuid.New()))
+		require.ErrorIs(t, err, sql.ErrNoRows)
+	})
+
+	t.Run("update and delete", func(t *testing.T) {
+		storage, ctx := setup(t)
+
+		birthday := newBirthday(time.Date(1990, time.May, 17, 0, 0, 0, 0, time.UTC), 35)
+		require.NoError(t, storage.Create(ctx, birthday))
+
+		birthday.DateOfBirth = time.Date(1980, time.January, 2, 0, 0, 0, 0, time.UTC)
+		birthday.PlaceOfBirth = types.NewNullable("Tehran")
+		birthday.Age = 45
+		require.NoError(t, storage.Update(ctx, birthday))
+
+		got, err := storage.Get(ctx, birthday.ID)
+		require.NoError(t, err)
+		assertBirthday(t, birthday, got)
+
+		require.NoError(t, storage.Delete(ctx, birthday.ID))
+
+		_, err = storage.Get(ctx, birthday.ID)
+		require.ErrorIs(t, err, sql.ErrNoRows)
+	})
+
+	t.Run("list and count with filters", func(t *testing.T) {
+		storage, ctx := setup(t)
+
+		ages := []int{40, 20, 30, 10}
+		ids := make(map[int]birthdayproto.BirthdayID, len(ages))
+		for i, age := range ages {
+			birthday := newBirthday(time.Date(2000-age, time.Month(i+1), 1, 0, 0, 0, 0, time.UTC), age)
+			require.NoError(t, storage.Create(ctx, birthday))
+			ids[age] = birthday.ID
+		}
+
+		byAge := order.Spec{{Field: "age", Direction: order.ASC}}
+
+		first, cursors, err := storage.List(ctx, pagination.Page{PageRows: 3}, byAge, persistence.ListFilters{})
+		require.NoError(t, err)
+		assert.Equal(t, []birthdayproto.BirthdayID{ids[10], ids[20], ids[30]}, birthdayIDs(first))
+
+		rest, cursors, err := storage.List(ctx, pagination.Page{PageRows: 3, Cursor: cursors.Next}, byAge,
+			persistence.ListFilters{})
+		require.NoError(t, err)
+		assert.Equal(t, []birthdayproto.BirthdayID{ids[40]}, birthdayIDs(rest))
+		assert.Empty(t, cursors.Next)
+
+		minAge, maxAge := 15, 35
+		filters := persistence.ListFilters{MinAge: &minAge, MaxAge: &maxAge}
+
+		filtered, _, err := storage.List(ctx, pagination.Page{PageRows: 3}, byAge, filters)
+		require.NoError(t, err)
+		assert.Equal(t, []birthdayproto.BirthdayID{ids[20], ids[30]}, birthdayIDs(filtered))
+
+		count, err := storage.Count(ctx, filters)
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+
+		month := 4
+		count, err = storage.Count(ctx, persistence.ListFilters{BirthMonth: &month})
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("list rejects unknown order", func(t *testing.T) {
+		storage, ctx := setup(t)
+
+		_, _, err := storage.List(ctx, pagination.Page{}, order.Spec{{Field: "name"}}, persistence.ListFilters{})
+		require.ErrorIs(t, err, derror.ErrUnknownOrder)
+	})
+}
+
+func newBirthday(dateOfBirth time.Time, age int) birthdayproto.Birthday {
+	return birthdayproto.Birthday{
+		ID:          id.MustNew[birthdayproto.BirthdayKind](),
+		UserID:      userproto.UserID(uuid.New()),
+		DateOfBirth: dateOfBirth,
+		Age:         age,
+	}
+}
+
+func assertBirthday(t *testing.T, want, got birthdayproto.Birthday) {
+	t.Helper()
+
+	assert.Equal(t, want.ID, got.ID)
+	assert.Equal(t, want.UserID, got.UserID)
+	assert.Equal(t, want.DateOfBirth.Format(time.DateOnly), got.DateOfBirth.Format(time.DateOnly))
+	assert.Equal(t, want.PlaceOfBirth, got.PlaceOfBirth)
+	assert.Equal(t, want.Age, got.Age)
+}
+
+func birthdayIDs(birthdays []birthdayproto.Birthday) []birthdayproto.BirthdayID {
+	ids := make([]birthdayproto.BirthdayID, 0, len(birthdays))
+	for _, birthday := range birthdays {
+		ids = append(ids, birthday.ID)
+	}
+
+	return ids
+}
@@ -2,6 +2,7 @@ INSERT INTO birthdays (
         id,
         user_id,
         date_of_birth,
+        place_of_birth,
         age,
         created_at,
         updated_at
@@ -11,6 +12,7 @@ VALUES (
         $2,
         $3,
         $4,
+        $5,
         NOW(),
         NOW()
     )
\ No newline at end of file
@@ -1,6 +1,7 @@
 SELECT id,
     user_id,
     date_of_birth,
+    place_of_birth,
     age,
     created_at,
     updated_at
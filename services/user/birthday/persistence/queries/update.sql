@@ -1,5 +1,6 @@
 UPDATE birthdays
 SET date_of_birth = $2,
-    age = $3,
+    place_of_birth = $3,
+    age = $4,
     updated_at = NOW()
 WHERE id = $1
\ No newline at end of file
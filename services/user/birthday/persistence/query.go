@@ -61,6 +61,7 @@ func (s *BirthdayStorage) Create(ctx context.Context, birthday birthdayproto.Bir
 		birthday.ID,
 		birthday.UserID,
 		birthday.DateOfBirth,
+		birthday.PlaceOfBirth,
 		birthday.Age,
 	)
 	if err != nil {
@@ -79,6 +80,7 @@ func (s *BirthdayStorage) Get(ctx context.Context, id birthdayproto.BirthdayID)
 		&birthday.ID,
 		&birthday.UserID,
 		&birthday.DateOfBirth,
+		&birthday.PlaceOfBirth,
 		&birthday.Age,
 		&birthday.CreatedAt,
 		&birthday.UpdatedAt,
@@ -99,6 +101,7 @@ func (s *BirthdayStorage) GetByUserID(ctx context.Context, userID userproto.User
 		&birthday.ID,
 		&birthday.UserID,
 		&birthday.DateOfBirth,
+		&birthday.PlaceOfBirth,
 		&birthday.Age,
 		&birthday.CreatedAt,
 		&birthday.UpdatedAt,
@@ -119,6 +122,7 @@ func (s *BirthdayStorage) Update(ctx context.Context, birthday birthdayproto.Bir
 		updateQuery,
 		birthday.ID,
 		birthday.DateOfBirth,
+		birthday.PlaceOfBirth,
 		birthday.Age,
 	)
 	if err != nil {
@@ -141,9 +145,21 @@ func (s *BirthdayStorage) Delete(ctx context.Context, id birthdayproto.BirthdayI
 }
 
 // List retrieves a paginated list of birthday records with optional filters.
-func (s *BirthdayStorage) List(ctx context.Context, page pagination.Page, orderBy order.OrderBy, filters ListFilters) ([]birthdayproto.Birthday, error) {
+func (s *BirthdayStorage) List(
+	ctx context.Context, page pagination.Page, sort order.Spec, filters ListFilters,
+) ([]birthdayproto.Birthday, pagination.Cursors, error) {
 	conn := session.GetDBConnection(ctx, s.Conn)
 
+	columns, err := orderWhitelist.Resolve(sort)
+	if err != nil {
+		return nil, pagination.Cursors{}, fmt.Errorf("resolving birthday order: %w", err)
+	}
+
+	keyset, err := pagination.NewSQLKeyset(page, "id", columns...)
+	if err != nil {
+		return nil, pagination.Cursors{}, fmt.Errorf("building birthday keyset: %w", err)
+	}
+
 	// Build the query with filters.
 	query := listQuery
 	args := []interface{}{}
@@ -171,22 +187,25 @@ func (s *BirthdayStorage) List(ctx context.Context, page pagination.Page, orderB
 	if filters.BirthMonth != nil {
 		whereConditions = append(whereConditions, fmt.Sprintf("EXTRACT(MONTH FROM date_of_birth) = $%d", argIndex))
 		args = append(args, *filters.BirthMonth)
+		argIndex++
+	}
+
+	// Continue after the cursor, if any.
+	if where, keysetArgs := keyset.Where(argIndex); where != "" {
+		whereConditions = append(whereConditions, where)
+		args = append(args, keysetArgs...)
 	}
 
 	if len(whereConditions) > 0 {
 		query += " WHERE " + strings.Join(whereConditions, " AND ")
 	}
 
-	// Add ordering.
-	query += " ORDER BY " + orderStringer(orderBy)
-
-	// Add pagination using the pattern from the framework.
-	offset := page.PageRows * page.PageNumber
-	query += fmt.Sprintf(" LIMIT %d OFFSET %d", page.PageRows, offset)
+	// Add ordering and pagination.
+	query += keyset.OrderBy() + keyset.Limit()
 
 	rows, err := conn.QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("listing birthday records: %w", err)
+		return nil, pagination.Cursors{}, fmt.Errorf("listing birthday records: %w", err)
 	}
 	defer rows.Close()
 
@@ -197,21 +216,24 @@ func (s *BirthdayStorage) List(ctx context.Context, page pagination.Page, orderB
 			&birthday.ID,
 			&birthday.UserID,
 			&birthday.DateOfBirth,
+			&birthday.PlaceOfBirth,
 			&birthday.Age,
 			&birthday.CreatedAt,
 			&birthday.UpdatedAt,
 		)
 		if err != nil {
-			return nil, fmt.Errorf("scanning birthday record: %w", err)
+			return nil, pagination.Cursors{}, fmt.Errorf("scanning birthday record: %w", err)
 		}
 		birthdays = append(birthdays, birthday)
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("iterating birthday records: %w", err)
+		return nil, pagination.Cursors{}, fmt.Errorf("iterating birthday records: %w", err)
 	}
 
-	return birthdays, nil
+	birthdays, cursors := pagination.Window(keyset, birthdays, cursorKey(columns), cursorID)
+
+	return birthdays, cursors, nil
 }
 
 // Count returns the total number of birthday records matching the filters.
@@ -0,0 +1,24 @@
+package persistence_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kianooshaz/skeleton/foundation/database/postgres/postgrestest"
+	"github.com/kianooshaz/skeleton/services/user/birthday/persistence"
+	"github.com/kianooshaz/skeleton/services/user/birthday/persistence/persistencetest"
+)
+
+func TestBirthdayMemoryStorage(t *testing.T) {
+	persistencetest.Run(t, func(t *testing.T) (persistencetest.Storage, context.Context) {
+		return persistence.NewBirthdayMemoryStorage(), t.Context()
+	})
+}
+
+func TestBirthdayStorage(t *testing.T) {
+	db := postgrestest.Open(t, "../schema.sql")
+
+	persistencetest.Run(t, func(t *testing.T) (persistencetest.Storage, context.Context) {
+		return &persistence.BirthdayStorage{Conn: db}, postgrestest.Context(t, db, "birthdays")
+	})
+}
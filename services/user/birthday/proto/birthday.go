@@ -4,19 +4,22 @@ import (
 	"context"
 	"time"
 
+	"github.com/kianooshaz/skeleton/foundation/derror"
 	"github.com/kianooshaz/skeleton/foundation/order"
 	"github.com/kianooshaz/skeleton/foundation/pagination"
+	"github.com/kianooshaz/skeleton/foundation/types"
 	userproto "github.com/kianooshaz/skeleton/services/user/user/proto"
 )
 
 // Birthday represents a user's birthday information.
 type Birthday struct {
-	ID          BirthdayID       `json:"id"`
-	UserID      userproto.UserID `json:"user_id"`
-	DateOfBirth time.Time        `json:"date_of_birth"`
-	Age         int              `json:"age"`
-	CreatedAt   time.Time        `json:"created_at"`
-	UpdatedAt   time.Time        `json:"updated_at"`
+	ID           BirthdayID             `json:"id"`
+	UserID       userproto.UserID       `json:"user_id"`
+	DateOfBirth  time.Time              `json:"date_of_birth"`
+	PlaceOfBirth types.Nullable[string] `json:"place_of_birth"`
+	Age          int                    `json:"age"`
+	CreatedAt    time.Time              `json:"created_at"`
+	UpdatedAt    time.Time              `json:"updated_at"`
 }
 
 // BirthdayService defines the interface for birthday operations.
@@ -31,8 +34,9 @@ type BirthdayService interface {
 
 // CreateRequest represents the request to create a birthday.
 type CreateRequest struct {
-	UserID      userproto.UserID `json:"user_id" validate:"required"`
-	DateOfBirth time.Time        `json:"date_of_birth" validate:"required"`
+	UserID       userproto.UserID       `json:"user_id" validate:"id"`
+	DateOfBirth  time.Time              `json:"date_of_birth" validate:"required,past"`
+	PlaceOfBirth types.Nullable[string] `json:"place_of_birth"`
 }
 
 // CreateResponse represents the response from creating a birthday.
@@ -42,7 +46,7 @@ type CreateResponse struct {
 
 // GetRequest represents the request to get a birthday by ID.
 type GetRequest struct {
-	ID BirthdayID `json:"id" validate:"required"`
+	ID BirthdayID `param:"id" json:"-" validate:"id"`
 }
 
 // GetResponse represents the response from getting a birthday.
@@ -52,7 +56,7 @@ type GetResponse struct {
 
 // GetByUserIDRequest represents the request to get a birthday by user ID.
 type GetByUserIDRequest struct {
-	UserID userproto.UserID `json:"user_id" validate:"required"`
+	UserID userproto.UserID `param:"user_id" json:"-" validate:"id"`
 }
 
 // GetByUserIDResponse represents the response from getting a birthday by user ID.
@@ -60,10 +64,22 @@ type GetByUserIDResponse struct {
 	Data Birthday `json:"data"`
 }
 
-// UpdateRequest represents the request to update a birthday.
+// UpdateRequest represents the request to update a birthday. Absent fields are
+// left untouched. The place of birth is cleared by null; the date of birth
+// cannot be.
 type UpdateRequest struct {
-	ID          BirthdayID `json:"id" validate:"required"`
-	DateOfBirth time.Time  `json:"date_of_birth" validate:"required"`
+	ID           BirthdayID                `param:"id" json:"-" validate:"id"`
+	DateOfBirth  types.Optional[time.Time] `json:"date_of_birth,omitzero" validate:"omitempty,past"`
+	PlaceOfBirth types.Optional[string]    `json:"place_of_birth,omitzero"`
+}
+
+// Validate reports whether the request keeps a date of birth.
+func (req UpdateRequest) Validate() error {
+	if req.DateOfBirth.IsNull() {
+		return derror.ErrInvalidRequest
+	}
+
+	return nil
 }
 
 // UpdateResponse represents the response from updating a birthday.
@@ -73,17 +89,17 @@ type UpdateResponse struct {
 
 // DeleteRequest represents the request to delete a birthday.
 type DeleteRequest struct {
-	ID BirthdayID `json:"id" validate:"required"`
+	ID BirthdayID `param:"id" json:"-" validate:"id"`
 }
 
 // ListRequest represents the request to list birthdays.
 type ListRequest struct {
 	pagination.Page
-	order.OrderBy
-	UserID     *userproto.UserID `json:"user_id,omitempty"`
-	MinAge     *int              `json:"min_age,omitempty"`
-	MaxAge     *int              `json:"max_age,omitempty"`
-	BirthMonth *int              `json:"birth_month,omitempty"`
+	Sort       order.Spec        `query:"sort"`
+	UserID     *userproto.UserID `query:"user_id" json:"user_id,omitempty"`
+	MinAge     *int              `query:"min_age" json:"min_age,omitempty" validate:"omitempty,min=0"`
+	MaxAge     *int              `query:"max_age" json:"max_age,omitempty" validate:"omitempty,min=0"`
+	BirthMonth *int              `query:"birth_month" json:"birth_month,omitempty" validate:"omitempty,min=1,max=12"`
 }
 
 // ListResponse represents the response from listing birthdays.
@@ -6,6 +6,7 @@ CREATE TABLE IF NOT EXISTS birthdays (
     id UUID PRIMARY KEY,
     user_id UUID NOT NULL,
     date_of_birth DATE NOT NULL,
+    place_of_birth TEXT,
     age INTEGER NOT NULL,
     created_at TIMESTAMP NOT NULL DEFAULT NOW(),
     updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
@@ -15,6 +16,9 @@ CREATE TABLE IF NOT EXISTS birthdays (
     CONSTRAINT birthdays_age_reasonable CHECK (age <= 200),
     CONSTRAINT birthdays_date_not_future CHECK (date_of_birth <= CURRENT_DATE)
 );
+-- Add the place of birth to tables created before it was recorded
+ALTER TABLE birthdays
+ADD COLUMN IF NOT EXISTS place_of_birth TEXT;
 -- Create indexes for better performance
 CREATE INDEX IF NOT EXISTS idx_birthdays_user_id ON birthdays (user_id);
 CREATE INDEX IF NOT EXISTS idx_birthdays_age ON birthdays (age);
@@ -30,6 +34,7 @@ COMMENT ON TABLE birthdays IS 'Stores user birthday information and calculated a
 COMMENT ON COLUMN birthdays.id IS 'Unique identifier for the birthday record';
 COMMENT ON COLUMN birthdays.user_id IS 'Foreign key reference to the user (unique per user)';
 COMMENT ON COLUMN birthdays.date_of_birth IS 'User date of birth';
+COMMENT ON COLUMN birthdays.place_of_birth IS 'Where the user was born, if they shared it';
 COMMENT ON COLUMN birthdays.age IS 'Calculated age based on date of birth';
 COMMENT ON COLUMN birthdays.created_at IS 'Timestamp when the record was created';
 COMMENT ON COLUMN birthdays.updated_at IS 'Timestamp when the record was last updated';
\ No newline at end of file
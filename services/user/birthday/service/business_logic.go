@@ -5,8 +5,11 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/kianooshaz/skeleton/foundation/derror"
+	"github.com/kianooshaz/skeleton/foundation/id"
 	"github.com/kianooshaz/skeleton/foundation/pagination"
+	"github.com/kianooshaz/skeleton/foundation/session"
 	"github.com/kianooshaz/skeleton/services/user/birthday/persistence"
 	birthdayproto "github.com/kianooshaz/skeleton/services/user/birthday/proto"
 )
@@ -39,6 +42,10 @@ func (s *Service) validateAge(age int) error {
 func (s *Service) Create(ctx context.Context, req birthdayproto.CreateRequest) (birthdayproto.CreateResponse, error) {
 	s.logger.Info("Creating birthday record", "user_id", req.UserID)
 
+	if err := session.AuthorizeUser(ctx, uuid.UUID(req.UserID)); err != nil {
+		return birthdayproto.CreateResponse{}, err
+	}
+
 	// Check if birthday already exists for this user.
 	exists, err := s.persister.ExistsByUserID(ctx, req.UserID)
 	if err != nil {
@@ -56,14 +63,20 @@ func (s *Service) Create(ctx context.Context, req birthdayproto.CreateRequest) (
 		return birthdayproto.CreateResponse{}, fmt.Errorf("validating age: %w", err)
 	}
 
+	birthdayID, err := id.New[birthdayproto.BirthdayKind]()
+	if err != nil {
+		return birthdayproto.CreateResponse{}, fmt.Errorf("generating birthday id: %w", err)
+	}
+
 	// Create birthday record.
 	birthday := birthdayproto.Birthday{
-		ID:          birthdayproto.NewBirthdayID(),
-		UserID:      req.UserID,
-		DateOfBirth: req.DateOfBirth,
-		Age:         age,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+		ID:           birthdayID,
+		UserID:       req.UserID,
+		DateOfBirth:  req.DateOfBirth,
+		PlaceOfBirth: req.PlaceOfBirth,
+		Age:          age,
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
 	}
 
 	if err := s.persister.Create(ctx, birthday); err != nil {
@@ -109,24 +122,26 @@ func (s *Service) Update(ctx context.Context, req birthdayproto.UpdateRequest) (
 		return birthdayproto.UpdateResponse{}, fmt.Errorf("getting existing birthday record: %w", err)
 	}
 
+	if err := session.AuthorizeUser(ctx, uuid.UUID(existingBirthday.UserID)); err != nil {
+		return birthdayproto.UpdateResponse{}, err
+	}
+
+	// Update the fields given, leaving the others untouched.
+	updatedBirthday := existingBirthday
+	updatedBirthday.UpdatedAt = time.Now()
+	if dateOfBirth, ok := req.DateOfBirth.Get(); ok {
+		updatedBirthday.DateOfBirth = dateOfBirth
+	}
+	req.PlaceOfBirth.Apply(&updatedBirthday.PlaceOfBirth)
+
 	// Calculate new age.
-	age := calculateAge(req.DateOfBirth)
+	updatedBirthday.Age = calculateAge(updatedBirthday.DateOfBirth)
 
 	// Validate age.
-	if err := s.validateAge(age); err != nil {
+	if err := s.validateAge(updatedBirthday.Age); err != nil {
 		return birthdayproto.UpdateResponse{}, fmt.Errorf("validating age: %w", err)
 	}
 
-	// Update birthday record.
-	updatedBirthday := birthdayproto.Birthday{
-		ID:          existingBirthday.ID,
-		UserID:      existingBirthday.UserID,
-		DateOfBirth: req.DateOfBirth,
-		Age:         age,
-		CreatedAt:   existingBirthday.CreatedAt,
-		UpdatedAt:   time.Now(),
-	}
-
 	if err := s.persister.Update(ctx, updatedBirthday); err != nil {
 		return birthdayproto.UpdateResponse{}, fmt.Errorf("updating birthday record: %w", err)
 	}
@@ -140,6 +155,15 @@ func (s *Service) Update(ctx context.Context, req birthdayproto.UpdateRequest) (
 func (s *Service) Delete(ctx context.Context, req birthdayproto.DeleteRequest) error {
 	s.logger.Info("Deleting birthday record", "birthday_id", req.ID)
 
+	birthday, err := s.persister.Get(ctx, req.ID)
+	if err != nil {
+		return fmt.Errorf("getting birthday record: %w", err)
+	}
+
+	if err := session.AuthorizeUser(ctx, uuid.UUID(birthday.UserID)); err != nil {
+		return err
+	}
+
 	if err := s.persister.Delete(ctx, req.ID); err != nil {
 		return fmt.Errorf("deleting birthday record: %w", err)
 	}
@@ -162,19 +186,22 @@ func (s *Service) List(ctx context.Context, req birthdayproto.ListRequest) (birt
 	}
 
 	// Get birthday records.
-	birthdays, err := s.persister.List(ctx, req.Page, req.OrderBy, filters)
+	birthdays, cursors, err := s.persister.List(ctx, req.Page, req.Sort, filters)
 	if err != nil {
 		return birthdayproto.ListResponse{}, fmt.Errorf("listing birthday records: %w", err)
 	}
 
-	// Get total count.
-	totalCount, err := s.persister.Count(ctx, filters)
-	if err != nil {
-		return birthdayproto.ListResponse{}, fmt.Errorf("counting birthday records: %w", err)
+	// Get total count, unless a keyset page did not ask for it.
+	var totalCount int
+	if req.NeedsTotal() {
+		totalCount, err = s.persister.Count(ctx, filters)
+		if err != nil {
+			return birthdayproto.ListResponse{}, fmt.Errorf("counting birthday records: %w", err)
+		}
 	}
 
 	// Build response.
-	response := pagination.NewResponse(req.Page, totalCount, birthdays)
+	response := pagination.NewCursorResponse(req.Page, totalCount, birthdays, cursors)
 
 	return birthdayproto.ListResponse(response), nil
 }
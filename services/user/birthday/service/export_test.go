@@ -0,0 +1,12 @@
+package birthdayservice
+
+import "log/slog"
+
+// NewWithStorage creates a service on the given storage.
+func NewWithStorage(cfg Config, storage persister, logger *slog.Logger) *Service {
+	return &Service{
+		config:    cfg,
+		logger:    logger,
+		persister: storage,
+	}
+}
@@ -28,7 +28,9 @@ type (
 		GetByUserID(ctx context.Context, userID userproto.UserID) (birthdayproto.Birthday, error)
 		Update(ctx context.Context, birthday birthdayproto.Birthday) error
 		Delete(ctx context.Context, id birthdayproto.BirthdayID) error
-		List(ctx context.Context, page pagination.Page, orderBy order.OrderBy, filters persistence.ListFilters) ([]birthdayproto.Birthday, error)
+		List(
+			ctx context.Context, page pagination.Page, sort order.Spec, filters persistence.ListFilters,
+		) ([]birthdayproto.Birthday, pagination.Cursors, error)
 		Count(ctx context.Context, filters persistence.ListFilters) (int, error)
 		ExistsByUserID(ctx context.Context, userID userproto.UserID) (bool, error)
 	}
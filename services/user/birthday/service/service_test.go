@@ -1,15 +1,26 @@
 package birthdayservice_test
 
 import (
+	"context"
+	"database/sql"
+	"io"
 	"log/slog"
 	"os"
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/kianooshaz/skeleton/foundation/derror"
+	"github.com/kianooshaz/skeleton/foundation/id"
+	"github.com/kianooshaz/skeleton/foundation/session"
+	"github.com/kianooshaz/skeleton/foundation/types"
+	"github.com/kianooshaz/skeleton/services/user/birthday/persistence"
+	birthdayproto "github.com/kianooshaz/skeleton/services/user/birthday/proto"
 	birthdayservice "github.com/kianooshaz/skeleton/services/user/birthday/service"
+	userproto "github.com/kianooshaz/skeleton/services/user/user/proto"
 )
 
 func TestBirthdayService_New(t *testing.T) {
@@ -71,3 +82,75 @@ func TestAgeCalculation(t *testing.T) {
 		})
 	}
 }
+
+func TestBirthdayService_Update(t *testing.T) {
+	userID := userproto.UserID(uuid.New())
+	ctx := session.SetPrincipal(context.Background(), session.Principal{UserID: uuid.UUID(userID)})
+
+	storage := persistence.NewBirthdayMemoryStorage()
+	service := birthdayservice.NewWithStorage(birthdayservice.Config{MaxAge: 150, MinAge: 0}, storage,
+		slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	created, err := service.Create(ctx, birthdayproto.CreateRequest{
+		UserID:      userID,
+		DateOfBirth: time.Now().AddDate(-20, 0, -1),
+	})
+	require.NoError(t, err)
+
+	// Execute.
+	updated, err := service.Update(ctx, birthdayproto.UpdateRequest{
+		ID:          created.Data.ID,
+		DateOfBirth: types.Some(time.Now().AddDate(-30, 0, -1)),
+	})
+
+	// Assert.
+	require.NoError(t, err)
+	assert.Equal(t, 30, updated.Data.Age)
+	assert.Equal(t, created.Data.UserID, updated.Data.UserID)
+
+	stored, err := storage.Get(ctx, created.Data.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 30, stored.Age)
+
+	// Absent fields are left untouched, and null ones cleared.
+	updated, err = service.Update(ctx, birthdayproto.UpdateRequest{
+		ID:           created.Data.ID,
+		PlaceOfBirth: types.Some("Tehran"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 30, updated.Data.Age)
+	assert.Equal(t, types.NewNullable("Tehran"), updated.Data.PlaceOfBirth)
+
+	updated, err = service.Update(ctx, birthdayproto.UpdateRequest{
+		ID:           created.Data.ID,
+		PlaceOfBirth: types.Null[string](),
+	})
+	require.NoError(t, err)
+	assert.False(t, updated.Data.PlaceOfBirth.IsValid())
+
+	// An age out of bounds is rejected and leaves the record untouched.
+	_, err = service.Update(ctx, birthdayproto.UpdateRequest{
+		ID:          created.Data.ID,
+		DateOfBirth: types.Some(time.Now().AddDate(-200, 0, 0)),
+	})
+	require.Error(t, err)
+
+	stored, err = storage.Get(ctx, created.Data.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 30, stored.Age)
+
+	// Other users may not change it.
+	other := session.SetPrincipal(context.Background(), session.Principal{UserID: uuid.New()})
+	_, err = service.Update(other, birthdayproto.UpdateRequest{
+		ID:          created.Data.ID,
+		DateOfBirth: types.Some(time.Now().AddDate(-40, 0, -1)),
+	})
+	require.ErrorIs(t, err, derror.ErrForbidden)
+	require.ErrorIs(t, service.Delete(other, birthdayproto.DeleteRequest{ID: created.Data.ID}), derror.ErrForbidden)
+
+	_, err = service.Update(ctx, birthdayproto.UpdateRequest{
+		ID:          id.MustNew[birthdayproto.BirthdayKind](),
+		DateOfBirth: types.Some(time.Now().AddDate(-30, 0, 0)),
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
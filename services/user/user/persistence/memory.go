@@ -0,0 +1,146 @@
+package persistence
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	dbproto "github.com/kianooshaz/skeleton/foundation/database/proto"
+	"github.com/kianooshaz/skeleton/foundation/derror"
+	"github.com/kianooshaz/skeleton/foundation/order"
+	"github.com/kianooshaz/skeleton/foundation/pagination"
+	"github.com/kianooshaz/skeleton/foundation/stat"
+	userproto "github.com/kianooshaz/skeleton/services/user/user/proto"
+)
+
+// UserMemoryStorage keeps users in memory. It behaves like UserStorage and is
+// meant for tests.
+type UserMemoryStorage struct {
+	mu    sync.RWMutex
+	users map[userproto.UserID]userproto.User
+}
+
+// NewUserMemoryStorage creates an empty UserMemoryStorage.
+func NewUserMemoryStorage() *UserMemoryStorage {
+	return &UserMemoryStorage{
+		users: make(map[userproto.UserID]userproto.User),
+	}
+}
+
+func (ms *UserMemoryStorage) Create(_ context.Context, user userproto.User) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	if _, ok := ms.users[user.ID]; ok {
+		return dbproto.ErrDuplicateKey
+	}
+
+	ms.users[user.ID] = user
+
+	return nil
+}
+
+func (ms *UserMemoryStorage) Get(_ context.Context, id userproto.UserID) (userproto.User, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	user, ok := ms.users[id]
+	if !ok {
+		return userproto.User{}, derror.ErrUserNotFound
+	}
+
+	return user, nil
+}
+
+func (ms *UserMemoryStorage) List(_ context.Context, page pagination.Page,
+	sort order.Spec) ([]userproto.User, pagination.Cursors, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	columns, err := orderWhitelist.Resolve(sort)
+	if err != nil {
+		return nil, pagination.Cursors{}, err
+	}
+
+	keyset := pagination.MemoryKeyset[userproto.User]{
+		Compare: compareUsers,
+		Key:     cursorKey(columns),
+		ID:      cursorID,
+		Lookup: func(id string) (userproto.User, bool) {
+			parsed, err := uuid.Parse(id)
+			if err != nil {
+				return userproto.User{}, false
+			}
+
+			user, ok := ms.users[userproto.UserID(parsed)]
+			return user, ok
+		},
+	}
+
+	return keyset.Page(slices.Collect(maps.Values(ms.users)), page, columns...)
+}
+
+func (ms *UserMemoryStorage) Count(_ context.Context) (int, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	return len(ms.users), nil
+}
+
+func (ms *UserMemoryStorage) Touch(_ context.Context, id userproto.UserID, at time.Time) (bool, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	user, ok := ms.users[id]
+	if !ok || !user.Status.Has(stat.Guest) {
+		return false, nil
+	}
+
+	user.LastSeenAt = at
+	ms.users[id] = user
+
+	return true, nil
+}
+
+func (ms *UserMemoryStorage) Upgrade(_ context.Context, id userproto.UserID) (bool, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	user, ok := ms.users[id]
+	if !ok || !user.Status.Has(stat.Guest) {
+		return false, nil
+	}
+
+	user.Status.Remove(stat.Guest)
+	user.Status.Add(stat.Registered)
+	ms.users[id] = user
+
+	return true, nil
+}
+
+func (ms *UserMemoryStorage) PurgeGuests(_ context.Context, idleSince time.Time) (int, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	var purged int
+	for id, user := range ms.users {
+		if user.Status.Has(stat.Guest) && user.LastSeenAt.Before(idleSince) {
+			delete(ms.users, id)
+			purged++
+		}
+	}
+
+	return purged, nil
+}
+
+func compareUsers(a, b userproto.User, column string) int {
+	switch column {
+	case "created_at":
+		return a.CreatedAt.Compare(b.CreatedAt)
+	default:
+		return 0
+	}
+}
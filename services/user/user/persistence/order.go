@@ -2,26 +2,32 @@ package persistence
 
 import (
 	"github.com/kianooshaz/skeleton/foundation/order"
+	"github.com/kianooshaz/skeleton/foundation/pagination"
+	userproto "github.com/kianooshaz/skeleton/services/user/user/proto"
 )
 
-var oderStringer order.StringerFunc = func(orderBy order.OrderBy) string {
-	var field string
-	switch orderBy.Field {
-	case "created_at":
-		field = "created_at"
-	default:
-		field = "created_at"
-	}
+var orderWhitelist = order.Whitelist{
+	Columns: map[string]string{
+		"created_at": "created_at",
+	},
+	Default: order.Spec{{Field: "created_at", Direction: order.ASC}},
+}
 
-	var direction string
-	switch orderBy.Direction {
-	case order.ASC:
-		direction = "ASC"
-	case order.DESC:
-		direction = "DESC"
-	default:
-		direction = "ASC"
+// cursorKey returns a function extracting the ORDER BY key of a user for the given columns.
+func cursorKey(columns []order.Column) func(userproto.User) []string {
+	return func(user userproto.User) []string {
+		keys := make([]string, 0, len(columns))
+		for _, column := range columns {
+			switch column.Name {
+			case "created_at":
+				keys = append(keys, pagination.TimeKey(user.CreatedAt))
+			}
+		}
+
+		return keys
 	}
+}
 
-	return field + " " + direction
+func cursorID(user userproto.User) string {
+	return user.ID.String()
 }
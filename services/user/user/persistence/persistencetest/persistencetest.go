@@ -0,0 +1,214 @@
+// Package persistencetest holds the contract every user storage must meet, so
+// the in-memory storage used by tests stays faithful to the PostgreSQL one.
+package persistencetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kianooshaz/skeleton/foundation/derror"
+	"github.com/kianooshaz/skeleton/foundation/order"
+	"github.com/kianooshaz/skeleton/foundation/pagination"
+	"github.com/kianooshaz/skeleton/foundation/stat"
+	userproto "github.com/kianooshaz/skeleton/services/user/user/proto"
+)
+
+// Storage is the user storage under contract.
+type Storage interface {
+	Create(ctx context.Context, user userproto.User) error
+	Get(ctx context.Context, id userproto.UserID) (userproto.User, error)
+	List(ctx context.Context, page pagination.Page, sort order.Spec) ([]userproto.User, pagination.Cursors, error)
+	Count(ctx context.Context) (int, error)
+	Touch(ctx context.Context, id userproto.UserID, at time.Time) (bool, error)
+	Upgrade(ctx context.Context, id userproto.UserID) (bool, error)
+	PurgeGuests(ctx context.Context, idleSince time.Time) (int, error)
+}
+
+// Setup returns an empty storage and the context to call it with.
+type Setup func(t *testing.T) (Storage, context.Context)
+
+// Run runs the contract tests against the storages returned by setup.
+func Run(t *testing.T, setup Setup) {
+	t.Run("create and get", func(t *testing.T) {
+		storage, ctx := setup(t)
+
+		user := newUser(t, time.Now())
+		require.NoError(t, storage.Create(ctx, user))
+		require.Error(t, storage.Create(ctx, user), "duplicate ID")
+
+		got, err := storage.Get(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, user.ID, got.ID)
+		assert.Equal(t, stat.Registered, got.Status)
+		assert.WithinDuration(t, user.LastSeenAt, got.LastSeenAt, time.Millisecond)
+		assert.WithinDuration(t, user.CreatedAt, got.CreatedAt, time.Millisecond)
+	})
+
+	t.Run("touch guests only", func(t *testing.T) {
+		storage, ctx := setup(t)
+
+		now := time.Now().UTC().Truncate(time.Microsecond)
+		guest := newGuest(t, now.Add(-time.Hour))
+		user := newUser(t, now.Add(-time.Hour))
+		require.NoError(t, storage.Create(ctx, guest))
+		require.NoError(t, storage.Create(ctx, user))
+
+		touched, err := storage.Touch(ctx, guest.ID, now)
+		require.NoError(t, err)
+		assert.True(t, touched)
+
+		got, err := storage.Get(ctx, guest.ID)
+		require.NoError(t, err)
+		assert.WithinDuration(t, now, got.LastSeenAt, time.Millisecond)
+
+		touched, err = storage.Touch(ctx, user.ID, now)
+		require.NoError(t, err)
+		assert.False(t, touched, "registered user")
+
+		touched, err = storage.Touch(ctx, userproto.UserID(uuid.New()), now)
+		require.NoError(t, err)
+		assert.False(t, touched, "missing user")
+	})
+
+	t.Run("upgrade", func(t *testing.T) {
+		storage, ctx := setup(t)
+
+		guest := newGuest(t, time.Now())
+		require.NoError(t, storage.Create(ctx, guest))
+
+		upgraded, err := storage.Upgrade(ctx, guest.ID)
+		require.NoError(t, err)
+		assert.True(t, upgraded)
+
+		got, err := storage.Get(ctx, guest.ID)
+		require.NoError(t, err)
+		assert.Equal(t, guest.ID, got.ID)
+		assert.Equal(t, stat.Registered, got.Status)
+
+		upgraded, err = storage.Upgrade(ctx, guest.ID)
+		require.NoError(t, err)
+		assert.False(t, upgraded, "already registered")
+	})
+
+	t.Run("purge idle guests", func(t *testing.T) {
+		storage, ctx := setup(t)
+
+		now := time.Now()
+		idle := newGuest(t, now.Add(-48*time.Hour))
+		active := newGuest(t, now.Add(-time.Hour))
+		user := newUser(t, now.Add(-48*time.Hour))
+		for _, u := range []userproto.User{idle, active, user} {
+			require.NoError(t, storage.Create(ctx, u))
+		}
+
+		purged, err := storage.PurgeGuests(ctx, now.Add(-24*time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, 1, purged)
+
+		_, err = storage.Get(ctx, idle.ID)
+		require.ErrorIs(t, err, derror.ErrUserNotFound)
+		_, err = storage.Get(ctx, active.ID)
+		require.NoError(t, err)
+		_, err = storage.Get(ctx, user.ID)
+		require.NoError(t, err)
+	})
+
+	t.Run("get missing", func(t *testing.T) {
+		storage, ctx := setup(t)
+
+		_, err := storage.Get(ctx, userproto.UserID(uuid.New()))
+		require.ErrorIs(t, err, derror.ErrUserNotFound)
+	})
+
+	t.Run("list and count", func(t *testing.T) {
+		storage, ctx := setup(t)
+
+		base := time.Now().Add(-time.Hour)
+		ids := make([]userproto.UserID, 0, 5)
+		for i := range 5 {
+			user := newUser(t, base.Add(time.Duration(i)*time.Minute))
+			require.NoError(t, storage.Create(ctx, user))
+			ids = append(ids, user.ID)
+		}
+
+		count, err := storage.Count(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 5, count)
+
+		first, cursors, err := storage.List(ctx, pagination.Page{PageRows: 2}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, ids[:2], userIDs(first))
+		assert.Empty(t, cursors.Prev)
+
+		second, cursors, err := storage.List(ctx, pagination.Page{PageRows: 2, Cursor: cursors.Next}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, ids[2:4], userIDs(second))
+
+		last, cursors, err := storage.List(ctx, pagination.Page{PageRows: 2, Cursor: cursors.Next}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, ids[4:], userIDs(last))
+		assert.Empty(t, cursors.Next)
+
+		back, _, err := storage.List(ctx, pagination.Page{PageRows: 2, Cursor: cursors.Prev}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, ids[2:4], userIDs(back))
+
+		newest, _, err := storage.List(ctx, pagination.Page{PageRows: 2, PageNumber: 1},
+			order.Spec{{Field: "created_at", Direction: order.DESC}})
+		require.NoError(t, err)
+		assert.Equal(t, []userproto.UserID{ids[4], ids[3]}, userIDs(newest))
+
+		numbered, _, err := storage.List(ctx, pagination.Page{PageRows: 2, PageNumber: 3}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, ids[4:], userIDs(numbered))
+	})
+
+	t.Run("list rejects unknown order and cursor", func(t *testing.T) {
+		storage, ctx := setup(t)
+
+		_, _, err := storage.List(ctx, pagination.Page{}, order.Spec{{Field: "password"}})
+		require.ErrorIs(t, err, derror.ErrUnknownOrder)
+
+		_, _, err = storage.List(ctx, pagination.Page{Cursor: "garbage"}, nil)
+		require.ErrorIs(t, err, derror.ErrInvalidCursor)
+	})
+}
+
+func newUser(t *testing.T, createdAt time.Time) userproto.User {
+	t.Helper()
+
+	id, err := uuid.NewV7()
+	require.NoError(t, err)
+
+	createdAt = createdAt.UTC().Truncate(time.Microsecond)
+
+	return userproto.User{
+		ID:         userproto.UserID(id),
+		Status:     stat.Registered,
+		LastSeenAt: createdAt,
+		CreatedAt:  createdAt,
+	}
+}
+
+func newGuest(t *testing.T, lastSeenAt time.Time) userproto.User {
+	t.Helper()
+
+	guest := newUser(t, lastSeenAt)
+	guest.Status = stat.Guest
+
+	return guest
+}
+
+func userIDs(users []userproto.User) []userproto.UserID {
+	ids := make([]userproto.UserID, 0, len(users))
+	for _, user := range users {
+		ids = append(ids, user.ID)
+	}
+
+	return ids
+}
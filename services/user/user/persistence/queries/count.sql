@@ -1,2 +1,2 @@
 SELECT COUNT(*)
-FROM `users`
\ No newline at end of file
+FROM users
\ No newline at end of file
@@ -1,2 +1,2 @@
-INSERT INTO `users` (`id`, `created_at`)
-VALUES ($1, $2)
\ No newline at end of file
+INSERT INTO users (id, status, last_seen_at, created_at)
+VALUES ($1, $2, $3, $4)
\ No newline at end of file
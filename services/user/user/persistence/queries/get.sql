@@ -1,4 +1,6 @@
-SELECT `id`,
-    `created_at`
-FROM `users`
-WHERE `id` = $1
\ No newline at end of file
+SELECT id,
+    status,
+    last_seen_at,
+    created_at
+FROM users
+WHERE id = $1
\ No newline at end of file
@@ -1,3 +1,5 @@
-SELECT `id`,
-    `created_at`
-FROM `users`
\ No newline at end of file
+SELECT id,
+    status,
+    last_seen_at,
+    created_at
+FROM users
\ No newline at end of file
@@ -0,0 +1,3 @@
+DELETE FROM users
+WHERE status & $1::BIGINT <> 0
+    AND last_seen_at < $2
\ No newline at end of file
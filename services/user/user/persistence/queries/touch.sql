@@ -0,0 +1,4 @@
+UPDATE users
+SET last_seen_at = $2
+WHERE id = $1
+    AND status & $3::BIGINT <> 0
\ No newline at end of file
@@ -0,0 +1,4 @@
+UPDATE users
+SET status = (status & ~$2::BIGINT) | $3::BIGINT
+WHERE id = $1
+    AND status & $2::BIGINT <> 0
\ No newline at end of file
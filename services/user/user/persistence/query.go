@@ -5,12 +5,14 @@ import (
 	"database/sql"
 	_ "embed"
 	"errors"
+	"time"
 
 	dbproto "github.com/kianooshaz/skeleton/foundation/database/proto"
 	"github.com/kianooshaz/skeleton/foundation/derror"
 	"github.com/kianooshaz/skeleton/foundation/order"
 	"github.com/kianooshaz/skeleton/foundation/pagination"
 	"github.com/kianooshaz/skeleton/foundation/session"
+	"github.com/kianooshaz/skeleton/foundation/stat"
 	userproto "github.com/kianooshaz/skeleton/services/user/user/proto"
 )
 
@@ -18,8 +20,6 @@ type UserStorage struct {
 	Conn dbproto.QueryExecutor
 }
 
-const defaultPageSize = 20
-
 //go:embed queries/create.sql
 var createQuery string
 
@@ -32,10 +32,19 @@ var listQuery string
 //go:embed queries/count.sql
 var countQuery string
 
+//go:embed queries/touch.sql
+var touchQuery string
+
+//go:embed queries/upgrade.sql
+var upgradeQuery string
+
+//go:embed queries/purge_guests.sql
+var purgeGuestsQuery string
+
 func (us *UserStorage) Create(ctx context.Context, user userproto.User) error {
 	conn := session.GetDBConnection(ctx, us.Conn)
 
-	_, err := conn.ExecContext(ctx, createQuery, user.ID, user.CreatedAt)
+	_, err := conn.ExecContext(ctx, createQuery, user.ID, user.Status, user.LastSeenAt, user.CreatedAt)
 	return err
 }
 
@@ -45,7 +54,7 @@ func (us *UserStorage) Get(ctx context.Context, id userproto.UserID) (userproto.
 	row := conn.QueryRowContext(ctx, getQuery, id)
 
 	var user userproto.User
-	err := row.Scan(&user.ID, &user.CreatedAt)
+	err := row.Scan(&user.ID, &user.Status, &user.LastSeenAt, &user.CreatedAt)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return userproto.User{}, derror.ErrUserNotFound
@@ -58,33 +67,103 @@ func (us *UserStorage) Get(ctx context.Context, id userproto.UserID) (userproto.
 }
 
 func (us *UserStorage) List(ctx context.Context, page pagination.Page,
-	orderBy order.OrderBy) ([]userproto.User, error) {
+	sort order.Spec) ([]userproto.User, pagination.Cursors, error) {
 	conn := session.GetDBConnection(ctx, us.Conn)
 
-	query := listQuery + page.String(pagination.SQLStringer(defaultPageSize)) + orderBy.String(oderStringer)
+	columns, err := orderWhitelist.Resolve(sort)
+	if err != nil {
+		return nil, pagination.Cursors{}, err
+	}
+
+	keyset, err := pagination.NewSQLKeyset(page, "id", columns...)
+	if err != nil {
+		return nil, pagination.Cursors{}, err
+	}
+
+	query := listQuery
+	where, args := keyset.Where(1)
+	if where != "" {
+		query += " WHERE " + where
+	}
+	query += keyset.OrderBy() + keyset.Limit()
 
-	rows, err := conn.QueryContext(ctx, query)
+	rows, err := conn.QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, err
+		return nil, pagination.Cursors{}, err
 	}
 	defer rows.Close()
 
 	var users []userproto.User
 	for rows.Next() {
 		var user userproto.User
-		err := rows.Scan(&user.ID, &user.CreatedAt)
+		err := rows.Scan(&user.ID, &user.Status, &user.LastSeenAt, &user.CreatedAt)
 		if err != nil {
-			return nil, err
+			return nil, pagination.Cursors{}, err
 		}
 		users = append(users, user)
 	}
 
-	return users, nil
+	if err := rows.Err(); err != nil {
+		return nil, pagination.Cursors{}, err
+	}
+
+	users, cursors := pagination.Window(keyset, users, cursorKey(columns), cursorID)
+
+	return users, cursors, nil
 }
 
 func (us *UserStorage) Count(ctx context.Context) (int, error) {
-	row := us.Conn.QueryRowContext(ctx, countQuery)
+	conn := session.GetDBConnection(ctx, us.Conn)
+
+	row := conn.QueryRowContext(ctx, countQuery)
 	var count int
 	err := row.Scan(&count)
 	return count, err
 }
+
+// Touch sets when a guest was last seen. It reports false when id is not a guest.
+func (us *UserStorage) Touch(ctx context.Context, id userproto.UserID, at time.Time) (bool, error) {
+	conn := session.GetDBConnection(ctx, us.Conn)
+
+	result, err := conn.ExecContext(ctx, touchQuery, id, at, stat.Guest)
+	if err != nil {
+		return false, err
+	}
+
+	return affected(result)
+}
+
+// Upgrade replaces the guest status of a user with the registered one. It
+// reports false when id is not a guest.
+func (us *UserStorage) Upgrade(ctx context.Context, id userproto.UserID) (bool, error) {
+	conn := session.GetDBConnection(ctx, us.Conn)
+
+	result, err := conn.ExecContext(ctx, upgradeQuery, id, stat.Guest, stat.Registered)
+	if err != nil {
+		return false, err
+	}
+
+	return affected(result)
+}
+
+// PurgeGuests deletes the guests last seen before idleSince and returns how many there were.
+func (us *UserStorage) PurgeGuests(ctx context.Context, idleSince time.Time) (int, error) {
+	conn := session.GetDBConnection(ctx, us.Conn)
+
+	result, err := conn.ExecContext(ctx, purgeGuestsQuery, stat.Guest, idleSince)
+	if err != nil {
+		return 0, err
+	}
+
+	rows, err := result.RowsAffected()
+	return int(rows), err
+}
+
+func affected(result sql.Result) (bool, error) {
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rows > 0, nil
+}
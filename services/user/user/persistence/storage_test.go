@@ -0,0 +1,24 @@
+package persistence_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kianooshaz/skeleton/foundation/database/postgres/postgrestest"
+	"github.com/kianooshaz/skeleton/services/user/user/persistence"
+	"github.com/kianooshaz/skeleton/services/user/user/persistence/persistencetest"
+)
+
+func TestUserMemoryStorage(t *testing.T) {
+	persistencetest.Run(t, func(t *testing.T) (persistencetest.Storage, context.Context) {
+		return persistence.NewUserMemoryStorage(), t.Context()
+	})
+}
+
+func TestUserStorage(t *testing.T) {
+	db := postgrestest.Open(t, "../schema.sql")
+
+	persistencetest.Run(t, func(t *testing.T) (persistencetest.Storage, context.Context) {
+		return &persistence.UserStorage{Conn: db}, postgrestest.Context(t, db, "users")
+	})
+}
@@ -6,17 +6,30 @@ import (
 
 	"github.com/kianooshaz/skeleton/foundation/order"
 	"github.com/kianooshaz/skeleton/foundation/pagination"
+	"github.com/kianooshaz/skeleton/foundation/stat"
 )
 
 type User struct {
-	ID        UserID    `json:"id"`
-	CreatedAt time.Time `json:"created_at"`
+	ID     UserID      `json:"id"`
+	Status stat.Status `json:"status"`
+	// LastSeenAt is when a guest was last seen, so idle guests can be purged.
+	LastSeenAt time.Time `json:"last_seen_at"`
+	CreatedAt  time.Time `json:"created_at"`
 }
 
 type UserService interface {
 	Create(ctx context.Context) (CreateResponse, error)
+	// CreateGuest creates a user with the guest status, for visitors that have not signed up.
+	CreateGuest(ctx context.Context) (CreateResponse, error)
 	Get(ctx context.Context, req GetRequest) (GetResponse, error)
 	List(ctx context.Context, req ListRequest) (ListResponse, error)
+	// Touch records that a guest is still active.
+	Touch(ctx context.Context, req TouchRequest) error
+	// Upgrade turns a guest into a registered user, keeping its ID and everything attached to it.
+	Upgrade(ctx context.Context, req UpgradeRequest) (UpgradeResponse, error)
+	// PurgeGuests deletes the guests that have not been seen since the given time.
+	PurgeGuests(ctx context.Context, req PurgeGuestsRequest) (PurgeGuestsResponse, error)
+	Shutdown(ctx context.Context)
 }
 
 type CreateResponse struct {
@@ -24,7 +37,7 @@ type CreateResponse struct {
 }
 
 type GetRequest struct {
-	ID UserID `json:"query"`
+	ID UserID `param:"id" json:"-"`
 }
 
 type GetResponse struct {
@@ -33,7 +46,27 @@ type GetResponse struct {
 
 type ListRequest struct {
 	pagination.Page
-	order.OrderBy
+	Sort order.Spec `query:"sort"`
 }
 
 type ListResponse pagination.Response[User]
+
+type TouchRequest struct {
+	ID UserID `json:"id"`
+}
+
+type UpgradeRequest struct {
+	ID UserID `json:"id"`
+}
+
+type UpgradeResponse struct {
+	Data User `json:"data"`
+}
+
+type PurgeGuestsRequest struct {
+	IdleSince time.Time `json:"idle_since"`
+}
+
+type PurgeGuestsResponse struct {
+	Count int `json:"count"`
+}
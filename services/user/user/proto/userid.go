@@ -1,9 +1,11 @@
 package userproto
 
-import "github.com/google/uuid"
+import "github.com/kianooshaz/skeleton/foundation/id"
 
-type UserID uuid.UUID
+// UserKind is the kind of user IDs.
+type UserKind struct{}
 
-func (u UserID) String() string {
-	return uuid.UUID(u).String()
-}
+func (UserKind) Prefix() string { return "usr" }
+
+// UserID identifies a user.
+type UserID = id.ID[UserKind]
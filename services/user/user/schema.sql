@@ -0,0 +1,15 @@
+-- User Service Database Schema
+-- This file contains the SQL schema for the user service tables.
+-- Run this manually in your PostgreSQL database to create the required tables.
+-- Create users table
+CREATE TABLE IF NOT EXISTS users (
+    id UUID PRIMARY KEY,
+    status BIGINT NOT NULL DEFAULT 1,
+    last_seen_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+-- Create indexes for better performance
+CREATE INDEX IF NOT EXISTS idx_users_created_at ON users (created_at);
+-- Only guests (status bit 16384) are looked up by last_seen_at, when idle ones are purged.
+CREATE INDEX IF NOT EXISTS idx_users_guests_last_seen_at ON users (last_seen_at)
+WHERE status & 16384 <> 0;
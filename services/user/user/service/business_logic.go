@@ -6,23 +6,36 @@ import (
 	"log/slog"
 	"time"
 
-	"github.com/google/uuid"
 	"github.com/kianooshaz/skeleton/foundation/derror"
+	"github.com/kianooshaz/skeleton/foundation/id"
 	"github.com/kianooshaz/skeleton/foundation/pagination"
+	"github.com/kianooshaz/skeleton/foundation/stat"
+	statusproto "github.com/kianooshaz/skeleton/services/account/status/proto"
 	userproto "github.com/kianooshaz/skeleton/services/user/user/proto"
 )
 
 func (s *Service) Create(ctx context.Context) (userproto.CreateResponse, error) {
-	id, err := uuid.NewV7()
+	return s.create(ctx, stat.Registered)
+}
+
+func (s *Service) CreateGuest(ctx context.Context) (userproto.CreateResponse, error) {
+	return s.create(ctx, stat.Guest)
+}
+
+func (s *Service) create(ctx context.Context, status stat.Status) (userproto.CreateResponse, error) {
+	userID, err := id.New[userproto.UserKind]()
 	if err != nil {
 		s.logger.ErrorContext(ctx, "Error encountered while generating user id", slog.String("error", err.Error()))
 
 		return userproto.CreateResponse{}, derror.ErrInternalSystem
 	}
 
+	now := s.now()
 	user := userproto.User{
-		ID:        userproto.UserID(id),
-		CreatedAt: time.Now(),
+		ID:         userID,
+		Status:     status,
+		LastSeenAt: now,
+		CreatedAt:  now,
 	}
 
 	if err = s.persister.Create(ctx, user); err != nil {
@@ -62,8 +75,13 @@ func (s *Service) Get(ctx context.Context, req userproto.GetRequest) (userproto.
 }
 
 func (s *Service) List(ctx context.Context, req userproto.ListRequest) (userproto.ListResponse, error) {
-	users, err := s.persister.List(ctx, req.Page, req.OrderBy)
+	users, cursors, err := s.persister.List(ctx, req.Page, req.Sort)
 	if err != nil {
+		if errors.Is(err, derror.ErrInvalidCursor) || errors.Is(err, derror.ErrUnknownOrder) ||
+			errors.Is(err, derror.ErrUnknownOrderDirection) {
+			return userproto.ListResponse{}, err
+		}
+
 		s.logger.ErrorContext(
 			ctx,
 			"Error encountered while listing users from storage",
@@ -74,17 +92,169 @@ func (s *Service) List(ctx context.Context, req userproto.ListRequest) (userprot
 		return userproto.ListResponse{}, derror.ErrInternalSystem
 	}
 
-	totalCount, err := s.persister.Count(ctx)
+	var totalCount int
+	if req.NeedsTotal() {
+		totalCount, err = s.persister.Count(ctx)
+		if err != nil {
+			s.logger.ErrorContext(
+				ctx,
+				"Error encountered while counting users from storage",
+				slog.String("error", err.Error()),
+				slog.Any("req", req),
+			)
+
+			return userproto.ListResponse{}, derror.ErrInternalSystem
+		}
+	}
+
+	return userproto.ListResponse(pagination.NewCursorResponse(req.Page, totalCount, users, cursors)), nil
+}
+
+func (s *Service) Touch(ctx context.Context, req userproto.TouchRequest) error {
+	touched, err := s.persister.Touch(ctx, req.ID, s.now())
 	if err != nil {
 		s.logger.ErrorContext(
 			ctx,
-			"Error encountered while counting users from storage",
+			"Error encountered while touching guest in storage",
 			slog.String("error", err.Error()),
 			slog.Any("req", req),
 		)
 
-		return userproto.ListResponse{}, derror.ErrInternalSystem
+		return derror.ErrInternalSystem
+	}
+
+	if !touched {
+		return s.notGuest(ctx, req.ID)
+	}
+
+	return nil
+}
+
+func (s *Service) Upgrade(ctx context.Context, req userproto.UpgradeRequest) (userproto.UpgradeResponse, error) {
+	user, err := s.Get(ctx, userproto.GetRequest{ID: req.ID})
+	if err != nil {
+		return userproto.UpgradeResponse{}, err
+	}
+
+	from := user.Data.Status
+	next, err := machine.Next(from, transitionUpgrade)
+	if err != nil {
+		return userproto.UpgradeResponse{}, err
+	}
+
+	tx, ctx, err := s.transactor.Begin(ctx)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Error encountered while beginning transaction", slog.String("error", err.Error()))
+
+		return userproto.UpgradeResponse{}, derror.ErrInternalSystem
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil {
+			s.logger.ErrorContext(ctx, "Error encountered while rolling back transaction", slog.String("error", err.Error()))
+		}
+	}()
+
+	upgraded, err := s.persister.Upgrade(ctx, req.ID)
+	if err != nil {
+		s.logger.ErrorContext(
+			ctx,
+			"Error encountered while upgrading guest in storage",
+			slog.String("error", err.Error()),
+			slog.Any("req", req),
+		)
+
+		return userproto.UpgradeResponse{}, derror.ErrInternalSystem
+	}
+
+	if !upgraded {
+		// Upgraded or purged since it was read.
+		return userproto.UpgradeResponse{}, s.notGuest(ctx, req.ID)
+	}
+
+	err = s.status.Record(ctx, statusproto.RecordRequest{
+		Entity:     machine.Entity(),
+		EntityID:   req.ID.String(),
+		Transition: transitionUpgrade,
+		From:       from,
+		To:         next,
+		Reason:     "signup",
+	})
+	if err != nil {
+		return userproto.UpgradeResponse{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		s.logger.ErrorContext(ctx, "Error encountered while committing transaction", slog.String("error", err.Error()))
+
+		return userproto.UpgradeResponse{}, derror.ErrInternalSystem
+	}
+
+	user.Data.Status = next
+
+	return userproto.UpgradeResponse{Data: user.Data}, nil
+}
+
+// notGuest explains why id could not be handled as a guest: either there is no
+// such user or it is not a guest.
+func (s *Service) notGuest(ctx context.Context, id userproto.UserID) error {
+	if _, err := s.Get(ctx, userproto.GetRequest{ID: id}); err != nil {
+		return err
+	}
+
+	return derror.ErrUserNotGuest
+}
+
+func (s *Service) PurgeGuests(ctx context.Context, req userproto.PurgeGuestsRequest) (userproto.PurgeGuestsResponse, error) {
+	count, err := s.persister.PurgeGuests(ctx, req.IdleSince)
+	if err != nil {
+		s.logger.ErrorContext(
+			ctx,
+			"Error encountered while purging guests from storage",
+			slog.String("error", err.Error()),
+			slog.Any("req", req),
+		)
+
+		return userproto.PurgeGuestsResponse{}, derror.ErrInternalSystem
+	}
+
+	return userproto.PurgeGuestsResponse{Count: count}, nil
+}
+
+// purgeIdleGuests purges the guests idle for longer than the guest TTL every
+// purge interval, until the service shuts down.
+func (s *Service) purgeIdleGuests() {
+	defer s.purgeWg.Done()
+
+	ticker := time.NewTicker(s.config.PurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.shutdown:
+			return
+		case <-ticker.C:
+			res, err := s.PurgeGuests(context.Background(), userproto.PurgeGuestsRequest{
+				IdleSince: s.now().Add(-s.config.GuestTTL),
+			})
+			if err == nil && res.Count > 0 {
+				s.logger.Info("Purged idle guests", slog.Int("count", res.Count))
+			}
+		}
 	}
+}
+
+func (s *Service) Shutdown(ctx context.Context) {
+	close(s.shutdown)
 
-	return userproto.ListResponse(pagination.NewResponse(req.Page, totalCount, users)), nil
+	done := make(chan struct{})
+	go func() {
+		s.purgeWg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		s.logger.Error("shutdown timeout; guest purge did not finish in time")
+	}
 }
@@ -0,0 +1,16 @@
+package userservice
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/kianooshaz/skeleton/foundation/session"
+	statusproto "github.com/kianooshaz/skeleton/services/account/status/proto"
+)
+
+// NewWithStorage creates a service on the given storage and status history, reading the time from now.
+func NewWithStorage(
+	cfg Config, storage persister, status statusproto.StatusService, logger *slog.Logger, now func() time.Time,
+) *Service {
+	return newService(cfg, storage, session.NopTransactor{}, status, logger, now)
+}
@@ -5,30 +5,52 @@ import (
 	"context"
 	"database/sql"
 	"log/slog"
+	"sync"
+	"time"
 
 	"github.com/kianooshaz/skeleton/foundation/order"
 	"github.com/kianooshaz/skeleton/foundation/pagination"
+	"github.com/kianooshaz/skeleton/foundation/session"
+	statusproto "github.com/kianooshaz/skeleton/services/account/status/proto"
 	"github.com/kianooshaz/skeleton/services/user/user/persistence"
 	userproto "github.com/kianooshaz/skeleton/services/user/user/proto"
 )
 
 type (
+	Config struct {
+		// GuestTTL is how long a guest may stay idle before it is purged. Zero keeps guests forever.
+		GuestTTL time.Duration `yaml:"guest_ttl"`
+		// PurgeInterval is how often idle guests are purged.
+		PurgeInterval time.Duration `yaml:"purge_interval"`
+	}
+
 	persister interface {
 		Create(ctx context.Context, user userproto.User) error
 		Get(ctx context.Context, id userproto.UserID) (userproto.User, error)
-		List(ctx context.Context, page pagination.Page, orderBy order.OrderBy) ([]userproto.User, error)
+		List(
+			ctx context.Context, page pagination.Page, sort order.Spec,
+		) ([]userproto.User, pagination.Cursors, error)
 		Count(ctx context.Context) (int, error)
+		Touch(ctx context.Context, id userproto.UserID, at time.Time) (bool, error)
+		Upgrade(ctx context.Context, id userproto.UserID) (bool, error)
+		PurgeGuests(ctx context.Context, idleSince time.Time) (int, error)
 	}
 
 	Service struct {
-		logger    *slog.Logger
-		persister persister
-		dbConn    *sql.DB
+		config     Config
+		logger     *slog.Logger
+		persister  persister
+		transactor session.Transactor
+		status     statusproto.StatusService
+		dbConn     *sql.DB
+		now        func() time.Time
+		shutdown   chan struct{}
+		purgeWg    *sync.WaitGroup
 	}
 )
 
 // New creates a new user service instance.
-func New(db *sql.DB, logger *slog.Logger) userproto.UserService {
+func New(cfg Config, db *sql.DB, status statusproto.StatusService, logger *slog.Logger) userproto.UserService {
 	serviceLogger := logger.With(
 		slog.Group("package_info",
 			slog.String("module", "user"),
@@ -36,11 +58,39 @@ func New(db *sql.DB, logger *slog.Logger) userproto.UserService {
 		),
 	)
 
-	return &Service{
-		logger: serviceLogger,
-		persister: &persistence.UserStorage{
-			Conn: db,
-		},
-		dbConn: db,
+	svc := newService(cfg, &persistence.UserStorage{Conn: db}, session.SQLTransactor{DB: db}, status, serviceLogger, time.Now)
+	svc.dbConn = db
+
+	return svc
+}
+
+func newService(
+	cfg Config,
+	persister persister,
+	transactor session.Transactor,
+	status statusproto.StatusService,
+	logger *slog.Logger,
+	now func() time.Time,
+) *Service {
+	if cfg.PurgeInterval == 0 {
+		cfg.PurgeInterval = time.Hour
 	}
+
+	svc := &Service{
+		config:     cfg,
+		logger:     logger,
+		persister:  persister,
+		transactor: transactor,
+		status:     status,
+		now:        now,
+		shutdown:   make(chan struct{}),
+		purgeWg:    &sync.WaitGroup{},
+	}
+
+	if cfg.GuestTTL > 0 {
+		svc.purgeWg.Add(1)
+		go svc.purgeIdleGuests()
+	}
+
+	return svc
 }
@@ -0,0 +1,139 @@
+package userservice_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kianooshaz/skeleton/foundation/derror"
+	"github.com/kianooshaz/skeleton/foundation/stat"
+	statusproto "github.com/kianooshaz/skeleton/services/account/status/proto"
+	"github.com/kianooshaz/skeleton/services/user/user/persistence"
+	userproto "github.com/kianooshaz/skeleton/services/user/user/proto"
+	userservice "github.com/kianooshaz/skeleton/services/user/user/service"
+)
+
+// statusRecorder keeps the status transitions recorded through it.
+type statusRecorder struct {
+	statusproto.StatusService
+
+	requests []statusproto.RecordRequest
+}
+
+func (r *statusRecorder) Record(_ context.Context, req statusproto.RecordRequest) error {
+	r.requests = append(r.requests, req)
+	return nil
+}
+
+func TestService_Guest(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	service := userservice.NewWithStorage(
+		userservice.Config{},
+		persistence.NewUserMemoryStorage(),
+		&statusRecorder{},
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+		func() time.Time { return now },
+	)
+	t.Cleanup(func() { service.Shutdown(ctx) })
+
+	guest, err := service.CreateGuest(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, stat.Guest, guest.Data.Status)
+	assert.Equal(t, now, guest.Data.LastSeenAt)
+
+	idle, err := service.CreateGuest(ctx)
+	require.NoError(t, err)
+
+	user, err := service.Create(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, stat.Registered, user.Data.Status)
+
+	now = now.Add(2 * time.Hour)
+	require.NoError(t, service.Touch(ctx, userproto.TouchRequest{ID: guest.Data.ID}))
+	require.ErrorIs(t, service.Touch(ctx, userproto.TouchRequest{ID: user.Data.ID}), derror.ErrUserNotGuest)
+
+	purged, err := service.PurgeGuests(ctx, userproto.PurgeGuestsRequest{IdleSince: now.Add(-time.Hour)})
+	require.NoError(t, err)
+	assert.Equal(t, 1, purged.Count)
+
+	_, err = service.Get(ctx, userproto.GetRequest{ID: idle.Data.ID})
+	require.ErrorIs(t, err, derror.ErrUserNotFound)
+
+	upgraded, err := service.Upgrade(ctx, userproto.UpgradeRequest{ID: guest.Data.ID})
+	require.NoError(t, err)
+	assert.Equal(t, guest.Data.ID, upgraded.Data.ID)
+	assert.Equal(t, stat.Registered, upgraded.Data.Status)
+}
+
+func TestService_Upgrade(t *testing.T) {
+	tests := []struct {
+		name    string
+		user    func(t *testing.T, service *userservice.Service) userproto.UserID
+		wantErr error
+	}{
+		{
+			name: "guest",
+			user: func(t *testing.T, service *userservice.Service) userproto.UserID {
+				guest, err := service.CreateGuest(context.Background())
+				require.NoError(t, err)
+				return guest.Data.ID
+			},
+		},
+		{
+			name: "registered user",
+			user: func(t *testing.T, service *userservice.Service) userproto.UserID {
+				user, err := service.Create(context.Background())
+				require.NoError(t, err)
+				return user.Data.ID
+			},
+			wantErr: derror.ErrUserNotGuest,
+		},
+		{
+			name: "missing user",
+			user: func(*testing.T, *userservice.Service) userproto.UserID {
+				return userproto.UserID(uuid.New())
+			},
+			wantErr: derror.ErrUserNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := &statusRecorder{}
+			service := userservice.NewWithStorage(
+				userservice.Config{},
+				persistence.NewUserMemoryStorage(),
+				status,
+				slog.New(slog.NewTextHandler(io.Discard, nil)),
+				time.Now,
+			)
+			id := tt.user(t, service)
+
+			// Execute.
+			_, err := service.Upgrade(context.Background(), userproto.UpgradeRequest{ID: id})
+
+			// Assert.
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				assert.Empty(t, status.requests)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, []statusproto.RecordRequest{{
+				Entity:     "user",
+				EntityID:   id.String(),
+				Transition: "upgrade",
+				From:       stat.Guest,
+				To:         stat.Registered,
+				Reason:     "signup",
+			}}, status.requests)
+		})
+	}
+}
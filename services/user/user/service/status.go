@@ -0,0 +1,20 @@
+package userservice
+
+import (
+	"github.com/kianooshaz/skeleton/foundation/derror"
+	"github.com/kianooshaz/skeleton/foundation/stat"
+)
+
+// transitionUpgrade turns a guest into a registered user.
+const transitionUpgrade = "upgrade"
+
+// machine declares the statuses of users.
+var machine = stat.NewMachine("user", stat.Registered|stat.Guest,
+	stat.Transition{
+		Name:     transitionUpgrade,
+		Add:      stat.Registered,
+		Remove:   stat.Guest,
+		Requires: stat.Guest,
+		Err:      derror.ErrUserNotGuest,
+	},
+)